@@ -2,22 +2,44 @@ package main
 
 // Main entry point for the API server
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"template-golang/config"
 	"template-golang/database"
 	dbsqlc "template-golang/db/sqlc"
 	"template-golang/modules/auth"
 	authHandler "template-golang/modules/auth/handlers"
 	authMiddleware "template-golang/modules/auth/middlewares"
+	authModels "template-golang/modules/auth/models"
+	authProviders "template-golang/modules/auth/providers"
 	authRepo "template-golang/modules/auth/repositories"
+	authSessions "template-golang/modules/auth/sessions"
 	authUsecase "template-golang/modules/auth/usecases"
 	"template-golang/modules/cockroach"
 	cockroachHandler "template-golang/modules/cockroach/handlers"
 	cockroachRepo "template-golang/modules/cockroach/repositories"
 	cockroachUsecase "template-golang/modules/cockroach/usecases"
+	"template-golang/modules/privacy"
+	privacyHandler "template-golang/modules/privacy/handlers"
+	privacyRepo "template-golang/modules/privacy/repositories"
+	privacyUsecase "template-golang/modules/privacy/usecases"
+	"template-golang/pkg/encryption"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/personaldata"
+	"template-golang/pkg/response"
 	"template-golang/server"
+
+	"github.com/markbates/goth/gothic"
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	cfg := config.NewConfig(&config.ConfigOption{})
 
 	// Setup database
@@ -25,24 +47,90 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	if cfg.Db.AutoMigrate {
+		if err := db.Migrate(ctx); err != nil {
+			panic(err)
+		}
+	}
 	pool := db.GetPool()
 	queries := dbsqlc.New(pool)
+	txManager := database.NewTxManager(pool, cfg)
+
+	// Cursors handed out by one replica must be accepted by the others
+	if cfg.Server.CursorSecret != "" {
+		if err := response.SetCursorSecret(cfg.Server.CursorSecret); err != nil {
+			panic(fmt.Errorf("invalid SERVER_CURSOR_SECRET: %w", err))
+		}
+	} else {
+		logger.Warn("SERVER_CURSOR_SECRET is not set, pagination cursors only work on this replica until it restarts")
+	}
 
 	// Auth module wiring
-	authRepository := authRepo.NewAuthRepository(queries)
-	jwtUsecase := authUsecase.NewJWTUsecase(cfg, authRepository)
-	middleware := authMiddleware.NewAuthMiddleware(jwtUsecase)
-	handler := authHandler.NewAuthHttpHandler(jwtUsecase, cfg, middleware, authRepository)
+	// Provider tokens and signing keys are stored encrypted, keys are rotated by making a new
+	// key active
+	tokenKeyRing, err := encryption.ParseKeyRing(cfg.Auth.TokenEncryptionKeys, cfg.Auth.TokenEncryptionKeyID)
+	if errors.Is(err, encryption.ErrNoKeys) {
+		panic(errors.New("AUTH_TOKEN_ENCRYPTION_KEYS is not set: generate a key with `openssl rand -base64 32` " +
+			"and set AUTH_TOKEN_ENCRYPTION_KEYS=<id>:<key>, e.g. 2026-01:<key>"))
+	}
+	if err != nil {
+		panic(fmt.Errorf("invalid AUTH_TOKEN_ENCRYPTION_KEYS: %w", err))
+	}
+	authRepository := authRepo.NewAuthRepository(queries, tokenKeyRing)
+	mfaRepository := authRepo.NewMFARepository(queries, tokenKeyRing)
+	refreshTokenRepository := authRepo.NewRefreshTokenRepository(queries)
+	signingKeyRepository := authRepo.NewSigningKeyRepository(queries, tokenKeyRing)
+	revokedTokenRepository := authRepo.NewRevokedTokenRepository(queries)
+	authCodeRepository := authRepo.NewAuthCodeRepository(queries)
+	sessionStore, err := authSessions.NewStore(cfg, authRepo.NewSessionRepository(queries))
+	if err != nil {
+		panic(err)
+	}
+	// OAuth state lives in Postgres, so a login can complete on any replica
+	gothic.Store = sessionStore
+	keySet := authUsecase.NewKeySet(cfg, signingKeyRepository, txManager)
+	revocationStore := authUsecase.NewRevocationStore(cfg, revokedTokenRepository)
+	auditLogger := authUsecase.NewAuditLogger(authRepo.NewAuditEventRepository(queries))
+	jwtUsecase := authUsecase.NewJWTUsecase(cfg, keySet, revocationStore, auditLogger, authRepository, refreshTokenRepository, txManager)
+	passwordUsecase := authUsecase.NewPasswordUsecase(jwtUsecase, authRepository)
+	authCodeUsecase := authUsecase.NewAuthCodeUsecase(cfg, jwtUsecase, authCodeRepository)
+	accountLinkUsecase := authUsecase.NewAccountLinkUsecase(authRepository, txManager)
+	userAdminUsecase := authUsecase.NewUserAdminUsecase(jwtUsecase, authRepository, txManager)
+	permissionStore := authUsecase.NewPermissionStore(cfg, authRepo.NewRoleRepository(queries))
+	profileUsecase := authUsecase.NewProfileUsecase(jwtUsecase, authRepository, permissionStore, txManager)
+	mfaUsecase := authUsecase.NewMFAUsecase(cfg, jwtUsecase, authRepository, mfaRepository, txManager)
+	apiKeyUsecase := authUsecase.NewAPIKeyUsecase(authRepo.NewAPIKeyRepository(queries), authRepository, permissionStore)
+	// Failed logins are counted per replica unless the login_attempts table shares them
+	loginAttemptRepository := authRepo.NewMemoryLoginAttemptRepository()
+	if cfg.Auth.ThrottleStore == authModels.ThrottleStorePostgres {
+		loginAttemptRepository = authRepo.NewLoginAttemptRepository(queries)
+	}
+	loginThrottle := authUsecase.NewLoginThrottle(cfg, loginAttemptRepository)
+	accountStatusStore := authUsecase.NewAccountStatusStore(cfg, authRepository)
+	middleware := authMiddleware.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, permissionStore, accountStatusStore, loginThrottle)
+	loginProviders, err := authProviders.NewProviders(cfg)
+	if err != nil {
+		panic(err)
+	}
+	handler := authHandler.NewAuthHttpHandler(jwtUsecase, passwordUsecase, authCodeUsecase, accountLinkUsecase, userAdminUsecase, profileUsecase, apiKeyUsecase, mfaUsecase, loginThrottle, auditLogger, keySet, cfg, middleware, authRepository, loginProviders)
 	authModule := &auth.Auth{
-		Handler:    handler,
-		Middleware: middleware,
+		Handler:     handler,
+		Middleware:  middleware,
+		KeySet:      keySet,
+		Revocations: revocationStore,
+		AuthCodes:   authCodeUsecase,
+		Sessions:    sessionStore,
+		Permissions: permissionStore,
+		Accounts:    accountStatusStore,
+		Throttle:    loginThrottle,
+		Reencryptor: authUsecase.NewTokenReencryptor(cfg, authRepository, mfaRepository),
 	}
 
 	// Cockroach module wiring
 	cockroachRepository := cockroachRepo.NewPostgresRepository(queries)
 	cockroachMessaging := cockroachRepo.NewFCMMessaging()
 	cockroachUsecase := cockroachUsecase.NewCockroachUsecaseImpl(cockroachRepository, cockroachMessaging)
-	cockroachHandler := cockroachHandler.NewCockroachHttpHandler(cockroachUsecase)
+	cockroachHandler := cockroachHandler.NewCockroachHttpHandler(cockroachUsecase, middleware)
 	cockroachModule := &cockroach.Cockroach{
 		Handler:    cockroachHandler,
 		Repository: cockroachRepository,
@@ -50,7 +138,35 @@ func main() {
 		Usecase:    cockroachUsecase,
 	}
 
+	// Privacy module wiring
+	// Erasers run last registered first, so the auth module owning the auth is erased last
+	personalDataRegistry := personaldata.NewRegistry()
+	authPersonalData := authUsecase.NewPersonalData(authRepo.NewPersonalDataRepository(queries), loginThrottle, txManager)
+	personalDataRegistry.Register("auth", authPersonalData, authPersonalData)
+	dataRequestUsecase := privacyUsecase.NewDataRequestUsecase(cfg, personalDataRegistry, privacyRepo.NewDataRequestRepository(queries))
+	personalDataRegistry.Register("privacy", dataRequestUsecase, dataRequestUsecase)
+	privacyModule := &privacy.Privacy{
+		Handler:      privacyHandler.NewPrivacyHttpHandler(dataRequestUsecase, auditLogger, middleware),
+		DataRequests: dataRequestUsecase,
+	}
+
 	// Create server
-	s := server.NewGin(cfg, cockroachModule, authModule)
-	s.Start()
+	s := server.NewGin(cfg, cockroachModule, authModule, privacyModule)
+
+	// Closers run in reverse order: the database pool is closed before the logger is flushed
+	s.RegisterCloser("logger", func(ctx context.Context) error {
+		// stdout/stderr do not support fsync on most platforms
+		if err := logger.GetDefault().Sync(); err != nil && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTTY) {
+			return err
+		}
+		return nil
+	})
+	s.RegisterCloser("database", func(ctx context.Context) error {
+		db.Close()
+		return nil
+	})
+
+	if err := s.Start(ctx); err != nil {
+		panic(err)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -11,14 +12,24 @@ import (
 type (
 	Config struct {
 		// Note: The mapstructure:",squash" tag ensures that nested fields are treated as top-level environment variables.
-		Server ServerConfig `mapstructure:",squash"`
-		Db     DbConfig     `mapstructure:",squash"`
-		Auth   AuthConfig   `mapstructure:",squash"`
+		Server  ServerConfig  `mapstructure:",squash"`
+		Db      DbConfig      `mapstructure:",squash"`
+		Auth    AuthConfig    `mapstructure:",squash"`
+		Privacy PrivacyConfig `mapstructure:",squash"`
 	}
 
 	ServerConfig struct {
 		Port int    `mapstructure:"SERVER_PORT"`
 		Mode string `mapstructure:"GIN_MODE"`
+
+		ReadTimeout     time.Duration `mapstructure:"SERVER_READ_TIMEOUT"`
+		WriteTimeout    time.Duration `mapstructure:"SERVER_WRITE_TIMEOUT"`
+		IdleTimeout     time.Duration `mapstructure:"SERVER_IDLE_TIMEOUT"`
+		ShutdownTimeout time.Duration `mapstructure:"SERVER_SHUTDOWN_TIMEOUT"`
+
+		TrustedProxies string `mapstructure:"SERVER_TRUSTED_PROXIES"` // comma separated IPs or CIDRs of the proxies whose X-Forwarded-For is trusted for the client IP, empty trusts none
+
+		CursorSecret string `mapstructure:"SERVER_CURSOR_SECRET"` // signs the pagination cursors, at least 32 bytes shared by every replica; empty uses a random key per process
 	}
 
 	DbConfig struct {
@@ -29,16 +40,70 @@ type (
 		DBName        string `mapstructure:"DB_DBNAME"`
 		SSLMode       string `mapstructure:"DB_SSLMODE"`
 		TimeZone      string `mapstructure:"DB_TIMEZONE"`
-		MigrationPath string `mapstructure:"DB_MIGRATION_PATH"`
+		MigrationPath string `mapstructure:"DB_MIGRATION_PATH"` // empty uses the migrations embedded in the binary
+		AutoMigrate   bool   `mapstructure:"DB_AUTO_MIGRATE"`
+
+		TxIsolationLevel string        `mapstructure:"DB_TX_ISOLATION_LEVEL"` // e.g. "read committed", "serializable"
+		TxMaxRetries     int           `mapstructure:"DB_TX_MAX_RETRIES"`     // retries on serialization failures and deadlocks
+		TxRetryBackoff   time.Duration `mapstructure:"DB_TX_RETRY_BACKOFF"`
 	}
 
 	AuthConfig struct {
 		PrivateKeyPath string `mapstructure:"PRIVATE_KEY_PATH"`
 
+		JWTIssuer       string        `mapstructure:"JWT_ISSUER"`
+		JWTAudience     string        `mapstructure:"JWT_AUDIENCE"`
+		AccessTokenTTL  time.Duration `mapstructure:"JWT_ACCESS_TOKEN_TTL"`
+		RefreshTokenTTL time.Duration `mapstructure:"JWT_REFRESH_TOKEN_TTL"`
+
+		KeyRotationInterval time.Duration `mapstructure:"JWT_KEY_ROTATION_INTERVAL"` // 0 disables scheduled rotation
+		KeyRefreshInterval  time.Duration `mapstructure:"JWT_KEY_REFRESH_INTERVAL"`  // how often keys rotated by other replicas are picked up
+
+		RevocationCacheTTL        time.Duration `mapstructure:"JWT_REVOCATION_CACHE_TTL"` // how long other replicas may accept a just-revoked token
+		RevocationCleanupInterval time.Duration `mapstructure:"JWT_REVOCATION_CLEANUP_INTERVAL"`
+
+		AccountStatusCacheTTL time.Duration `mapstructure:"AUTH_ACCOUNT_STATUS_CACHE_TTL"` // how long a deactivated or deleted account may keep using its tokens, 0 checks every request
+
+		ProvidersFile string `mapstructure:"AUTH_PROVIDERS_FILE"`  // YAML or JSON file declaring the login providers
+		EmailAutoLink bool   `mapstructure:"AUTH_EMAIL_AUTO_LINK"` // link a new provider account to the account with its verified email
+
+		RolePermissions            string        `mapstructure:"AUTH_ROLE_PERMISSIONS"`             // e.g. "admin=*;user=cockroach:read,cockroach:write", empty uses the role_permissions table
+		PermissionsRefreshInterval time.Duration `mapstructure:"AUTH_PERMISSIONS_REFRESH_INTERVAL"` // how often the role_permissions table is reloaded
+
+		MFARequiredRoles string        `mapstructure:"AUTH_MFA_REQUIRED_ROLES"` // comma separated roles that must pass TOTP MFA to log in, e.g. "admin"
+		MFAIssuer        string        `mapstructure:"AUTH_MFA_ISSUER"`         // issuer shown by authenticator apps
+		MFAPendingTTL    time.Duration `mapstructure:"AUTH_MFA_PENDING_TTL"`    // lifetime of the mfa_pending token handed out until the second factor is verified
+
+		ThrottleStore           string        `mapstructure:"AUTH_THROTTLE_STORE"`            // where failed logins are counted: "memory", or "postgres" to share them between replicas
+		ThrottleFreeAttempts    int           `mapstructure:"AUTH_THROTTLE_FREE_ATTEMPTS"`    // failures per IP or account before the backoff starts
+		ThrottleBaseDelay       time.Duration `mapstructure:"AUTH_THROTTLE_BASE_DELAY"`       // wait after the first failure past the free ones, doubled by each further failure
+		ThrottleLockoutAttempts int           `mapstructure:"AUTH_THROTTLE_LOCKOUT_ATTEMPTS"` // failures that lock the IP or account out
+		ThrottleLockoutDuration time.Duration `mapstructure:"AUTH_THROTTLE_LOCKOUT_DURATION"`
+		ThrottleWindow          time.Duration `mapstructure:"AUTH_THROTTLE_WINDOW"` // failures are forgotten when none happened for this long
+
+		TokenDelivery string        `mapstructure:"AUTH_TOKEN_DELIVERY"` // how the OAuth callback hands tokens to the frontend: "code" or "cookie"
+		AuthCodeTTL   time.Duration `mapstructure:"AUTH_CODE_TTL"`       // lifetime of the one-time code of the "code" delivery
+		CookieDomain  string        `mapstructure:"AUTH_COOKIE_DOMAIN"`  // domain of the token cookies of the "cookie" delivery, empty for the API host
+		CookieSecure  bool          `mapstructure:"AUTH_COOKIE_SECURE"`  // send the token cookies over HTTPS only
+
+		TokenEncryptionKeys    string        `mapstructure:"AUTH_TOKEN_ENCRYPTION_KEYS"`    // comma separated "id:base64 key" pairs of 32 byte keys encrypting the stored provider tokens, MFA secrets and signing keys
+		TokenEncryptionKeyID   string        `mapstructure:"AUTH_TOKEN_ENCRYPTION_KEY_ID"`  // key encrypting new tokens, may be empty with a single key; the other keys only decrypt
+		TokenReencryptInterval time.Duration `mapstructure:"AUTH_TOKEN_REENCRYPT_INTERVAL"` // how often tokens under other keys or in plaintext are re-encrypted with the active key
+
+		SessionSecret          string        `mapstructure:"SESSION_SECRET"` // signs and encrypts the OAuth session cookie, at least 32 bytes
+		SessionTTL             time.Duration `mapstructure:"SESSION_TTL"`
+		SessionCleanupInterval time.Duration `mapstructure:"SESSION_CLEANUP_INTERVAL"`
+
 		LineClientID      string `mapstructure:"LINE_CLIENT_ID"`
 		LineClientSecret  string `mapstructure:"LINE_CLIENT_SECRET"`
 		LineCallbackURL   string `mapstructure:"LINE_CALLBACK_URL"`
-		LineFECallbackURL string `mapstructure:"LINE_FE_CALLBACK_URL"`
+		LineFECallbackURL string `mapstructure:"LINE_FE_CALLBACK_URL"` // frontend page receiving the tokens, for every provider
+	}
+
+	PrivacyConfig struct {
+		PollInterval time.Duration `mapstructure:"PRIVACY_POLL_INTERVAL"` // how often each replica looks for pending export and erasure requests
+		ExportTTL    time.Duration `mapstructure:"PRIVACY_EXPORT_TTL"`    // how long a completed export can be downloaded
+		JobTimeout   time.Duration `mapstructure:"PRIVACY_JOB_TIMEOUT"`   // requests running longer are taken over by another replica
 	}
 )
 
@@ -50,7 +115,11 @@ var (
 	_once   sync.Once
 	_config = &Config{
 		Server: ServerConfig{
-			Port: 8080,
+			Port:            8080,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
 		},
 		Db: DbConfig{
 			Host:          "0.0.0.0",
@@ -60,10 +129,57 @@ var (
 			DBName:        "postgres",
 			SSLMode:       "disable",
 			TimeZone:      "Asia/Bangkok",
-			MigrationPath: "file://db/migrations",
+			MigrationPath: "",
+			AutoMigrate:   false,
+
+			TxIsolationLevel: "read committed",
+			TxMaxRetries:     3,
+			TxRetryBackoff:   50 * time.Millisecond,
 		},
 		Auth: AuthConfig{
-			PrivateKeyPath: "private.pem",
+			PrivateKeyPath:  "private.pem",
+			JWTIssuer:       "template-golang",
+			JWTAudience:     "template-golang-api",
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+
+			KeyRotationInterval: 0,
+			KeyRefreshInterval:  time.Minute,
+
+			RevocationCacheTTL:        30 * time.Second,
+			RevocationCleanupInterval: time.Hour,
+
+			AccountStatusCacheTTL: 30 * time.Second,
+
+			EmailAutoLink: false,
+
+			RolePermissions:            "",
+			PermissionsRefreshInterval: time.Minute,
+
+			MFARequiredRoles: "",
+			MFAIssuer:        "template-golang",
+			MFAPendingTTL:    5 * time.Minute,
+
+			ThrottleStore:           "memory",
+			ThrottleFreeAttempts:    5,
+			ThrottleBaseDelay:       time.Second,
+			ThrottleLockoutAttempts: 10,
+			ThrottleLockoutDuration: 15 * time.Minute,
+			ThrottleWindow:          15 * time.Minute,
+
+			TokenDelivery: "code",
+			AuthCodeTTL:   time.Minute,
+			CookieSecure:  true,
+
+			TokenReencryptInterval: time.Hour,
+
+			SessionTTL:             time.Hour,
+			SessionCleanupInterval: time.Hour,
+		},
+		Privacy: PrivacyConfig{
+			PollInterval: 10 * time.Second,
+			ExportTTL:    7 * 24 * time.Hour,
+			JobTimeout:   15 * time.Minute,
 		},
 	}
 )
@@ -91,7 +207,7 @@ func NewConfig(configOption *ConfigOption) *Config {
 
 		if _config.Server.Mode != "release" {
 			fmt.Println("======================================================")
-			fmt.Printf("[Loaded] Config: %+v\n", _config)
+			fmt.Printf("[Loaded] Config: %+v\n", _config.redacted())
 			fmt.Println("======================================================")
 		}
 	})
@@ -127,3 +243,20 @@ func BindEnvsFromStruct(prefix string, s any) {
 		}
 	}
 }
+
+// redacted returns a copy of the config whose secrets are masked, safe to print.
+func (c *Config) redacted() *Config {
+	r := *c
+	for _, secret := range []*string{
+		&r.Server.CursorSecret,
+		&r.Db.Password,
+		&r.Auth.TokenEncryptionKeys,
+		&r.Auth.SessionSecret,
+		&r.Auth.LineClientSecret,
+	} {
+		if *secret != "" {
+			*secret = "[redacted]"
+		}
+	}
+	return &r
+}
@@ -10,4 +10,6 @@ type Database interface {
 	GetPool() *pgxpool.Pool
 	Close()
 	Ping(ctx context.Context) error
+	// Migrate applies pending schema migrations while holding a Postgres advisory lock.
+	Migrate(ctx context.Context) error
 }
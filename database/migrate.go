@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"template-golang/db/migrations"
+	"template-golang/pkg/logger"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// migrationLockID is the Postgres advisory lock key held while migrating,
+// so replicas starting at the same time apply migrations one after another.
+const migrationLockID int64 = 7_305_128_961_624_154_977
+
+// ErrDirtySchema is returned when a previous migration failed part-way through.
+var ErrDirtySchema = errors.New("database schema is dirty")
+
+// migrateUp applies all pending migrations from migrationPath, or from the embedded
+// db/migrations files when migrationPath is empty.
+func migrateUp(ctx context.Context, pool *pgxpool.Pool, migrationPath string) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for migration lock: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			logger.Errorf("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	src, err := openMigrationSource(migrationPath)
+	if err != nil {
+		return err
+	}
+
+	targetVersion, err := latestVersion(src)
+	if err != nil {
+		return err
+	}
+
+	driver, err := pgx.WithInstance(stdlib.OpenDBFromPool(pool), &pgx.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("migrations", src, "pgx5", driver)
+	if err != nil {
+		return fmt.Errorf("failed to create migration instance: %w", err)
+	}
+	defer func() {
+		if srcErr, dbErr := m.Close(); srcErr != nil || dbErr != nil {
+			logger.Errorf("Failed to close migration instance: source: %v, database: %v", srcErr, dbErr)
+		}
+	}()
+
+	currentVersion, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("failed to read current migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w at version %d: fix it manually and run `make migrate.force`", ErrDirtySchema, currentVersion)
+	}
+
+	logger.Infof("Database migration: current version %d, target version %d", currentVersion, targetVersion)
+
+	if currentVersion == targetVersion {
+		return nil
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	logger.Infof("Database migrated to version %d", targetVersion)
+	return nil
+}
+
+func openMigrationSource(migrationPath string) (source.Driver, error) {
+	if migrationPath == "" {
+		src, err := iofs.New(migrations.FS, ".")
+		if err != nil {
+			return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+		}
+		return src, nil
+	}
+
+	src, err := source.Open(migrationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations at %s: %w", migrationPath, err)
+	}
+	return src, nil
+}
+
+// latestVersion walks the migration source and returns the highest version it contains.
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		version = next
+	}
+}
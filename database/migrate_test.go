@@ -0,0 +1,58 @@
+package database
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"template-golang/db/migrations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion_EmbeddedMigrations(t *testing.T) {
+	src, err := openMigrationSource("")
+	require.NoError(t, err)
+	defer func() { _ = src.Close() }()
+
+	// Migrations are numbered sequentially, so the latest version is the number of up files
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+
+	version, err := latestVersion(src)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(len(ups)), version)
+}
+
+func TestLatestVersion_EmptyDirectory(t *testing.T) {
+	src, err := openMigrationSource("file://" + t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = src.Close() }()
+
+	version, err := latestVersion(src)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), version)
+}
+
+func TestOpenMigrationSource_FilePath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000007_example.up.sql"), []byte("SELECT 1;"), 0o600))
+
+	src, err := openMigrationSource("file://" + dir)
+	require.NoError(t, err)
+	defer func() { _ = src.Close() }()
+
+	version, err := latestVersion(src)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), version)
+}
+
+func TestOpenMigrationSource_InvalidPath(t *testing.T) {
+	_, err := openMigrationSource("file://" + filepath.Join(t.TempDir(), "missing"))
+
+	assert.Error(t, err)
+}
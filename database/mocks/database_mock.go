@@ -117,6 +117,57 @@ func (_c *MockDatabase_GetPool_Call) RunAndReturn(run func() *pgxpool.Pool) *Moc
 	return _c
 }
 
+// Migrate provides a mock function for the type MockDatabase
+func (_mock *MockDatabase) Migrate(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Migrate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDatabase_Migrate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Migrate'
+type MockDatabase_Migrate_Call struct {
+	*mock.Call
+}
+
+// Migrate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) Migrate(ctx interface{}) *MockDatabase_Migrate_Call {
+	return &MockDatabase_Migrate_Call{Call: _e.mock.On("Migrate", ctx)}
+}
+
+func (_c *MockDatabase_Migrate_Call) Run(run func(ctx context.Context)) *MockDatabase_Migrate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDatabase_Migrate_Call) Return(err error) *MockDatabase_Migrate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDatabase_Migrate_Call) RunAndReturn(run func(ctx context.Context) error) *MockDatabase_Migrate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ping provides a mock function for the type MockDatabase
 func (_mock *MockDatabase) Ping(ctx context.Context) error {
 	ret := _mock.Called(ctx)
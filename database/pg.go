@@ -9,7 +9,8 @@ import (
 )
 
 type postgresDatabase struct {
-	pool *pgxpool.Pool
+	pool          *pgxpool.Pool
+	migrationPath string
 }
 
 func NewPostgresDatabase(cfg *config.Config) (Database, error) {
@@ -35,7 +36,8 @@ func NewPostgresDatabase(cfg *config.Config) (Database, error) {
 	}
 
 	return &postgresDatabase{
-		pool: pool,
+		pool:          pool,
+		migrationPath: cfg.Db.MigrationPath,
 	}, nil
 }
 
@@ -52,3 +54,7 @@ func (d *postgresDatabase) Close() {
 func (d *postgresDatabase) Ping(ctx context.Context) error {
 	return d.pool.Ping(ctx)
 }
+
+func (d *postgresDatabase) Migrate(ctx context.Context) error {
+	return migrateUp(ctx, d.pool, d.migrationPath)
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TxManager runs units of work inside a database transaction.
+type TxManager interface {
+	// WithTx runs fn in a transaction, committing when fn returns nil and rolling back otherwise.
+	// Repositories called with the ctx passed to fn join the same transaction. If ctx already
+	// carries a transaction, fn joins it instead of starting a new one.
+	// fn may run more than once when the transaction is retried, so it must not have side effects
+	// outside the database.
+	WithTx(ctx context.Context, fn func(ctx context.Context, q *db.Queries) error) error
+}
+
+type txContextKey struct{}
+
+// SQLSTATE codes that are safe to retry by re-running the whole transaction
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+type pgxTxManager struct {
+	pool       *pgxpool.Pool
+	queries    *db.Queries
+	isoLevel   pgx.TxIsoLevel
+	maxRetries int
+	backoff    time.Duration
+}
+
+func NewTxManager(pool *pgxpool.Pool, cfg *config.Config) TxManager {
+	return &pgxTxManager{
+		pool:       pool,
+		queries:    db.New(pool),
+		isoLevel:   pgx.TxIsoLevel(strings.ToLower(cfg.Db.TxIsolationLevel)),
+		maxRetries: cfg.Db.TxMaxRetries,
+		backoff:    cfg.Db.TxRetryBackoff,
+	}
+}
+
+func (m *pgxTxManager) WithTx(ctx context.Context, fn func(ctx context.Context, q *db.Queries) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return fn(ctx, m.queries.WithTx(tx))
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.runTx(ctx, fn)
+		if err == nil || !IsRetryableTxError(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		delay := m.backoff * time.Duration(attempt+1)
+		logger.Warnf("Retrying transaction after %v (attempt %d/%d): %v", delay, attempt+1, m.maxRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (m *pgxTxManager) runTx(ctx context.Context, fn func(ctx context.Context, q *db.Queries) error) (err error) {
+	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: m.isoLevel})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.Background())
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(context.Background()); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				logger.Errorf("Failed to roll back transaction: %v", rbErr)
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, tx), m.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// TxFromContext returns the transaction started by TxManager.WithTx, if any.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// Queries returns q bound to the transaction carried by ctx, or q itself outside a transaction.
+func Queries(ctx context.Context, q *db.Queries) *db.Queries {
+	if tx, ok := TxFromContext(ctx); ok {
+		return q.WithTx(tx)
+	}
+	return q
+}
+
+// IsRetryableTxError reports whether err is a serialization failure or deadlock
+// that can be resolved by re-running the transaction.
+func IsRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "template-golang/db/sqlc"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryableTxError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock detected", &pgconn.PgError{Code: "40P01"}, true},
+		{"wrapped serialization failure", fmt.Errorf("failed to commit transaction: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRetryableTxError(tt.err))
+		})
+	}
+}
+
+func TestQueries_WithoutTransaction(t *testing.T) {
+	q := db.New(nil)
+
+	_, ok := TxFromContext(context.Background())
+
+	assert.False(t, ok)
+	assert.Same(t, q, Queries(context.Background(), q))
+}
@@ -0,0 +1,17 @@
+-- Create refresh_tokens table
+-- Only the SHA-256 hash of each opaque refresh token is stored. Tokens issued by rotating
+-- one another share a family_id so a reused token can revoke the whole chain.
+CREATE TABLE refresh_tokens (
+    id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auth_id VARCHAR(36) NOT NULL REFERENCES auths(id) ON DELETE CASCADE,
+    family_id VARCHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for refresh_tokens
+CREATE INDEX idx_refresh_tokens_auth_id ON refresh_tokens(auth_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
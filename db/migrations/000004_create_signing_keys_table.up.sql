@@ -0,0 +1,14 @@
+-- Create signing_keys table
+-- Keys used to sign access tokens. The newest key that is not retired signs new tokens;
+-- retired keys keep verifying tokens they signed until expires_at.
+CREATE TABLE signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key TEXT NOT NULL,
+    retired_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index on created_at to find the active key
+CREATE INDEX idx_signing_keys_created_at ON signing_keys(created_at);
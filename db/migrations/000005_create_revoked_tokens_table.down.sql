@@ -0,0 +1,5 @@
+-- Drop tokens_revoked_at column
+ALTER TABLE auths DROP COLUMN IF EXISTS tokens_revoked_at;
+
+-- Drop revoked_tokens table
+DROP TABLE IF EXISTS revoked_tokens;
@@ -0,0 +1,15 @@
+-- Create revoked_tokens table
+-- Access tokens revoked before they expire, keyed by their jti claim. Rows can be deleted
+-- once expires_at has passed because the token is rejected as expired from then on.
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(36) PRIMARY KEY,
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auth_id VARCHAR(36) NOT NULL REFERENCES auths(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on expires_at for cleanup
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+-- Every access token issued to an auth at or before tokens_revoked_at is revoked
+ALTER TABLE auths ADD COLUMN tokens_revoked_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,2 @@
+-- Drop auth_codes table
+DROP TABLE IF EXISTS auth_codes;
@@ -0,0 +1,16 @@
+-- Create auth_codes table
+-- One-time authorization codes handed to the frontend after an OAuth callback. Only the
+-- SHA-256 hash of each code is stored, together with the PKCE S256 challenge the code
+-- verifier must match when the code is exchanged for tokens.
+CREATE TABLE auth_codes (
+    id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auth_id VARCHAR(36) NOT NULL REFERENCES auths(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL UNIQUE,
+    code_challenge VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index on expires_at for cleanup
+CREATE INDEX idx_auth_codes_expires_at ON auth_codes(expires_at);
@@ -0,0 +1,14 @@
+-- Create sessions table
+-- Server-side sessions of gothic, which hold the OAuth state between the login redirect and
+-- the callback. The cookie only carries the signed and encrypted session id, so any replica
+-- can complete a login another replica started.
+CREATE TABLE sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data BYTEA NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on expires_at for cleanup
+CREATE INDEX idx_sessions_expires_at ON sessions(expires_at);
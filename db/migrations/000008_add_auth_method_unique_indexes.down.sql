@@ -0,0 +1,3 @@
+-- Drop unique indexes of auth_methods
+DROP INDEX IF EXISTS idx_auth_methods_auth_id_provider;
+DROP INDEX IF EXISTS idx_auth_methods_provider_provider_id;
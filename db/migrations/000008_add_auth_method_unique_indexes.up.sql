@@ -0,0 +1,7 @@
+-- A provider account can belong to a single auth, and an auth links each provider at most
+-- once. Soft deleted rows are left out so an unlinked provider can be linked again.
+CREATE UNIQUE INDEX idx_auth_methods_provider_provider_id ON auth_methods(provider, provider_id)
+WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX idx_auth_methods_auth_id_provider ON auth_methods(auth_id, provider)
+WHERE deleted_at IS NULL;
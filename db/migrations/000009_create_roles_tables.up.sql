@@ -0,0 +1,30 @@
+-- Create roles table
+-- Roles map to named permissions such as cockroach:read. A permission ending in :* grants
+-- every permission of its resource, * grants every permission.
+CREATE TABLE roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create role_permissions table
+CREATE TABLE role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role, permission)
+);
+
+-- Seed the built-in roles with their default permissions
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access'),
+    ('staff', 'Manages cockroach reports and reads users'),
+    ('user', 'Reports cockroaches');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', '*'),
+    ('staff', 'users:read'),
+    ('staff', 'cockroach:*'),
+    ('user', 'cockroach:read'),
+    ('user', 'cockroach:write');
@@ -0,0 +1,20 @@
+-- Create api_keys table
+-- Keys for machine-to-machine clients, acting as the auth that owns them. A key is
+-- "<prefix>.<secret>": the prefix finds the row and is kept in clear so admins can tell keys
+-- apart, only the SHA-256 hash of the whole key is stored. Scopes limit the permissions the
+-- key gets from the role of its owner.
+CREATE TABLE api_keys (
+    id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auth_id VARCHAR(36) NOT NULL REFERENCES auths(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index on auth_id for listing the keys of an auth
+CREATE INDEX idx_api_keys_auth_id ON api_keys(auth_id);
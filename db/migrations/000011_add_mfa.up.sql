@@ -0,0 +1,18 @@
+-- TOTP MFA of an auth. mfa_secret is set on enrolment and mfa_enabled_at once a first code
+-- confirms it. mfa_last_step is the time step of the last accepted code, so a code cannot be
+-- used twice.
+ALTER TABLE auths ADD COLUMN mfa_secret VARCHAR(64);
+ALTER TABLE auths ADD COLUMN mfa_enabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE auths ADD COLUMN mfa_last_step BIGINT;
+
+-- Create mfa_recovery_codes table
+-- One-time codes that replace a TOTP code when the authenticator is lost. Only the SHA-256
+-- hash of each code is stored.
+CREATE TABLE mfa_recovery_codes (
+    id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auth_id VARCHAR(36) NOT NULL REFERENCES auths(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (auth_id, code_hash)
+);
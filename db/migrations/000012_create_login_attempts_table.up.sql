@@ -0,0 +1,12 @@
+-- Create login_attempts table
+-- Failed logins counted per key, such as "ip:203.0.113.7" or "account:john", so the throttle
+-- of every replica sees the failures of the others. Keys are blocked until blocked_until.
+CREATE TABLE login_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    blocked_until TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index on last_failure_at for cleanup
+CREATE INDEX idx_login_attempts_last_failure_at ON login_attempts(last_failure_at);
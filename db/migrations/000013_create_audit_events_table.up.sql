@@ -0,0 +1,23 @@
+-- Create audit_events table
+-- Security relevant events of the auth module, such as logins, role changes and deletions: who
+-- did what to whom, from which client, and whether it succeeded. Rows are never updated. Actor
+-- and target are not foreign keys, so the events of a user outlive the user.
+CREATE TABLE audit_events (
+    id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    actor_id VARCHAR(36),
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id VARCHAR(255),
+    outcome VARCHAR(20) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    request_id VARCHAR(64),
+    metadata JSONB NOT NULL DEFAULT '{}'
+);
+
+-- Create indexes for the filters of the admin query, newest first
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_actor_id ON audit_events(actor_id, created_at DESC);
+CREATE INDEX idx_audit_events_target ON audit_events(target_id, created_at DESC);
+CREATE INDEX idx_audit_events_action ON audit_events(action, created_at DESC);
@@ -0,0 +1,2 @@
+-- Drop data_requests table
+DROP TABLE IF EXISTS data_requests;
@@ -0,0 +1,27 @@
+-- Create data_requests table
+-- Data subject requests: exports of everything stored about an auth, and erasures deleting or
+-- anonymizing it. Requests are jobs run in the background by any replica. auth_id is not a
+-- foreign key, so the record of an erasure outlives the auth it erased. The JSON document of
+-- a completed export is kept in result until expires_at.
+CREATE TABLE data_requests (
+    id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auth_id VARCHAR(36) NOT NULL,
+    requested_by VARCHAR(36),
+    kind VARCHAR(20) NOT NULL,
+    erasure_mode VARCHAR(20),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    result BYTEA,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for listing the requests of an auth and for picking up pending ones
+CREATE INDEX idx_data_requests_auth_id ON data_requests(auth_id, created_at DESC);
+CREATE INDEX idx_data_requests_status ON data_requests(status, created_at);
+
+-- An auth has at most one export and one erasure waiting or running at a time
+CREATE UNIQUE INDEX idx_data_requests_open ON data_requests(auth_id, kind)
+    WHERE status IN ('pending', 'running');
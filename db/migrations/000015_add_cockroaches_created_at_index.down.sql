@@ -0,0 +1,2 @@
+-- Drop created_at index of cockroaches
+DROP INDEX IF EXISTS idx_cockroaches_created_at;
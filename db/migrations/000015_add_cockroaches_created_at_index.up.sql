@@ -0,0 +1,2 @@
+-- Index the cockroaches listed newest first and filtered by creation time
+CREATE INDEX idx_cockroaches_created_at ON cockroaches(created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Drop created_at index of auths
+DROP INDEX IF EXISTS idx_auths_created_at;
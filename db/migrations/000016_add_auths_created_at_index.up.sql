@@ -0,0 +1,2 @@
+-- Index the auths paged through newest first by the admin user list
+CREATE INDEX idx_auths_created_at ON auths(created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Narrow mfa_secret of auths back, which fails while encrypted secrets are stored
+ALTER TABLE auths ALTER COLUMN mfa_secret TYPE VARCHAR(64);
@@ -0,0 +1,2 @@
+-- Widen mfa_secret of auths for the enc:v1 envelope of the encrypted TOTP secret
+ALTER TABLE auths ALTER COLUMN mfa_secret TYPE TEXT;
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migration files so the binary does not depend on the source tree at runtime.
+package migrations
+
+import "embed"
+
+// FS holds every *.sql migration in this directory.
+//
+//go:embed *.sql
+var FS embed.FS
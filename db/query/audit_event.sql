@@ -0,0 +1,46 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor_id, action, target_type, target_id, outcome, ip_address, user_agent, request_id, metadata)
+VALUES (@actor_id, @action, @target_type, @target_id, @outcome, @ip_address, @user_agent, @request_id, @metadata)
+RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg('actor_id')::varchar IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('action')::varchar IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('target_type')::varchar IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::varchar IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('outcome')::varchar IS NULL OR outcome = sqlc.narg('outcome'))
+  AND (sqlc.narg('ip_address')::varchar IS NULL OR ip_address = sqlc.narg('ip_address'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*) FROM audit_events
+WHERE (sqlc.narg('actor_id')::varchar IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('action')::varchar IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('target_type')::varchar IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::varchar IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('outcome')::varchar IS NULL OR outcome = sqlc.narg('outcome'))
+  AND (sqlc.narg('ip_address')::varchar IS NULL OR ip_address = sqlc.narg('ip_address'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'));
+
+-- name: ListAuditEventsBySubject :many
+-- Events done by or to auth_id, oldest first
+SELECT * FROM audit_events
+WHERE actor_id = @auth_id::varchar OR target_id = @auth_id::varchar
+ORDER BY created_at, id;
+
+-- name: AnonymizeAuditEvents :execrows
+-- Removes the username and email from the metadata of the events of auth_id and of the events
+-- naming one of identifiers, such as failed logins, and the client of those it did itself.
+-- identifiers are lowercase; the usernames are compared trimmed and lowercased, as logins may
+-- be attempted with any case. The events stay, so the log keeps what happened.
+UPDATE audit_events
+SET ip_address = CASE WHEN actor_id IS NULL OR actor_id = @auth_id::varchar THEN NULL ELSE ip_address END,
+    user_agent = CASE WHEN actor_id IS NULL OR actor_id = @auth_id::varchar THEN NULL ELSE user_agent END,
+    metadata = metadata - 'username' - 'email'
+WHERE actor_id = @auth_id::varchar OR target_id = @auth_id::varchar
+   OR lower(btrim(metadata->>'username')) = ANY(@identifiers::text[]);
@@ -10,6 +10,11 @@ WHERE username = $1 AND deleted_at IS NULL;
 SELECT * FROM auths
 WHERE email = $1 AND deleted_at IS NULL;
 
+-- name: LockAuthByID :one
+SELECT * FROM auths
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
 -- name: CreateAuth :one
 INSERT INTO auths (username, password, email, role, active)
 VALUES ($1, $2, $3, $4, $5)
@@ -21,16 +26,80 @@ SET username = $2, password = $3, email = $4, role = $5, active = $6, updated_at
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: UpdateAuthPassword :exec
+UPDATE auths
+SET password = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL;
+
 -- name: SoftDeleteAuth :exec
 UPDATE auths
 SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: CloseAuth :execrows
+-- Soft deletes the auth of a user closing their account, freeing its username and email for
+-- a new account
+UPDATE auths
+SET username = NULL, email = NULL, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL;
+
 -- name: ListAllAuths :many
 SELECT * FROM auths
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC;
 
+-- name: ListAuths :many
+-- The auths matching the filter after the cursor, or before it when backward is set, newest
+-- first; without a cursor, the first ones. See ListCockroaches for the shape of the query.
+WITH matching AS NOT MATERIALIZED (
+    SELECT * FROM auths
+    WHERE (deleted_at IS NOT NULL) = sqlc.arg('deleted')::boolean
+      AND (sqlc.narg('role')::varchar IS NULL OR role = sqlc.narg('role'))
+      AND (sqlc.narg('active')::boolean IS NULL OR active = sqlc.narg('active'))
+      AND (sqlc.narg('provider')::varchar IS NULL OR EXISTS (
+        SELECT 1 FROM auth_methods
+        WHERE auth_methods.auth_id = auths.id AND auth_methods.provider = sqlc.narg('provider') AND auth_methods.deleted_at IS NULL
+      ))
+      AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+      AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+)
+(
+    SELECT * FROM matching
+    WHERE NOT sqlc.arg('backward')::boolean
+      AND (created_at, id) < (COALESCE(sqlc.narg('cursor_created_at')::timestamptz, 'infinity'), COALESCE(sqlc.narg('cursor_id')::varchar, ''))
+    ORDER BY created_at DESC, id DESC
+    LIMIT sqlc.arg('limit')
+)
+UNION ALL
+(
+    SELECT * FROM (
+        SELECT * FROM matching
+        WHERE sqlc.arg('backward')::boolean
+          AND (created_at, id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::varchar)
+        ORDER BY created_at ASC, id ASC
+        LIMIT sqlc.arg('limit')
+    ) AS before_cursor
+)
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateAuthRole :one
+UPDATE auths
+SET role = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateAuthActive :one
+UPDATE auths
+SET active = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RestoreAuth :one
+UPDATE auths
+SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
 -- Auth Methods queries
 -- name: CreateAuthMethod :one
 INSERT INTO auth_methods (
@@ -59,3 +128,61 @@ RETURNING *;
 UPDATE auth_methods
 SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListAuthMethodsToReencrypt :many
+-- Auth methods after after_id holding a token that does not start with key_prefix, i.e. a
+-- token stored in plaintext or encrypted with another key than the active one
+SELECT * FROM auth_methods
+WHERE id > @after_id
+  AND (
+    (access_token IS NOT NULL AND access_token NOT LIKE @key_prefix::text || '%')
+    OR (refresh_token IS NOT NULL AND refresh_token NOT LIKE @key_prefix::text || '%')
+    OR (id_token IS NOT NULL AND id_token NOT LIKE @key_prefix::text || '%')
+    OR (access_token_secret IS NOT NULL AND access_token_secret NOT LIKE @key_prefix::text || '%')
+  )
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: UpdateAuthMethodTokens :execrows
+-- Rewrites the tokens of an auth method unless it was updated since it was read at updated_at.
+-- updated_at is left alone, the tokens keep their values.
+UPDATE auth_methods
+SET access_token = @access_token, refresh_token = @refresh_token, id_token = @id_token,
+    access_token_secret = @access_token_secret
+WHERE id = @id AND updated_at = @updated_at;
+
+-- name: LockAnyAuthByID :one
+-- Like LockAuthByID, soft-deleted auths included
+SELECT * FROM auths
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListAllAuthMethodsByAuthID :many
+-- Every auth method of auth_id, unlinked ones included
+SELECT * FROM auth_methods
+WHERE auth_id = $1
+ORDER BY created_at, id;
+
+-- name: DeleteAuth :execrows
+-- Deletes the auth for good, together with every row referencing it
+DELETE FROM auths
+WHERE id = $1;
+
+-- name: AnonymizeAuth :execrows
+-- Strips the auth of everything identifying its owner and of its credentials, keeping the row
+-- so that what references it still resolves
+UPDATE auths
+SET username = NULL, password = NULL, email = NULL, active = false,
+    mfa_secret = NULL, mfa_enabled_at = NULL, mfa_last_step = NULL,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP), tokens_revoked_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteAuthMethodsByAuthID :exec
+DELETE FROM auth_methods
+WHERE auth_id = $1;
+
+-- name: GetAnyAuthByID :one
+-- Like GetAuthByID, soft-deleted auths included
+SELECT * FROM auths
+WHERE id = $1;
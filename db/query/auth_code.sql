@@ -0,0 +1,20 @@
+-- name: CreateAuthCode :one
+INSERT INTO auth_codes (auth_id, code_hash, code_challenge, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ConsumeAuthCode :one
+-- Marks the code used and returns it, only once and only before it expires, so a code
+-- cannot be exchanged twice even by concurrent requests
+UPDATE auth_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE code_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteExpiredAuthCodes :execrows
+DELETE FROM auth_codes
+WHERE expires_at <= CURRENT_TIMESTAMP OR used_at IS NOT NULL;
+
+-- name: DeleteAuthCodesByAuthID :exec
+DELETE FROM auth_codes
+WHERE auth_id = $1;
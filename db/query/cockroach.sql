@@ -8,8 +8,32 @@ SELECT id, amount, created_at FROM cockroaches
 WHERE id = $1;
 
 -- name: ListCockroaches :many
-SELECT id, amount, created_at FROM cockroaches
-ORDER BY created_at DESC;
+-- The cockroaches matching the filter after the cursor, or before it when backward is set,
+-- newest first; without a cursor, the first ones. The filter is written once in matching, and
+-- only the branch of the direction runs, each walking the created_at index from the cursor.
+WITH matching AS NOT MATERIALIZED (
+    SELECT id, amount, created_at FROM cockroaches
+    WHERE (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+      AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+)
+(
+    SELECT * FROM matching
+    WHERE NOT sqlc.arg('backward')::boolean
+      AND (created_at, id) < (COALESCE(sqlc.narg('cursor_created_at')::timestamptz, 'infinity'), COALESCE(sqlc.narg('cursor_id')::integer, 0))
+    ORDER BY created_at DESC, id DESC
+    LIMIT sqlc.arg('limit')
+)
+UNION ALL
+(
+    SELECT * FROM (
+        SELECT * FROM matching
+        WHERE sqlc.arg('backward')::boolean
+          AND (created_at, id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::integer)
+        ORDER BY created_at ASC, id ASC
+        LIMIT sqlc.arg('limit')
+    ) AS before_cursor
+)
+ORDER BY created_at DESC, id DESC;
 
 -- name: UpdateCockroach :one
 UPDATE cockroaches
@@ -17,6 +41,6 @@ SET amount = $2
 WHERE id = $1
 RETURNING id, amount, created_at;
 
--- name: DeleteCockroach :exec
+-- name: DeleteCockroach :execrows
 DELETE FROM cockroaches
 WHERE id = $1;
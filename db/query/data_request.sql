@@ -0,0 +1,59 @@
+-- name: CreateDataRequest :one
+INSERT INTO data_requests (auth_id, requested_by, kind, erasure_mode)
+VALUES (@auth_id, @requested_by, @kind, @erasure_mode)
+RETURNING *;
+
+-- name: GetDataRequest :one
+SELECT * FROM data_requests
+WHERE id = $1;
+
+-- name: ListDataRequests :many
+SELECT id, created_at, auth_id, requested_by, kind, erasure_mode, status, error, started_at, completed_at, expires_at
+FROM data_requests
+WHERE (sqlc.narg('auth_id')::varchar IS NULL OR auth_id = sqlc.narg('auth_id'))
+  AND (sqlc.narg('kind')::varchar IS NULL OR kind = sqlc.narg('kind'))
+  AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountDataRequests :one
+SELECT COUNT(*) FROM data_requests
+WHERE (sqlc.narg('auth_id')::varchar IS NULL OR auth_id = sqlc.narg('auth_id'))
+  AND (sqlc.narg('kind')::varchar IS NULL OR kind = sqlc.narg('kind'))
+  AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'));
+
+-- name: ClaimDataRequest :one
+-- Marks the oldest pending request running and returns it. Requests left running since before
+-- stale_before by a replica that stopped are picked up again. SKIP LOCKED lets replicas claim
+-- different requests concurrently.
+UPDATE data_requests
+SET status = 'running', started_at = CURRENT_TIMESTAMP
+WHERE id = (
+    SELECT id FROM data_requests
+    WHERE status = 'pending' OR (status = 'running' AND started_at < @stale_before::timestamptz)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteDataRequest :exec
+UPDATE data_requests
+SET status = 'completed', completed_at = CURRENT_TIMESTAMP, error = NULL, result = @result, expires_at = @expires_at
+WHERE id = @id;
+
+-- name: FailDataRequest :exec
+UPDATE data_requests
+SET status = 'failed', completed_at = CURRENT_TIMESTAMP, error = @reason
+WHERE id = @id;
+
+-- name: DeleteDataRequestResults :execrows
+-- Drops the export documents of auth_id, e.g. when the auth is erased
+UPDATE data_requests
+SET result = NULL, expires_at = LEAST(expires_at, CURRENT_TIMESTAMP)
+WHERE auth_id = $1 AND result IS NOT NULL;
+
+-- name: DeleteExpiredDataRequestResults :execrows
+UPDATE data_requests
+SET result = NULL
+WHERE result IS NOT NULL AND expires_at <= CURRENT_TIMESTAMP;
@@ -0,0 +1,59 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts
+WHERE key = $1;
+
+-- name: RecordLoginFailure :one
+-- Counts a failure of the key, starting over when its last failure is before window_start
+INSERT INTO login_attempts (key, failures, last_failure_at)
+VALUES (@key, 1, CURRENT_TIMESTAMP)
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < @window_start THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ReserveLoginAttempt :one
+-- Counts an attempt of the key as failed before it is made, starting over when its last failure
+-- is before window_start, and blocks the key for the delay in milliseconds delays has for its
+-- failures, the last one past its end. Concurrent attempts are so turned away until the attempt
+-- is released. Returns no row when the key is blocked.
+INSERT INTO login_attempts (key, failures, last_failure_at, blocked_until)
+VALUES (@key, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP + (@delays::bigint[])[1] * INTERVAL '1 millisecond')
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < @window_start THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = CURRENT_TIMESTAMP,
+    blocked_until = CURRENT_TIMESTAMP + (@delays::bigint[])[LEAST(
+        CASE
+            WHEN login_attempts.last_failure_at < @window_start THEN 1
+            ELSE login_attempts.failures + 1
+        END,
+        cardinality(@delays::bigint[])
+    )] * INTERVAL '1 millisecond'
+WHERE login_attempts.blocked_until IS NULL OR login_attempts.blocked_until <= CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ReleaseLoginAttempt :exec
+-- Takes back an attempt counted by ReserveLoginAttempt that did not fail, and its block
+UPDATE login_attempts
+SET failures = GREATEST(failures - 1, 0),
+    blocked_until = NULL
+WHERE key = $1;
+
+-- name: BlockLoginAttempts :exec
+UPDATE login_attempts
+SET blocked_until = $2
+WHERE key = $1;
+
+-- name: DeleteLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE key = $1;
+
+-- name: DeleteExpiredLoginAttempts :execrows
+DELETE FROM login_attempts
+WHERE last_failure_at < @before
+  AND (blocked_until IS NULL OR blocked_until < CURRENT_TIMESTAMP);
@@ -0,0 +1,51 @@
+-- name: SetMFASecret :one
+-- Starts an enrolment, replacing the secret of an enrolment that was never confirmed
+UPDATE auths
+SET mfa_secret = $2, mfa_last_step = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND mfa_enabled_at IS NULL
+RETURNING *;
+
+-- name: EnableMFA :execrows
+UPDATE auths
+SET mfa_enabled_at = CURRENT_TIMESTAMP, mfa_last_step = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND mfa_secret IS NOT NULL AND mfa_enabled_at IS NULL;
+
+-- name: UseMFAStep :execrows
+-- Records the time step of an accepted code, only when it is later than the last one, so
+-- concurrent requests cannot both use the same code
+UPDATE auths
+SET mfa_last_step = $2
+WHERE id = $1 AND (mfa_last_step IS NULL OR mfa_last_step < $2);
+
+-- name: ListMFASecretsToReencrypt :many
+-- Auths after after_id whose MFA secret does not start with key_prefix, i.e. a secret stored in
+-- plaintext or encrypted with another key than the active one
+SELECT * FROM auths
+WHERE id > @after_id AND mfa_secret IS NOT NULL AND mfa_secret NOT LIKE @key_prefix::text || '%'
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: UpdateMFASecret :execrows
+-- Rewrites the MFA secret of an auth unless it was updated since it was read at updated_at.
+-- updated_at is left alone, the secret keeps its value.
+UPDATE auths
+SET mfa_secret = @secret
+WHERE id = @id AND updated_at = @updated_at;
+
+-- name: DisableMFA :exec
+UPDATE auths
+SET mfa_secret = NULL, mfa_enabled_at = NULL, mfa_last_step = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (auth_id, code_hash)
+VALUES ($1, $2);
+
+-- name: UseMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE auth_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE auth_id = $1;
@@ -0,0 +1,35 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (auth_id, family_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = $1;
+
+-- name: MarkRefreshTokenUsed :one
+-- Only succeeds for a token that has not been used or revoked yet, so two concurrent
+-- refreshes with the same token cannot both rotate it
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE family_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokensByAuthID :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE auth_id = $1 AND revoked_at IS NULL;
+
+-- name: ListRefreshTokensByAuthID :many
+SELECT * FROM refresh_tokens
+WHERE auth_id = $1
+ORDER BY created_at, id;
+
+-- name: DeleteRefreshTokensByAuthID :exec
+DELETE FROM refresh_tokens
+WHERE auth_id = $1;
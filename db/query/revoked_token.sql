@@ -0,0 +1,24 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (jti, auth_id, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS (
+    SELECT 1 FROM revoked_tokens
+    WHERE jti = $1
+);
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at <= CURRENT_TIMESTAMP;
+
+-- name: RevokeAuthTokens :one
+UPDATE auths
+SET tokens_revoked_at = date_trunc('second', CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING tokens_revoked_at;
+
+-- name: GetAuthTokensRevokedAt :one
+SELECT tokens_revoked_at FROM auths
+WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- name: ListRolePermissions :many
+SELECT * FROM role_permissions
+ORDER BY role, permission;
@@ -0,0 +1,17 @@
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE id = $1 AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: SaveSession :exec
+INSERT INTO sessions (id, data, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (id) DO UPDATE
+SET data = EXCLUDED.data, expires_at = EXCLUDED.expires_at, updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE id = $1;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at <= CURRENT_TIMESTAMP;
@@ -0,0 +1,30 @@
+-- name: CreateSigningKey :one
+INSERT INTO signing_keys (kid, algorithm, private_key)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListSigningKeys :many
+-- Keys that can still verify tokens, newest first
+SELECT * FROM signing_keys
+WHERE expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP
+ORDER BY created_at DESC;
+
+-- name: GetActiveSigningKey :one
+SELECT * FROM signing_keys
+WHERE retired_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: RetireSigningKeys :exec
+-- Retires every active key except kid. Retired keys verify until expires_at.
+UPDATE signing_keys
+SET retired_at = CURRENT_TIMESTAMP, expires_at = $2
+WHERE kid <> $1 AND retired_at IS NULL;
+
+-- name: DeleteExpiredSigningKeys :exec
+DELETE FROM signing_keys
+WHERE expires_at <= CURRENT_TIMESTAMP;
+
+-- name: LockSigningKeys :exec
+-- Serializes rotations across replicas until the end of the transaction
+SELECT pg_advisory_xact_lock(7305128961624154978);
@@ -0,0 +1,159 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: api_key.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAPIKey = `-- name: CreateAPIKey :one
+INSERT INTO api_keys (auth_id, name, prefix, key_hash, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at, auth_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at
+`
+
+type CreateAPIKeyParams struct {
+	AuthID    string             `json:"auth_id"`
+	Name      string             `json:"name"`
+	Prefix    string             `json:"prefix"`
+	KeyHash   string             `json:"key_hash"`
+	Scopes    []string           `json:"scopes"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (APIKey, error) {
+	row := q.db.QueryRow(ctx, createAPIKey,
+		arg.AuthID,
+		arg.Name,
+		arg.Prefix,
+		arg.KeyHash,
+		arg.Scopes,
+		arg.ExpiresAt,
+	)
+	var i APIKey
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const deleteAPIKeysByAuthID = `-- name: DeleteAPIKeysByAuthID :exec
+DELETE FROM api_keys
+WHERE auth_id = $1
+`
+
+func (q *Queries) DeleteAPIKeysByAuthID(ctx context.Context, authID string) error {
+	_, err := q.db.Exec(ctx, deleteAPIKeysByAuthID, authID)
+	return err
+}
+
+const getAPIKeyByPrefix = `-- name: GetAPIKeyByPrefix :one
+SELECT id, created_at, auth_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at FROM api_keys
+WHERE prefix = $1
+`
+
+func (q *Queries) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	row := q.db.QueryRow(ctx, getAPIKeyByPrefix, prefix)
+	var i APIKey
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const listAPIKeys = `-- name: ListAPIKeys :many
+SELECT id, created_at, auth_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at FROM api_keys
+WHERE $1::varchar IS NULL OR auth_id = $1
+ORDER BY created_at DESC, id DESC
+`
+
+// Lists every key, newest first, or only the keys of auth_id when it is given
+func (q *Queries) ListAPIKeys(ctx context.Context, authID *string) ([]APIKey, error) {
+	rows, err := q.db.Query(ctx, listAPIKeys, authID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []APIKey
+	for rows.Next() {
+		var i APIKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.AuthID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			&i.Scopes,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeAPIKey = `-- name: RevokeAPIKey :one
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING id, created_at, auth_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at
+`
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, id string) (APIKey, error) {
+	row := q.db.QueryRow(ctx, revokeAPIKey, id)
+	var i APIKey
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const touchAPIKey = `-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) TouchAPIKey(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, touchAPIKey, id)
+	return err
+}
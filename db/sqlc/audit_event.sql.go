@@ -0,0 +1,228 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: audit_event.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const anonymizeAuditEvents = `-- name: AnonymizeAuditEvents :execrows
+UPDATE audit_events
+SET ip_address = CASE WHEN actor_id IS NULL OR actor_id = $1::varchar THEN NULL ELSE ip_address END,
+    user_agent = CASE WHEN actor_id IS NULL OR actor_id = $1::varchar THEN NULL ELSE user_agent END,
+    metadata = metadata - 'username' - 'email'
+WHERE actor_id = $1::varchar OR target_id = $1::varchar
+   OR lower(btrim(metadata->>'username')) = ANY($2::text[])
+`
+
+// Removes the username and email from the metadata of the events of auth_id and of the events
+// naming one of identifiers, such as failed logins, and the client of those it did itself.
+// identifiers are lowercase; the usernames are compared trimmed and lowercased, as logins may
+// be attempted with any case. The events stay, so the log keeps what happened.
+func (q *Queries) AnonymizeAuditEvents(ctx context.Context, authID string, identifiers []string) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeAuditEvents, authID, identifiers)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countAuditEvents = `-- name: CountAuditEvents :one
+SELECT COUNT(*) FROM audit_events
+WHERE ($1::varchar IS NULL OR actor_id = $1)
+  AND ($2::varchar IS NULL OR action = $2)
+  AND ($3::varchar IS NULL OR target_type = $3)
+  AND ($4::varchar IS NULL OR target_id = $4)
+  AND ($5::varchar IS NULL OR outcome = $5)
+  AND ($6::varchar IS NULL OR ip_address = $6)
+  AND ($7::timestamptz IS NULL OR created_at >= $7)
+  AND ($8::timestamptz IS NULL OR created_at < $8)
+`
+
+type CountAuditEventsParams struct {
+	ActorID     *string            `json:"actor_id"`
+	Action      *string            `json:"action"`
+	TargetType  *string            `json:"target_type"`
+	TargetID    *string            `json:"target_id"`
+	Outcome     *string            `json:"outcome"`
+	IpAddress   *string            `json:"ip_address"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
+}
+
+func (q *Queries) CountAuditEvents(ctx context.Context, arg CountAuditEventsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAuditEvents,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Outcome,
+		arg.IpAddress,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor_id, action, target_type, target_id, outcome, ip_address, user_agent, request_id, metadata)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, created_at, actor_id, action, target_type, target_id, outcome, ip_address, user_agent, request_id, metadata
+`
+
+type CreateAuditEventParams struct {
+	ActorID    *string `json:"actor_id"`
+	Action     string  `json:"action"`
+	TargetType *string `json:"target_type"`
+	TargetID   *string `json:"target_id"`
+	Outcome    string  `json:"outcome"`
+	IpAddress  *string `json:"ip_address"`
+	UserAgent  *string `json:"user_agent"`
+	RequestID  *string `json:"request_id"`
+	Metadata   []byte  `json:"metadata"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRow(ctx, createAuditEvent,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Outcome,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.RequestID,
+		arg.Metadata,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ActorID,
+		&i.Action,
+		&i.TargetType,
+		&i.TargetID,
+		&i.Outcome,
+		&i.IpAddress,
+		&i.UserAgent,
+		&i.RequestID,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, created_at, actor_id, action, target_type, target_id, outcome, ip_address, user_agent, request_id, metadata FROM audit_events
+WHERE ($1::varchar IS NULL OR actor_id = $1)
+  AND ($2::varchar IS NULL OR action = $2)
+  AND ($3::varchar IS NULL OR target_type = $3)
+  AND ($4::varchar IS NULL OR target_id = $4)
+  AND ($5::varchar IS NULL OR outcome = $5)
+  AND ($6::varchar IS NULL OR ip_address = $6)
+  AND ($7::timestamptz IS NULL OR created_at >= $7)
+  AND ($8::timestamptz IS NULL OR created_at < $8)
+ORDER BY created_at DESC, id DESC
+LIMIT $10 OFFSET $9
+`
+
+type ListAuditEventsParams struct {
+	ActorID     *string            `json:"actor_id"`
+	Action      *string            `json:"action"`
+	TargetType  *string            `json:"target_type"`
+	TargetID    *string            `json:"target_id"`
+	Outcome     *string            `json:"outcome"`
+	IpAddress   *string            `json:"ip_address"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
+	Offset      int32              `json:"offset"`
+	Limit       int32              `json:"limit"`
+}
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEvents,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Outcome,
+		arg.IpAddress,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ActorID,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Outcome,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEventsBySubject = `-- name: ListAuditEventsBySubject :many
+SELECT id, created_at, actor_id, action, target_type, target_id, outcome, ip_address, user_agent, request_id, metadata FROM audit_events
+WHERE actor_id = $1::varchar OR target_id = $1::varchar
+ORDER BY created_at, id
+`
+
+// Events done by or to auth_id, oldest first
+func (q *Queries) ListAuditEventsBySubject(ctx context.Context, authID string) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEventsBySubject, authID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ActorID,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Outcome,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -11,10 +11,45 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeAuth = `-- name: AnonymizeAuth :execrows
+UPDATE auths
+SET username = NULL, password = NULL, email = NULL, active = false,
+    mfa_secret = NULL, mfa_enabled_at = NULL, mfa_last_step = NULL,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP), tokens_revoked_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+// Strips the auth of everything identifying its owner and of its credentials, keeping the row
+// so that what references it still resolves
+func (q *Queries) AnonymizeAuth(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeAuth, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const closeAuth = `-- name: CloseAuth :execrows
+UPDATE auths
+SET username = NULL, email = NULL, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+// Soft deletes the auth of a user closing their account, freeing its username and email for
+// a new account
+func (q *Queries) CloseAuth(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, closeAuth, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createAuth = `-- name: CreateAuth :one
 INSERT INTO auths (username, password, email, role, active)
 VALUES ($1, $2, $3, $4, $5)
-RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active
+RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step
 `
 
 func (q *Queries) CreateAuth(ctx context.Context, username *string, password *string, email *string, role string, active bool) (Auth, error) {
@@ -36,6 +71,10 @@ func (q *Queries) CreateAuth(ctx context.Context, username *string, password *st
 		&i.Email,
 		&i.Role,
 		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
 	)
 	return i, err
 }
@@ -118,8 +157,59 @@ func (q *Queries) CreateAuthMethod(ctx context.Context, arg CreateAuthMethodPara
 	return i, err
 }
 
+const deleteAuth = `-- name: DeleteAuth :execrows
+DELETE FROM auths
+WHERE id = $1
+`
+
+// Deletes the auth for good, together with every row referencing it
+func (q *Queries) DeleteAuth(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuth, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteAuthMethodsByAuthID = `-- name: DeleteAuthMethodsByAuthID :exec
+DELETE FROM auth_methods
+WHERE auth_id = $1
+`
+
+func (q *Queries) DeleteAuthMethodsByAuthID(ctx context.Context, authID *string) error {
+	_, err := q.db.Exec(ctx, deleteAuthMethodsByAuthID, authID)
+	return err
+}
+
+const getAnyAuthByID = `-- name: GetAnyAuthByID :one
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
+WHERE id = $1
+`
+
+// Like GetAuthByID, soft-deleted auths included
+func (q *Queries) GetAnyAuthByID(ctx context.Context, id string) (Auth, error) {
+	row := q.db.QueryRow(ctx, getAnyAuthByID, id)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
+
 const getAuthByEmail = `-- name: GetAuthByEmail :one
-SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active FROM auths
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
 WHERE email = $1 AND deleted_at IS NULL
 `
 
@@ -136,12 +226,16 @@ func (q *Queries) GetAuthByEmail(ctx context.Context, email *string) (Auth, erro
 		&i.Email,
 		&i.Role,
 		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
 	)
 	return i, err
 }
 
 const getAuthByID = `-- name: GetAuthByID :one
-SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active FROM auths
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -158,12 +252,16 @@ func (q *Queries) GetAuthByID(ctx context.Context, id string) (Auth, error) {
 		&i.Email,
 		&i.Role,
 		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
 	)
 	return i, err
 }
 
 const getAuthByUsername = `-- name: GetAuthByUsername :one
-SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active FROM auths
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
 WHERE username = $1 AND deleted_at IS NULL
 `
 
@@ -180,6 +278,10 @@ func (q *Queries) GetAuthByUsername(ctx context.Context, username *string) (Auth
 		&i.Email,
 		&i.Role,
 		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
 	)
 	return i, err
 }
@@ -265,8 +367,57 @@ func (q *Queries) GetAuthMethodsByAuthID(ctx context.Context, authID *string) ([
 	return items, nil
 }
 
+const listAllAuthMethodsByAuthID = `-- name: ListAllAuthMethodsByAuthID :many
+SELECT id, created_at, updated_at, deleted_at, auth_id, provider, provider_id, email, user_id, name, first_name, last_name, nick_name, description, avatar_url, location, access_token, refresh_token, id_token, expires_at, access_token_secret FROM auth_methods
+WHERE auth_id = $1
+ORDER BY created_at, id
+`
+
+// Every auth method of auth_id, unlinked ones included
+func (q *Queries) ListAllAuthMethodsByAuthID(ctx context.Context, authID *string) ([]AuthMethod, error) {
+	rows, err := q.db.Query(ctx, listAllAuthMethodsByAuthID, authID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthMethod
+	for rows.Next() {
+		var i AuthMethod
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AuthID,
+			&i.Provider,
+			&i.ProviderID,
+			&i.Email,
+			&i.UserID,
+			&i.Name,
+			&i.FirstName,
+			&i.LastName,
+			&i.NickName,
+			&i.Description,
+			&i.AvatarUrl,
+			&i.Location,
+			&i.AccessToken,
+			&i.RefreshToken,
+			&i.IDToken,
+			&i.ExpiresAt,
+			&i.AccessTokenSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAllAuths = `-- name: ListAllAuths :many
-SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active FROM auths
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 `
@@ -290,6 +441,10 @@ func (q *Queries) ListAllAuths(ctx context.Context) ([]Auth, error) {
 			&i.Email,
 			&i.Role,
 			&i.Active,
+			&i.TokensRevokedAt,
+			&i.MFASecret,
+			&i.MFAEnabledAt,
+			&i.MFALastStep,
 		); err != nil {
 			return nil, err
 		}
@@ -301,6 +456,255 @@ func (q *Queries) ListAllAuths(ctx context.Context) ([]Auth, error) {
 	return items, nil
 }
 
+const listAuthMethodsToReencrypt = `-- name: ListAuthMethodsToReencrypt :many
+SELECT id, created_at, updated_at, deleted_at, auth_id, provider, provider_id, email, user_id, name, first_name, last_name, nick_name, description, avatar_url, location, access_token, refresh_token, id_token, expires_at, access_token_secret FROM auth_methods
+WHERE id > $1
+  AND (
+    (access_token IS NOT NULL AND access_token NOT LIKE $2::text || '%')
+    OR (refresh_token IS NOT NULL AND refresh_token NOT LIKE $2::text || '%')
+    OR (id_token IS NOT NULL AND id_token NOT LIKE $2::text || '%')
+    OR (access_token_secret IS NOT NULL AND access_token_secret NOT LIKE $2::text || '%')
+  )
+ORDER BY id
+LIMIT $3
+`
+
+// Auth methods after after_id holding a token that does not start with key_prefix, i.e. a
+// token stored in plaintext or encrypted with another key than the active one
+func (q *Queries) ListAuthMethodsToReencrypt(ctx context.Context, afterID string, keyPrefix string, batchSize int32) ([]AuthMethod, error) {
+	rows, err := q.db.Query(ctx, listAuthMethodsToReencrypt, afterID, keyPrefix, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthMethod
+	for rows.Next() {
+		var i AuthMethod
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AuthID,
+			&i.Provider,
+			&i.ProviderID,
+			&i.Email,
+			&i.UserID,
+			&i.Name,
+			&i.FirstName,
+			&i.LastName,
+			&i.NickName,
+			&i.Description,
+			&i.AvatarUrl,
+			&i.Location,
+			&i.AccessToken,
+			&i.RefreshToken,
+			&i.IDToken,
+			&i.ExpiresAt,
+			&i.AccessTokenSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuths = `-- name: ListAuths :many
+WITH matching AS NOT MATERIALIZED (
+    SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
+    WHERE (deleted_at IS NOT NULL) = $1::boolean
+      AND ($2::varchar IS NULL OR role = $2)
+      AND ($3::boolean IS NULL OR active = $3)
+      AND ($4::varchar IS NULL OR EXISTS (
+        SELECT 1 FROM auth_methods
+        WHERE auth_methods.auth_id = auths.id AND auth_methods.provider = $4 AND auth_methods.deleted_at IS NULL
+      ))
+      AND ($5::timestamptz IS NULL OR created_at >= $5)
+      AND ($6::timestamptz IS NULL OR created_at < $6)
+)
+(
+    SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM matching
+    WHERE NOT $7::boolean
+      AND (created_at, id) < (COALESCE($8::timestamptz, 'infinity'), COALESCE($9::varchar, ''))
+    ORDER BY created_at DESC, id DESC
+    LIMIT $10
+)
+UNION ALL
+(
+    SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM (
+        SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM matching
+        WHERE $7::boolean
+          AND (created_at, id) > ($8::timestamptz, $9::varchar)
+        ORDER BY created_at ASC, id ASC
+        LIMIT $10
+    ) AS before_cursor
+)
+ORDER BY created_at DESC, id DESC
+`
+
+type ListAuthsParams struct {
+	Deleted         bool               `json:"deleted"`
+	Role            *string            `json:"role"`
+	Active          *bool              `json:"active"`
+	Provider        *string            `json:"provider"`
+	CreatedFrom     pgtype.Timestamptz `json:"created_from"`
+	CreatedTo       pgtype.Timestamptz `json:"created_to"`
+	Backward        bool               `json:"backward"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        *string            `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+type ListAuthsRow struct {
+	ID              string             `json:"id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	Username        *string            `json:"username"`
+	Password        *string            `json:"password"`
+	Email           *string            `json:"email"`
+	Role            string             `json:"role"`
+	Active          bool               `json:"active"`
+	TokensRevokedAt pgtype.Timestamptz `json:"tokens_revoked_at"`
+	MFASecret       *string            `json:"mfa_secret"`
+	MFAEnabledAt    pgtype.Timestamptz `json:"mfa_enabled_at"`
+	MFALastStep     *int64             `json:"mfa_last_step"`
+}
+
+// The auths matching the filter after the cursor, or before it when backward is set, newest
+// first; without a cursor, the first ones. See ListCockroaches for the shape of the query.
+func (q *Queries) ListAuths(ctx context.Context, arg ListAuthsParams) ([]ListAuthsRow, error) {
+	rows, err := q.db.Query(ctx, listAuths,
+		arg.Deleted,
+		arg.Role,
+		arg.Active,
+		arg.Provider,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Backward,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthsRow
+	for rows.Next() {
+		var i ListAuthsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Username,
+			&i.Password,
+			&i.Email,
+			&i.Role,
+			&i.Active,
+			&i.TokensRevokedAt,
+			&i.MFASecret,
+			&i.MFAEnabledAt,
+			&i.MFALastStep,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAnyAuthByID = `-- name: LockAnyAuthByID :one
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
+WHERE id = $1
+FOR UPDATE
+`
+
+// Like LockAuthByID, soft-deleted auths included
+func (q *Queries) LockAnyAuthByID(ctx context.Context, id string) (Auth, error) {
+	row := q.db.QueryRow(ctx, lockAnyAuthByID, id)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
+
+const lockAuthByID = `-- name: LockAuthByID :one
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) LockAuthByID(ctx context.Context, id string) (Auth, error) {
+	row := q.db.QueryRow(ctx, lockAuthByID, id)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
+
+const restoreAuth = `-- name: RestoreAuth :one
+UPDATE auths
+SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step
+`
+
+func (q *Queries) RestoreAuth(ctx context.Context, id string) (Auth, error) {
+	row := q.db.QueryRow(ctx, restoreAuth, id)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
+
 const softDeleteAuth = `-- name: SoftDeleteAuth :exec
 UPDATE auths
 SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
@@ -327,7 +731,7 @@ const updateAuth = `-- name: UpdateAuth :one
 UPDATE auths
 SET username = $2, password = $3, email = $4, role = $5, active = $6, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active
+RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step
 `
 
 type UpdateAuthParams struct {
@@ -359,6 +763,38 @@ func (q *Queries) UpdateAuth(ctx context.Context, arg UpdateAuthParams) (Auth, e
 		&i.Email,
 		&i.Role,
 		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
+
+const updateAuthActive = `-- name: UpdateAuthActive :one
+UPDATE auths
+SET active = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step
+`
+
+func (q *Queries) UpdateAuthActive(ctx context.Context, iD string, active bool) (Auth, error) {
+	row := q.db.QueryRow(ctx, updateAuthActive, iD, active)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
 	)
 	return i, err
 }
@@ -414,3 +850,75 @@ func (q *Queries) UpdateAuthMethod(ctx context.Context, arg UpdateAuthMethodPara
 	)
 	return i, err
 }
+
+const updateAuthMethodTokens = `-- name: UpdateAuthMethodTokens :execrows
+UPDATE auth_methods
+SET access_token = $1, refresh_token = $2, id_token = $3,
+    access_token_secret = $4
+WHERE id = $5 AND updated_at = $6
+`
+
+type UpdateAuthMethodTokensParams struct {
+	AccessToken       *string            `json:"access_token"`
+	RefreshToken      *string            `json:"refresh_token"`
+	IDToken           *string            `json:"id_token"`
+	AccessTokenSecret *string            `json:"access_token_secret"`
+	ID                string             `json:"id"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Rewrites the tokens of an auth method unless it was updated since it was read at updated_at.
+// updated_at is left alone, the tokens keep their values.
+func (q *Queries) UpdateAuthMethodTokens(ctx context.Context, arg UpdateAuthMethodTokensParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateAuthMethodTokens,
+		arg.AccessToken,
+		arg.RefreshToken,
+		arg.IDToken,
+		arg.AccessTokenSecret,
+		arg.ID,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAuthPassword = `-- name: UpdateAuthPassword :exec
+UPDATE auths
+SET password = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) UpdateAuthPassword(ctx context.Context, iD string, password *string) error {
+	_, err := q.db.Exec(ctx, updateAuthPassword, iD, password)
+	return err
+}
+
+const updateAuthRole = `-- name: UpdateAuthRole :one
+UPDATE auths
+SET role = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step
+`
+
+func (q *Queries) UpdateAuthRole(ctx context.Context, iD string, role string) (Auth, error) {
+	row := q.db.QueryRow(ctx, updateAuthRole, iD, role)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
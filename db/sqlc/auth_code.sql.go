@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: auth_code.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const consumeAuthCode = `-- name: ConsumeAuthCode :one
+UPDATE auth_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE code_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+RETURNING id, created_at, auth_id, code_hash, code_challenge, expires_at, used_at
+`
+
+// Marks the code used and returns it, only once and only before it expires, so a code
+// cannot be exchanged twice even by concurrent requests
+func (q *Queries) ConsumeAuthCode(ctx context.Context, codeHash string) (AuthCode, error) {
+	row := q.db.QueryRow(ctx, consumeAuthCode, codeHash)
+	var i AuthCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.CodeHash,
+		&i.CodeChallenge,
+		&i.ExpiresAt,
+		&i.UsedAt,
+	)
+	return i, err
+}
+
+const createAuthCode = `-- name: CreateAuthCode :one
+INSERT INTO auth_codes (auth_id, code_hash, code_challenge, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, auth_id, code_hash, code_challenge, expires_at, used_at
+`
+
+func (q *Queries) CreateAuthCode(ctx context.Context, authID string, codeHash string, codeChallenge string, expiresAt pgtype.Timestamptz) (AuthCode, error) {
+	row := q.db.QueryRow(ctx, createAuthCode,
+		authID,
+		codeHash,
+		codeChallenge,
+		expiresAt,
+	)
+	var i AuthCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.CodeHash,
+		&i.CodeChallenge,
+		&i.ExpiresAt,
+		&i.UsedAt,
+	)
+	return i, err
+}
+
+const deleteAuthCodesByAuthID = `-- name: DeleteAuthCodesByAuthID :exec
+DELETE FROM auth_codes
+WHERE auth_id = $1
+`
+
+func (q *Queries) DeleteAuthCodesByAuthID(ctx context.Context, authID string) error {
+	_, err := q.db.Exec(ctx, deleteAuthCodesByAuthID, authID)
+	return err
+}
+
+const deleteExpiredAuthCodes = `-- name: DeleteExpiredAuthCodes :execrows
+DELETE FROM auth_codes
+WHERE expires_at <= CURRENT_TIMESTAMP OR used_at IS NOT NULL
+`
+
+func (q *Queries) DeleteExpiredAuthCodes(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredAuthCodes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createCockroach = `-- name: CreateCockroach :one
@@ -22,14 +24,17 @@ func (q *Queries) CreateCockroach(ctx context.Context, amount int32) (Cockroach,
 	return i, err
 }
 
-const deleteCockroach = `-- name: DeleteCockroach :exec
+const deleteCockroach = `-- name: DeleteCockroach :execrows
 DELETE FROM cockroaches
 WHERE id = $1
 `
 
-func (q *Queries) DeleteCockroach(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteCockroach, id)
-	return err
+func (q *Queries) DeleteCockroach(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCockroach, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getCockroachByID = `-- name: GetCockroachByID :one
@@ -45,19 +50,65 @@ func (q *Queries) GetCockroachByID(ctx context.Context, id int32) (Cockroach, er
 }
 
 const listCockroaches = `-- name: ListCockroaches :many
-SELECT id, amount, created_at FROM cockroaches
-ORDER BY created_at DESC
+WITH matching AS NOT MATERIALIZED (
+    SELECT id, amount, created_at FROM cockroaches
+    WHERE ($1::timestamptz IS NULL OR created_at >= $1)
+      AND ($2::timestamptz IS NULL OR created_at < $2)
+)
+(
+    SELECT id, amount, created_at FROM matching
+    WHERE NOT $3::boolean
+      AND (created_at, id) < (COALESCE($4::timestamptz, 'infinity'), COALESCE($5::integer, 0))
+    ORDER BY created_at DESC, id DESC
+    LIMIT $6
+)
+UNION ALL
+(
+    SELECT id, amount, created_at FROM (
+        SELECT id, amount, created_at FROM matching
+        WHERE $3::boolean
+          AND (created_at, id) > ($4::timestamptz, $5::integer)
+        ORDER BY created_at ASC, id ASC
+        LIMIT $6
+    ) AS before_cursor
+)
+ORDER BY created_at DESC, id DESC
 `
 
-func (q *Queries) ListCockroaches(ctx context.Context) ([]Cockroach, error) {
-	rows, err := q.db.Query(ctx, listCockroaches)
+type ListCockroachesParams struct {
+	CreatedFrom     pgtype.Timestamptz `json:"created_from"`
+	CreatedTo       pgtype.Timestamptz `json:"created_to"`
+	Backward        bool               `json:"backward"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        *int32             `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+type ListCockroachesRow struct {
+	ID        int32              `json:"id"`
+	Amount    int32              `json:"amount"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// The cockroaches matching the filter after the cursor, or before it when backward is set,
+// newest first; without a cursor, the first ones. The filter is written once in matching, and
+// only the branch of the direction runs, each walking the created_at index from the cursor.
+func (q *Queries) ListCockroaches(ctx context.Context, arg ListCockroachesParams) ([]ListCockroachesRow, error) {
+	rows, err := q.db.Query(ctx, listCockroaches,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Backward,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Cockroach
+	var items []ListCockroachesRow
 	for rows.Next() {
-		var i Cockroach
+		var i ListCockroachesRow
 		if err := rows.Scan(&i.ID, &i.Amount, &i.CreatedAt); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,231 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: data_request.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimDataRequest = `-- name: ClaimDataRequest :one
+UPDATE data_requests
+SET status = 'running', started_at = CURRENT_TIMESTAMP
+WHERE id = (
+    SELECT id FROM data_requests
+    WHERE status = 'pending' OR (status = 'running' AND started_at < $1::timestamptz)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, created_at, auth_id, requested_by, kind, erasure_mode, status, error, started_at, completed_at, result, expires_at
+`
+
+// Marks the oldest pending request running and returns it. Requests left running since before
+// stale_before by a replica that stopped are picked up again. SKIP LOCKED lets replicas claim
+// different requests concurrently.
+func (q *Queries) ClaimDataRequest(ctx context.Context, staleBefore pgtype.Timestamptz) (DataRequest, error) {
+	row := q.db.QueryRow(ctx, claimDataRequest, staleBefore)
+	var i DataRequest
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.RequestedBy,
+		&i.Kind,
+		&i.ErasureMode,
+		&i.Status,
+		&i.Error,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.Result,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const completeDataRequest = `-- name: CompleteDataRequest :exec
+UPDATE data_requests
+SET status = 'completed', completed_at = CURRENT_TIMESTAMP, error = NULL, result = $1, expires_at = $2
+WHERE id = $3
+`
+
+func (q *Queries) CompleteDataRequest(ctx context.Context, result []byte, expiresAt pgtype.Timestamptz, iD string) error {
+	_, err := q.db.Exec(ctx, completeDataRequest, result, expiresAt, iD)
+	return err
+}
+
+const countDataRequests = `-- name: CountDataRequests :one
+SELECT COUNT(*) FROM data_requests
+WHERE ($1::varchar IS NULL OR auth_id = $1)
+  AND ($2::varchar IS NULL OR kind = $2)
+  AND ($3::varchar IS NULL OR status = $3)
+`
+
+func (q *Queries) CountDataRequests(ctx context.Context, authID *string, kind *string, status *string) (int64, error) {
+	row := q.db.QueryRow(ctx, countDataRequests, authID, kind, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createDataRequest = `-- name: CreateDataRequest :one
+INSERT INTO data_requests (auth_id, requested_by, kind, erasure_mode)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, auth_id, requested_by, kind, erasure_mode, status, error, started_at, completed_at, result, expires_at
+`
+
+func (q *Queries) CreateDataRequest(ctx context.Context, authID string, requestedBy *string, kind string, erasureMode *string) (DataRequest, error) {
+	row := q.db.QueryRow(ctx, createDataRequest,
+		authID,
+		requestedBy,
+		kind,
+		erasureMode,
+	)
+	var i DataRequest
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.RequestedBy,
+		&i.Kind,
+		&i.ErasureMode,
+		&i.Status,
+		&i.Error,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.Result,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteDataRequestResults = `-- name: DeleteDataRequestResults :execrows
+UPDATE data_requests
+SET result = NULL, expires_at = LEAST(expires_at, CURRENT_TIMESTAMP)
+WHERE auth_id = $1 AND result IS NOT NULL
+`
+
+// Drops the export documents of auth_id, e.g. when the auth is erased
+func (q *Queries) DeleteDataRequestResults(ctx context.Context, authID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDataRequestResults, authID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredDataRequestResults = `-- name: DeleteExpiredDataRequestResults :execrows
+UPDATE data_requests
+SET result = NULL
+WHERE result IS NOT NULL AND expires_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) DeleteExpiredDataRequestResults(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredDataRequestResults)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const failDataRequest = `-- name: FailDataRequest :exec
+UPDATE data_requests
+SET status = 'failed', completed_at = CURRENT_TIMESTAMP, error = $1
+WHERE id = $2
+`
+
+func (q *Queries) FailDataRequest(ctx context.Context, reason *string, iD string) error {
+	_, err := q.db.Exec(ctx, failDataRequest, reason, iD)
+	return err
+}
+
+const getDataRequest = `-- name: GetDataRequest :one
+SELECT id, created_at, auth_id, requested_by, kind, erasure_mode, status, error, started_at, completed_at, result, expires_at FROM data_requests
+WHERE id = $1
+`
+
+func (q *Queries) GetDataRequest(ctx context.Context, id string) (DataRequest, error) {
+	row := q.db.QueryRow(ctx, getDataRequest, id)
+	var i DataRequest
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.RequestedBy,
+		&i.Kind,
+		&i.ErasureMode,
+		&i.Status,
+		&i.Error,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.Result,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listDataRequests = `-- name: ListDataRequests :many
+SELECT id, created_at, auth_id, requested_by, kind, erasure_mode, status, error, started_at, completed_at, expires_at
+FROM data_requests
+WHERE ($1::varchar IS NULL OR auth_id = $1)
+  AND ($2::varchar IS NULL OR kind = $2)
+  AND ($3::varchar IS NULL OR status = $3)
+ORDER BY created_at DESC, id DESC
+LIMIT $5 OFFSET $4
+`
+
+type ListDataRequestsRow struct {
+	ID          string             `json:"id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	AuthID      string             `json:"auth_id"`
+	RequestedBy *string            `json:"requested_by"`
+	Kind        string             `json:"kind"`
+	ErasureMode *string            `json:"erasure_mode"`
+	Status      string             `json:"status"`
+	Error       *string            `json:"error"`
+	StartedAt   pgtype.Timestamptz `json:"started_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) ListDataRequests(ctx context.Context, authID *string, kind *string, status *string, offset int32, limit int32) ([]ListDataRequestsRow, error) {
+	rows, err := q.db.Query(ctx, listDataRequests,
+		authID,
+		kind,
+		status,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDataRequestsRow
+	for rows.Next() {
+		var i ListDataRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.AuthID,
+			&i.RequestedBy,
+			&i.Kind,
+			&i.ErasureMode,
+			&i.Status,
+			&i.Error,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
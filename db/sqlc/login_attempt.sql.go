@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: login_attempt.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const blockLoginAttempts = `-- name: BlockLoginAttempts :exec
+UPDATE login_attempts
+SET blocked_until = $2
+WHERE key = $1
+`
+
+func (q *Queries) BlockLoginAttempts(ctx context.Context, key string, blockedUntil pgtype.Timestamptz) error {
+	_, err := q.db.Exec(ctx, blockLoginAttempts, key, blockedUntil)
+	return err
+}
+
+const deleteExpiredLoginAttempts = `-- name: DeleteExpiredLoginAttempts :execrows
+DELETE FROM login_attempts
+WHERE last_failure_at < $1
+  AND (blocked_until IS NULL OR blocked_until < CURRENT_TIMESTAMP)
+`
+
+func (q *Queries) DeleteExpiredLoginAttempts(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredLoginAttempts, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteLoginAttempts = `-- name: DeleteLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE key = $1
+`
+
+func (q *Queries) DeleteLoginAttempts(ctx context.Context, key string) error {
+	_, err := q.db.Exec(ctx, deleteLoginAttempts, key)
+	return err
+}
+
+const getLoginAttempt = `-- name: GetLoginAttempt :one
+SELECT key, failures, last_failure_at, blocked_until FROM login_attempts
+WHERE key = $1
+`
+
+func (q *Queries) GetLoginAttempt(ctx context.Context, key string) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, getLoginAttempt, key)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Key,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.BlockedUntil,
+	)
+	return i, err
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO login_attempts (key, failures, last_failure_at)
+VALUES ($1, 1, CURRENT_TIMESTAMP)
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < $2 THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = CURRENT_TIMESTAMP
+RETURNING key, failures, last_failure_at, blocked_until
+`
+
+// Counts a failure of the key, starting over when its last failure is before window_start
+func (q *Queries) RecordLoginFailure(ctx context.Context, key string, windowStart pgtype.Timestamptz) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, recordLoginFailure, key, windowStart)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Key,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.BlockedUntil,
+	)
+	return i, err
+}
+
+const releaseLoginAttempt = `-- name: ReleaseLoginAttempt :exec
+UPDATE login_attempts
+SET failures = GREATEST(failures - 1, 0),
+    blocked_until = NULL
+WHERE key = $1
+`
+
+// Takes back an attempt counted by ReserveLoginAttempt that did not fail, and its block
+func (q *Queries) ReleaseLoginAttempt(ctx context.Context, key string) error {
+	_, err := q.db.Exec(ctx, releaseLoginAttempt, key)
+	return err
+}
+
+const reserveLoginAttempt = `-- name: ReserveLoginAttempt :one
+INSERT INTO login_attempts (key, failures, last_failure_at, blocked_until)
+VALUES ($1, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP + ($2::bigint[])[1] * INTERVAL '1 millisecond')
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < $3 THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = CURRENT_TIMESTAMP,
+    blocked_until = CURRENT_TIMESTAMP + ($2::bigint[])[LEAST(
+        CASE
+            WHEN login_attempts.last_failure_at < $3 THEN 1
+            ELSE login_attempts.failures + 1
+        END,
+        cardinality($2::bigint[])
+    )] * INTERVAL '1 millisecond'
+WHERE login_attempts.blocked_until IS NULL OR login_attempts.blocked_until <= CURRENT_TIMESTAMP
+RETURNING key, failures, last_failure_at, blocked_until
+`
+
+// Counts an attempt of the key as failed before it is made, starting over when its last failure
+// is before window_start, and blocks the key for the delay in milliseconds delays has for its
+// failures, the last one past its end. Concurrent attempts are so turned away until the attempt
+// is released. Returns no row when the key is blocked.
+func (q *Queries) ReserveLoginAttempt(ctx context.Context, key string, delays []int64, windowStart pgtype.Timestamptz) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, reserveLoginAttempt, key, delays, windowStart)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Key,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.BlockedUntil,
+	)
+	return i, err
+}
@@ -0,0 +1,175 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: mfa.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createMFARecoveryCode = `-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (auth_id, code_hash)
+VALUES ($1, $2)
+`
+
+func (q *Queries) CreateMFARecoveryCode(ctx context.Context, authID string, codeHash string) error {
+	_, err := q.db.Exec(ctx, createMFARecoveryCode, authID, codeHash)
+	return err
+}
+
+const deleteMFARecoveryCodes = `-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE auth_id = $1
+`
+
+func (q *Queries) DeleteMFARecoveryCodes(ctx context.Context, authID string) error {
+	_, err := q.db.Exec(ctx, deleteMFARecoveryCodes, authID)
+	return err
+}
+
+const disableMFA = `-- name: DisableMFA :exec
+UPDATE auths
+SET mfa_secret = NULL, mfa_enabled_at = NULL, mfa_last_step = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) DisableMFA(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, disableMFA, id)
+	return err
+}
+
+const enableMFA = `-- name: EnableMFA :execrows
+UPDATE auths
+SET mfa_enabled_at = CURRENT_TIMESTAMP, mfa_last_step = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND mfa_secret IS NOT NULL AND mfa_enabled_at IS NULL
+`
+
+func (q *Queries) EnableMFA(ctx context.Context, iD string, mFALastStep *int64) (int64, error) {
+	result, err := q.db.Exec(ctx, enableMFA, iD, mFALastStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listMFASecretsToReencrypt = `-- name: ListMFASecretsToReencrypt :many
+SELECT id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step FROM auths
+WHERE id > $1 AND mfa_secret IS NOT NULL AND mfa_secret NOT LIKE $2::text || '%'
+ORDER BY id
+LIMIT $3
+`
+
+// Auths after after_id whose MFA secret does not start with key_prefix, i.e. a secret stored in
+// plaintext or encrypted with another key than the active one
+func (q *Queries) ListMFASecretsToReencrypt(ctx context.Context, afterID string, keyPrefix string, batchSize int32) ([]Auth, error) {
+	rows, err := q.db.Query(ctx, listMFASecretsToReencrypt, afterID, keyPrefix, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Auth
+	for rows.Next() {
+		var i Auth
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Username,
+			&i.Password,
+			&i.Email,
+			&i.Role,
+			&i.Active,
+			&i.TokensRevokedAt,
+			&i.MFASecret,
+			&i.MFAEnabledAt,
+			&i.MFALastStep,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setMFASecret = `-- name: SetMFASecret :one
+UPDATE auths
+SET mfa_secret = $2, mfa_last_step = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND mfa_enabled_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, username, password, email, role, active, tokens_revoked_at, mfa_secret, mfa_enabled_at, mfa_last_step
+`
+
+// Starts an enrolment, replacing the secret of an enrolment that was never confirmed
+func (q *Queries) SetMFASecret(ctx context.Context, iD string, mFASecret *string) (Auth, error) {
+	row := q.db.QueryRow(ctx, setMFASecret, iD, mFASecret)
+	var i Auth
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Username,
+		&i.Password,
+		&i.Email,
+		&i.Role,
+		&i.Active,
+		&i.TokensRevokedAt,
+		&i.MFASecret,
+		&i.MFAEnabledAt,
+		&i.MFALastStep,
+	)
+	return i, err
+}
+
+const updateMFASecret = `-- name: UpdateMFASecret :execrows
+UPDATE auths
+SET mfa_secret = $1
+WHERE id = $2 AND updated_at = $3
+`
+
+// Rewrites the MFA secret of an auth unless it was updated since it was read at updated_at.
+// updated_at is left alone, the secret keeps its value.
+func (q *Queries) UpdateMFASecret(ctx context.Context, secret *string, iD string, updatedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, updateMFASecret, secret, iD, updatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useMFARecoveryCode = `-- name: UseMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE auth_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+func (q *Queries) UseMFARecoveryCode(ctx context.Context, authID string, codeHash string) (int64, error) {
+	result, err := q.db.Exec(ctx, useMFARecoveryCode, authID, codeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useMFAStep = `-- name: UseMFAStep :execrows
+UPDATE auths
+SET mfa_last_step = $2
+WHERE id = $1 AND (mfa_last_step IS NULL OR mfa_last_step < $2)
+`
+
+// Records the time step of an accepted code, only when it is later than the last one, so
+// concurrent requests cannot both use the same code
+func (q *Queries) UseMFAStep(ctx context.Context, iD string, mFALastStep *int64) (int64, error) {
+	result, err := q.db.Exec(ctx, useMFAStep, iD, mFALastStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -8,16 +8,57 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type APIKey struct {
+	ID         string             `json:"id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	AuthID     string             `json:"auth_id"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	KeyHash    string             `json:"key_hash"`
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type AuditEvent struct {
+	ID         string             `json:"id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ActorID    *string            `json:"actor_id"`
+	Action     string             `json:"action"`
+	TargetType *string            `json:"target_type"`
+	TargetID   *string            `json:"target_id"`
+	Outcome    string             `json:"outcome"`
+	IpAddress  *string            `json:"ip_address"`
+	UserAgent  *string            `json:"user_agent"`
+	RequestID  *string            `json:"request_id"`
+	Metadata   []byte             `json:"metadata"`
+}
+
 type Auth struct {
-	ID        string             `json:"id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
-	Username  *string            `json:"username"`
-	Password  *string            `json:"password"`
-	Email     *string            `json:"email"`
-	Role      string             `json:"role"`
-	Active    bool               `json:"active"`
+	ID              string             `json:"id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	Username        *string            `json:"username"`
+	Password        *string            `json:"password"`
+	Email           *string            `json:"email"`
+	Role            string             `json:"role"`
+	Active          bool               `json:"active"`
+	TokensRevokedAt pgtype.Timestamptz `json:"tokens_revoked_at"`
+	MFASecret       *string            `json:"mfa_secret"`
+	MFAEnabledAt    pgtype.Timestamptz `json:"mfa_enabled_at"`
+	MFALastStep     *int64             `json:"mfa_last_step"`
+}
+
+type AuthCode struct {
+	ID            string             `json:"id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	AuthID        string             `json:"auth_id"`
+	CodeHash      string             `json:"code_hash"`
+	CodeChallenge string             `json:"code_challenge"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	UsedAt        pgtype.Timestamptz `json:"used_at"`
 }
 
 type AuthMethod struct {
@@ -49,3 +90,81 @@ type Cockroach struct {
 	Amount    int32              `json:"amount"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
+
+type DataRequest struct {
+	ID          string             `json:"id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	AuthID      string             `json:"auth_id"`
+	RequestedBy *string            `json:"requested_by"`
+	Kind        string             `json:"kind"`
+	ErasureMode *string            `json:"erasure_mode"`
+	Status      string             `json:"status"`
+	Error       *string            `json:"error"`
+	StartedAt   pgtype.Timestamptz `json:"started_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	Result      []byte             `json:"result"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
+type LoginAttempt struct {
+	Key           string             `json:"key"`
+	Failures      int32              `json:"failures"`
+	LastFailureAt pgtype.Timestamptz `json:"last_failure_at"`
+	BlockedUntil  pgtype.Timestamptz `json:"blocked_until"`
+}
+
+type MFARecoveryCode struct {
+	ID        string             `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	AuthID    string             `json:"auth_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+}
+
+type RefreshToken struct {
+	ID        string             `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	AuthID    string             `json:"auth_id"`
+	FamilyID  string             `json:"family_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type RevokedToken struct {
+	Jti       string             `json:"jti"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	AuthID    string             `json:"auth_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type Role struct {
+	Name        string             `json:"name"`
+	Description *string            `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type RolePermission struct {
+	Role       string             `json:"role"`
+	Permission string             `json:"permission"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Session struct {
+	ID        string             `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Data      []byte             `json:"data"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type SigningKey struct {
+	Kid        string             `json:"kid"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	Algorithm  string             `json:"algorithm"`
+	PrivateKey string             `json:"private_key"`
+	RetiredAt  pgtype.Timestamptz `json:"retired_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+}
@@ -0,0 +1,152 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: refresh_token.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createRefreshToken = `-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (auth_id, family_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, auth_id, family_id, token_hash, expires_at, used_at, revoked_at
+`
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, authID string, familyID string, tokenHash string, expiresAt pgtype.Timestamptz) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, createRefreshToken,
+		authID,
+		familyID,
+		tokenHash,
+		expiresAt,
+	)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const deleteRefreshTokensByAuthID = `-- name: DeleteRefreshTokensByAuthID :exec
+DELETE FROM refresh_tokens
+WHERE auth_id = $1
+`
+
+func (q *Queries) DeleteRefreshTokensByAuthID(ctx context.Context, authID string) error {
+	_, err := q.db.Exec(ctx, deleteRefreshTokensByAuthID, authID)
+	return err
+}
+
+const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
+SELECT id, created_at, auth_id, family_id, token_hash, expires_at, used_at, revoked_at FROM refresh_tokens
+WHERE token_hash = $1
+`
+
+func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByHash, tokenHash)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const listRefreshTokensByAuthID = `-- name: ListRefreshTokensByAuthID :many
+SELECT id, created_at, auth_id, family_id, token_hash, expires_at, used_at, revoked_at FROM refresh_tokens
+WHERE auth_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListRefreshTokensByAuthID(ctx context.Context, authID string) ([]RefreshToken, error) {
+	rows, err := q.db.Query(ctx, listRefreshTokensByAuthID, authID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RefreshToken
+	for rows.Next() {
+		var i RefreshToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.AuthID,
+			&i.FamilyID,
+			&i.TokenHash,
+			&i.ExpiresAt,
+			&i.UsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markRefreshTokenUsed = `-- name: MarkRefreshTokenUsed :one
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+RETURNING id, created_at, auth_id, family_id, token_hash, expires_at, used_at, revoked_at
+`
+
+// Only succeeds for a token that has not been used or revoked yet, so two concurrent
+// refreshes with the same token cannot both rotate it
+func (q *Queries) MarkRefreshTokenUsed(ctx context.Context, id string) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, markRefreshTokenUsed, id)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.AuthID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE family_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokenFamily, familyID)
+	return err
+}
+
+const revokeRefreshTokensByAuthID = `-- name: RevokeRefreshTokensByAuthID :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE auth_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshTokensByAuthID(ctx context.Context, authID string) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokensByAuthID, authID)
+	return err
+}
@@ -0,0 +1,76 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: revoked_token.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteExpiredRevokedTokens = `-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredRevokedTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAuthTokensRevokedAt = `-- name: GetAuthTokensRevokedAt :one
+SELECT tokens_revoked_at FROM auths
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetAuthTokensRevokedAt(ctx context.Context, id string) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getAuthTokensRevokedAt, id)
+	var tokens_revoked_at pgtype.Timestamptz
+	err := row.Scan(&tokens_revoked_at)
+	return tokens_revoked_at, err
+}
+
+const isTokenRevoked = `-- name: IsTokenRevoked :one
+SELECT EXISTS (
+    SELECT 1 FROM revoked_tokens
+    WHERE jti = $1
+)
+`
+
+func (q *Queries) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	row := q.db.QueryRow(ctx, isTokenRevoked, jti)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const revokeAuthTokens = `-- name: RevokeAuthTokens :one
+UPDATE auths
+SET tokens_revoked_at = date_trunc('second', CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING tokens_revoked_at
+`
+
+func (q *Queries) RevokeAuthTokens(ctx context.Context, id string) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, revokeAuthTokens, id)
+	var tokens_revoked_at pgtype.Timestamptz
+	err := row.Scan(&tokens_revoked_at)
+	return tokens_revoked_at, err
+}
+
+const revokeToken = `-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (jti, auth_id, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING
+`
+
+func (q *Queries) RevokeToken(ctx context.Context, jti string, authID string, expiresAt pgtype.Timestamptz) error {
+	_, err := q.db.Exec(ctx, revokeToken, jti, authID, expiresAt)
+	return err
+}
@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: session.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, deleteSession, id)
+	return err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT id, created_at, updated_at, data, expires_at FROM sessions
+WHERE id = $1 AND expires_at > CURRENT_TIMESTAMP
+`
+
+func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
+	row := q.db.QueryRow(ctx, getSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Data,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const saveSession = `-- name: SaveSession :exec
+INSERT INTO sessions (id, data, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (id) DO UPDATE
+SET data = EXCLUDED.data, expires_at = EXCLUDED.expires_at, updated_at = CURRENT_TIMESTAMP
+`
+
+func (q *Queries) SaveSession(ctx context.Context, iD string, data []byte, expiresAt pgtype.Timestamptz) error {
+	_, err := q.db.Exec(ctx, saveSession, iD, data, expiresAt)
+	return err
+}
@@ -0,0 +1,119 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: signing_key.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSigningKey = `-- name: CreateSigningKey :one
+INSERT INTO signing_keys (kid, algorithm, private_key)
+VALUES ($1, $2, $3)
+RETURNING kid, created_at, algorithm, private_key, retired_at, expires_at
+`
+
+func (q *Queries) CreateSigningKey(ctx context.Context, kid string, algorithm string, privateKey string) (SigningKey, error) {
+	row := q.db.QueryRow(ctx, createSigningKey, kid, algorithm, privateKey)
+	var i SigningKey
+	err := row.Scan(
+		&i.Kid,
+		&i.CreatedAt,
+		&i.Algorithm,
+		&i.PrivateKey,
+		&i.RetiredAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteExpiredSigningKeys = `-- name: DeleteExpiredSigningKeys :exec
+DELETE FROM signing_keys
+WHERE expires_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) DeleteExpiredSigningKeys(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredSigningKeys)
+	return err
+}
+
+const getActiveSigningKey = `-- name: GetActiveSigningKey :one
+SELECT kid, created_at, algorithm, private_key, retired_at, expires_at FROM signing_keys
+WHERE retired_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetActiveSigningKey(ctx context.Context) (SigningKey, error) {
+	row := q.db.QueryRow(ctx, getActiveSigningKey)
+	var i SigningKey
+	err := row.Scan(
+		&i.Kid,
+		&i.CreatedAt,
+		&i.Algorithm,
+		&i.PrivateKey,
+		&i.RetiredAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listSigningKeys = `-- name: ListSigningKeys :many
+SELECT kid, created_at, algorithm, private_key, retired_at, expires_at FROM signing_keys
+WHERE expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP
+ORDER BY created_at DESC
+`
+
+// Keys that can still verify tokens, newest first
+func (q *Queries) ListSigningKeys(ctx context.Context) ([]SigningKey, error) {
+	rows, err := q.db.Query(ctx, listSigningKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SigningKey
+	for rows.Next() {
+		var i SigningKey
+		if err := rows.Scan(
+			&i.Kid,
+			&i.CreatedAt,
+			&i.Algorithm,
+			&i.PrivateKey,
+			&i.RetiredAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockSigningKeys = `-- name: LockSigningKeys :exec
+SELECT pg_advisory_xact_lock(7305128961624154978)
+`
+
+// Serializes rotations across replicas until the end of the transaction
+func (q *Queries) LockSigningKeys(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockSigningKeys)
+	return err
+}
+
+const retireSigningKeys = `-- name: RetireSigningKeys :exec
+UPDATE signing_keys
+SET retired_at = CURRENT_TIMESTAMP, expires_at = $2
+WHERE kid <> $1 AND retired_at IS NULL
+`
+
+// Retires every active key except kid. Retired keys verify until expires_at.
+func (q *Queries) RetireSigningKeys(ctx context.Context, kid string, expiresAt pgtype.Timestamptz) error {
+	_, err := q.db.Exec(ctx, retireSigningKeys, kid, expiresAt)
+	return err
+}
@@ -2,11 +2,87 @@
 package auth
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"template-golang/modules/auth/handlers"
 	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/sessions"
+	"template-golang/modules/auth/usecases"
+
+	"github.com/gin-gonic/gin"
 )
 
 type Auth struct {
-	Handler    handlers.AuthHandler
-	Middleware middlewares.AuthMiddleware
+	Handler     handlers.AuthHandler
+	Middleware  middlewares.AuthMiddleware
+	KeySet      usecases.KeySet
+	Revocations usecases.RevocationStore
+	AuthCodes   usecases.AuthCodeUsecase
+	Sessions    *sessions.Store
+	Permissions usecases.PermissionStore
+	Accounts    usecases.AccountStatusStore
+	Throttle    usecases.LoginThrottle
+	Reencryptor usecases.TokenReencryptor
+
+	stopBackground context.CancelFunc
+	backgroundDone sync.WaitGroup
+}
+
+func (a *Auth) Name() string {
+	return "auth"
+}
+
+func (a *Auth) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	a.Handler.Routes(routerGroup)
+}
+
+func (a *Auth) RegisterRootRoutes(router *gin.RouterGroup) {
+	a.Handler.WellKnownRoutes(router)
+}
+
+// Start loads the signing keys and role permissions, then keeps them in sync with other
+// replicas, rotates keys, cleans up expired token revocations, auth codes, sessions and login
+// attempts and re-encrypts provider tokens under the active key in the background
+func (a *Auth) Start(ctx context.Context) error {
+	if err := a.KeySet.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+	if err := a.Permissions.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to load role permissions: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	a.stopBackground = cancel
+
+	for _, run := range []func(context.Context){a.KeySet.Run, a.Revocations.Run, a.AuthCodes.Run, a.Sessions.Run, a.Permissions.Run, a.Accounts.Run, a.Throttle.Run, a.Reencryptor.Run} {
+		a.backgroundDone.Add(1)
+		go func() {
+			defer a.backgroundDone.Done()
+			run(runCtx)
+		}()
+	}
+
+	return nil
+}
+
+func (a *Auth) Stop(ctx context.Context) error {
+	if a.stopBackground == nil {
+		return nil
+	}
+
+	a.stopBackground()
+
+	done := make(chan struct{})
+	go func() {
+		a.backgroundDone.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -5,7 +5,40 @@ import "github.com/gin-gonic/gin"
 type AuthHandler interface {
 	Login(c *gin.Context)
 	AuthCallback(c *gin.Context)
+	LinkProvider(c *gin.Context)
+	UnlinkProvider(c *gin.Context)
+	LinkedProviders(c *gin.Context)
+	ExchangeCode(c *gin.Context)
 	Logout(c *gin.Context)
+	RefreshToken(c *gin.Context)
+	Register(c *gin.Context)
+	PasswordLogin(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	GetProfile(c *gin.Context)
+	UpdateProfile(c *gin.Context)
+	DeleteAccount(c *gin.Context)
+	EnrollMFA(c *gin.Context)
+	ConfirmMFA(c *gin.Context)
+	VerifyMFA(c *gin.Context)
+	RegenerateRecoveryCodes(c *gin.Context)
+	DisableMFA(c *gin.Context)
+	LogoutSession(c *gin.Context)
+	LogoutAll(c *gin.Context)
 	Example(c *gin.Context)
+	JWKS(c *gin.Context)
+	RotateSigningKey(c *gin.Context)
+	RevokeUserTokens(c *gin.Context)
+	GetUsers(c *gin.Context)
+	GetUser(c *gin.Context)
+	SetUserRole(c *gin.Context)
+	ActivateUser(c *gin.Context)
+	DeactivateUser(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	RestoreUser(c *gin.Context)
+	CreateAPIKey(c *gin.Context)
+	GetAPIKeys(c *gin.Context)
+	RevokeAPIKey(c *gin.Context)
 	Routes(routerGroup *gin.RouterGroup)
+	// WellKnownRoutes registers the discovery routes served from the server root
+	WellKnownRoutes(routerGroup *gin.RouterGroup)
 }
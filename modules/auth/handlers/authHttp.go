@@ -1,39 +1,81 @@
 package handlers
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 	"template-golang/config"
+	db "template-golang/db/sqlc"
 	"template-golang/modules/auth/middlewares"
 	"template-golang/modules/auth/models"
 	"template-golang/modules/auth/repositories"
 	"template-golang/modules/auth/usecases"
+	"template-golang/pkg/authz"
+	pkgContext "template-golang/pkg/context"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/markbates/goth"
 	"github.com/markbates/goth/gothic"
-	"github.com/markbates/goth/providers/line"
 )
 
-// TODO: fix goth/gothic: no SESSION_SECRET environment variable is set. The default cookie store is not available and any calls will fail. Ignore this warning if you are using a different store.
+// linkSessionKeyPrefix prefixes the gothic session value naming the account a provider login
+// is linked to. The key ends with the OAuth state of the link, so a login started later in the
+// same browser, which has another state, is never mistaken for the link.
+const linkSessionKeyPrefix = "link:"
+
+// userList names the list of GET /admin/auth/users, which its cursors are bound to
+const userList = "users"
+
 type authHttpHandler struct {
-	jwtUsecase     usecases.JWTUsecase
-	conf           *config.Config
-	authMiddleware middlewares.AuthMiddleware
-	authRepo       repositories.AuthRepository
+	jwtUsecase         usecases.JWTUsecase
+	passwordUsecase    usecases.PasswordUsecase
+	authCodeUsecase    usecases.AuthCodeUsecase
+	accountLinkUsecase usecases.AccountLinkUsecase
+	userAdminUsecase   usecases.UserAdminUsecase
+	profileUsecase     usecases.ProfileUsecase
+	apiKeyUsecase      usecases.APIKeyUsecase
+	mfaUsecase         usecases.MFAUsecase
+	loginThrottle      usecases.LoginThrottle
+	auditLogger        usecases.AuditLogger
+	keySet             usecases.KeySet
+	conf               *config.Config
+	authMiddleware     middlewares.AuthMiddleware
+	authRepo           repositories.AuthRepository
 }
 
-func NewAuthHttpHandler(jwtUsecase usecases.JWTUsecase, conf *config.Config,
-	authMiddleware middlewares.AuthMiddleware, authRepo repositories.AuthRepository) AuthHandler {
-	goth.UseProviders(
-		line.New(conf.Auth.LineClientID, conf.Auth.LineClientSecret, conf.Auth.LineCallbackURL, "profile", "openid", "email"),
-	)
+func NewAuthHttpHandler(jwtUsecase usecases.JWTUsecase, passwordUsecase usecases.PasswordUsecase, authCodeUsecase usecases.AuthCodeUsecase,
+	accountLinkUsecase usecases.AccountLinkUsecase, userAdminUsecase usecases.UserAdminUsecase, profileUsecase usecases.ProfileUsecase,
+	apiKeyUsecase usecases.APIKeyUsecase, mfaUsecase usecases.MFAUsecase, loginThrottle usecases.LoginThrottle, auditLogger usecases.AuditLogger, keySet usecases.KeySet, conf *config.Config, authMiddleware middlewares.AuthMiddleware, authRepo repositories.AuthRepository, providers []goth.Provider) AuthHandler {
+	goth.UseProviders(providers...)
 
 	return &authHttpHandler{
-		jwtUsecase:     jwtUsecase,
-		conf:           conf,
-		authMiddleware: authMiddleware,
-		authRepo:       authRepo,
+		jwtUsecase:         jwtUsecase,
+		passwordUsecase:    passwordUsecase,
+		authCodeUsecase:    authCodeUsecase,
+		accountLinkUsecase: accountLinkUsecase,
+		userAdminUsecase:   userAdminUsecase,
+		profileUsecase:     profileUsecase,
+		apiKeyUsecase:      apiKeyUsecase,
+		mfaUsecase:         mfaUsecase,
+		loginThrottle:      loginThrottle,
+		auditLogger:        auditLogger,
+		keySet:             keySet,
+		conf:               conf,
+		authMiddleware:     authMiddleware,
+		authRepo:           authRepo,
 	}
 }
 
@@ -44,9 +86,33 @@ func (h *authHttpHandler) Login(c *gin.Context) {
 		c.JSON(400, gin.H{"message": "Provider is required"})
 		return
 	}
+	if _, err := goth.GetProvider(provider); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown provider"})
+		return
+	}
+	if h.throttled(c, usecases.ThrottleIPKey(c.ClientIP())) {
+		return
+	}
 
 	q := c.Request.URL.Query()
 	q.Add("provider", c.Param("provider"))
+
+	// The frontend sends the S256 challenge of its PKCE verifier. It travels in the OAuth state,
+	// which gothic checks against the session, so the callback can bind the code to it.
+	if h.tokenDelivery() == models.TokenDeliveryCode {
+		challenge := q.Get("code_challenge")
+		if q.Get("code_challenge_method") != "S256" || !usecases.ValidCodeChallenge(challenge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "code_challenge with code_challenge_method S256 is required"})
+			return
+		}
+
+		nonce := make([]byte, 32)
+		if _, err := rand.Read(nonce); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+			return
+		}
+		q.Set("state", base64.RawURLEncoding.EncodeToString(nonce)+"."+challenge)
+	}
 	c.Request.URL.RawQuery = q.Encode()
 
 	gothic.BeginAuthHandler(c.Writer, c.Request)
@@ -59,52 +125,806 @@ func (h *authHttpHandler) AuthCallback(c *gin.Context) {
 		c.JSON(400, gin.H{"message": "Provider is required"})
 		return
 	}
+	if _, err := goth.GetProvider(provider); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown provider"})
+		return
+	}
+	ipKey := usecases.ThrottleIPKey(c.ClientIP())
+	if h.throttled(c, ipKey) {
+		return
+	}
 
 	q := c.Request.URL.Query()
 	q.Add("provider", c.Param("provider"))
+	// gothic only reads a posted callback when the query is empty, so move the form into the query
+	if c.Request.Method == http.MethodPost {
+		if err := c.Request.ParseForm(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid callback form"})
+			return
+		}
+		for key, values := range c.Request.PostForm {
+			q[key] = values
+		}
+	}
 	c.Request.URL.RawQuery = q.Encode()
 
+	// Read before CompleteUserAuth, which clears the session
+	linkAuthID, err := h.linkAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session"})
+		return
+	}
+
 	user, err := gothic.CompleteUserAuth(c.Writer, c.Request)
 	if err != nil {
+		h.failAttempt(c, ipKey)
+		h.audit(c, models.AuditEvent{Action: models.AuditActionLogin, Metadata: map[string]any{"provider": provider}}, err)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
+	if linkAuthID != "" {
+		h.completeLink(c, linkAuthID, user)
+		return
+	}
+
 	// Insert or update user in the database
-	err = h.jwtUsecase.UpsertUser(user)
+	auth, err := h.jwtUsecase.UpsertUser(requestContext(c), user)
 	if err != nil {
+		h.audit(c, models.AuditEvent{Action: models.AuditActionLogin, Metadata: map[string]any{"provider": provider}}, err)
+		if errors.Is(err, usecases.ErrAccountExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "An account with this email already exists, log in to it and link this provider"})
+			return
+		}
+		if errors.Is(err, usecases.ErrAccountDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This account is disabled"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upsert user"})
 		return
 	}
-	// // Retrieve the user from the database
-	// user, err = h.jwtUsecase.GetUserByID(user.UserID)
-	// if err != nil {
-	// 	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
-	// 	return
-	// }
-	// // If user is not found, return unauthorized
-	// if user == nil {
-	// 	c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
-	// 	return
-	// }
-	// // If user is not active, return unauthorized
-	// if !user.IsActive {
-	// 	c.JSON(http.StatusUnauthorized, gin.H{"error": "User is not active"})
-	// 	return
-	// }
+	h.audit(c, models.AuditEvent{
+		ActorID:    auth.ID,
+		Action:     models.AuditActionLogin,
+		TargetType: models.AuditTargetUser,
+		TargetID:   auth.ID,
+		Metadata:   map[string]any{"provider": provider},
+	}, nil)
+
+	// Tokens never go into the redirect URL, where they would end up in the browser history,
+	// proxy logs and Referer headers
+	if h.tokenDelivery() == models.TokenDeliveryCookie {
+		tokens, err := h.jwtUsecase.IssueTokens(c.Request.Context(), auth.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+			return
+		}
 
-	// Generate JWT for the authenticated user
-	token, err := h.jwtUsecase.GenerateJWT(user.UserID)
+		h.setTokenCookies(c, tokens)
+		c.Redirect(http.StatusFound, h.conf.Auth.LineFECallbackURL)
+		return
+	}
+
+	// The state was validated by gothic and carries the challenge sent to Login
+	_, challenge, _ := strings.Cut(c.Request.URL.Query().Get("state"), ".")
+	code, err := h.authCodeUsecase.Issue(c.Request.Context(), auth.ID, challenge)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		if errors.Is(err, usecases.ErrInvalidCodeChallenge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing code challenge, start the login again"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate authorization code"})
 		return
 	}
 
-	// Redirect with the token as a query parameter
-	redirectURL := h.conf.Auth.LineFECallbackURL + "?token=" + token
+	// Redirect with the one-time code the frontend exchanges at POST /auth/token
+	query := url.Values{}
+	query.Set("code", code)
+	redirectURL := h.conf.Auth.LineFECallbackURL + "?" + query.Encode()
 	c.Redirect(http.StatusFound, redirectURL)
 }
 
+// LinkProvider starts an OAuth login with the provider that links the provider account to the
+// current user instead of logging in. It answers with the authorization URL rather than a
+// redirect, because the request carries the access token. The browser has to open the URL
+// with the session cookie of this response, so the request must be sent with credentials.
+func (h *authHttpHandler) LinkProvider(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	providerName := c.Param("provider")
+	provider, err := goth.GetProvider(providerName)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown provider"})
+		return
+	}
+
+	state := gothic.SetState(c.Request)
+	providerSession, err := provider.BeginAuth(state)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+	authURL, err := providerSession.GetAuthURL()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+
+	// Store the provider session the way gothic.GetAuthURL does, next to the account to link,
+	// in a single save. Separate saves would each start a new session.
+	session, err := gothic.Store.New(c.Request, gothic.SessionName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session"})
+		return
+	}
+	value, err := gzipSessionValue(providerSession.Marshal())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+	session.Values[providerName] = value
+	session.Values[linkSessionKeyPrefix+state] = claims.Subject
+	if err := session.Save(c.Request, c.Writer); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LinkProviderResponse{URL: authURL})
+}
+
+// linkAuthID returns the account the OAuth login of the callback links to, or "" for a login
+func (h *authHttpHandler) linkAuthID(c *gin.Context) (string, error) {
+	state := gothic.GetState(c.Request)
+	if state == "" {
+		return "", nil
+	}
+
+	session, err := gothic.Store.Get(c.Request, gothic.SessionName)
+	if err != nil {
+		return "", err
+	}
+	authID, _ := session.Values[linkSessionKeyPrefix+state].(string)
+	return authID, nil
+}
+
+// completeLink links the provider account of user to authID and sends the browser back to the
+// frontend with the linked provider
+func (h *authHttpHandler) completeLink(c *gin.Context, authID string, user goth.User) {
+	err := h.accountLinkUsecase.Link(c.Request.Context(), authID, user)
+	h.audit(c, models.AuditEvent{
+		ActorID:    authID,
+		Action:     models.AuditActionProviderLink,
+		TargetType: models.AuditTargetUser,
+		TargetID:   authID,
+		Metadata:   map[string]any{"provider": user.Provider},
+	}, err)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrProviderLinkedElsewhere):
+			c.JSON(http.StatusConflict, gin.H{"error": "This provider account is linked to another user"})
+		case errors.Is(err, usecases.ErrProviderAlreadyLinked):
+			c.JSON(http.StatusConflict, gin.H{"error": "Another account of this provider is already linked"})
+		case errors.Is(err, pgx.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link provider"})
+		}
+		return
+	}
+
+	query := url.Values{}
+	query.Set("linked", user.Provider)
+	c.Redirect(http.StatusFound, h.conf.Auth.LineFECallbackURL+"?"+query.Encode())
+}
+
+// gzipSessionValue compresses value the way gothic stores values in its session
+func gzipSessionValue(value string) (string, error) {
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write([]byte(value)); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// UnlinkProvider removes a linked provider from the current user, unless it is their last way
+// to log in
+func (h *authHttpHandler) UnlinkProvider(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := h.accountLinkUsecase.Unlink(c.Request.Context(), claims.Subject, c.Param("provider"))
+	h.audit(c, userEvent(models.AuditActionProviderUnlink, claims.Subject, map[string]any{"provider": c.Param("provider")}), err)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrProviderNotLinked):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Provider is not linked"})
+		case errors.Is(err, usecases.ErrLastLoginMethod):
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot unlink the last login method"})
+		case errors.Is(err, pgx.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink provider"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "provider unlinked"})
+}
+
+// LinkedProviders lists the provider accounts linked to the current user
+func (h *authHttpHandler) LinkedProviders(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	authMethods, err := h.accountLinkUsecase.LinkedProviders(c.Request.Context(), claims.Subject)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get linked providers"})
+		return
+	}
+
+	providers := make([]models.LinkedProvider, 0, len(authMethods))
+	for _, method := range authMethods {
+		providers = append(providers, newLinkedProvider(method))
+	}
+	c.JSON(http.StatusOK, gin.H{"providers": providers})
+}
+
+// newLinkedProvider returns the public fields of an auth method
+func newLinkedProvider(method *db.AuthMethod) models.LinkedProvider {
+	provider := models.LinkedProvider{
+		Provider: method.Provider,
+		LinkedAt: method.CreatedAt.Time,
+	}
+	if method.Email != nil {
+		provider.Email = *method.Email
+	}
+	if method.Name != nil {
+		provider.Name = *method.Name
+	}
+	if method.AvatarUrl != nil {
+		provider.AvatarURL = *method.AvatarUrl
+	}
+	return provider
+}
+
+// ExchangeCode exchanges the one-time code of an OAuth callback and the PKCE verifier it is
+// bound to for a token pair. A code works once and only until AUTH_CODE_TTL has passed.
+func (h *authHttpHandler) ExchangeCode(c *gin.Context) {
+	var req models.ExchangeCodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tokens, err := h.authCodeUsecase.Exchange(c.Request.Context(), req.Code, req.CodeVerifier)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidAuthCode) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization code"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exchange authorization code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Register creates a local account with a username and password and logs it in
+func (h *authHttpHandler) Register(c *gin.Context) {
+	var req models.RegisterRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tokens, err := h.passwordUsecase.Register(c.Request.Context(), req)
+	h.audit(c, tokenEvent(models.AuditActionRegister, tokens, map[string]any{"username": req.Username}), err)
+	if err != nil {
+		if errors.Is(err, usecases.ErrRegistrationFailed) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Unable to register with these details"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tokens)
+}
+
+// PasswordLogin logs a local account in with its username or email and password
+func (h *authHttpHandler) PasswordLogin(c *gin.Context) {
+	var req models.LoginRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	// Guessing passwords is throttled by IP and by the account under attack
+	ipKey, accountKey := usecases.ThrottleIPKey(c.ClientIP()), usecases.ThrottleAccountKey(req.Username)
+	if h.reserveAttempt(c, ipKey, accountKey) {
+		return
+	}
+
+	tokens, err := h.passwordUsecase.Login(c.Request.Context(), req)
+	h.audit(c, tokenEvent(models.AuditActionPasswordLogin, tokens, map[string]any{"username": req.Username}), err)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+			return
+		}
+		h.releaseAttempt(c, ipKey, accountKey)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+
+	h.resetAttempts(c, accountKey)
+	h.releaseAttempt(c, ipKey)
+	c.JSON(http.StatusOK, tokens)
+}
+
+// ChangePassword replaces the password of the current user after checking the current one
+func (h *authHttpHandler) ChangePassword(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	err := h.passwordUsecase.ChangePassword(c.Request.Context(), claims.Subject, req)
+	h.audit(c, userEvent(models.AuditActionPasswordChange, claims.Subject, nil), err)
+	if err != nil {
+		// 403 rather than 401, so clients do not mistake it for an expired session
+		if errors.Is(err, usecases.ErrInvalidCredentials) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid current password"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password changed"})
+}
+
+// GetProfile returns the current user with their linked providers and the permissions of
+// the request
+func (h *authHttpHandler) GetProfile(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	auth, authMethods, err := h.profileUsecase.GetProfile(c.Request.Context(), claims.Subject)
+	if err != nil {
+		respondProfileError(c, err, "Failed to get profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, newProfile(c, auth, authMethods))
+}
+
+// UpdateProfile changes the username or email of the current user
+func (h *authHttpHandler) UpdateProfile(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	_, err := h.profileUsecase.UpdateProfile(c.Request.Context(), claims.Subject, req)
+	fields := []string{}
+	if req.Username != nil {
+		fields = append(fields, "username")
+	}
+	if req.Email != nil {
+		fields = append(fields, "email")
+	}
+	h.audit(c, userEvent(models.AuditActionProfileUpdate, claims.Subject, map[string]any{"fields": fields}), err)
+	if err != nil {
+		respondProfileError(c, err, "Failed to update profile")
+		return
+	}
+
+	auth, authMethods, err := h.profileUsecase.GetProfile(c.Request.Context(), claims.Subject)
+	if err != nil {
+		respondProfileError(c, err, "Failed to get profile")
+		return
+	}
+	c.JSON(http.StatusOK, newProfile(c, auth, authMethods))
+}
+
+// DeleteAccount deletes the account of the current user and signs out all of their devices
+func (h *authHttpHandler) DeleteAccount(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	// Accounts without a password send no body
+	var req models.DeleteAccountRequest
+	if c.Request.ContentLength != 0 && !bindAndValidate(c, &req) {
+		return
+	}
+
+	err := h.profileUsecase.DeleteAccount(c.Request.Context(), claims.Subject, req.Password)
+	h.audit(c, userEvent(models.AuditActionAccountDelete, claims.Subject, nil), err)
+	if err != nil {
+		respondProfileError(c, err, "Failed to delete account")
+		return
+	}
+
+	// Drop the cookies of the cookie delivery when the session uses them
+	if _, err := c.Cookie(models.AccessTokenCookie); err == nil {
+		h.clearTokenCookies(c)
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "account deleted"})
+}
+
+// respondProfileError maps the errors of the profile use case to responses, 500 with message
+// otherwise
+func respondProfileError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidCredentials):
+		// 403 rather than 401, so clients do not mistake it for an expired session
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid current password"})
+	case errors.Is(err, usecases.ErrProfileTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": "Username or email is already taken"})
+	case errors.Is(err, usecases.ErrAdminSelfDelete):
+		c.JSON(http.StatusConflict, gin.H{"error": "Admins cannot delete themselves"})
+	case errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// newProfile returns the fields of auth its owner sees, with the providers of authMethods and
+// the permissions the auth middleware granted the request
+func newProfile(c *gin.Context, auth *db.Auth, authMethods []*db.AuthMethod) models.Profile {
+	profile := models.Profile{
+		ID:          auth.ID,
+		Role:        auth.Role,
+		Permissions: []string{},
+		HasPassword: auth.Password != nil && *auth.Password != "",
+		MFAEnabled:  auth.MFAEnabledAt.Valid,
+		CreatedAt:   auth.CreatedAt.Time,
+		UpdatedAt:   auth.UpdatedAt.Time,
+		Providers:   make([]models.LinkedProvider, 0, len(authMethods)),
+	}
+	if auth.Username != nil {
+		profile.Username = *auth.Username
+	}
+	if auth.Email != nil {
+		profile.Email = *auth.Email
+	}
+	if principal, ok := authz.FromContext(c.Request.Context()); ok && principal.Permissions != nil {
+		profile.Permissions = principal.Permissions
+	}
+	for _, method := range authMethods {
+		profile.Providers = append(profile.Providers, newLinkedProvider(method))
+	}
+	return profile
+}
+
+// EnrollMFA starts a TOTP enrolment of the current user. MFA is enabled by ConfirmMFA.
+func (h *authHttpHandler) EnrollMFA(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	enrollment, err := h.mfaUsecase.Enroll(c.Request.Context(), claims.Subject)
+	if err != nil {
+		respondMFAError(c, err, "Failed to start MFA enrolment")
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmMFA enables MFA with a first code of the enrolment and returns the recovery codes. A
+// login waiting for MFA, because the role of the user requires it, is completed as well.
+func (h *authHttpHandler) ConfirmMFA(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.MFACodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	confirmation, err := h.mfaUsecase.Confirm(c.Request.Context(), claims, req.Code)
+	h.audit(c, userEvent(models.AuditActionMFAEnable, claims.Subject, nil), err)
+	if err != nil {
+		respondMFAError(c, err, "Failed to enable MFA")
+		return
+	}
+
+	if confirmation.Tokens != nil && h.tokenDelivery() == models.TokenDeliveryCookie {
+		h.setTokenCookies(c, confirmation.Tokens)
+	}
+	c.JSON(http.StatusOK, confirmation)
+}
+
+// VerifyMFA exchanges the mfa_pending token of a login and a TOTP or recovery code for a token pair
+func (h *authHttpHandler) VerifyMFA(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.MFACodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	// Six digit codes are quick to guess without a throttle
+	ipKey, accountKey := usecases.ThrottleIPKey(c.ClientIP()), usecases.ThrottleAccountKey(claims.Subject)
+	if h.reserveAttempt(c, ipKey, accountKey) {
+		return
+	}
+
+	tokens, err := h.mfaUsecase.Verify(c.Request.Context(), claims, req.Code)
+	h.audit(c, userEvent(models.AuditActionMFAVerify, claims.Subject, nil), err)
+	if err != nil {
+		if !errors.Is(err, usecases.ErrInvalidMFACode) {
+			h.releaseAttempt(c, ipKey, accountKey)
+		}
+		respondMFAError(c, err, "Failed to verify MFA code")
+		return
+	}
+	h.resetAttempts(c, accountKey)
+	h.releaseAttempt(c, ipKey)
+
+	// The pending token came from the cookie of the OAuth callback in the cookie delivery
+	if h.tokenDelivery() == models.TokenDeliveryCookie {
+		h.setTokenCookies(c, tokens)
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user
+func (h *authHttpHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.MFACodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	recoveryCodes, err := h.mfaUsecase.RegenerateRecoveryCodes(c.Request.Context(), claims.Subject, req.Code)
+	h.audit(c, userEvent(models.AuditActionMFARecoveryCodes, claims.Subject, nil), err)
+	if err != nil {
+		respondMFAError(c, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": recoveryCodes})
+}
+
+// DisableMFA turns MFA off for the current user
+func (h *authHttpHandler) DisableMFA(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.MFACodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	err := h.mfaUsecase.Disable(c.Request.Context(), claims.Subject, req.Code)
+	h.audit(c, userEvent(models.AuditActionMFADisable, claims.Subject, nil), err)
+	if err != nil {
+		respondMFAError(c, err, "Failed to disable MFA")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "mfa disabled"})
+}
+
+// respondMFAError maps the errors of the MFA use case to responses, 500 with message otherwise
+func respondMFAError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidMFACode):
+		// 403 rather than 401, so clients do not mistake it for an expired session
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid MFA code"})
+	case errors.Is(err, usecases.ErrMFAAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": "MFA is already enabled"})
+	case errors.Is(err, usecases.ErrMFANotEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": "MFA is not enabled"})
+	case errors.Is(err, usecases.ErrMFANotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": "No login is waiting for MFA"})
+	case errors.Is(err, usecases.ErrMFARequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "MFA is required for your role"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// throttled responds 429 when one of keys is blocked by the login throttle. The throttle fails
+// open, so an unreachable store does not lock everyone out.
+func (h *authHttpHandler) throttled(c *gin.Context, keys ...string) bool {
+	retryAfter, err := h.loginThrottle.Check(c.Request.Context(), keys...)
+	if err != nil {
+		logger.Errorf("Failed to check login throttle: %v", err)
+		return false
+	}
+	if retryAfter > 0 {
+		middlewares.AbortThrottled(c, retryAfter)
+		return true
+	}
+	return false
+}
+
+// reserveAttempt responds 429 when one of keys is blocked by the login throttle. Otherwise the
+// attempt is counted as failed up front, so a burst of concurrent attempts cannot all get past
+// the throttle, and must be taken back with releaseAttempt or resetAttempts unless it fails.
+// The throttle fails open like throttled.
+func (h *authHttpHandler) reserveAttempt(c *gin.Context, keys ...string) bool {
+	retryAfter, err := h.loginThrottle.Reserve(c.Request.Context(), keys...)
+	if err != nil {
+		logger.Errorf("Failed to reserve login attempt: %v", err)
+		return false
+	}
+	if retryAfter > 0 {
+		middlewares.AbortThrottled(c, retryAfter)
+		return true
+	}
+	return false
+}
+
+// releaseAttempt takes back the attempt reserved for keys when it did not fail
+func (h *authHttpHandler) releaseAttempt(c *gin.Context, keys ...string) {
+	if err := h.loginThrottle.Release(c.Request.Context(), keys...); err != nil {
+		logger.Errorf("Failed to release login attempt: %v", err)
+	}
+}
+
+// failAttempt counts a failed attempt of keys in the login throttle
+func (h *authHttpHandler) failAttempt(c *gin.Context, keys ...string) {
+	if _, err := h.loginThrottle.Fail(c.Request.Context(), keys...); err != nil {
+		logger.Errorf("Failed to record login failure: %v", err)
+	}
+}
+
+// resetAttempts forgets the failed attempts of keys after a successful login
+func (h *authHttpHandler) resetAttempts(c *gin.Context, keys ...string) {
+	if err := h.loginThrottle.Reset(c.Request.Context(), keys...); err != nil {
+		logger.Errorf("Failed to reset login throttle: %v", err)
+	}
+}
+
+// audit records event of the request in the audit log, as failed with err as reason when err
+// is not nil. The client and the actor default to those of pkg/context.RequestContext.
+func (h *authHttpHandler) audit(c *gin.Context, event models.AuditEvent, err error) {
+	event.Outcome = models.AuditOutcomeSuccess
+	if err != nil {
+		event.Outcome = models.AuditOutcomeFailure
+		if event.Metadata == nil {
+			event.Metadata = map[string]any{}
+		}
+		event.Metadata["reason"] = err.Error()
+	}
+	h.auditLogger.Log(requestContext(c), event)
+}
+
+// requestContext returns the context of the request carrying the request ID, user and client of
+// its pkg/context.RequestContext, for the audit log
+func requestContext(c *gin.Context) context.Context {
+	return pkgContext.NewRequestContext(c).WithRequestValues(c.Request.Context())
+}
+
+// userEvent is an audit event of action on the user authID
+func userEvent(action string, authID string, metadata map[string]any) models.AuditEvent {
+	return models.AuditEvent{
+		Action:     action,
+		TargetType: models.AuditTargetUser,
+		TargetID:   authID,
+		Metadata:   metadata,
+	}
+}
+
+// tokenEvent is an audit event of a login issuing tokens, done by and to the user the tokens
+// were issued to. Without tokens the user is unknown.
+func tokenEvent(action string, tokens *models.TokenPair, metadata map[string]any) models.AuditEvent {
+	event := models.AuditEvent{Action: action, Metadata: metadata}
+	if tokens == nil {
+		return event
+	}
+
+	// The token was just signed by this service, so its signature is not checked again
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(tokens.AccessToken, &claims); err == nil && claims.Subject != "" {
+		event.ActorID = claims.Subject
+		event.TargetType = models.AuditTargetUser
+		event.TargetID = claims.Subject
+	}
+	return event
+}
+
+// bindAndValidate binds the JSON body into req and checks its validate tags, responding with
+// 400 when either fails
+func bindAndValidate(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return false
+	}
+	if errs := validator.ValidateStruct(req); errs != nil {
+		response.ValidationError(c, errs)
+		return false
+	}
+	return true
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. The presented token is
+// rotated and cannot be used again. Without a body the refresh token cookie is used and the
+// new pair is set as cookies again.
+func (h *authHttpHandler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	fromCookie := false
+	if cookie, err := c.Cookie(models.RefreshTokenCookie); err == nil && c.Request.ContentLength == 0 {
+		req.RefreshToken = cookie
+		fromCookie = true
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refresh_token is required"})
+		return
+	}
+
+	tokens, err := h.jwtUsecase.RefreshTokens(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		// Only failures are recorded, refreshes are too frequent to be worth it. A reused token
+		// is a sign of theft.
+		h.audit(c, models.AuditEvent{Action: models.AuditActionRefreshToken}, err)
+		if errors.Is(err, usecases.ErrInvalidRefreshToken) || errors.Is(err, usecases.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	if fromCookie {
+		h.setTokenCookies(c, tokens)
+		c.JSON(http.StatusOK, gin.H{"message": "token refreshed"})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
 func (h *authHttpHandler) Logout(c *gin.Context) {
 	// Translate provider
 	provider := c.Param("provider")
@@ -112,6 +932,10 @@ func (h *authHttpHandler) Logout(c *gin.Context) {
 		c.JSON(400, gin.H{"message": "Provider is required"})
 		return
 	}
+	if _, err := goth.GetProvider(provider); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown provider"})
+		return
+	}
 
 	q := c.Request.URL.Query()
 	q.Add("provider", c.Param("provider"))
@@ -125,28 +949,503 @@ func (h *authHttpHandler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
 }
 
+// LogoutSession revokes the access token of the request and, when given, the refresh token
+// of the same session, so both stop working before they expire
+func (h *authHttpHandler) LogoutSession(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+	refreshCookie, refreshCookieErr := c.Cookie(models.RefreshTokenCookie)
+	if req.RefreshToken == "" {
+		req.RefreshToken = refreshCookie
+	}
+
+	err := h.jwtUsecase.Logout(c.Request.Context(), claims, req.RefreshToken)
+	h.audit(c, userEvent(models.AuditActionLogout, claims.Subject, nil), err)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	// Drop the cookies of the cookie delivery when the session uses them
+	if _, err := c.Cookie(models.AccessTokenCookie); err == nil || refreshCookieErr == nil {
+		h.clearTokenCookies(c)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+// LogoutAll revokes every token of the current user, signing out all of their devices
+func (h *authHttpHandler) LogoutAll(c *gin.Context) {
+	claims, ok := accessClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := h.jwtUsecase.RevokeAllTokens(c.Request.Context(), claims.Subject)
+	h.audit(c, userEvent(models.AuditActionLogoutAll, claims.Subject, nil), err)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out everywhere"})
+}
+
+// tokenDelivery returns how the OAuth callback hands tokens to the frontend
+func (h *authHttpHandler) tokenDelivery() string {
+	if h.conf.Auth.TokenDelivery == models.TokenDeliveryCookie {
+		return models.TokenDeliveryCookie
+	}
+	return models.TokenDeliveryCode
+}
+
+// setTokenCookies stores tokens in HttpOnly cookies, out of reach of scripts. SameSite=Lax
+// keeps them off cross-site POSTs. The refresh token is only sent to the auth routes.
+func (h *authHttpHandler) setTokenCookies(c *gin.Context, tokens *models.TokenPair) {
+	h.setCookie(c, models.AccessTokenCookie, tokens.AccessToken, "/", int(tokens.ExpiresIn))
+	// mfa_pending tokens come without refresh token
+	if tokens.RefreshToken != "" {
+		h.setCookie(c, models.RefreshTokenCookie, tokens.RefreshToken, authPath(c), int(h.conf.Auth.RefreshTokenTTL.Seconds()))
+	}
+}
+
+// clearTokenCookies expires the cookies set by setTokenCookies
+func (h *authHttpHandler) clearTokenCookies(c *gin.Context) {
+	h.setCookie(c, models.AccessTokenCookie, "", "/", -1)
+	h.setCookie(c, models.RefreshTokenCookie, "", authPath(c), -1)
+}
+
+func (h *authHttpHandler) setCookie(c *gin.Context, name string, value string, path string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   h.conf.Auth.CookieDomain,
+		MaxAge:   maxAge,
+		Secure:   h.conf.Auth.CookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// authPath returns the path of the auth routes of the matched route, e.g. /api/v1/auth
+func authPath(c *gin.Context) string {
+	fullPath := c.FullPath()
+	if i := strings.Index(fullPath, "/auth/"); i >= 0 {
+		return fullPath[:i] + "/auth"
+	}
+	return "/"
+}
+
+// accessClaims returns the claims set by the auth middleware
+func accessClaims(c *gin.Context) (*models.AccessClaims, bool) {
+	value, exists := c.Get("claims")
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*models.AccessClaims)
+	return claims, ok && claims != nil
+}
+
 func (h *authHttpHandler) Example(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "example",
 	})
 }
 
+// JWKS serves the public keys that verify access tokens, so other services can verify
+// them without sharing the private key
+func (h *authHttpHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keySet.JWKS())
+}
+
 // ======================== Admin Routes ========================
 
-// GetUsers retrieves multiple users with pagination
+// RotateSigningKey makes a new signing key active. Tokens signed by the previous key stay valid.
+func (h *authHttpHandler) RotateSigningKey(c *gin.Context) {
+	key, err := h.keySet.Rotate(c.Request.Context())
+	event := models.AuditEvent{Action: models.AuditActionSigningKeyRotate, TargetType: models.AuditTargetSigningKey}
+	if key != nil {
+		event.TargetID = key.Kid
+	}
+	h.audit(c, event, err)
+	if err != nil {
+		if errors.Is(err, usecases.ErrKeyRotationUnavailable) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Key rotation is not available"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate signing key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"kid": key.Kid})
+}
+
+// RevokeUserTokens revokes every token of a user, e.g. after a compromised device
+func (h *authHttpHandler) RevokeUserTokens(c *gin.Context) {
+	err := h.jwtUsecase.RevokeAllTokens(c.Request.Context(), c.Param("id"))
+	h.audit(c, userEvent(models.AuditActionUserRevokeTokens, c.Param("id"), nil), err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "tokens revoked"})
+}
+
+// GetUsers lists users newest first, page by page with the next and prev cursors of meta,
+// filtered by role, active status, linked provider and creation time. deleted=true lists
+// soft-deleted users instead.
 func (h *authHttpHandler) GetUsers(c *gin.Context) {
-	ctx := c.Request.Context()
+	var filter models.UserFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if errs := validator.ValidateStruct(filter); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
 
-	users, err := h.authRepo.ListAllAuths(ctx)
+	pagination, err := response.GetCursorPaginationFromContext(c, userList, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+		response.Error(c, err)
 		return
 	}
+	var cursor *models.UserCursor
+	if pagination.Cursor != nil {
+		cursor = &models.UserCursor{
+			CreatedAt: pagination.Cursor.CreatedAt,
+			ID:        pagination.Cursor.ID,
+			Before:    pagination.Backward(),
+		}
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"users": users,
-		"count": len(users),
+	auths, err := h.userAdminUsecase.ListUsers(c.Request.Context(), filter, cursor, pagination.FetchLimit())
+	if err != nil {
+		respondUserError(c, err, "Failed to retrieve users")
+		return
+	}
+
+	auths, next, prev := response.CursorPage(auths, pagination, func(auth *db.Auth) (time.Time, string) {
+		return auth.CreatedAt.Time, auth.ID
 	})
+	users := make([]models.AdminUser, 0, len(auths))
+	for _, auth := range auths {
+		users = append(users, newAdminUser(auth, nil))
+	}
+	response.CursorPaginated(c, users, pagination, next, prev)
+}
+
+// GetUser returns a user with their linked providers
+func (h *authHttpHandler) GetUser(c *gin.Context) {
+	auth, authMethods, err := h.userAdminUsecase.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondUserError(c, err, "Failed to retrieve user")
+		return
+	}
+
+	response.Success(c, newAdminUser(auth, authMethods))
+}
+
+// SetUserRole changes the role of a user
+func (h *authHttpHandler) SetUserRole(c *gin.Context) {
+	if h.isCurrentUser(c) {
+		response.Conflict(c, "Admins cannot change their own role")
+		return
+	}
+
+	var req models.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body")
+		return
+	}
+	if errs := validator.ValidateStruct(req); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+
+	auth, err := h.userAdminUsecase.SetRole(c.Request.Context(), c.Param("id"), models.Role(req.Role))
+	h.audit(c, userEvent(models.AuditActionUserRoleChange, c.Param("id"), map[string]any{"role": req.Role}), err)
+	if err != nil {
+		respondUserError(c, err, "Failed to change role")
+		return
+	}
+
+	response.SuccessWithMessage(c, "role changed", newAdminUser(auth, nil))
+}
+
+// ActivateUser lets a deactivated user log in again
+func (h *authHttpHandler) ActivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+// DeactivateUser stops a user from logging in and revokes their tokens
+func (h *authHttpHandler) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+func (h *authHttpHandler) setUserActive(c *gin.Context, active bool) {
+	if h.isCurrentUser(c) {
+		response.Conflict(c, "Admins cannot change their own status")
+		return
+	}
+
+	auth, err := h.userAdminUsecase.SetActive(c.Request.Context(), c.Param("id"), active)
+	action := models.AuditActionUserDeactivate
+	if active {
+		action = models.AuditActionUserActivate
+	}
+	h.audit(c, userEvent(action, c.Param("id"), nil), err)
+	if err != nil {
+		respondUserError(c, err, "Failed to change status")
+		return
+	}
+
+	message := "user deactivated"
+	if active {
+		message = "user activated"
+	}
+	response.SuccessWithMessage(c, message, newAdminUser(auth, nil))
+}
+
+// DeleteUser soft deletes a user and revokes their tokens. RestoreUser undoes it.
+func (h *authHttpHandler) DeleteUser(c *gin.Context) {
+	if h.isCurrentUser(c) {
+		response.Conflict(c, "Admins cannot delete themselves")
+		return
+	}
+
+	err := h.userAdminUsecase.DeleteUser(c.Request.Context(), c.Param("id"))
+	h.audit(c, userEvent(models.AuditActionUserDelete, c.Param("id"), nil), err)
+	if err != nil {
+		respondUserError(c, err, "Failed to delete user")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// RestoreUser restores a soft-deleted user
+func (h *authHttpHandler) RestoreUser(c *gin.Context) {
+	auth, err := h.userAdminUsecase.RestoreUser(c.Request.Context(), c.Param("id"))
+	h.audit(c, userEvent(models.AuditActionUserRestore, c.Param("id"), nil), err)
+	if err != nil {
+		respondUserError(c, err, "Failed to restore user")
+		return
+	}
+
+	response.SuccessWithMessage(c, "user restored", newAdminUser(auth, nil))
+}
+
+// isCurrentUser reports whether the :id of the request is the admin sending it. Admins cannot
+// lock themselves out.
+func (h *authHttpHandler) isCurrentUser(c *gin.Context) bool {
+	claims, ok := accessClaims(c)
+	return ok && claims.Subject == c.Param("id")
+}
+
+// respondUserError responds 404 for unknown users, 403 for missing permissions and 500 with
+// message otherwise
+func respondUserError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		response.NotFound(c, "User not found")
+	case errors.Is(err, authz.ErrForbidden), errors.Is(err, authz.ErrUnauthenticated):
+		response.Forbidden(c, "Insufficient permissions")
+	default:
+		response.InternalServerError(c, message)
+	}
+}
+
+// newAdminUser returns the fields of auth admins see, with the providers of authMethods
+func newAdminUser(auth *db.Auth, authMethods []*db.AuthMethod) models.AdminUser {
+	user := models.AdminUser{
+		ID:          auth.ID,
+		Role:        auth.Role,
+		Active:      auth.Active,
+		HasPassword: auth.Password != nil && *auth.Password != "",
+		CreatedAt:   auth.CreatedAt.Time,
+		UpdatedAt:   auth.UpdatedAt.Time,
+	}
+	if auth.Username != nil {
+		user.Username = *auth.Username
+	}
+	if auth.Email != nil {
+		user.Email = *auth.Email
+	}
+	if auth.DeletedAt.Valid {
+		user.DeletedAt = &auth.DeletedAt.Time
+	}
+	for _, method := range authMethods {
+		user.Providers = append(user.Providers, newLinkedProvider(method))
+	}
+	return user
+}
+
+// CreateAPIKey creates an API key acting as a user, limited to the requested scopes. The key is
+// only part of this response.
+func (h *authHttpHandler) CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body")
+		return
+	}
+	if errs := validator.ValidateStruct(req); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		response.BadRequest(c, "expires_at must be in the future")
+		return
+	}
+
+	apiKey, key, err := h.apiKeyUsecase.CreateKey(c.Request.Context(), req)
+	event := models.AuditEvent{
+		Action:     models.AuditActionAPIKeyCreate,
+		TargetType: models.AuditTargetAPIKey,
+		Metadata:   map[string]any{"auth_id": req.AuthID, "scopes": req.Scopes},
+	}
+	if apiKey != nil {
+		event.TargetID = apiKey.ID
+	}
+	h.audit(c, event, err)
+	if err != nil {
+		respondUserError(c, err, "Failed to create API key")
+		return
+	}
+
+	response.Created(c, models.CreatedAPIKey{APIKey: newAPIKey(apiKey), Key: key})
+}
+
+// GetAPIKeys lists the API keys, of the user in the auth_id query when given
+func (h *authHttpHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyUsecase.ListKeys(c.Request.Context(), c.Query("auth_id"))
+	if err != nil {
+		respondUserError(c, err, "Failed to retrieve API keys")
+		return
+	}
+
+	apiKeys := make([]models.APIKey, 0, len(keys))
+	for _, key := range keys {
+		apiKeys = append(apiKeys, newAPIKey(key))
+	}
+	response.Success(c, apiKeys)
+}
+
+// RevokeAPIKey revokes an API key. Revoked keys stay listed.
+func (h *authHttpHandler) RevokeAPIKey(c *gin.Context) {
+	apiKey, err := h.apiKeyUsecase.RevokeKey(c.Request.Context(), c.Param("id"))
+	h.audit(c, models.AuditEvent{Action: models.AuditActionAPIKeyRevoke, TargetType: models.AuditTargetAPIKey, TargetID: c.Param("id")}, err)
+	if errors.Is(err, pgx.ErrNoRows) {
+		response.NotFound(c, "API key not found")
+		return
+	}
+	if err != nil {
+		respondUserError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	response.SuccessWithMessage(c, "api key revoked", newAPIKey(apiKey))
+}
+
+// newAPIKey returns the fields of key admins see. The key hash is never part of it.
+func newAPIKey(key *db.APIKey) models.APIKey {
+	apiKey := models.APIKey{
+		ID:        key.ID,
+		AuthID:    key.AuthID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt.Time,
+	}
+	if key.ExpiresAt.Valid {
+		apiKey.ExpiresAt = &key.ExpiresAt.Time
+	}
+	if key.LastUsedAt.Valid {
+		apiKey.LastUsedAt = &key.LastUsedAt.Time
+	}
+	if key.RevokedAt.Valid {
+		apiKey.RevokedAt = &key.RevokedAt.Time
+	}
+	return apiKey
+}
+
+// GetAuditEvents lists the audit log page by page, newest first, filtered by actor, action,
+// target, outcome, IP address and time
+func (h *authHttpHandler) GetAuditEvents(c *gin.Context) {
+	var filter models.AuditEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if errs := validator.ValidateStruct(filter); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+
+	pagination := response.GetPaginationFromContext(c)
+	events, total, err := h.auditLogger.ListEvents(c.Request.Context(), filter, pagination.Limit, pagination.Offset())
+	if err != nil {
+		respondUserError(c, err, "Failed to retrieve audit events")
+		return
+	}
+
+	auditEvents := make([]models.AuditEventResponse, 0, len(events))
+	for _, event := range events {
+		auditEvents = append(auditEvents, newAuditEvent(event))
+	}
+	response.Paginated(c, auditEvents, pagination, total)
+}
+
+// newAuditEvent returns the fields of event admins see
+func newAuditEvent(event *db.AuditEvent) models.AuditEventResponse {
+	auditEvent := models.AuditEventResponse{
+		ID:        event.ID,
+		CreatedAt: event.CreatedAt.Time,
+		Action:    event.Action,
+		Outcome:   event.Outcome,
+		Metadata:  event.Metadata,
+	}
+	if event.ActorID != nil {
+		auditEvent.ActorID = *event.ActorID
+	}
+	if event.TargetType != nil {
+		auditEvent.TargetType = *event.TargetType
+	}
+	if event.TargetID != nil {
+		auditEvent.TargetID = *event.TargetID
+	}
+	if event.IpAddress != nil {
+		auditEvent.IPAddress = *event.IpAddress
+	}
+	if event.UserAgent != nil {
+		auditEvent.UserAgent = *event.UserAgent
+	}
+	if event.RequestID != nil {
+		auditEvent.RequestID = *event.RequestID
+	}
+	if len(auditEvent.Metadata) == 0 {
+		auditEvent.Metadata = []byte("{}")
+	}
+	return auditEvent
 }
 
 // ======================== Admin Routes ========================
@@ -156,16 +1455,56 @@ func (h *authHttpHandler) Routes(routerGroup *gin.RouterGroup) {
 
 	authProviderGroup.GET("/login", h.Login)
 	authProviderGroup.GET("/callback", h.AuthCallback)
+	authProviderGroup.POST("/callback", h.AuthCallback) // Apple posts the callback as a form
 	authProviderGroup.GET("/logout", h.Logout)
+	authProviderGroup.POST("/link", h.authMiddleware.Handle(), h.LinkProvider)
+	authProviderGroup.DELETE("/link", h.authMiddleware.Handle(), h.UnlinkProvider)
+
+	routerGroup.POST("/auth/token", h.ExchangeCode)
+	routerGroup.POST("/auth/token/refresh", h.RefreshToken)
+	routerGroup.POST("/auth/register", h.Register)
+	routerGroup.POST("/auth/login", h.PasswordLogin)
 
 	authGroup := routerGroup.Group("/auth")
 	authGroup.Use(h.authMiddleware.Handle())
 	authGroup.GET("/example", h.Example)
+	authGroup.POST("/logout", h.LogoutSession)
+	authGroup.POST("/logout/all", h.LogoutAll)
+	authGroup.PUT("/password", h.ChangePassword)
+	authGroup.GET("/providers", h.LinkedProviders)
+	authGroup.GET("/me", h.GetProfile)
+	authGroup.PATCH("/me", h.UpdateProfile)
+	authGroup.DELETE("/me", h.DeleteAccount)
+	authGroup.POST("/mfa/recovery-codes", h.RegenerateRecoveryCodes)
+	authGroup.DELETE("/mfa", h.DisableMFA)
 
+	// The enrolment and verification also take the mfa_pending token of a login
+	mfaGroup := routerGroup.Group("/auth/mfa")
+	mfaGroup.Use(h.authMiddleware.HandleMFA())
+	mfaGroup.POST("/enroll", h.EnrollMFA)
+	mfaGroup.POST("/confirm", h.ConfirmMFA)
+	mfaGroup.POST("/verify", h.VerifyMFA)
+
+	// Every admin route checks its permission with Requires, so API keys are accepted
 	authAdminGroup := routerGroup.Group("/admin/auth")
-	authAdminGroup.Use(h.authMiddleware.Handle(),
-		h.authMiddleware.Allows(
-			[]models.Role{models.RoleAdmin}),
-	)
-	authAdminGroup.GET("/users", h.GetUsers)
+	authAdminGroup.Use(h.authMiddleware.AcceptAPIKeys(), h.authMiddleware.Handle())
+	readUsers := h.authMiddleware.Requires(models.PermissionUsersRead)
+	writeUsers := h.authMiddleware.Requires(models.PermissionUsersWrite)
+	authAdminGroup.GET("/users", readUsers, h.GetUsers)
+	authAdminGroup.GET("/users/:id", readUsers, h.GetUser)
+	authAdminGroup.PUT("/users/:id/role", writeUsers, h.SetUserRole)
+	authAdminGroup.POST("/users/:id/activate", writeUsers, h.ActivateUser)
+	authAdminGroup.POST("/users/:id/deactivate", writeUsers, h.DeactivateUser)
+	authAdminGroup.DELETE("/users/:id", writeUsers, h.DeleteUser)
+	authAdminGroup.POST("/users/:id/restore", writeUsers, h.RestoreUser)
+	authAdminGroup.POST("/users/:id/revoke-tokens", writeUsers, h.RevokeUserTokens)
+	authAdminGroup.GET("/api-keys", h.authMiddleware.Requires(models.PermissionAPIKeysRead), h.GetAPIKeys)
+	authAdminGroup.POST("/api-keys", h.authMiddleware.Requires(models.PermissionAPIKeysWrite), h.CreateAPIKey)
+	authAdminGroup.DELETE("/api-keys/:id", h.authMiddleware.Requires(models.PermissionAPIKeysWrite), h.RevokeAPIKey)
+	authAdminGroup.POST("/keys/rotate", h.authMiddleware.Requires(models.PermissionSigningKeysRotate), h.RotateSigningKey)
+	authAdminGroup.GET("/audit-events", h.authMiddleware.Requires(models.PermissionAuditRead), h.GetAuditEvents)
+}
+
+func (h *authHttpHandler) WellKnownRoutes(routerGroup *gin.RouterGroup) {
+	routerGroup.GET("/.well-known/jwks.json", h.JWKS)
 }
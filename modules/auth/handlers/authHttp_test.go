@@ -1,21 +1,55 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/middlewares"
 	authMocks "template-golang/modules/auth/middlewares/mocks"
 	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
 	jwtMocks "template-golang/modules/auth/usecases/mocks"
+	"template-golang/pkg/authz"
+	pkgContext "template-golang/pkg/context"
+	"template-golang/pkg/response"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/markbates/goth"
+	"github.com/markbates/goth/providers/line"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// newTestThrottle returns a login throttle with the default policy, counting in memory
+func newTestThrottle() usecases.LoginThrottle {
+	return usecases.NewLoginThrottle(&config.Config{}, repositories.NewMemoryLoginAttemptRepository())
+}
+
+// newTestAuditLogger returns an audit logger accepting any event. Tests checking the events of
+// a handler set their own expectations on it.
+func newTestAuditLogger(t *testing.T) *jwtMocks.MockAuditLogger {
+	auditLogger := jwtMocks.NewMockAuditLogger(t)
+	auditLogger.EXPECT().Log(mock.Anything, mock.Anything).Maybe()
+	return auditLogger
+}
+
+// useLineProvider registers the LINE provider the login routes are tested with
+func useLineProvider() {
+	goth.UseProviders(line.New("test-client-id", "test-client-secret", "http://localhost:8080/auth/line/callback"))
+}
+
 func TestNewAuthHttpHandler(t *testing.T) {
 	// Setup
 	mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
@@ -31,32 +65,67 @@ func TestNewAuthHttpHandler(t *testing.T) {
 	}
 
 	// Execute
-	handler := NewAuthHttpHandler(mockJWTUsecase, conf, mockAuthMiddleware, nil)
+	providers := []goth.Provider{line.New("test-client-id", "test-client-secret", "http://localhost:8080/auth/line/callback")}
+	handler := NewAuthHttpHandler(mockJWTUsecase, jwtMocks.NewMockPasswordUsecase(t), jwtMocks.NewMockAuthCodeUsecase(t), jwtMocks.NewMockAccountLinkUsecase(t), jwtMocks.NewMockUserAdminUsecase(t), jwtMocks.NewMockProfileUsecase(t), jwtMocks.NewMockAPIKeyUsecase(t), jwtMocks.NewMockMFAUsecase(t), jwtMocks.NewMockLoginThrottle(t), jwtMocks.NewMockAuditLogger(t), jwtMocks.NewMockKeySet(t), conf, mockAuthMiddleware, nil, providers)
 
 	// Assert
 	assert.NotNil(t, handler)
+	_, err := goth.GetProvider("line")
+	assert.NoError(t, err)
 }
 
 func TestAuthHttpHandler_Login(t *testing.T) {
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
 	tests := []struct {
 		name           string
 		provider       string
+		query          string
+		tokenDelivery  string
 		expectedStatus int
 		expectedBody   string
 	}{
 		{
 			name:           "successful login with provider",
 			provider:       "line",
+			query:          "?code_challenge=" + challenge + "&code_challenge_method=S256",
 			expectedStatus: http.StatusTemporaryRedirect, // gothic.BeginAuthHandler redirects
 		},
+		{
+			name:           "missing code challenge",
+			provider:       "line",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"code_challenge with code_challenge_method S256 is required"}`,
+		},
+		{
+			name:           "plain code challenge method",
+			provider:       "line",
+			query:          "?code_challenge=" + challenge + "&code_challenge_method=plain",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"code_challenge with code_challenge_method S256 is required"}`,
+		},
+		{
+			name:           "cookie delivery needs no code challenge",
+			provider:       "line",
+			tokenDelivery:  models.TokenDeliveryCookie,
+			expectedStatus: http.StatusTemporaryRedirect,
+		},
 		{
 			name:           "missing provider parameter",
 			provider:       "",
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"message":"Provider is required"}`,
 		},
+		{
+			name:           "unknown provider",
+			provider:       "unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"Unknown provider"}`,
+		},
 	}
 
+	useLineProvider()
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
@@ -67,12 +136,15 @@ func TestAuthHttpHandler_Login(t *testing.T) {
 					LineClientSecret:  "test-client-secret",
 					LineCallbackURL:   "http://localhost:8080/auth/line/callback",
 					LineFECallbackURL: "http://localhost:3000/callback",
+					TokenDelivery:     tt.tokenDelivery,
 				},
 			}
 
 			handler := &authHttpHandler{
-				jwtUsecase: mockJWTUsecase,
-				conf:       conf,
+				auditLogger:   newTestAuditLogger(t),
+				jwtUsecase:    mockJWTUsecase,
+				conf:          conf,
+				loginThrottle: newTestThrottle(),
 			}
 
 			// Setup Gin
@@ -81,7 +153,7 @@ func TestAuthHttpHandler_Login(t *testing.T) {
 			c, _ := gin.CreateTestContext(w)
 
 			// Create request
-			req := httptest.NewRequest("GET", "/auth/"+tt.provider+"/login", nil)
+			req := httptest.NewRequest("GET", "/auth/"+tt.provider+"/login"+tt.query, nil)
 			c.Request = req
 			c.Params = gin.Params{
 				{Key: "provider", Value: tt.provider},
@@ -95,6 +167,12 @@ func TestAuthHttpHandler_Login(t *testing.T) {
 			if tt.expectedBody != "" {
 				assert.JSONEq(t, tt.expectedBody, w.Body.String())
 			}
+			if tt.expectedStatus == http.StatusTemporaryRedirect && tt.query != "" {
+				// The challenge travels in the OAuth state
+				location, err := url.Parse(w.Header().Get("Location"))
+				assert.NoError(t, err)
+				assert.True(t, strings.HasSuffix(location.Query().Get("state"), "."+challenge))
+			}
 		})
 	}
 }
@@ -121,11 +199,23 @@ func TestAuthHttpHandler_AuthCallback(t *testing.T) {
 				assert.Equal(t, "Provider is required", response["message"])
 			},
 		},
+		{
+			name:     "unknown provider",
+			provider: "unknown",
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				// No mock calls expected
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"error":"Unknown provider"}`, w.Body.String())
+			},
+		},
 		{
 			name:     "JWT generation fails",
 			provider: "line",
 			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
-				m.On("GenerateJWT", "test-user-id").Return("", errors.New("jwt generation failed"))
+				m.On("UpsertUser", mock.Anything, mock.Anything).Return(&db.Auth{ID: "auth-1"}, nil)
+				m.On("IssueTokens", mock.Anything, "auth-1").Return(nil, errors.New("jwt generation failed"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -139,12 +229,15 @@ func TestAuthHttpHandler_AuthCallback(t *testing.T) {
 			name:     "successful JWT generation",
 			provider: "line",
 			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
-				m.On("GenerateJWT", "test-user-id").Return("test-jwt-token", nil)
+				m.On("UpsertUser", mock.Anything, mock.Anything).Return(&db.Auth{ID: "auth-1"}, nil)
+				m.On("IssueTokens", mock.Anything, "auth-1").
+					Return(&models.TokenPair{AccessToken: "test-jwt-token", RefreshToken: "test-refresh-token"}, nil)
 			},
 			expectedStatus: http.StatusFound,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				location := w.Header().Get("Location")
-				assert.Contains(t, location, "http://localhost:3000/callback?token=test-jwt-token")
+				assert.Contains(t, location, "http://localhost:3000/callback?code=")
+				assert.NotContains(t, location, "token=")
 			},
 		},
 	}
@@ -171,8 +264,10 @@ func TestAuthHttpHandler_AuthCallback(t *testing.T) {
 			}
 
 			handler := &authHttpHandler{
-				jwtUsecase: mockJWTUsecase,
-				conf:       conf,
+				auditLogger:   newTestAuditLogger(t),
+				jwtUsecase:    mockJWTUsecase,
+				conf:          conf,
+				loginThrottle: newTestThrottle(),
 			}
 
 			// Setup Gin
@@ -217,8 +312,16 @@ func TestAuthHttpHandler_Logout(t *testing.T) {
 			provider:       "line",
 			expectedStatus: http.StatusOK, // gothic.Logout returns 200 on success
 		},
+		{
+			name:           "unknown provider",
+			provider:       "unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"Unknown provider"}`,
+		},
 	}
 
+	useLineProvider()
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
@@ -233,8 +336,9 @@ func TestAuthHttpHandler_Logout(t *testing.T) {
 			}
 
 			handler := &authHttpHandler{
-				jwtUsecase: mockJWTUsecase,
-				conf:       conf,
+				auditLogger: newTestAuditLogger(t),
+				jwtUsecase:  mockJWTUsecase,
+				conf:        conf,
 			}
 
 			// Setup Gin
@@ -261,72 +365,1790 @@ func TestAuthHttpHandler_Logout(t *testing.T) {
 	}
 }
 
-func TestAuthHttpHandler_Routes(t *testing.T) {
-	// Setup
-	mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
-	conf := &config.Config{
-		Auth: config.AuthConfig{
-			LineClientID:      "test-client-id",
-			LineClientSecret:  "test-client-secret",
-			LineCallbackURL:   "http://localhost:8080/auth/line/callback",
-			LineFECallbackURL: "http://localhost:3000/callback",
+func TestAuthHttpHandler_ExchangeCode(t *testing.T) {
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*jwtMocks.MockAuthCodeUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing code verifier",
+			body:           `{"code":"code"}`,
+			setupMocks:     func(m *jwtMocks.MockAuthCodeUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name: "invalid code",
+			body: `{"code":"used","code_verifier":"` + verifier + `"}`,
+			setupMocks: func(m *jwtMocks.MockAuthCodeUsecase) {
+				m.EXPECT().Exchange(mock.Anything, "used", verifier).Return(nil, usecases.ErrInvalidAuthCode)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Invalid authorization code"`,
+		},
+		{
+			name: "storage failure",
+			body: `{"code":"code","code_verifier":"` + verifier + `"}`,
+			setupMocks: func(m *jwtMocks.MockAuthCodeUsecase) {
+				m.EXPECT().Exchange(mock.Anything, "code", verifier).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `"error":"Failed to exchange authorization code"`,
+		},
+		{
+			name: "exchanged",
+			body: `{"code":"code","code_verifier":"` + verifier + `"}`,
+			setupMocks: func(m *jwtMocks.MockAuthCodeUsecase) {
+				m.EXPECT().Exchange(mock.Anything, "code", verifier).
+					Return(&models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"access_token":"access"`,
 		},
 	}
 
-	mockAuthMiddleware := authMocks.NewMockAuthMiddleware(t)
-	// Set up expectation for authMiddleware.Handle() to be called
-	mockAuthMiddleware.On("Handle").Return(gin.HandlerFunc(func(c *gin.Context) {
-		c.Next()
-	}))
-	mockAuthMiddleware.On("Allows", []models.Role{models.RoleAdmin}).Return(gin.HandlerFunc(func(c *gin.Context) {
-		c.Next()
-	}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthCodeUsecase := jwtMocks.NewMockAuthCodeUsecase(t)
+			tt.setupMocks(mockAuthCodeUsecase)
 
-	handler := &authHttpHandler{
-		jwtUsecase:     mockJWTUsecase,
-		conf:           conf,
-		authMiddleware: mockAuthMiddleware,
+			handler := &authHttpHandler{authCodeUsecase: mockAuthCodeUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/token", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.ExchangeCode(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
 	}
+}
 
-	// Setup Gin router
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
-	api := router.Group("/api/v1")
+func TestAuthHttpHandler_RefreshToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*jwtMocks.MockJWTUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing refresh token",
+			body:           `{}`,
+			setupMocks:     func(m *jwtMocks.MockJWTUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"refresh_token is required"}`,
+		},
+		{
+			name: "invalid refresh token",
+			body: `{"refresh_token":"unknown"}`,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().RefreshTokens(mock.Anything, "unknown").Return(nil, usecases.ErrInvalidRefreshToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid refresh token"}`,
+		},
+		{
+			name: "reused refresh token",
+			body: `{"refresh_token":"used"}`,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().RefreshTokens(mock.Anything, "used").Return(nil, usecases.ErrRefreshTokenReused)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid refresh token"}`,
+		},
+		{
+			name: "storage failure",
+			body: `{"refresh_token":"valid"}`,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().RefreshTokens(mock.Anything, "valid").Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to refresh token"}`,
+		},
+		{
+			name: "successful refresh",
+			body: `{"refresh_token":"valid"}`,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().RefreshTokens(mock.Anything, "valid").Return(&models.TokenPair{
+					AccessToken:  "new-access-token",
+					RefreshToken: "new-refresh-token",
+					TokenType:    "Bearer",
+					ExpiresIn:    900,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"access_token":"new-access-token","refresh_token":"new-refresh-token","token_type":"Bearer","expires_in":900}`,
+		},
+	}
 
-	// Execute
-	handler.Routes(api)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+			tt.setupMocks(mockJWTUsecase)
 
-	// Test that routes are registered correctly
-	routes := router.Routes()
+			handler := &authHttpHandler{
+				auditLogger: newTestAuditLogger(t),
+				jwtUsecase:  mockJWTUsecase,
+				conf:        &config.Config{},
+			}
 
-	expectedRoutes := map[string]string{
-		"/api/v1/auth/:provider/login":    "GET",
-		"/api/v1/auth/:provider/callback": "GET",
-		"/api/v1/auth/:provider/logout":   "GET",
-		"/api/v1/auth/example":            "GET",
+			// Setup Gin
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// Create request
+			req := httptest.NewRequest("POST", "/auth/token/refresh", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+
+			// Execute
+			handler.RefreshToken(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
 	}
+}
 
-	// Check that all expected routes are registered
-	routeMap := make(map[string]bool)
-	for _, route := range routes {
-		routeKey := route.Method + " " + route.Path
-		routeMap[routeKey] = true
+func TestAuthHttpHandler_Register(t *testing.T) {
+	valid := `{"username":"john_doe","email":"john@example.com","password":"S3cret!pass"}`
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*jwtMocks.MockPasswordUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "malformed body",
+			body:           `{`,
+			setupMocks:     func(m *jwtMocks.MockPasswordUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"error":"Invalid request body"`,
+		},
+		{
+			name:           "weak password",
+			body:           `{"username":"john_doe","password":"password"}`,
+			setupMocks:     func(m *jwtMocks.MockPasswordUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name: "username or email taken",
+			body: valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().Register(mock.Anything, mock.Anything).Return(nil, usecases.ErrRegistrationFailed)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"error":"Unable to register with these details"`,
+		},
+		{
+			name: "storage failure",
+			body: valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().Register(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `"error":"Failed to register"`,
+		},
+		{
+			name: "registered",
+			body: valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().Register(mock.Anything, models.RegisterRequest{
+					Username: "john_doe",
+					Email:    "john@example.com",
+					Password: "S3cret!pass",
+				}).Return(&models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"access_token":"access"`,
+		},
 	}
 
-	for expectedRoute, expectedMethod := range expectedRoutes {
-		routeKey := expectedMethod + " " + expectedRoute
-		assert.True(t, routeMap[routeKey], "Route %s should be registered", routeKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPasswordUsecase := jwtMocks.NewMockPasswordUsecase(t)
+			tt.setupMocks(mockPasswordUsecase)
 
-		// Test each route responds (basic smoke test)
-		w := httptest.NewRecorder()
+			handler := &authHttpHandler{passwordUsecase: mockPasswordUsecase, auditLogger: newTestAuditLogger(t)}
 
-		// Modify the path for parameterized routes
-		testPath := strings.Replace(expectedRoute, ":provider", "line", 1)
-		req := httptest.NewRequest(expectedMethod, testPath, nil)
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/register", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
 
-		router.ServeHTTP(w, req)
+			handler.Register(c)
 
-		// We don't expect 404 for registered routes
-		assert.NotEqual(t, http.StatusNotFound, w.Code, "Route %s should be accessible", testPath)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_PasswordLogin(t *testing.T) {
+	valid := `{"username":"john@example.com","password":"S3cret!pass"}`
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*jwtMocks.MockPasswordUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing password",
+			body:           `{"username":"john"}`,
+			setupMocks:     func(m *jwtMocks.MockPasswordUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name: "invalid credentials",
+			body: valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().Login(mock.Anything, mock.Anything).Return(nil, usecases.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Invalid username or password"`,
+		},
+		{
+			name: "storage failure",
+			body: valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().Login(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `"error":"Failed to log in"`,
+		},
+		{
+			name: "logged in",
+			body: valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().Login(mock.Anything, models.LoginRequest{Username: "john@example.com", Password: "S3cret!pass"}).
+					Return(&models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"access_token":"access"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPasswordUsecase := jwtMocks.NewMockPasswordUsecase(t)
+			tt.setupMocks(mockPasswordUsecase)
+
+			handler := &authHttpHandler{passwordUsecase: mockPasswordUsecase, loginThrottle: newTestThrottle(), auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/login", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.PasswordLogin(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_PasswordLogin_Throttle(t *testing.T) {
+	valid := `{"username":"John@Example.com","password":"S3cret!pass"}`
+	ipKey, accountKey := usecases.ThrottleIPKey("192.0.2.1"), usecases.ThrottleAccountKey("john@example.com")
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*jwtMocks.MockPasswordUsecase, *jwtMocks.MockLoginThrottle)
+		expectedStatus int
+		retryAfter     string
+	}{
+		{
+			name: "blocked",
+			setupMocks: func(p *jwtMocks.MockPasswordUsecase, th *jwtMocks.MockLoginThrottle) {
+				th.EXPECT().Reserve(mock.Anything, []string{ipKey, accountKey}).Return(1500*time.Millisecond, nil)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			retryAfter:     "2",
+		},
+		{
+			name: "failure keeps the reserved attempt",
+			setupMocks: func(p *jwtMocks.MockPasswordUsecase, th *jwtMocks.MockLoginThrottle) {
+				th.EXPECT().Reserve(mock.Anything, []string{ipKey, accountKey}).Return(time.Duration(0), nil)
+				p.EXPECT().Login(mock.Anything, mock.Anything).Return(nil, usecases.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "success resets the account and releases the IP",
+			setupMocks: func(p *jwtMocks.MockPasswordUsecase, th *jwtMocks.MockLoginThrottle) {
+				th.EXPECT().Reserve(mock.Anything, []string{ipKey, accountKey}).Return(time.Duration(0), nil)
+				p.EXPECT().Login(mock.Anything, mock.Anything).Return(&models.TokenPair{AccessToken: "access"}, nil)
+				th.EXPECT().Reset(mock.Anything, []string{accountKey}).Return(nil)
+				th.EXPECT().Release(mock.Anything, []string{ipKey}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "error releases the attempt",
+			setupMocks: func(p *jwtMocks.MockPasswordUsecase, th *jwtMocks.MockLoginThrottle) {
+				th.EXPECT().Reserve(mock.Anything, []string{ipKey, accountKey}).Return(time.Duration(0), nil)
+				p.EXPECT().Login(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+				th.EXPECT().Release(mock.Anything, []string{ipKey, accountKey}).Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "throttle store failure lets the login through",
+			setupMocks: func(p *jwtMocks.MockPasswordUsecase, th *jwtMocks.MockLoginThrottle) {
+				th.EXPECT().Reserve(mock.Anything, []string{ipKey, accountKey}).Return(time.Duration(0), errors.New("db down"))
+				p.EXPECT().Login(mock.Anything, mock.Anything).Return(&models.TokenPair{AccessToken: "access"}, nil)
+				th.EXPECT().Reset(mock.Anything, []string{accountKey}).Return(nil)
+				th.EXPECT().Release(mock.Anything, []string{ipKey}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
 	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPasswordUsecase := jwtMocks.NewMockPasswordUsecase(t)
+			mockLoginThrottle := jwtMocks.NewMockLoginThrottle(t)
+			tt.setupMocks(mockPasswordUsecase, mockLoginThrottle)
+
+			handler := &authHttpHandler{passwordUsecase: mockPasswordUsecase, loginThrottle: mockLoginThrottle, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/login", strings.NewReader(valid))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.PasswordLogin(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestAuthHttpHandler_PasswordLogin_Audit(t *testing.T) {
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "auth-1"}).SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		tokens        *models.TokenPair
+		err           error
+		expectedEvent models.AuditEvent
+	}{
+		{
+			name: "failure without actor",
+			err:  usecases.ErrInvalidCredentials,
+			expectedEvent: models.AuditEvent{
+				Action:   models.AuditActionPasswordLogin,
+				Outcome:  models.AuditOutcomeFailure,
+				Metadata: map[string]any{"username": "john", "reason": "invalid credentials"},
+			},
+		},
+		{
+			name:   "success by the user of the tokens",
+			tokens: &models.TokenPair{AccessToken: accessToken, RefreshToken: "refresh"},
+			expectedEvent: models.AuditEvent{
+				ActorID:    "auth-1",
+				Action:     models.AuditActionPasswordLogin,
+				TargetType: models.AuditTargetUser,
+				TargetID:   "auth-1",
+				Outcome:    models.AuditOutcomeSuccess,
+				Metadata:   map[string]any{"username": "john"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPasswordUsecase := jwtMocks.NewMockPasswordUsecase(t)
+			mockPasswordUsecase.EXPECT().Login(mock.Anything, mock.Anything).Return(tt.tokens, tt.err).Once()
+
+			// The client of the request travels in the context of the event
+			mockAuditLogger := jwtMocks.NewMockAuditLogger(t)
+			mockAuditLogger.EXPECT().Log(mock.MatchedBy(func(ctx context.Context) bool {
+				return ctx.Value(pkgContext.UserAgentKey) == "test-agent" && ctx.Value(pkgContext.IPAddressKey) == "192.0.2.1"
+			}), tt.expectedEvent).Once()
+
+			handler := &authHttpHandler{passwordUsecase: mockPasswordUsecase, loginThrottle: newTestThrottle(), auditLogger: mockAuditLogger}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/login", strings.NewReader(`{"username":"john","password":"S3cret!pass"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("User-Agent", "test-agent")
+
+			handler.PasswordLogin(c)
+		})
+	}
+}
+
+func TestAuthHttpHandler_ChangePassword(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}
+	valid := `{"current_password":"S3cret!pass","new_password":"N3w!password"}`
+
+	tests := []struct {
+		name           string
+		claims         *models.AccessClaims
+		body           string
+		setupMocks     func(*jwtMocks.MockPasswordUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing claims",
+			body:           valid,
+			setupMocks:     func(m *jwtMocks.MockPasswordUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Unauthorized"`,
+		},
+		{
+			name:           "new password equals current",
+			claims:         claims,
+			body:           `{"current_password":"S3cret!pass","new_password":"S3cret!pass"}`,
+			setupMocks:     func(m *jwtMocks.MockPasswordUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name:   "wrong current password",
+			claims: claims,
+			body:   valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().ChangePassword(mock.Anything, "auth-1", mock.Anything).Return(usecases.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `"error":"Invalid current password"`,
+		},
+		{
+			name:   "changed",
+			claims: claims,
+			body:   valid,
+			setupMocks: func(m *jwtMocks.MockPasswordUsecase) {
+				m.EXPECT().ChangePassword(mock.Anything, "auth-1", models.ChangePasswordRequest{
+					CurrentPassword: "S3cret!pass",
+					NewPassword:     "N3w!password",
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"message":"password changed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPasswordUsecase := jwtMocks.NewMockPasswordUsecase(t)
+			tt.setupMocks(mockPasswordUsecase)
+
+			handler := &authHttpHandler{passwordUsecase: mockPasswordUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PUT", "/auth/password", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.claims != nil {
+				c.Set("claims", tt.claims)
+			}
+
+			handler.ChangePassword(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_GetProfile(t *testing.T) {
+	username := "john"
+	passwordHash := "hash"
+	accessToken := "provider-access-token"
+	auth := &db.Auth{ID: "auth-1", Username: &username, Password: &passwordHash, Role: "user", Active: true}
+	auth.MFAEnabledAt.Valid = true
+
+	mockProfileUsecase := jwtMocks.NewMockProfileUsecase(t)
+	mockProfileUsecase.EXPECT().GetProfile(mock.Anything, "auth-1").Return(auth, []*db.AuthMethod{
+		{ID: "method-1", Provider: "github", AccessToken: &accessToken},
+	}, nil).Once()
+
+	handler := &authHttpHandler{profileUsecase: mockProfileUsecase, auditLogger: newTestAuditLogger(t)}
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/auth/me", nil)
+	c.Request = c.Request.WithContext(authz.WithPrincipal(c.Request.Context(), authz.Principal{
+		UserID:      "auth-1",
+		Role:        "user",
+		Permissions: []string{models.PermissionUsersRead},
+	}))
+	c.Set("claims", &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}})
+
+	handler.GetProfile(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var profile models.Profile
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+	assert.Equal(t, "john", profile.Username)
+	assert.Equal(t, "user", profile.Role)
+	assert.Equal(t, []string{models.PermissionUsersRead}, profile.Permissions)
+	assert.True(t, profile.HasPassword)
+	assert.True(t, profile.MFAEnabled)
+	assert.Len(t, profile.Providers, 1)
+	assert.NotContains(t, w.Body.String(), accessToken)
+	assert.NotContains(t, w.Body.String(), passwordHash)
+}
+
+func TestAuthHttpHandler_UpdateProfile(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}
+	username := "jane"
+
+	tests := []struct {
+		name           string
+		claims         *models.AccessClaims
+		body           string
+		setupMocks     func(*jwtMocks.MockProfileUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing claims",
+			body:           `{"username":"jane"}`,
+			setupMocks:     func(m *jwtMocks.MockProfileUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Unauthorized"`,
+		},
+		{
+			name:           "invalid email",
+			claims:         claims,
+			body:           `{"email":"not-an-email"}`,
+			setupMocks:     func(m *jwtMocks.MockProfileUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name:   "email without current password",
+			claims: claims,
+			body:   `{"email":"jane@example.com"}`,
+			setupMocks: func(m *jwtMocks.MockProfileUsecase) {
+				m.EXPECT().UpdateProfile(mock.Anything, "auth-1", mock.Anything).Return(nil, usecases.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `"error":"Invalid current password"`,
+		},
+		{
+			name:   "username taken",
+			claims: claims,
+			body:   `{"username":"jane"}`,
+			setupMocks: func(m *jwtMocks.MockProfileUsecase) {
+				m.EXPECT().UpdateProfile(mock.Anything, "auth-1", mock.Anything).Return(nil, usecases.ErrProfileTaken)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"error":"Username or email is already taken"`,
+		},
+		{
+			name:   "updated",
+			claims: claims,
+			body:   `{"username":"jane"}`,
+			setupMocks: func(m *jwtMocks.MockProfileUsecase) {
+				updated := &db.Auth{ID: "auth-1", Username: &username, Role: "user"}
+				m.EXPECT().UpdateProfile(mock.Anything, "auth-1", models.UpdateProfileRequest{Username: &username}).Return(updated, nil)
+				m.EXPECT().GetProfile(mock.Anything, "auth-1").Return(updated, nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"username":"jane"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProfileUsecase := jwtMocks.NewMockProfileUsecase(t)
+			tt.setupMocks(mockProfileUsecase)
+
+			handler := &authHttpHandler{profileUsecase: mockProfileUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PATCH", "/auth/me", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.claims != nil {
+				c.Set("claims", tt.claims)
+			}
+
+			handler.UpdateProfile(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_DeleteAccount(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*jwtMocks.MockProfileUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "without body",
+			setupMocks: func(m *jwtMocks.MockProfileUsecase) {
+				m.EXPECT().DeleteAccount(mock.Anything, "auth-1", "").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"message":"account deleted"`,
+		},
+		{
+			name: "wrong password",
+			body: `{"password":"wrong"}`,
+			setupMocks: func(m *jwtMocks.MockProfileUsecase) {
+				m.EXPECT().DeleteAccount(mock.Anything, "auth-1", "wrong").Return(usecases.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `"error":"Invalid current password"`,
+		},
+		{
+			name: "admin",
+			body: `{"password":"S3cret!pass"}`,
+			setupMocks: func(m *jwtMocks.MockProfileUsecase) {
+				m.EXPECT().DeleteAccount(mock.Anything, "auth-1", "S3cret!pass").Return(usecases.ErrAdminSelfDelete)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"error":"Admins cannot delete themselves"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProfileUsecase := jwtMocks.NewMockProfileUsecase(t)
+			tt.setupMocks(mockProfileUsecase)
+
+			handler := &authHttpHandler{profileUsecase: mockProfileUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/auth/me", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("claims", claims)
+
+			handler.DeleteAccount(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_VerifyMFA(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}, MFAPending: true}
+
+	tests := []struct {
+		name           string
+		delivery       string
+		claims         *models.AccessClaims
+		body           string
+		setupMocks     func(*jwtMocks.MockMFAUsecase)
+		expectedStatus int
+		expectedBody   string
+		expectCookies  bool
+	}{
+		{
+			name:           "missing claims",
+			body:           `{"code":"123456"}`,
+			setupMocks:     func(m *jwtMocks.MockMFAUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Unauthorized"`,
+		},
+		{
+			name:           "missing code",
+			claims:         claims,
+			body:           `{}`,
+			setupMocks:     func(m *jwtMocks.MockMFAUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name:   "invalid code",
+			claims: claims,
+			body:   `{"code":"123456"}`,
+			setupMocks: func(m *jwtMocks.MockMFAUsecase) {
+				m.EXPECT().Verify(mock.Anything, claims, "123456").Return(nil, usecases.ErrInvalidMFACode)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `"error":"Invalid MFA code"`,
+		},
+		{
+			name:   "login not pending",
+			claims: claims,
+			body:   `{"code":"123456"}`,
+			setupMocks: func(m *jwtMocks.MockMFAUsecase) {
+				m.EXPECT().Verify(mock.Anything, claims, "123456").Return(nil, usecases.ErrMFANotPending)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"error":"No login is waiting for MFA"`,
+		},
+		{
+			name:   "verified",
+			claims: claims,
+			body:   `{"code":"123456"}`,
+			setupMocks: func(m *jwtMocks.MockMFAUsecase) {
+				m.EXPECT().Verify(mock.Anything, claims, "123456").Return(&models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"refresh_token":"refresh"`,
+		},
+		{
+			name:     "verified with cookie delivery",
+			delivery: models.TokenDeliveryCookie,
+			claims:   claims,
+			body:     `{"code":"123456"}`,
+			setupMocks: func(m *jwtMocks.MockMFAUsecase) {
+				m.EXPECT().Verify(mock.Anything, claims, "123456").Return(&models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"access_token":"access"`,
+			expectCookies:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMFAUsecase := jwtMocks.NewMockMFAUsecase(t)
+			tt.setupMocks(mockMFAUsecase)
+
+			handler := &authHttpHandler{
+				auditLogger:   newTestAuditLogger(t),
+				mfaUsecase:    mockMFAUsecase,
+				conf:          &config.Config{Auth: config.AuthConfig{TokenDelivery: tt.delivery}},
+				loginThrottle: newTestThrottle(),
+			}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/mfa/verify", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.claims != nil {
+				c.Set("claims", tt.claims)
+			}
+
+			handler.VerifyMFA(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectCookies, len(w.Result().Cookies()) > 0)
+		})
+	}
+}
+
+func TestAuthHttpHandler_DisableMFA(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "disabled", expectedStatus: http.StatusOK, expectedBody: `"message":"mfa disabled"`},
+		{name: "required by role", err: usecases.ErrMFARequired, expectedStatus: http.StatusForbidden, expectedBody: `"error":"MFA is required for your role"`},
+		{name: "not enabled", err: usecases.ErrMFANotEnabled, expectedStatus: http.StatusConflict, expectedBody: `"error":"MFA is not enabled"`},
+		{name: "failure", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError, expectedBody: `"error":"Failed to disable MFA"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMFAUsecase := jwtMocks.NewMockMFAUsecase(t)
+			mockMFAUsecase.EXPECT().Disable(mock.Anything, "auth-1", "123456").Return(tt.err)
+
+			handler := &authHttpHandler{mfaUsecase: mockMFAUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/auth/mfa", strings.NewReader(`{"code":"123456"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("claims", claims)
+
+			handler.DisableMFA(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_LinkProvider(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}
+
+	tests := []struct {
+		name           string
+		provider       string
+		claims         *models.AccessClaims
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing claims",
+			provider:       "line",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Unauthorized"`,
+		},
+		{
+			name:           "unknown provider",
+			provider:       "unknown",
+			claims:         claims,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"error":"Unknown provider"`,
+		},
+		{
+			name:           "returns authorization url",
+			provider:       "line",
+			claims:         claims,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"url":"https://access.line.me/`,
+		},
+	}
+
+	useLineProvider()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &authHttpHandler{auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/"+tt.provider+"/link", nil)
+			c.Params = gin.Params{{Key: "provider", Value: tt.provider}}
+			if tt.claims != nil {
+				c.Set("claims", tt.claims)
+			}
+
+			handler.LinkProvider(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			if tt.expectedStatus == http.StatusOK {
+				assert.NotEmpty(t, w.Header().Get("Set-Cookie"), "the provider session has to be stored")
+			}
+		})
+	}
+}
+
+func TestAuthHttpHandler_UnlinkProvider(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}
+
+	tests := []struct {
+		name           string
+		claims         *models.AccessClaims
+		unlinkErr      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing claims",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `"error":"Unauthorized"`,
+		},
+		{
+			name:           "not linked",
+			claims:         claims,
+			unlinkErr:      usecases.ErrProviderNotLinked,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"error":"Provider is not linked"`,
+		},
+		{
+			name:           "last login method",
+			claims:         claims,
+			unlinkErr:      usecases.ErrLastLoginMethod,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"error":"Cannot unlink the last login method"`,
+		},
+		{
+			name:           "unlinked",
+			claims:         claims,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"message":"provider unlinked"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAccountLinkUsecase := jwtMocks.NewMockAccountLinkUsecase(t)
+			if tt.claims != nil {
+				mockAccountLinkUsecase.EXPECT().Unlink(mock.Anything, "auth-1", "github").Return(tt.unlinkErr)
+			}
+
+			handler := &authHttpHandler{accountLinkUsecase: mockAccountLinkUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("DELETE", "/auth/github/link", nil)
+			c.Params = gin.Params{{Key: "provider", Value: "github"}}
+			if tt.claims != nil {
+				c.Set("claims", tt.claims)
+			}
+
+			handler.UnlinkProvider(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_LinkedProviders(t *testing.T) {
+	email := "john@example.com"
+	accessToken := "provider-access-token"
+
+	mockAccountLinkUsecase := jwtMocks.NewMockAccountLinkUsecase(t)
+	mockAccountLinkUsecase.EXPECT().LinkedProviders(mock.Anything, "auth-1").Return([]*db.AuthMethod{
+		{ID: "method-1", Provider: "github", Email: &email, AccessToken: &accessToken},
+	}, nil)
+
+	handler := &authHttpHandler{accountLinkUsecase: mockAccountLinkUsecase, auditLogger: newTestAuditLogger(t)}
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/auth/providers", nil)
+	c.Set("claims", &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}})
+
+	handler.LinkedProviders(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"provider":"github"`)
+	assert.Contains(t, w.Body.String(), `"email":"john@example.com"`)
+	assert.NotContains(t, w.Body.String(), accessToken)
+}
+
+func TestAuthHttpHandler_LogoutSession(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1", Subject: "auth-1"}}
+
+	tests := []struct {
+		name           string
+		claims         *models.AccessClaims
+		body           string
+		setupMocks     func(*jwtMocks.MockJWTUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "missing claims",
+			setupMocks:     func(m *jwtMocks.MockJWTUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Unauthorized"}`,
+		},
+		{
+			name:   "access token only",
+			claims: claims,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().Logout(mock.Anything, claims, "").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"message":"logged out"}`,
+		},
+		{
+			name:   "with refresh token",
+			claims: claims,
+			body:   `{"refresh_token":"refresh"}`,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().Logout(mock.Anything, claims, "refresh").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"message":"logged out"}`,
+		},
+		{
+			name:           "malformed body",
+			claims:         claims,
+			body:           `{`,
+			setupMocks:     func(m *jwtMocks.MockJWTUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Invalid request body"}`,
+		},
+		{
+			name:   "storage failure",
+			claims: claims,
+			setupMocks: func(m *jwtMocks.MockJWTUsecase) {
+				m.EXPECT().Logout(mock.Anything, claims, "").Return(errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to log out"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+			tt.setupMocks(mockJWTUsecase)
+
+			handler := &authHttpHandler{jwtUsecase: mockJWTUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/auth/logout", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.claims != nil {
+				c.Set("claims", tt.claims)
+			}
+
+			handler.LogoutSession(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestAuthHttpHandler_RefreshToken_Cookie(t *testing.T) {
+	mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+	mockJWTUsecase.EXPECT().RefreshTokens(mock.Anything, "cookie-refresh").Return(&models.TokenPair{
+		AccessToken:  "new-access-token",
+		RefreshToken: "new-refresh-token",
+		ExpiresIn:    900,
+	}, nil).Once()
+
+	handler := &authHttpHandler{
+		auditLogger: newTestAuditLogger(t),
+		jwtUsecase:  mockJWTUsecase,
+		conf:        &config.Config{Auth: config.AuthConfig{CookieSecure: true}},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/token/refresh", handler.RefreshToken)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/v1/auth/token/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: models.RefreshTokenCookie, Value: "cookie-refresh"})
+	router.ServeHTTP(w, req)
+
+	// The new pair goes back into the cookies only, never into the body
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"token refreshed"}`, w.Body.String())
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	if assert.Contains(t, cookies, models.AccessTokenCookie) && assert.Contains(t, cookies, models.RefreshTokenCookie) {
+		assert.Equal(t, "new-access-token", cookies[models.AccessTokenCookie].Value)
+		assert.Equal(t, "/", cookies[models.AccessTokenCookie].Path)
+		assert.Equal(t, 900, cookies[models.AccessTokenCookie].MaxAge)
+		assert.Equal(t, "new-refresh-token", cookies[models.RefreshTokenCookie].Value)
+		assert.Equal(t, "/api/v1/auth", cookies[models.RefreshTokenCookie].Path)
+		for _, cookie := range cookies {
+			assert.True(t, cookie.HttpOnly)
+			assert.True(t, cookie.Secure)
+			assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		}
+	}
+}
+
+func TestAuthHttpHandler_LogoutSession_Cookie(t *testing.T) {
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1", Subject: "auth-1"}}
+
+	mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+	mockJWTUsecase.EXPECT().Logout(mock.Anything, claims, "cookie-refresh").Return(nil).Once()
+
+	handler := &authHttpHandler{jwtUsecase: mockJWTUsecase, conf: &config.Config{}, auditLogger: newTestAuditLogger(t)}
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/auth/logout", nil)
+	c.Request.AddCookie(&http.Cookie{Name: models.AccessTokenCookie, Value: "cookie-access"})
+	c.Request.AddCookie(&http.Cookie{Name: models.RefreshTokenCookie, Value: "cookie-refresh"})
+	c.Set("claims", claims)
+
+	handler.LogoutSession(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 2)
+	for _, cookie := range cookies {
+		assert.Empty(t, cookie.Value)
+		assert.Negative(t, cookie.MaxAge)
+	}
+}
+
+func TestAuthHttpHandler_LogoutAll(t *testing.T) {
+	mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+	mockJWTUsecase.EXPECT().RevokeAllTokens(mock.Anything, "auth-1").Return(nil).Once()
+
+	handler := &authHttpHandler{jwtUsecase: mockJWTUsecase, auditLogger: newTestAuditLogger(t)}
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/auth/logout/all", nil)
+	c.Set("claims", &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}})
+
+	handler.LogoutAll(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"logged out everywhere"}`, w.Body.String())
+}
+
+func TestAuthHttpHandler_RevokeUserTokens(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "revoked", expectedStatus: http.StatusOK, expectedBody: `{"message":"tokens revoked"}`},
+		{name: "unknown user", err: pgx.ErrNoRows, expectedStatus: http.StatusNotFound, expectedBody: `{"error":"User not found"}`},
+		{name: "storage failure", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to revoke tokens"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+			mockJWTUsecase.EXPECT().RevokeAllTokens(mock.Anything, "auth-1").Return(tt.err).Once()
+
+			handler := &authHttpHandler{jwtUsecase: mockJWTUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/admin/auth/users/auth-1/revoke-tokens", nil)
+			c.Params = gin.Params{{Key: "id", Value: "auth-1"}}
+
+			handler.RevokeUserTokens(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestAuthHttpHandler_GetUsers(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func(*jwtMocks.MockUserAdminUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:  "first page filtered",
+			query: "?limit=1&role=staff&active=true&provider=github&created_from=2026-01-01T00:00:00Z",
+			setupMocks: func(m *jwtMocks.MockUserAdminUsecase) {
+				hash := "$argon2id$hash"
+				m.EXPECT().ListUsers(mock.Anything, mock.MatchedBy(func(f models.UserFilter) bool {
+					return f.Role == "staff" && f.Active != nil && *f.Active && f.Provider == "github" && f.CreatedFrom.Year() == 2026
+				}), (*models.UserCursor)(nil), 2).Return([]*db.Auth{
+					{ID: "auth-3", Role: "staff", Active: true, Password: &hash},
+					{ID: "auth-2", Role: "staff", Active: true},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":"auth-3"`, `"has_password":true`, `"limit":1`, `"next":"`},
+		},
+		{
+			name: "page before a cursor",
+			query: "?cursor=" + response.EncodeCursor(response.Cursor{
+				CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				ID:        "auth-2",
+				Direction: response.CursorPrev,
+				List:      userList,
+				Filter:    response.CursorFilterHash(models.UserFilter{}),
+			}),
+			setupMocks: func(m *jwtMocks.MockUserAdminUsecase) {
+				m.EXPECT().ListUsers(mock.Anything, mock.Anything, &models.UserCursor{CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ID: "auth-2", Before: true}, 11).
+					Return([]*db.Auth{{ID: "auth-3", Role: "user"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":"auth-3"`, `"next":"`},
+		},
+		{
+			name: "cursor of another filter",
+			query: "?role=staff&cursor=" + response.EncodeCursor(response.Cursor{
+				CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				ID:        "auth-2",
+				Direction: response.CursorNext,
+				List:      userList,
+				Filter:    response.CursorFilterHash(models.UserFilter{}),
+			}),
+			setupMocks:     func(m *jwtMocks.MockUserAdminUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Invalid cursor"`},
+		},
+		{
+			name:           "tampered cursor",
+			query:          "?cursor=e30.c2lnbmF0dXJl",
+			setupMocks:     func(m *jwtMocks.MockUserAdminUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Invalid cursor"`},
+		},
+		{
+			name:           "invalid role",
+			query:          "?role=root",
+			setupMocks:     func(m *jwtMocks.MockUserAdminUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"type":"validation"`},
+		},
+		{
+			name:  "missing permission",
+			query: "",
+			setupMocks: func(m *jwtMocks.MockUserAdminUsecase) {
+				m.EXPECT().ListUsers(mock.Anything, mock.Anything, (*models.UserCursor)(nil), 11).
+					Return(nil, fmt.Errorf("%w: missing permission users:read", authz.ErrForbidden))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   []string{`"message":"Insufficient permissions"`},
+		},
+		{
+			name:           "invalid created_from",
+			query:          "?created_from=yesterday",
+			setupMocks:     func(m *jwtMocks.MockUserAdminUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Invalid query parameters"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserAdminUsecase := jwtMocks.NewMockUserAdminUsecase(t)
+			tt.setupMocks(mockUserAdminUsecase)
+
+			handler := &authHttpHandler{userAdminUsecase: mockUserAdminUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/admin/auth/users"+tt.query, nil)
+
+			handler.GetUsers(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+			assert.NotContains(t, w.Body.String(), "argon2id")
+		})
+	}
+}
+
+func TestAuthHttpHandler_GetAuditEvents(t *testing.T) {
+	actorID := "admin-1"
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func(*jwtMocks.MockAuditLogger)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:  "paginated and filtered",
+			query: "?page=2&limit=1&actor_id=admin-1&action=user.delete&outcome=success&created_to=2026-02-01T00:00:00Z",
+			setupMocks: func(m *jwtMocks.MockAuditLogger) {
+				m.EXPECT().ListEvents(mock.Anything, mock.MatchedBy(func(f models.AuditEventFilter) bool {
+					return f.ActorID == "admin-1" && f.Action == models.AuditActionUserDelete && f.Outcome == "success" && f.CreatedTo.Month() == 2
+				}), 1, 1).Return([]*db.AuditEvent{{
+					ID:       "event-1",
+					ActorID:  &actorID,
+					Action:   models.AuditActionUserDelete,
+					Outcome:  models.AuditOutcomeSuccess,
+					Metadata: []byte(`{"reason":"test"}`),
+				}}, 2, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":"event-1"`, `"actor_id":"admin-1"`, `"metadata":{"reason":"test"}`, `"page":2`, `"total":2`},
+		},
+		{
+			name:           "invalid outcome",
+			query:          "?outcome=maybe",
+			setupMocks:     func(m *jwtMocks.MockAuditLogger) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"type":"validation"`},
+		},
+		{
+			name:  "missing permission",
+			query: "",
+			setupMocks: func(m *jwtMocks.MockAuditLogger) {
+				m.EXPECT().ListEvents(mock.Anything, mock.Anything, 10, 0).
+					Return(nil, 0, fmt.Errorf("%w: missing permission audit:read", authz.ErrForbidden))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   []string{`"message":"Insufficient permissions"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuditLogger := jwtMocks.NewMockAuditLogger(t)
+			tt.setupMocks(mockAuditLogger)
+
+			handler := &authHttpHandler{auditLogger: mockAuditLogger}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/admin/auth/audit-events"+tt.query, nil)
+
+			handler.GetAuditEvents(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestAuthHttpHandler_SetUserRole(t *testing.T) {
+	admin := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "admin-1"}}
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		setupMocks     func(*jwtMocks.MockUserAdminUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "changed",
+			id:   "auth-1",
+			body: `{"role":"staff"}`,
+			setupMocks: func(m *jwtMocks.MockUserAdminUsecase) {
+				m.EXPECT().SetRole(mock.Anything, "auth-1", models.RoleStaff).Return(&db.Auth{ID: "auth-1", Role: "staff"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"role":"staff"`,
+		},
+		{
+			name:           "invalid role",
+			id:             "auth-1",
+			body:           `{"role":"root"}`,
+			setupMocks:     func(m *jwtMocks.MockUserAdminUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"type":"validation"`,
+		},
+		{
+			name: "unknown user",
+			id:   "missing",
+			body: `{"role":"staff"}`,
+			setupMocks: func(m *jwtMocks.MockUserAdminUsecase) {
+				m.EXPECT().SetRole(mock.Anything, "missing", models.RoleStaff).Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"message":"User not found"`,
+		},
+		{
+			name:           "own role",
+			id:             "admin-1",
+			body:           `{"role":"user"}`,
+			setupMocks:     func(m *jwtMocks.MockUserAdminUsecase) {},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"message":"Admins cannot change their own role"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserAdminUsecase := jwtMocks.NewMockUserAdminUsecase(t)
+			tt.setupMocks(mockUserAdminUsecase)
+
+			handler := &authHttpHandler{userAdminUsecase: mockUserAdminUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PUT", "/admin/auth/users/"+tt.id+"/role", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+			c.Set("claims", admin)
+
+			handler.SetUserRole(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthHttpHandler_DeleteAndRestoreUser(t *testing.T) {
+	mockUserAdminUsecase := jwtMocks.NewMockUserAdminUsecase(t)
+	mockUserAdminUsecase.EXPECT().DeleteUser(mock.Anything, "auth-1").Return(nil).Once()
+	mockUserAdminUsecase.EXPECT().RestoreUser(mock.Anything, "auth-1").Return(nil, pgx.ErrNoRows).Once()
+
+	handler := &authHttpHandler{userAdminUsecase: mockUserAdminUsecase, auditLogger: newTestAuditLogger(t)}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.DELETE("/admin/auth/users/:id", handler.DeleteUser)
+	router.POST("/admin/auth/users/:id/restore", handler.RestoreUser)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/admin/auth/users/auth-1", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Restoring a user that is not deleted finds nothing to restore
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/auth/users/auth-1/restore", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"User not found"`)
+}
+
+func TestAuthHttpHandler_Routes(t *testing.T) {
+	// Setup
+	mockJWTUsecase := jwtMocks.NewMockJWTUsecase(t)
+	conf := &config.Config{
+		Auth: config.AuthConfig{
+			LineClientID:      "test-client-id",
+			LineClientSecret:  "test-client-secret",
+			LineCallbackURL:   "http://localhost:8080/auth/line/callback",
+			LineFECallbackURL: "http://localhost:3000/callback",
+		},
+	}
+
+	mockAuthMiddleware := authMocks.NewMockAuthMiddleware(t)
+	// Set up expectation for authMiddleware.Handle() to be called
+	mockAuthMiddleware.On("Handle").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+	mockAuthMiddleware.On("HandleMFA").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+	mockAuthMiddleware.On("AcceptAPIKeys").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+	mockAuthMiddleware.On("Requires", mock.Anything).Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+
+	mockKeySet := jwtMocks.NewMockKeySet(t)
+	mockKeySet.EXPECT().Rotate(mock.Anything).Return(nil, usecases.ErrKeyRotationUnavailable).Maybe()
+
+	useLineProvider()
+
+	handler := &authHttpHandler{
+		auditLogger:    newTestAuditLogger(t),
+		jwtUsecase:     mockJWTUsecase,
+		loginThrottle:  newTestThrottle(),
+		keySet:         mockKeySet,
+		conf:           conf,
+		authMiddleware: mockAuthMiddleware,
+	}
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+
+	// Execute
+	handler.Routes(api)
+
+	// Test that routes are registered correctly
+	routes := router.Routes()
+
+	expectedRoutes := map[string]string{
+		"/api/v1/auth/:provider/login":    "GET",
+		"/api/v1/auth/:provider/callback": "GET",
+		"/api/v1/auth/:provider/logout":   "GET",
+		"/api/v1/auth/example":            "GET",
+		"/api/v1/auth/token":              "POST",
+		"/api/v1/auth/token/refresh":      "POST",
+		"/api/v1/auth/logout":             "POST",
+		"/api/v1/auth/logout/all":         "POST",
+		"/api/v1/auth/register":           "POST",
+		"/api/v1/auth/login":              "POST",
+		"/api/v1/auth/password":           "PUT",
+		"/api/v1/auth/providers":          "GET",
+		"/api/v1/auth/me":                 "GET",
+		"/api/v1/auth/:provider/link":     "POST",
+		"/api/v1/auth/mfa/enroll":         "POST",
+		"/api/v1/auth/mfa/confirm":        "POST",
+		"/api/v1/auth/mfa/verify":         "POST",
+		"/api/v1/auth/mfa/recovery-codes": "POST",
+		"/api/v1/auth/mfa":                "DELETE",
+	}
+
+	// Check that all expected routes are registered
+	routeMap := make(map[string]bool)
+	for _, route := range routes {
+		routeKey := route.Method + " " + route.Path
+		routeMap[routeKey] = true
+	}
+
+	for expectedRoute, expectedMethod := range expectedRoutes {
+		routeKey := expectedMethod + " " + expectedRoute
+		assert.True(t, routeMap[routeKey], "Route %s should be registered", routeKey)
+
+		// Test each route responds (basic smoke test)
+		w := httptest.NewRecorder()
+
+		// Modify the path for parameterized routes
+		testPath := strings.Replace(expectedRoute, ":provider", "line", 1)
+		req := httptest.NewRequest(expectedMethod, testPath, nil)
+
+		router.ServeHTTP(w, req)
+
+		// We don't expect 404 for registered routes
+		assert.NotEqual(t, http.StatusNotFound, w.Code, "Route %s should be accessible", testPath)
+	}
+}
+
+func TestAuthHttpHandler_Routes_RefuseAPIKeys(t *testing.T) {
+	mockAPIKey := jwtMocks.NewMockAPIKeyUsecase(t)
+	accountStatus := jwtMocks.NewMockAccountStatusStore(t)
+
+	authMiddleware := middlewares.NewAuthMiddleware(jwtMocks.NewMockJWTUsecase(t), mockAPIKey,
+		usecases.NewPermissionStore(&config.Config{}, nil), accountStatus, newTestThrottle())
+	handler := &authHttpHandler{
+		auditLogger:    newTestAuditLogger(t),
+		loginThrottle:  newTestThrottle(),
+		conf:           &config.Config{},
+		authMiddleware: authMiddleware,
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.Routes(router.Group("/api/v1"))
+
+	// A key scoped to cockroach:write must not act as its owner on the self-service routes
+	for _, route := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/auth/line/link"},
+		{http.MethodDelete, "/api/v1/auth/line/link"},
+		{http.MethodPost, "/api/v1/auth/logout/all"},
+		{http.MethodPut, "/api/v1/auth/password"},
+		{http.MethodGet, "/api/v1/auth/me"},
+		{http.MethodDelete, "/api/v1/auth/me"},
+		{http.MethodPost, "/api/v1/auth/mfa/enroll"},
+		{http.MethodDelete, "/api/v1/auth/mfa"},
+	} {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(route.method, route.path, strings.NewReader("{}"))
+			req.Header.Set(models.APIKeyHeader, "abc123.secret")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Contains(t, w.Body.String(), "API keys are not accepted on this route")
+		})
+	}
+
+	// The admin routes accept the key, which only gets the permissions it is scoped to
+	mockAPIKey.EXPECT().ValidateKey(mock.Anything, "abc123.secret").Return(&models.TokenValidationResult{
+		Valid: true,
+		Claims: &models.AccessClaims{
+			Role:             models.RoleAdmin,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "sensor-owner"},
+		},
+		UserID: "sensor-owner",
+		Scopes: []string{"cockroach:write"},
+	}, nil).Once()
+	accountStatus.EXPECT().IsActive(mock.Anything, "sensor-owner").Return(true, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/auth/users", nil)
+	req.Header.Set(models.APIKeyHeader, "abc123.secret")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Insufficient permissions")
+}
+
+func TestAuthHttpHandler_JWKS(t *testing.T) {
+	mockKeySet := jwtMocks.NewMockKeySet(t)
+	mockKeySet.EXPECT().JWKS().Return(&models.JWKS{Keys: []models.JWK{
+		{Kty: "EC", Crv: "P-256", X: "x", Y: "y", Kid: "kid-1", Use: "sig", Alg: "ES256"},
+	}}).Once()
+
+	handler := &authHttpHandler{keySet: mockKeySet, auditLogger: newTestAuditLogger(t)}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.WellKnownRoutes(&router.RouterGroup)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"keys":[{"kty":"EC","crv":"P-256","x":"x","y":"y","kid":"kid-1","use":"sig","alg":"ES256"}]}`, w.Body.String())
+}
+
+func TestAuthHttpHandler_RotateSigningKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*jwtMocks.MockKeySet)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful rotation",
+			setupMocks: func(m *jwtMocks.MockKeySet) {
+				m.EXPECT().Rotate(mock.Anything).Return(&models.SigningKey{Kid: "kid-2"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"kid":"kid-2"}`,
+		},
+		{
+			name: "rotation not available with key file only",
+			setupMocks: func(m *jwtMocks.MockKeySet) {
+				m.EXPECT().Rotate(mock.Anything).Return(nil, usecases.ErrKeyRotationUnavailable)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"Key rotation is not available"}`,
+		},
+		{
+			name: "rotation fails",
+			setupMocks: func(m *jwtMocks.MockKeySet) {
+				m.EXPECT().Rotate(mock.Anything).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to rotate signing key"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockKeySet := jwtMocks.NewMockKeySet(t)
+			tt.setupMocks(mockKeySet)
+
+			handler := &authHttpHandler{keySet: mockKeySet, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/admin/auth/keys/rotate", nil)
+
+			handler.RotateSigningKey(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestAuthHttpHandler_CreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*jwtMocks.MockAPIKeyUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name: "created",
+			body: `{"auth_id":"auth-1","name":"sensor","scopes":["cockroach:write"]}`,
+			setupMocks: func(m *jwtMocks.MockAPIKeyUsecase) {
+				m.EXPECT().CreateKey(mock.Anything, mock.MatchedBy(func(req models.CreateAPIKeyRequest) bool {
+					return req.AuthID == "auth-1" && req.Name == "sensor" && len(req.Scopes) == 1
+				})).Return(&db.APIKey{ID: "key-1", AuthID: "auth-1", Prefix: "abc123", KeyHash: "hash", Scopes: []string{"cockroach:write"}}, "abc123.secret", nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   []string{`"key":"abc123.secret"`, `"prefix":"abc123"`},
+		},
+		{
+			name:           "missing scopes",
+			body:           `{"auth_id":"auth-1","name":"sensor","scopes":[]}`,
+			setupMocks:     func(m *jwtMocks.MockAPIKeyUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"type":"validation"`},
+		},
+		{
+			name:           "expired",
+			body:           `{"auth_id":"auth-1","name":"sensor","scopes":["cockroach:write"],"expires_at":"2020-01-01T00:00:00Z"}`,
+			setupMocks:     func(m *jwtMocks.MockAPIKeyUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{"expires_at must be in the future"},
+		},
+		{
+			name: "unknown user",
+			body: `{"auth_id":"missing","name":"sensor","scopes":["cockroach:write"]}`,
+			setupMocks: func(m *jwtMocks.MockAPIKeyUsecase) {
+				m.EXPECT().CreateKey(mock.Anything, mock.Anything).Return(nil, "", pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   []string{`"message":"User not found"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyUsecase := jwtMocks.NewMockAPIKeyUsecase(t)
+			tt.setupMocks(mockAPIKeyUsecase)
+
+			handler := &authHttpHandler{apiKeyUsecase: mockAPIKeyUsecase, auditLogger: newTestAuditLogger(t)}
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/admin/auth/api-keys", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.CreateAPIKey(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+			assert.NotContains(t, w.Body.String(), `"hash"`)
+		})
+	}
+}
+
+func TestAuthHttpHandler_ListAndRevokeAPIKeys(t *testing.T) {
+	mockAPIKeyUsecase := jwtMocks.NewMockAPIKeyUsecase(t)
+	mockAPIKeyUsecase.EXPECT().ListKeys(mock.Anything, "auth-1").Return([]*db.APIKey{{ID: "key-1", AuthID: "auth-1", KeyHash: "hash"}}, nil).Once()
+	mockAPIKeyUsecase.EXPECT().RevokeKey(mock.Anything, "missing").Return(nil, fmt.Errorf("failed to revoke api key: %w", pgx.ErrNoRows)).Once()
+
+	handler := &authHttpHandler{apiKeyUsecase: mockAPIKeyUsecase, auditLogger: newTestAuditLogger(t)}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/auth/api-keys", handler.GetAPIKeys)
+	router.DELETE("/admin/auth/api-keys/:id", handler.RevokeAPIKey)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/auth/api-keys?auth_id=auth-1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"key-1"`)
+	assert.NotContains(t, w.Body.String(), `"hash"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/admin/auth/api-keys/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"API key not found"`)
 }
@@ -36,24 +36,1024 @@ func (_m *MockAuthHandler) EXPECT() *MockAuthHandler_Expecter {
 	return &MockAuthHandler_Expecter{mock: &_m.Mock}
 }
 
+// ActivateUser provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) ActivateUser(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_ActivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivateUser'
+type MockAuthHandler_ActivateUser_Call struct {
+	*mock.Call
+}
+
+// ActivateUser is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) ActivateUser(c interface{}) *MockAuthHandler_ActivateUser_Call {
+	return &MockAuthHandler_ActivateUser_Call{Call: _e.mock.On("ActivateUser", c)}
+}
+
+func (_c *MockAuthHandler_ActivateUser_Call) Run(run func(c *gin.Context)) *MockAuthHandler_ActivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_ActivateUser_Call) Return() *MockAuthHandler_ActivateUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_ActivateUser_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_ActivateUser_Call {
+	_c.Run(run)
+	return _c
+}
+
 // AuthCallback provides a mock function for the type MockAuthHandler
 func (_mock *MockAuthHandler) AuthCallback(c *gin.Context) {
 	_mock.Called(c)
 	return
 }
 
-// MockAuthHandler_AuthCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthCallback'
-type MockAuthHandler_AuthCallback_Call struct {
+// MockAuthHandler_AuthCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthCallback'
+type MockAuthHandler_AuthCallback_Call struct {
+	*mock.Call
+}
+
+// AuthCallback is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) AuthCallback(c interface{}) *MockAuthHandler_AuthCallback_Call {
+	return &MockAuthHandler_AuthCallback_Call{Call: _e.mock.On("AuthCallback", c)}
+}
+
+func (_c *MockAuthHandler_AuthCallback_Call) Run(run func(c *gin.Context)) *MockAuthHandler_AuthCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_AuthCallback_Call) Return() *MockAuthHandler_AuthCallback_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_AuthCallback_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_AuthCallback_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ChangePassword provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) ChangePassword(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockAuthHandler_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) ChangePassword(c interface{}) *MockAuthHandler_ChangePassword_Call {
+	return &MockAuthHandler_ChangePassword_Call{Call: _e.mock.On("ChangePassword", c)}
+}
+
+func (_c *MockAuthHandler_ChangePassword_Call) Run(run func(c *gin.Context)) *MockAuthHandler_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_ChangePassword_Call) Return() *MockAuthHandler_ChangePassword_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_ChangePassword_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_ChangePassword_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ConfirmMFA provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) ConfirmMFA(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_ConfirmMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmMFA'
+type MockAuthHandler_ConfirmMFA_Call struct {
+	*mock.Call
+}
+
+// ConfirmMFA is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) ConfirmMFA(c interface{}) *MockAuthHandler_ConfirmMFA_Call {
+	return &MockAuthHandler_ConfirmMFA_Call{Call: _e.mock.On("ConfirmMFA", c)}
+}
+
+func (_c *MockAuthHandler_ConfirmMFA_Call) Run(run func(c *gin.Context)) *MockAuthHandler_ConfirmMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_ConfirmMFA_Call) Return() *MockAuthHandler_ConfirmMFA_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_ConfirmMFA_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_ConfirmMFA_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CreateAPIKey provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) CreateAPIKey(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type MockAuthHandler_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) CreateAPIKey(c interface{}) *MockAuthHandler_CreateAPIKey_Call {
+	return &MockAuthHandler_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", c)}
+}
+
+func (_c *MockAuthHandler_CreateAPIKey_Call) Run(run func(c *gin.Context)) *MockAuthHandler_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_CreateAPIKey_Call) Return() *MockAuthHandler_CreateAPIKey_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_CreateAPIKey_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_CreateAPIKey_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeactivateUser provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) DeactivateUser(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_DeactivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateUser'
+type MockAuthHandler_DeactivateUser_Call struct {
+	*mock.Call
+}
+
+// DeactivateUser is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) DeactivateUser(c interface{}) *MockAuthHandler_DeactivateUser_Call {
+	return &MockAuthHandler_DeactivateUser_Call{Call: _e.mock.On("DeactivateUser", c)}
+}
+
+func (_c *MockAuthHandler_DeactivateUser_Call) Run(run func(c *gin.Context)) *MockAuthHandler_DeactivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_DeactivateUser_Call) Return() *MockAuthHandler_DeactivateUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_DeactivateUser_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_DeactivateUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteAccount provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) DeleteAccount(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type MockAuthHandler_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) DeleteAccount(c interface{}) *MockAuthHandler_DeleteAccount_Call {
+	return &MockAuthHandler_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", c)}
+}
+
+func (_c *MockAuthHandler_DeleteAccount_Call) Run(run func(c *gin.Context)) *MockAuthHandler_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_DeleteAccount_Call) Return() *MockAuthHandler_DeleteAccount_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_DeleteAccount_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_DeleteAccount_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) DeleteUser(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockAuthHandler_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) DeleteUser(c interface{}) *MockAuthHandler_DeleteUser_Call {
+	return &MockAuthHandler_DeleteUser_Call{Call: _e.mock.On("DeleteUser", c)}
+}
+
+func (_c *MockAuthHandler_DeleteUser_Call) Run(run func(c *gin.Context)) *MockAuthHandler_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_DeleteUser_Call) Return() *MockAuthHandler_DeleteUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_DeleteUser_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_DeleteUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DisableMFA provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) DisableMFA(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_DisableMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableMFA'
+type MockAuthHandler_DisableMFA_Call struct {
+	*mock.Call
+}
+
+// DisableMFA is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) DisableMFA(c interface{}) *MockAuthHandler_DisableMFA_Call {
+	return &MockAuthHandler_DisableMFA_Call{Call: _e.mock.On("DisableMFA", c)}
+}
+
+func (_c *MockAuthHandler_DisableMFA_Call) Run(run func(c *gin.Context)) *MockAuthHandler_DisableMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_DisableMFA_Call) Return() *MockAuthHandler_DisableMFA_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_DisableMFA_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_DisableMFA_Call {
+	_c.Run(run)
+	return _c
+}
+
+// EnrollMFA provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) EnrollMFA(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_EnrollMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollMFA'
+type MockAuthHandler_EnrollMFA_Call struct {
+	*mock.Call
+}
+
+// EnrollMFA is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) EnrollMFA(c interface{}) *MockAuthHandler_EnrollMFA_Call {
+	return &MockAuthHandler_EnrollMFA_Call{Call: _e.mock.On("EnrollMFA", c)}
+}
+
+func (_c *MockAuthHandler_EnrollMFA_Call) Run(run func(c *gin.Context)) *MockAuthHandler_EnrollMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_EnrollMFA_Call) Return() *MockAuthHandler_EnrollMFA_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_EnrollMFA_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_EnrollMFA_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Example provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) Example(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_Example_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Example'
+type MockAuthHandler_Example_Call struct {
+	*mock.Call
+}
+
+// Example is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) Example(c interface{}) *MockAuthHandler_Example_Call {
+	return &MockAuthHandler_Example_Call{Call: _e.mock.On("Example", c)}
+}
+
+func (_c *MockAuthHandler_Example_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Example_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_Example_Call) Return() *MockAuthHandler_Example_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_Example_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Example_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ExchangeCode provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) ExchangeCode(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_ExchangeCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExchangeCode'
+type MockAuthHandler_ExchangeCode_Call struct {
+	*mock.Call
+}
+
+// ExchangeCode is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) ExchangeCode(c interface{}) *MockAuthHandler_ExchangeCode_Call {
+	return &MockAuthHandler_ExchangeCode_Call{Call: _e.mock.On("ExchangeCode", c)}
+}
+
+func (_c *MockAuthHandler_ExchangeCode_Call) Run(run func(c *gin.Context)) *MockAuthHandler_ExchangeCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_ExchangeCode_Call) Return() *MockAuthHandler_ExchangeCode_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_ExchangeCode_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_ExchangeCode_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetAPIKeys provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) GetAPIKeys(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_GetAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKeys'
+type MockAuthHandler_GetAPIKeys_Call struct {
+	*mock.Call
+}
+
+// GetAPIKeys is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) GetAPIKeys(c interface{}) *MockAuthHandler_GetAPIKeys_Call {
+	return &MockAuthHandler_GetAPIKeys_Call{Call: _e.mock.On("GetAPIKeys", c)}
+}
+
+func (_c *MockAuthHandler_GetAPIKeys_Call) Run(run func(c *gin.Context)) *MockAuthHandler_GetAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_GetAPIKeys_Call) Return() *MockAuthHandler_GetAPIKeys_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_GetAPIKeys_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_GetAPIKeys_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetProfile provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) GetProfile(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type MockAuthHandler_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) GetProfile(c interface{}) *MockAuthHandler_GetProfile_Call {
+	return &MockAuthHandler_GetProfile_Call{Call: _e.mock.On("GetProfile", c)}
+}
+
+func (_c *MockAuthHandler_GetProfile_Call) Run(run func(c *gin.Context)) *MockAuthHandler_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_GetProfile_Call) Return() *MockAuthHandler_GetProfile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_GetProfile_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_GetProfile_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) GetUser(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockAuthHandler_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) GetUser(c interface{}) *MockAuthHandler_GetUser_Call {
+	return &MockAuthHandler_GetUser_Call{Call: _e.mock.On("GetUser", c)}
+}
+
+func (_c *MockAuthHandler_GetUser_Call) Run(run func(c *gin.Context)) *MockAuthHandler_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_GetUser_Call) Return() *MockAuthHandler_GetUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_GetUser_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_GetUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetUsers provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) GetUsers(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockAuthHandler_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) GetUsers(c interface{}) *MockAuthHandler_GetUsers_Call {
+	return &MockAuthHandler_GetUsers_Call{Call: _e.mock.On("GetUsers", c)}
+}
+
+func (_c *MockAuthHandler_GetUsers_Call) Run(run func(c *gin.Context)) *MockAuthHandler_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_GetUsers_Call) Return() *MockAuthHandler_GetUsers_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_GetUsers_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_GetUsers_Call {
+	_c.Run(run)
+	return _c
+}
+
+// JWKS provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) JWKS(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type MockAuthHandler_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) JWKS(c interface{}) *MockAuthHandler_JWKS_Call {
+	return &MockAuthHandler_JWKS_Call{Call: _e.mock.On("JWKS", c)}
+}
+
+func (_c *MockAuthHandler_JWKS_Call) Run(run func(c *gin.Context)) *MockAuthHandler_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_JWKS_Call) Return() *MockAuthHandler_JWKS_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_JWKS_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_JWKS_Call {
+	_c.Run(run)
+	return _c
+}
+
+// LinkProvider provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) LinkProvider(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_LinkProvider_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkProvider'
+type MockAuthHandler_LinkProvider_Call struct {
+	*mock.Call
+}
+
+// LinkProvider is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) LinkProvider(c interface{}) *MockAuthHandler_LinkProvider_Call {
+	return &MockAuthHandler_LinkProvider_Call{Call: _e.mock.On("LinkProvider", c)}
+}
+
+func (_c *MockAuthHandler_LinkProvider_Call) Run(run func(c *gin.Context)) *MockAuthHandler_LinkProvider_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_LinkProvider_Call) Return() *MockAuthHandler_LinkProvider_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_LinkProvider_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_LinkProvider_Call {
+	_c.Run(run)
+	return _c
+}
+
+// LinkedProviders provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) LinkedProviders(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_LinkedProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkedProviders'
+type MockAuthHandler_LinkedProviders_Call struct {
+	*mock.Call
+}
+
+// LinkedProviders is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) LinkedProviders(c interface{}) *MockAuthHandler_LinkedProviders_Call {
+	return &MockAuthHandler_LinkedProviders_Call{Call: _e.mock.On("LinkedProviders", c)}
+}
+
+func (_c *MockAuthHandler_LinkedProviders_Call) Run(run func(c *gin.Context)) *MockAuthHandler_LinkedProviders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_LinkedProviders_Call) Return() *MockAuthHandler_LinkedProviders_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_LinkedProviders_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_LinkedProviders_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Login provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) Login(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockAuthHandler_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) Login(c interface{}) *MockAuthHandler_Login_Call {
+	return &MockAuthHandler_Login_Call{Call: _e.mock.On("Login", c)}
+}
+
+func (_c *MockAuthHandler_Login_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_Login_Call) Return() *MockAuthHandler_Login_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_Login_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Login_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) Logout(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuthHandler_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) Logout(c interface{}) *MockAuthHandler_Logout_Call {
+	return &MockAuthHandler_Logout_Call{Call: _e.mock.On("Logout", c)}
+}
+
+func (_c *MockAuthHandler_Logout_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_Logout_Call) Return() *MockAuthHandler_Logout_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_Logout_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Logout_Call {
+	_c.Run(run)
+	return _c
+}
+
+// LogoutAll provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) LogoutAll(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type MockAuthHandler_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) LogoutAll(c interface{}) *MockAuthHandler_LogoutAll_Call {
+	return &MockAuthHandler_LogoutAll_Call{Call: _e.mock.On("LogoutAll", c)}
+}
+
+func (_c *MockAuthHandler_LogoutAll_Call) Run(run func(c *gin.Context)) *MockAuthHandler_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_LogoutAll_Call) Return() *MockAuthHandler_LogoutAll_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_LogoutAll_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_LogoutAll_Call {
+	_c.Run(run)
+	return _c
+}
+
+// LogoutSession provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) LogoutSession(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_LogoutSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutSession'
+type MockAuthHandler_LogoutSession_Call struct {
+	*mock.Call
+}
+
+// LogoutSession is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) LogoutSession(c interface{}) *MockAuthHandler_LogoutSession_Call {
+	return &MockAuthHandler_LogoutSession_Call{Call: _e.mock.On("LogoutSession", c)}
+}
+
+func (_c *MockAuthHandler_LogoutSession_Call) Run(run func(c *gin.Context)) *MockAuthHandler_LogoutSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_LogoutSession_Call) Return() *MockAuthHandler_LogoutSession_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_LogoutSession_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_LogoutSession_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PasswordLogin provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) PasswordLogin(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_PasswordLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PasswordLogin'
+type MockAuthHandler_PasswordLogin_Call struct {
+	*mock.Call
+}
+
+// PasswordLogin is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) PasswordLogin(c interface{}) *MockAuthHandler_PasswordLogin_Call {
+	return &MockAuthHandler_PasswordLogin_Call{Call: _e.mock.On("PasswordLogin", c)}
+}
+
+func (_c *MockAuthHandler_PasswordLogin_Call) Run(run func(c *gin.Context)) *MockAuthHandler_PasswordLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_PasswordLogin_Call) Return() *MockAuthHandler_PasswordLogin_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_PasswordLogin_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_PasswordLogin_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RefreshToken provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RefreshToken(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type MockAuthHandler_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) RefreshToken(c interface{}) *MockAuthHandler_RefreshToken_Call {
+	return &MockAuthHandler_RefreshToken_Call{Call: _e.mock.On("RefreshToken", c)}
+}
+
+func (_c *MockAuthHandler_RefreshToken_Call) Run(run func(c *gin.Context)) *MockAuthHandler_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_RefreshToken_Call) Return() *MockAuthHandler_RefreshToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_RefreshToken_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_RefreshToken_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RegenerateRecoveryCodes provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_RegenerateRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateRecoveryCodes'
+type MockAuthHandler_RegenerateRecoveryCodes_Call struct {
 	*mock.Call
 }
 
-// AuthCallback is a helper method to define mock.On call
+// RegenerateRecoveryCodes is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *MockAuthHandler_Expecter) AuthCallback(c interface{}) *MockAuthHandler_AuthCallback_Call {
-	return &MockAuthHandler_AuthCallback_Call{Call: _e.mock.On("AuthCallback", c)}
+func (_e *MockAuthHandler_Expecter) RegenerateRecoveryCodes(c interface{}) *MockAuthHandler_RegenerateRecoveryCodes_Call {
+	return &MockAuthHandler_RegenerateRecoveryCodes_Call{Call: _e.mock.On("RegenerateRecoveryCodes", c)}
 }
 
-func (_c *MockAuthHandler_AuthCallback_Call) Run(run func(c *gin.Context)) *MockAuthHandler_AuthCallback_Call {
+func (_c *MockAuthHandler_RegenerateRecoveryCodes_Call) Run(run func(c *gin.Context)) *MockAuthHandler_RegenerateRecoveryCodes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *gin.Context
 		if args[0] != nil {
@@ -66,34 +1066,34 @@ func (_c *MockAuthHandler_AuthCallback_Call) Run(run func(c *gin.Context)) *Mock
 	return _c
 }
 
-func (_c *MockAuthHandler_AuthCallback_Call) Return() *MockAuthHandler_AuthCallback_Call {
+func (_c *MockAuthHandler_RegenerateRecoveryCodes_Call) Return() *MockAuthHandler_RegenerateRecoveryCodes_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockAuthHandler_AuthCallback_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_AuthCallback_Call {
+func (_c *MockAuthHandler_RegenerateRecoveryCodes_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_RegenerateRecoveryCodes_Call {
 	_c.Run(run)
 	return _c
 }
 
-// Example provides a mock function for the type MockAuthHandler
-func (_mock *MockAuthHandler) Example(c *gin.Context) {
+// Register provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) Register(c *gin.Context) {
 	_mock.Called(c)
 	return
 }
 
-// MockAuthHandler_Example_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Example'
-type MockAuthHandler_Example_Call struct {
+// MockAuthHandler_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockAuthHandler_Register_Call struct {
 	*mock.Call
 }
 
-// Example is a helper method to define mock.On call
+// Register is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *MockAuthHandler_Expecter) Example(c interface{}) *MockAuthHandler_Example_Call {
-	return &MockAuthHandler_Example_Call{Call: _e.mock.On("Example", c)}
+func (_e *MockAuthHandler_Expecter) Register(c interface{}) *MockAuthHandler_Register_Call {
+	return &MockAuthHandler_Register_Call{Call: _e.mock.On("Register", c)}
 }
 
-func (_c *MockAuthHandler_Example_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Example_Call {
+func (_c *MockAuthHandler_Register_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Register_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *gin.Context
 		if args[0] != nil {
@@ -106,34 +1106,34 @@ func (_c *MockAuthHandler_Example_Call) Run(run func(c *gin.Context)) *MockAuthH
 	return _c
 }
 
-func (_c *MockAuthHandler_Example_Call) Return() *MockAuthHandler_Example_Call {
+func (_c *MockAuthHandler_Register_Call) Return() *MockAuthHandler_Register_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockAuthHandler_Example_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Example_Call {
+func (_c *MockAuthHandler_Register_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Register_Call {
 	_c.Run(run)
 	return _c
 }
 
-// Login provides a mock function for the type MockAuthHandler
-func (_mock *MockAuthHandler) Login(c *gin.Context) {
+// RestoreUser provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RestoreUser(c *gin.Context) {
 	_mock.Called(c)
 	return
 }
 
-// MockAuthHandler_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type MockAuthHandler_Login_Call struct {
+// MockAuthHandler_RestoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUser'
+type MockAuthHandler_RestoreUser_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// RestoreUser is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *MockAuthHandler_Expecter) Login(c interface{}) *MockAuthHandler_Login_Call {
-	return &MockAuthHandler_Login_Call{Call: _e.mock.On("Login", c)}
+func (_e *MockAuthHandler_Expecter) RestoreUser(c interface{}) *MockAuthHandler_RestoreUser_Call {
+	return &MockAuthHandler_RestoreUser_Call{Call: _e.mock.On("RestoreUser", c)}
 }
 
-func (_c *MockAuthHandler_Login_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Login_Call {
+func (_c *MockAuthHandler_RestoreUser_Call) Run(run func(c *gin.Context)) *MockAuthHandler_RestoreUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *gin.Context
 		if args[0] != nil {
@@ -146,34 +1146,34 @@ func (_c *MockAuthHandler_Login_Call) Run(run func(c *gin.Context)) *MockAuthHan
 	return _c
 }
 
-func (_c *MockAuthHandler_Login_Call) Return() *MockAuthHandler_Login_Call {
+func (_c *MockAuthHandler_RestoreUser_Call) Return() *MockAuthHandler_RestoreUser_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockAuthHandler_Login_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Login_Call {
+func (_c *MockAuthHandler_RestoreUser_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_RestoreUser_Call {
 	_c.Run(run)
 	return _c
 }
 
-// Logout provides a mock function for the type MockAuthHandler
-func (_mock *MockAuthHandler) Logout(c *gin.Context) {
+// RevokeAPIKey provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RevokeAPIKey(c *gin.Context) {
 	_mock.Called(c)
 	return
 }
 
-// MockAuthHandler_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
-type MockAuthHandler_Logout_Call struct {
+// MockAuthHandler_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type MockAuthHandler_RevokeAPIKey_Call struct {
 	*mock.Call
 }
 
-// Logout is a helper method to define mock.On call
+// RevokeAPIKey is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *MockAuthHandler_Expecter) Logout(c interface{}) *MockAuthHandler_Logout_Call {
-	return &MockAuthHandler_Logout_Call{Call: _e.mock.On("Logout", c)}
+func (_e *MockAuthHandler_Expecter) RevokeAPIKey(c interface{}) *MockAuthHandler_RevokeAPIKey_Call {
+	return &MockAuthHandler_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", c)}
 }
 
-func (_c *MockAuthHandler_Logout_Call) Run(run func(c *gin.Context)) *MockAuthHandler_Logout_Call {
+func (_c *MockAuthHandler_RevokeAPIKey_Call) Run(run func(c *gin.Context)) *MockAuthHandler_RevokeAPIKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *gin.Context
 		if args[0] != nil {
@@ -186,12 +1186,92 @@ func (_c *MockAuthHandler_Logout_Call) Run(run func(c *gin.Context)) *MockAuthHa
 	return _c
 }
 
-func (_c *MockAuthHandler_Logout_Call) Return() *MockAuthHandler_Logout_Call {
+func (_c *MockAuthHandler_RevokeAPIKey_Call) Return() *MockAuthHandler_RevokeAPIKey_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockAuthHandler_Logout_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_Logout_Call {
+func (_c *MockAuthHandler_RevokeAPIKey_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_RevokeAPIKey_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RevokeUserTokens provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RevokeUserTokens(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_RevokeUserTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserTokens'
+type MockAuthHandler_RevokeUserTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeUserTokens is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) RevokeUserTokens(c interface{}) *MockAuthHandler_RevokeUserTokens_Call {
+	return &MockAuthHandler_RevokeUserTokens_Call{Call: _e.mock.On("RevokeUserTokens", c)}
+}
+
+func (_c *MockAuthHandler_RevokeUserTokens_Call) Run(run func(c *gin.Context)) *MockAuthHandler_RevokeUserTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_RevokeUserTokens_Call) Return() *MockAuthHandler_RevokeUserTokens_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_RevokeUserTokens_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_RevokeUserTokens_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RotateSigningKey provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RotateSigningKey(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_RotateSigningKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSigningKey'
+type MockAuthHandler_RotateSigningKey_Call struct {
+	*mock.Call
+}
+
+// RotateSigningKey is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) RotateSigningKey(c interface{}) *MockAuthHandler_RotateSigningKey_Call {
+	return &MockAuthHandler_RotateSigningKey_Call{Call: _e.mock.On("RotateSigningKey", c)}
+}
+
+func (_c *MockAuthHandler_RotateSigningKey_Call) Run(run func(c *gin.Context)) *MockAuthHandler_RotateSigningKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_RotateSigningKey_Call) Return() *MockAuthHandler_RotateSigningKey_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_RotateSigningKey_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_RotateSigningKey_Call {
 	_c.Run(run)
 	return _c
 }
@@ -235,3 +1315,203 @@ func (_c *MockAuthHandler_Routes_Call) RunAndReturn(run func(routerGroup *gin.Ro
 	_c.Run(run)
 	return _c
 }
+
+// SetUserRole provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) SetUserRole(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_SetUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRole'
+type MockAuthHandler_SetUserRole_Call struct {
+	*mock.Call
+}
+
+// SetUserRole is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) SetUserRole(c interface{}) *MockAuthHandler_SetUserRole_Call {
+	return &MockAuthHandler_SetUserRole_Call{Call: _e.mock.On("SetUserRole", c)}
+}
+
+func (_c *MockAuthHandler_SetUserRole_Call) Run(run func(c *gin.Context)) *MockAuthHandler_SetUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_SetUserRole_Call) Return() *MockAuthHandler_SetUserRole_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_SetUserRole_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_SetUserRole_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UnlinkProvider provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) UnlinkProvider(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_UnlinkProvider_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlinkProvider'
+type MockAuthHandler_UnlinkProvider_Call struct {
+	*mock.Call
+}
+
+// UnlinkProvider is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) UnlinkProvider(c interface{}) *MockAuthHandler_UnlinkProvider_Call {
+	return &MockAuthHandler_UnlinkProvider_Call{Call: _e.mock.On("UnlinkProvider", c)}
+}
+
+func (_c *MockAuthHandler_UnlinkProvider_Call) Run(run func(c *gin.Context)) *MockAuthHandler_UnlinkProvider_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_UnlinkProvider_Call) Return() *MockAuthHandler_UnlinkProvider_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_UnlinkProvider_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_UnlinkProvider_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) UpdateProfile(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockAuthHandler_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) UpdateProfile(c interface{}) *MockAuthHandler_UpdateProfile_Call {
+	return &MockAuthHandler_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", c)}
+}
+
+func (_c *MockAuthHandler_UpdateProfile_Call) Run(run func(c *gin.Context)) *MockAuthHandler_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_UpdateProfile_Call) Return() *MockAuthHandler_UpdateProfile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_UpdateProfile_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_UpdateProfile_Call {
+	_c.Run(run)
+	return _c
+}
+
+// VerifyMFA provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) VerifyMFA(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuthHandler_VerifyMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMFA'
+type MockAuthHandler_VerifyMFA_Call struct {
+	*mock.Call
+}
+
+// VerifyMFA is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuthHandler_Expecter) VerifyMFA(c interface{}) *MockAuthHandler_VerifyMFA_Call {
+	return &MockAuthHandler_VerifyMFA_Call{Call: _e.mock.On("VerifyMFA", c)}
+}
+
+func (_c *MockAuthHandler_VerifyMFA_Call) Run(run func(c *gin.Context)) *MockAuthHandler_VerifyMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_VerifyMFA_Call) Return() *MockAuthHandler_VerifyMFA_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_VerifyMFA_Call) RunAndReturn(run func(c *gin.Context)) *MockAuthHandler_VerifyMFA_Call {
+	_c.Run(run)
+	return _c
+}
+
+// WellKnownRoutes provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) WellKnownRoutes(routerGroup *gin.RouterGroup) {
+	_mock.Called(routerGroup)
+	return
+}
+
+// MockAuthHandler_WellKnownRoutes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WellKnownRoutes'
+type MockAuthHandler_WellKnownRoutes_Call struct {
+	*mock.Call
+}
+
+// WellKnownRoutes is a helper method to define mock.On call
+//   - routerGroup *gin.RouterGroup
+func (_e *MockAuthHandler_Expecter) WellKnownRoutes(routerGroup interface{}) *MockAuthHandler_WellKnownRoutes_Call {
+	return &MockAuthHandler_WellKnownRoutes_Call{Call: _e.mock.On("WellKnownRoutes", routerGroup)}
+}
+
+func (_c *MockAuthHandler_WellKnownRoutes_Call) Run(run func(routerGroup *gin.RouterGroup)) *MockAuthHandler_WellKnownRoutes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.RouterGroup
+		if args[0] != nil {
+			arg0 = args[0].(*gin.RouterGroup)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_WellKnownRoutes_Call) Return() *MockAuthHandler_WellKnownRoutes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthHandler_WellKnownRoutes_Call) RunAndReturn(run func(routerGroup *gin.RouterGroup)) *MockAuthHandler_WellKnownRoutes_Call {
+	_c.Run(run)
+	return _c
+}
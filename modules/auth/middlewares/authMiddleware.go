@@ -7,6 +7,16 @@ import (
 )
 
 type AuthMiddleware interface {
+	// Handle authenticates the request with an API key or access token. mfa_pending tokens are
+	// rejected, and so are API keys on routes that did not opt in with AcceptAPIKeys.
 	Handle() gin.HandlerFunc
+	// AcceptAPIKeys opts the routes it guards into API keys. It goes before Handle, on routes
+	// whose handlers only do what Requires allows.
+	AcceptAPIKeys() gin.HandlerFunc
+	// HandleMFA is Handle for the MFA routes, which also accept mfa_pending tokens
+	HandleMFA() gin.HandlerFunc
 	Allows(roles []models.Role) gin.HandlerFunc
+	// Requires lets requests through whose role grants every one of permissions. It runs after
+	// Handle, which puts the permissions of the role into the request context.
+	Requires(permissions ...string) gin.HandlerFunc
 }
@@ -1,30 +1,69 @@
 package middlewares
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"template-golang/modules/auth/models"
 	"template-golang/modules/auth/usecases"
+	"template-golang/pkg/authz"
 	"template-golang/pkg/logger"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type userAuthMiddleware struct {
-	jwtUsecase usecases.JWTUsecase
+	jwtUsecase      usecases.JWTUsecase
+	apiKeyUsecase   usecases.APIKeyUsecase
+	permissionStore usecases.PermissionStore
+	accountStatus   usecases.AccountStatusStore
+	loginThrottle   usecases.LoginThrottle
 }
 
-func NewAuthMiddleware(jwtUsecase usecases.JWTUsecase) AuthMiddleware {
+func NewAuthMiddleware(jwtUsecase usecases.JWTUsecase, apiKeyUsecase usecases.APIKeyUsecase, permissionStore usecases.PermissionStore,
+	accountStatus usecases.AccountStatusStore, loginThrottle usecases.LoginThrottle) AuthMiddleware {
 	return &userAuthMiddleware{
-		jwtUsecase: jwtUsecase,
+		jwtUsecase:      jwtUsecase,
+		apiKeyUsecase:   apiKeyUsecase,
+		permissionStore: permissionStore,
+		accountStatus:   accountStatus,
+		loginThrottle:   loginThrottle,
+	}
+}
+
+// apiKeysAcceptedKey flags in the gin context the requests of routes opted into API keys with
+// AcceptAPIKeys
+const apiKeysAcceptedKey = "api_keys_accepted"
+
+func (m *userAuthMiddleware) AcceptAPIKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiKeysAcceptedKey, true)
+		c.Next()
 	}
 }
 
 func (m *userAuthMiddleware) Handle() gin.HandlerFunc {
+	return m.authenticate(false)
+}
+
+func (m *userAuthMiddleware) HandleMFA() gin.HandlerFunc {
+	return m.authenticate(true)
+}
+
+// authenticate validates the API key or access token of the request. mfa_pending tokens only
+// pass when allowMFAPending is set.
+func (m *userAuthMiddleware) authenticate(allowMFAPending bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Extract token from Authorization header
+		// Extract the credential: an API key from the X-API-Key header or "Authorization: ApiKey",
+		// otherwise an access token from "Authorization: Bearer" or from the access token cookie
+		// set by the cookie delivery of the OAuth callback
+		apiKey := c.GetHeader(models.APIKeyHeader)
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		cookieToken, _ := c.Cookie(models.AccessTokenCookie)
+		if apiKey == "" && authHeader == "" && cookieToken == "" {
 			logger.Warn("Missing Authorization header")
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
@@ -34,22 +73,59 @@ func (m *userAuthMiddleware) Handle() gin.HandlerFunc {
 			return
 		}
 
-		// Check for Bearer token format
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" || strings.TrimSpace(tokenParts[1]) == "" {
-			logger.Warn("Invalid Authorization header format")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid authorization header format",
+		tokenString := cookieToken
+		if apiKey == "" && authHeader != "" {
+			// Check for Bearer token or ApiKey format
+			tokenParts := strings.Split(authHeader, " ")
+			if len(tokenParts) != 2 || (tokenParts[0] != "Bearer" && tokenParts[0] != "ApiKey") || strings.TrimSpace(tokenParts[1]) == "" {
+				logger.Warn("Invalid Authorization header format")
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "Unauthorized",
+					"message": "Invalid authorization header format",
+				})
+				c.Abort()
+				return
+			}
+
+			if tokenParts[0] == "ApiKey" {
+				apiKey = tokenParts[1]
+			} else {
+				tokenString = tokenParts[1]
+			}
+		}
+
+		// The scopes of an API key only limit the permissions Requires checks, so on routes that
+		// did not opt in, such as changing the password or exporting personal data, the key
+		// would act as its owner
+		if apiKey != "" && !c.GetBool(apiKeysAcceptedKey) {
+			logger.Warnf("API key used on %s, which does not accept API keys", c.FullPath())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": "API keys are not accepted on this route",
 			})
 			c.Abort()
 			return
 		}
 
-		tokenString := tokenParts[1]
+		// Clients guessing tokens or API keys are blocked by IP. Every request passes here, so
+		// the block is looked up in memory rather than in the store.
+		tokenKey := usecases.ThrottleTokenIPKey(c.ClientIP())
+		if retryAfter := m.loginThrottle.CheckCached(tokenKey); retryAfter > 0 {
+			AbortThrottled(c, retryAfter)
+			return
+		}
 
-		// Verify the token
-		result, err := m.jwtUsecase.ValidateJWT(tokenString)
+		// Verify the API key or token
+		var result *models.TokenValidationResult
+		var err error
+		switch {
+		case apiKey != "":
+			result, err = m.apiKeyUsecase.ValidateKey(c.Request.Context(), apiKey)
+		case allowMFAPending:
+			result, err = m.jwtUsecase.ValidateMFAJWT(c.Request.Context(), tokenString)
+		default:
+			result, err = m.jwtUsecase.ValidateJWT(c.Request.Context(), tokenString)
+		}
 		if err != nil {
 			logger.Errorf("Token verification error: %v", err)
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -81,6 +157,24 @@ func (m *userAuthMiddleware) Handle() gin.HandlerFunc {
 			return
 		}
 
+		// Expired and revoked tokens are a normal part of a session, such as a tab left open
+		// after logging out; only invalid ones count as failed attempts
+		if !result.Revoked && !result.Valid {
+			if _, err := m.loginThrottle.Fail(c.Request.Context(), tokenKey); err != nil {
+				logger.Errorf("Failed to record login failure: %v", err)
+			}
+		}
+
+		if result.Revoked {
+			logger.Warn("Token has been revoked")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
 		if !result.Valid {
 			logger.Warn("Invalid token")
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -91,11 +185,59 @@ func (m *userAuthMiddleware) Handle() gin.HandlerFunc {
 			return
 		}
 
-		// TODO: check this
+		if result.Claims.MFAPending && !allowMFAPending {
+			logger.Warn("Token is waiting for MFA")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "MFA verification required",
+			})
+			c.Abort()
+			return
+		}
+
+		// Tokens outlive the deactivation or deletion of their account by the status cache TTL at most
+		active, err := m.accountStatus.IsActive(c.Request.Context(), result.UserID)
+		if err != nil {
+			logger.Errorf("Account status check error: %v", err)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Token verification failed",
+			})
+			c.Abort()
+			return
+		}
+		if !active {
+			logger.Warnf("Account %s is disabled", result.UserID)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Account is disabled",
+			})
+			c.Abort()
+			return
+		}
 
 		// Token is valid, set user context
 		c.Set("userID", result.UserID)
 		c.Set("claims", result.Claims)
+		c.Set("user_role", result.Claims.Role.ToString())
+
+		// Use cases check permissions with authz.Require on the request context
+		// API keys only get the permissions of their owner that they are scoped to, and logins
+		// waiting for MFA get none
+		permissions := m.permissionStore.Permissions(result.Claims.Role)
+		if result.Scopes != nil {
+			permissions = authz.Restrict(permissions, result.Scopes)
+		}
+		if result.Claims.MFAPending {
+			permissions = []string{}
+		}
+		c.Set("permissions", permissions)
+		c.Request = c.Request.WithContext(authz.WithPrincipal(c.Request.Context(), authz.Principal{
+			UserID:      result.UserID,
+			Role:        result.Claims.Role.ToString(),
+			Permissions: permissions,
+			APIKey:      apiKey != "",
+		}))
 
 		logger.Infof("Successfully authenticated user: %s", result.UserID)
 
@@ -103,6 +245,18 @@ func (m *userAuthMiddleware) Handle() gin.HandlerFunc {
 	}
 }
 
+// AbortThrottled responds 429 to a client blocked by the login throttle, telling it in
+// Retry-After how many seconds to wait
+func AbortThrottled(c *gin.Context, retryAfter time.Duration) {
+	logger.Warnf("Throttled %s for %s", c.ClientIP(), retryAfter)
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":   "Too Many Requests",
+		"message": "Too many failed attempts, try again later",
+	})
+	c.Abort()
+}
+
 func (m *userAuthMiddleware) Allows(roles []models.Role) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user claims from context (set by Handle middleware)
@@ -117,8 +271,8 @@ func (m *userAuthMiddleware) Allows(roles []models.Role) gin.HandlerFunc {
 			return
 		}
 
-		userClaims, ok := claims.(map[string]interface{})
-		if !ok {
+		userClaims, ok := claims.(*models.AccessClaims)
+		if !ok || userClaims == nil {
 			logger.Warn("Invalid claims format")
 			c.JSON(http.StatusForbidden, gin.H{
 				"error":   "Forbidden",
@@ -129,8 +283,8 @@ func (m *userAuthMiddleware) Allows(roles []models.Role) gin.HandlerFunc {
 		}
 
 		// Extract user role from claims
-		userRole, exists := userClaims["role"]
-		if !exists {
+		userRoleStr := userClaims.Role.ToString()
+		if userRoleStr == "" {
 			logger.Warn("No role found in user claims")
 			c.JSON(http.StatusForbidden, gin.H{
 				"error":   "Forbidden",
@@ -140,17 +294,6 @@ func (m *userAuthMiddleware) Allows(roles []models.Role) gin.HandlerFunc {
 			return
 		}
 
-		userRoleStr, ok := userRole.(string)
-		if !ok {
-			logger.Warn("Invalid role format in claims")
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "Invalid role format",
-			})
-			c.Abort()
-			return
-		}
-
 		// Check if user role is in allowed roles
 		for _, allowedRole := range roles {
 			if userRoleStr == allowedRole.ToString() {
@@ -168,3 +311,30 @@ func (m *userAuthMiddleware) Allows(roles []models.Role) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+func (m *userAuthMiddleware) Requires(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := authz.Require(c.Request.Context(), permissions...)
+		if err == nil {
+			c.Next()
+			return
+		}
+
+		if errors.Is(err, authz.ErrUnauthenticated) {
+			logger.Warn("No principal found in context")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "No user claims found",
+			})
+			c.Abort()
+			return
+		}
+
+		logger.Warnf("User %s is not authorized for this resource: %v", c.GetString("userID"), err)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": "Insufficient permissions",
+		})
+		c.Abort()
+	}
+}
@@ -1,51 +1,77 @@
 package middlewares
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"template-golang/config"
 	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
 	"template-golang/modules/auth/usecases"
 	"template-golang/modules/auth/usecases/mocks"
+	"template-golang/pkg/authz"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func setupTestMiddleware(jwtUsecase usecases.JWTUsecase) (*gin.Engine, gin.HandlerFunc) {
+// newTestThrottle returns a login throttle with the default policy, counting in memory
+func newTestThrottle() usecases.LoginThrottle {
+	return usecases.NewLoginThrottle(&config.Config{}, repositories.NewMemoryLoginAttemptRepository())
+}
+
+// activeAccounts is an account status store where every account is active except the disabled ones
+type activeAccounts map[string]bool
+
+func (a activeAccounts) IsActive(_ context.Context, authID string) (bool, error) {
+	return !a[authID], nil
+}
+
+func (a activeAccounts) Run(context.Context) {}
+
+func setupTestMiddleware(jwtUsecase usecases.JWTUsecase, apiKeyUsecase usecases.APIKeyUsecase) (*gin.Engine, gin.HandlerFunc) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	middleware := NewAuthMiddleware(jwtUsecase)
+	middleware := NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
 	authMiddleware := middleware.Handle()
 
-	// Create a test route that uses the middleware
-	router.GET("/protected", authMiddleware, func(c *gin.Context) {
+	// Create a test route that uses the middleware, and one that also accepts API keys
+	handler := func(c *gin.Context) {
 		userID := c.GetString("userID")
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Success",
 			"userID":  userID,
 		})
-	})
+	}
+	router.GET("/protected", authMiddleware, handler)
+	router.GET("/keys", middleware.AcceptAPIKeys(), authMiddleware, handler)
 
 	return router, authMiddleware
 }
 
 func TestAuthMiddleware_ValidToken(t *testing.T) {
 	mockJWT := mocks.NewMockJWTUsecase(t)
-	router, _ := setupTestMiddleware(mockJWT)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
 
 	// Mock successful token validation
 	mockResult := &models.TokenValidationResult{
 		Valid:    true,
 		Expired:  false,
 		NotExist: false,
-		Claims:   jwt.MapClaims{"sub": "test-user-123"},
-		UserID:   "test-user-123",
+		Claims: &models.AccessClaims{
+			Role:             models.RoleUser,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "test-user-123"},
+		},
+		UserID: "test-user-123",
 	}
-	mockJWT.On("ValidateJWT", "valid-token").Return(mockResult, nil)
+	mockJWT.On("ValidateJWT", mock.Anything, "valid-token").Return(mockResult, nil)
 
 	// Create request with valid Bearer token
 	w := httptest.NewRecorder()
@@ -62,9 +88,29 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 	assert.Equal(t, "test-user-123", response["userID"])
 }
 
+func TestAuthMiddleware_AccessTokenCookie(t *testing.T) {
+	mockJWT := mocks.NewMockJWTUsecase(t)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
+
+	mockJWT.On("ValidateJWT", mock.Anything, "cookie-token").Return(&models.TokenValidationResult{
+		Valid:  true,
+		Claims: &models.AccessClaims{Role: models.RoleUser},
+		UserID: "test-user-123",
+	}, nil)
+
+	// Create request with the token in the cookie only
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.AddCookie(&http.Cookie{Name: models.AccessTokenCookie, Value: "cookie-token"})
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestAuthMiddleware_MissingAuthorizationHeader(t *testing.T) {
 	mockJWT := mocks.NewMockJWTUsecase(t)
-	router, _ := setupTestMiddleware(mockJWT)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
 
 	// Create request without Authorization header
 	w := httptest.NewRecorder()
@@ -82,7 +128,7 @@ func TestAuthMiddleware_MissingAuthorizationHeader(t *testing.T) {
 
 func TestAuthMiddleware_InvalidAuthorizationFormat(t *testing.T) {
 	mockJWT := mocks.NewMockJWTUsecase(t)
-	router, _ := setupTestMiddleware(mockJWT)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
 
 	tests := []struct {
 		name        string
@@ -126,7 +172,7 @@ func TestAuthMiddleware_InvalidAuthorizationFormat(t *testing.T) {
 
 func TestAuthMiddleware_ExpiredToken(t *testing.T) {
 	mockJWT := mocks.NewMockJWTUsecase(t)
-	router, _ := setupTestMiddleware(mockJWT)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
 
 	// Mock expired token validation
 	mockResult := &models.TokenValidationResult{
@@ -136,7 +182,7 @@ func TestAuthMiddleware_ExpiredToken(t *testing.T) {
 		Claims:   nil,
 		UserID:   "",
 	}
-	mockJWT.On("ValidateJWT", "expired-token").Return(mockResult, nil)
+	mockJWT.On("ValidateJWT", mock.Anything, "expired-token").Return(mockResult, nil)
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
@@ -154,7 +200,7 @@ func TestAuthMiddleware_ExpiredToken(t *testing.T) {
 
 func TestAuthMiddleware_InvalidToken(t *testing.T) {
 	mockJWT := mocks.NewMockJWTUsecase(t)
-	router, _ := setupTestMiddleware(mockJWT)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
 
 	// Mock invalid token validation
 	mockResult := &models.TokenValidationResult{
@@ -164,7 +210,7 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 		Claims:   nil,
 		UserID:   "",
 	}
-	mockJWT.On("ValidateJWT", "invalid-token").Return(mockResult, nil)
+	mockJWT.On("ValidateJWT", mock.Anything, "invalid-token").Return(mockResult, nil)
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
@@ -179,3 +225,418 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 	assert.Equal(t, "Unauthorized", response["error"])
 	assert.Equal(t, "Invalid token", response["message"])
 }
+
+func TestAuthMiddleware_RevokedToken(t *testing.T) {
+	mockJWT := mocks.NewMockJWTUsecase(t)
+	router, _ := setupTestMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t))
+
+	mockJWT.On("ValidateJWT", mock.Anything, "revoked-token").Return(&models.TokenValidationResult{Revoked: true}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer revoked-token")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "Unauthorized", response["error"])
+	assert.Equal(t, "Token has been revoked", response["message"])
+}
+
+func TestAuthMiddleware_MFAPendingToken(t *testing.T) {
+	mockJWT := mocks.NewMockJWTUsecase(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	middleware := NewAuthMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t), usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
+	router.GET("/protected", middleware.Handle(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/mfa/verify", middleware.HandleMFA(), func(c *gin.Context) {
+		principal, _ := authz.FromContext(c.Request.Context())
+		assert.Empty(t, principal.Permissions)
+		c.Status(http.StatusOK)
+	})
+
+	// Handle validates mfa_pending tokens with ValidateJWT, which refuses their audience
+	mockJWT.On("ValidateJWT", mock.Anything, "pending-token").Return(&models.TokenValidationResult{}, jwt.ErrTokenInvalidAudience)
+	mockJWT.On("ValidateMFAJWT", mock.Anything, "pending-token").Return(&models.TokenValidationResult{
+		Valid:  true,
+		Claims: &models.AccessClaims{MFAPending: true},
+		UserID: "test-user-123",
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer pending-token")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "Token verification failed", response["message"])
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/mfa/verify", nil)
+	req.Header.Set("Authorization", "Bearer pending-token")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthMiddleware_DisabledAccount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockJWT := mocks.NewMockJWTUsecase(t)
+	middleware := NewAuthMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t), usecases.NewPermissionStore(&config.Config{}, nil),
+		activeAccounts{"disabled-user": true}, newTestThrottle())
+	router := gin.New()
+	router.GET("/protected", middleware.Handle(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	mockJWT.On("ValidateJWT", mock.Anything, "valid-token").Return(&models.TokenValidationResult{
+		Valid: true,
+		Claims: &models.AccessClaims{
+			Role:             models.RoleUser,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "disabled-user"},
+		},
+		UserID: "disabled-user",
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":"Unauthorized","message":"Account is disabled"}`, w.Body.String())
+}
+
+func TestAuthMiddleware_ThrottlesInvalidTokens(t *testing.T) {
+	mockJWT := mocks.NewMockJWTUsecase(t)
+	throttle := newTestThrottle()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	middleware := NewAuthMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t), usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, throttle)
+	router.GET("/protected", middleware.Handle(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Success"})
+	})
+
+	mockJWT.On("ValidateJWT", mock.Anything, "guessed-token").Return(&models.TokenValidationResult{}, nil)
+	mockJWT.On("ValidateJWT", mock.Anything, "expired-token").Return(&models.TokenValidationResult{Expired: true}, nil)
+	mockJWT.On("ValidateJWT", mock.Anything, "revoked-token").Return(&models.TokenValidationResult{Revoked: true}, nil)
+
+	serve := func(token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Expired and revoked tokens do not count
+	for range 10 {
+		assert.Equal(t, http.StatusUnauthorized, serve("expired-token").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve("revoked-token").Code)
+	}
+
+	// The free attempts, then the first failure past them blocks the IP
+	for range 6 {
+		assert.Equal(t, http.StatusUnauthorized, serve("guessed-token").Code)
+	}
+
+	w := serve("guessed-token")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	mockJWT.AssertNumberOfCalls(t, "ValidateJWT", 26)
+
+	// The logins of the IP are counted apart
+	retryAfter, err := throttle.Check(context.Background(), usecases.ThrottleIPKey("192.0.2.1"))
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
+
+func TestAuthMiddleware_ValidTokenSkipsThrottleStore(t *testing.T) {
+	mockJWT := mocks.NewMockJWTUsecase(t)
+	// The login attempt store expects no call
+	throttle := usecases.NewLoginThrottle(&config.Config{}, repoMocks.NewMockLoginAttemptRepository(t))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	middleware := NewAuthMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t), usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, throttle)
+	router.GET("/protected", middleware.Handle(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Success"})
+	})
+
+	mockJWT.On("ValidateJWT", mock.Anything, "valid-token").Return(&models.TokenValidationResult{
+		Valid:  true,
+		Claims: &models.AccessClaims{Role: models.RoleUser},
+		UserID: "test-user-123",
+	}, nil).Times(3)
+
+	for range 3 {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{name: "X-API-Key header", header: models.APIKeyHeader, value: "abc123.secret"},
+		{name: "Authorization ApiKey", header: "Authorization", value: "ApiKey abc123.secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKey := mocks.NewMockAPIKeyUsecase(t)
+			mockAPIKey.EXPECT().ValidateKey(mock.Anything, "abc123.secret").Return(&models.TokenValidationResult{
+				Valid: true,
+				Claims: &models.AccessClaims{
+					Role:             models.RoleUser,
+					RegisteredClaims: jwt.RegisteredClaims{Subject: "sensor-owner"},
+				},
+				UserID: "sensor-owner",
+				Scopes: []string{"cockroach:write"},
+			}, nil).Once()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			middleware := NewAuthMiddleware(mocks.NewMockJWTUsecase(t), mockAPIKey, usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
+			router.POST("/cockroach", middleware.AcceptAPIKeys(), middleware.Handle(), middleware.Requires("cockroach:write"), func(c *gin.Context) {
+				principal, _ := authz.FromContext(c.Request.Context())
+				c.JSON(http.StatusOK, gin.H{"userID": c.GetString("userID"), "apiKey": principal.APIKey})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/cockroach", nil)
+			req.Header.Set(tt.header, tt.value)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), "sensor-owner")
+			assert.Contains(t, w.Body.String(), `"apiKey":true`)
+		})
+	}
+}
+
+func TestAuthMiddleware_APIKeyRefusedWithoutOptIn(t *testing.T) {
+	// The key is refused before it is validated
+	router, _ := setupTestMiddleware(mocks.NewMockJWTUsecase(t), mocks.NewMockAPIKeyUsecase(t))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(models.APIKeyHeader, "abc123.secret")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "API keys are not accepted on this route")
+}
+
+func TestAuthMiddleware_RevokedAPIKey(t *testing.T) {
+	mockAPIKey := mocks.NewMockAPIKeyUsecase(t)
+	router, _ := setupTestMiddleware(mocks.NewMockJWTUsecase(t), mockAPIKey)
+
+	mockAPIKey.EXPECT().ValidateKey(mock.Anything, "abc123.secret").Return(&models.TokenValidationResult{Revoked: true}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/keys", nil)
+	req.Header.Set(models.APIKeyHeader, "abc123.secret")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Token has been revoked")
+}
+
+func TestAuthMiddleware_APIKeyScopes(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopes         []string
+		permission     string
+		expectedStatus int
+	}{
+		{name: "scoped permission", scopes: []string{"cockroach:write"}, permission: "cockroach:write", expectedStatus: http.StatusOK},
+		{name: "role permission outside scopes", scopes: []string{"cockroach:write"}, permission: "cockroach:read", expectedStatus: http.StatusForbidden},
+		{name: "scope outside role permissions", scopes: []string{"users:write"}, permission: "users:write", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKey := mocks.NewMockAPIKeyUsecase(t)
+			mockAPIKey.EXPECT().ValidateKey(mock.Anything, "abc123.secret").Return(&models.TokenValidationResult{
+				Valid:  true,
+				Claims: &models.AccessClaims{Role: models.RoleUser},
+				UserID: "sensor-owner",
+				Scopes: tt.scopes,
+			}, nil).Once()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			middleware := NewAuthMiddleware(mocks.NewMockJWTUsecase(t), mockAPIKey, usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
+			router.POST("/cockroach", middleware.AcceptAPIKeys(), middleware.Handle(), middleware.Requires(tt.permission), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "Success"})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/cockroach", nil)
+			req.Header.Set(models.APIKeyHeader, "abc123.secret")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func setupAllowsRouter(mockJWT *mocks.MockJWTUsecase, roles []models.Role) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	middleware := NewAuthMiddleware(mockJWT, &mocks.MockAPIKeyUsecase{}, usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
+	router.GET("/admin", middleware.Handle(), middleware.Allows(roles), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Success"})
+	})
+
+	return router
+}
+
+func TestAuthMiddleware_Allows(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           models.Role
+		expectedStatus int
+	}{
+		{
+			name:           "admin is allowed",
+			role:           models.RoleAdmin,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "user is forbidden",
+			role:           models.RoleUser,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing role is forbidden",
+			role:           "",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJWT := mocks.NewMockJWTUsecase(t)
+			router := setupAllowsRouter(mockJWT, []models.Role{models.RoleAdmin})
+
+			mockJWT.On("ValidateJWT", mock.Anything, "valid-token").Return(&models.TokenValidationResult{
+				Valid: true,
+				Claims: &models.AccessClaims{
+					Role:             tt.role,
+					RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"},
+				},
+				UserID: "auth-1",
+			}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			req.Header.Set("Authorization", "Bearer valid-token")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_Requires(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           models.Role
+		permissions    []string
+		expectedStatus int
+	}{
+		{
+			name:           "admin has every permission",
+			role:           models.RoleAdmin,
+			permissions:    []string{models.PermissionUsersWrite, models.PermissionSigningKeysRotate},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "staff reads users",
+			role:           models.RoleStaff,
+			permissions:    []string{models.PermissionUsersRead},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "staff cannot write users",
+			role:           models.RoleStaff,
+			permissions:    []string{models.PermissionUsersRead, models.PermissionUsersWrite},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "unknown role has no permissions",
+			role:           "guest",
+			permissions:    []string{models.PermissionUsersRead},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJWT := mocks.NewMockJWTUsecase(t)
+			mockJWT.On("ValidateJWT", mock.Anything, "valid-token").Return(&models.TokenValidationResult{
+				Valid: true,
+				Claims: &models.AccessClaims{
+					Role:             tt.role,
+					RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"},
+				},
+				UserID: "auth-1",
+			}, nil)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			middleware := NewAuthMiddleware(mockJWT, mocks.NewMockAPIKeyUsecase(t), usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
+			router.GET("/users", middleware.Handle(), middleware.Requires(tt.permissions...), func(c *gin.Context) {
+				// Use cases see the same principal
+				principal, ok := authz.FromContext(c.Request.Context())
+				assert.True(t, ok)
+				assert.Equal(t, "auth-1", principal.UserID)
+				c.JSON(http.StatusOK, gin.H{"message": "Success"})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			req.Header.Set("Authorization", "Bearer valid-token")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_RequiresWithoutHandle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	middleware := NewAuthMiddleware(mocks.NewMockJWTUsecase(t), mocks.NewMockAPIKeyUsecase(t), usecases.NewPermissionStore(&config.Config{}, nil), activeAccounts{}, newTestThrottle())
+	router.GET("/users", middleware.Requires(models.PermissionUsersRead), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Success"})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
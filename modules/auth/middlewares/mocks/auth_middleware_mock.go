@@ -38,6 +38,52 @@ func (_m *MockAuthMiddleware) EXPECT() *MockAuthMiddleware_Expecter {
 	return &MockAuthMiddleware_Expecter{mock: &_m.Mock}
 }
 
+// AcceptAPIKeys provides a mock function for the type MockAuthMiddleware
+func (_mock *MockAuthMiddleware) AcceptAPIKeys() gin.HandlerFunc {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptAPIKeys")
+	}
+
+	var r0 gin.HandlerFunc
+	if returnFunc, ok := ret.Get(0).(func() gin.HandlerFunc); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gin.HandlerFunc)
+		}
+	}
+	return r0
+}
+
+// MockAuthMiddleware_AcceptAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptAPIKeys'
+type MockAuthMiddleware_AcceptAPIKeys_Call struct {
+	*mock.Call
+}
+
+// AcceptAPIKeys is a helper method to define mock.On call
+func (_e *MockAuthMiddleware_Expecter) AcceptAPIKeys() *MockAuthMiddleware_AcceptAPIKeys_Call {
+	return &MockAuthMiddleware_AcceptAPIKeys_Call{Call: _e.mock.On("AcceptAPIKeys")}
+}
+
+func (_c *MockAuthMiddleware_AcceptAPIKeys_Call) Run(run func()) *MockAuthMiddleware_AcceptAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAuthMiddleware_AcceptAPIKeys_Call) Return(handlerFunc gin.HandlerFunc) *MockAuthMiddleware_AcceptAPIKeys_Call {
+	_c.Call.Return(handlerFunc)
+	return _c
+}
+
+func (_c *MockAuthMiddleware_AcceptAPIKeys_Call) RunAndReturn(run func() gin.HandlerFunc) *MockAuthMiddleware_AcceptAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Allows provides a mock function for the type MockAuthMiddleware
 func (_mock *MockAuthMiddleware) Allows(roles []models.Role) gin.HandlerFunc {
 	ret := _mock.Called(roles)
@@ -136,3 +182,111 @@ func (_c *MockAuthMiddleware_Handle_Call) RunAndReturn(run func() gin.HandlerFun
 	_c.Call.Return(run)
 	return _c
 }
+
+// HandleMFA provides a mock function for the type MockAuthMiddleware
+func (_mock *MockAuthMiddleware) HandleMFA() gin.HandlerFunc {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleMFA")
+	}
+
+	var r0 gin.HandlerFunc
+	if returnFunc, ok := ret.Get(0).(func() gin.HandlerFunc); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gin.HandlerFunc)
+		}
+	}
+	return r0
+}
+
+// MockAuthMiddleware_HandleMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleMFA'
+type MockAuthMiddleware_HandleMFA_Call struct {
+	*mock.Call
+}
+
+// HandleMFA is a helper method to define mock.On call
+func (_e *MockAuthMiddleware_Expecter) HandleMFA() *MockAuthMiddleware_HandleMFA_Call {
+	return &MockAuthMiddleware_HandleMFA_Call{Call: _e.mock.On("HandleMFA")}
+}
+
+func (_c *MockAuthMiddleware_HandleMFA_Call) Run(run func()) *MockAuthMiddleware_HandleMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAuthMiddleware_HandleMFA_Call) Return(handlerFunc gin.HandlerFunc) *MockAuthMiddleware_HandleMFA_Call {
+	_c.Call.Return(handlerFunc)
+	return _c
+}
+
+func (_c *MockAuthMiddleware_HandleMFA_Call) RunAndReturn(run func() gin.HandlerFunc) *MockAuthMiddleware_HandleMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Requires provides a mock function for the type MockAuthMiddleware
+func (_mock *MockAuthMiddleware) Requires(permissions ...string) gin.HandlerFunc {
+	var tmpRet mock.Arguments
+	if len(permissions) > 0 {
+		tmpRet = _mock.Called(permissions)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Requires")
+	}
+
+	var r0 gin.HandlerFunc
+	if returnFunc, ok := ret.Get(0).(func(...string) gin.HandlerFunc); ok {
+		r0 = returnFunc(permissions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gin.HandlerFunc)
+		}
+	}
+	return r0
+}
+
+// MockAuthMiddleware_Requires_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Requires'
+type MockAuthMiddleware_Requires_Call struct {
+	*mock.Call
+}
+
+// Requires is a helper method to define mock.On call
+//   - permissions ...string
+func (_e *MockAuthMiddleware_Expecter) Requires(permissions ...interface{}) *MockAuthMiddleware_Requires_Call {
+	return &MockAuthMiddleware_Requires_Call{Call: _e.mock.On("Requires",
+		append([]interface{}{}, permissions...)...)}
+}
+
+func (_c *MockAuthMiddleware_Requires_Call) Run(run func(permissions ...string)) *MockAuthMiddleware_Requires_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthMiddleware_Requires_Call) Return(handlerFunc gin.HandlerFunc) *MockAuthMiddleware_Requires_Call {
+	_c.Call.Return(handlerFunc)
+	return _c
+}
+
+func (_c *MockAuthMiddleware_Requires_Call) RunAndReturn(run func(permissions ...string) gin.HandlerFunc) *MockAuthMiddleware_Requires_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,17 @@
+package models
+
+import "github.com/golang-jwt/jwt/v5"
+
+// AccessClaims are the claims carried by an access token. The registered claims hold
+// sub (the auths.id), iss, aud, exp, nbf, iat and jti.
+type AccessClaims struct {
+	// Role is empty in mfa_pending tokens
+	Role  Role   `json:"role,omitempty"`
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+	// MFAPending marks the short-lived token of a login waiting for its second factor. Its
+	// audience is that of access tokens suffixed with ":mfa_pending", and it is only accepted
+	// by the MFA routes.
+	MFAPending bool `json:"mfa_pending,omitempty"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// LinkProviderResponse is the response of POST /auth/:provider/link. The browser has to open
+// URL with the session cookie set by the same response.
+type LinkProviderResponse struct {
+	URL string `json:"url"`
+}
+
+// LinkedProvider is a provider account linked to the current user. Provider tokens are never
+// part of it.
+type LinkedProvider struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	AvatarURL string    `json:"avatar_url,omitempty"`
+	LinkedAt  time.Time `json:"linked_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// UserFilter is the query of GET /admin/auth/users. Zero fields do not filter.
+type UserFilter struct {
+	Role        string    `form:"role" validate:"omitempty,oneof=admin staff user"`
+	Active      *bool     `form:"active"`
+	Provider    string    `form:"provider" validate:"omitempty,max=50"`
+	CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Deleted lists soft-deleted users instead of the others
+	Deleted bool `form:"deleted"`
+}
+
+// UserCursor is the user a page of GET /admin/auth/users starts after, newest first, or before
+// when Before is set
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        string
+	Before    bool
+}
+
+// UpdateRoleRequest is the body of PUT /admin/auth/users/:id/role
+type UpdateRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin staff user"`
+}
+
+// AdminUser is a user as admins see it. The password hash is never part of it.
+type AdminUser struct {
+	ID          string           `json:"id"`
+	Username    string           `json:"username,omitempty"`
+	Email       string           `json:"email,omitempty"`
+	Role        string           `json:"role"`
+	Active      bool             `json:"active"`
+	HasPassword bool             `json:"has_password"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	DeletedAt   *time.Time       `json:"deleted_at,omitempty"`
+	Providers   []LinkedProvider `json:"providers,omitempty"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// APIKeyHeader carries an API key. Keys are also accepted as "Authorization: ApiKey <key>".
+const APIKeyHeader = "X-API-Key"
+
+// CreateAPIKeyRequest is the body of POST /admin/auth/api-keys
+type CreateAPIKeyRequest struct {
+	// AuthID is the auth the key acts as
+	AuthID string `json:"auth_id" validate:"required,max=36"`
+	Name   string `json:"name" validate:"required,max=255"`
+	// Scopes are the permissions the key may use, out of those granted by the role of AuthID
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKey is an API key as admins see it. The key itself is only shown once, on creation.
+type APIKey struct {
+	ID         string     `json:"id"`
+	AuthID     string     `json:"auth_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedAPIKey is the response of POST /admin/auth/api-keys
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actions of the audit log
+const (
+	AuditActionLogin            = "auth.login"
+	AuditActionPasswordLogin    = "auth.password_login"
+	AuditActionRegister         = "auth.register"
+	AuditActionLogout           = "auth.logout"
+	AuditActionLogoutAll        = "auth.logout_all"
+	AuditActionRefreshToken     = "auth.refresh_token"
+	AuditActionPasswordChange   = "auth.password_change"
+	AuditActionProfileUpdate    = "auth.profile_update"
+	AuditActionAccountDelete    = "auth.account_delete"
+	AuditActionMFAEnable        = "auth.mfa_enable"
+	AuditActionMFAVerify        = "auth.mfa_verify"
+	AuditActionMFADisable       = "auth.mfa_disable"
+	AuditActionMFARecoveryCodes = "auth.mfa_recovery_codes"
+	AuditActionProviderLink     = "auth.provider_link"
+	AuditActionProviderUnlink   = "auth.provider_unlink"
+	AuditActionUserCreate       = "user.create"
+	AuditActionUserRoleChange   = "user.role_change"
+	AuditActionUserActivate     = "user.activate"
+	AuditActionUserDeactivate   = "user.deactivate"
+	AuditActionUserDelete       = "user.delete"
+	AuditActionUserRestore      = "user.restore"
+	AuditActionUserRevokeTokens = "user.revoke_tokens"
+	AuditActionAPIKeyCreate     = "api_key.create"
+	AuditActionAPIKeyRevoke     = "api_key.revoke"
+	AuditActionSigningKeyRotate = "signing_key.rotate"
+)
+
+// Outcomes of audited actions
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// Types of the targets of audited actions
+const (
+	AuditTargetUser       = "user"
+	AuditTargetAPIKey     = "api_key"
+	AuditTargetSigningKey = "signing_key"
+)
+
+// AuditEvent is an event to record in the audit log. Empty client fields are filled in from
+// the context of the request.
+type AuditEvent struct {
+	// ActorID is the auth doing the action, empty when it is unknown, e.g. for a failed login
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Outcome    string
+	IPAddress  string
+	UserAgent  string
+	RequestID  string
+	// Metadata holds the details of the action, such as the provider or the reason of a failure
+	Metadata map[string]any
+}
+
+// AuditEventFilter is the query of GET /admin/auth/audit-events. Zero fields do not filter.
+type AuditEventFilter struct {
+	ActorID     string    `form:"actor_id" validate:"omitempty,max=36"`
+	Action      string    `form:"action" validate:"omitempty,max=100"`
+	TargetType  string    `form:"target_type" validate:"omitempty,max=50"`
+	TargetID    string    `form:"target_id" validate:"omitempty,max=255"`
+	Outcome     string    `form:"outcome" validate:"omitempty,oneof=success failure"`
+	IPAddress   string    `form:"ip_address" validate:"omitempty,max=45"`
+	CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// AuditEventResponse is a recorded audit event as admins see it
+type AuditEventResponse struct {
+	ID         string          `json:"id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type,omitempty"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Outcome    string          `json:"outcome"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Metadata   json.RawMessage `json:"metadata"`
+}
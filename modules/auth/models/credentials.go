@@ -0,0 +1,20 @@
+package models
+
+// RegisterRequest is the body of POST /auth/register
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required,username"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
+	Password string `json:"password" validate:"required,password_strength,max=128"`
+}
+
+// LoginRequest is the body of POST /auth/login. Username also accepts the email address.
+type LoginRequest struct {
+	Username string `json:"username" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,max=128"`
+}
+
+// ChangePasswordRequest is the body of PUT /auth/password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required,max=128"`
+	NewPassword     string `json:"new_password" validate:"required,password_strength,max=128,nefield=CurrentPassword"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "crypto/ecdsa"
+
+// JWK is the public half of a signing key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// SigningKey is a key that signs access tokens, identified by the kid header
+type SigningKey struct {
+	Kid        string
+	PrivateKey *ecdsa.PrivateKey
+}
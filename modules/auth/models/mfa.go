@@ -0,0 +1,20 @@
+package models
+
+// MFAEnrollment is the response of POST /auth/mfa/enroll. Authenticator apps read the URI from
+// a QR code or take the secret typed in.
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// MFACodeRequest is the body of the MFA routes taking a TOTP or recovery code
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,min=6,max=32"`
+}
+
+// MFAConfirmation is the response of POST /auth/mfa/confirm. The recovery codes are only shown
+// here. Tokens are set when the enrolment completed an mfa_pending login.
+type MFAConfirmation struct {
+	RecoveryCodes []string   `json:"recovery_codes"`
+	Tokens        *TokenPair `json:"tokens,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// Permissions checked by the auth module
+const (
+	PermissionUsersRead         = "users:read"
+	PermissionUsersWrite        = "users:write"
+	PermissionSigningKeysRotate = "signing_keys:rotate"
+	PermissionAPIKeysRead       = "api_keys:read"
+	PermissionAPIKeysWrite      = "api_keys:write"
+	PermissionAuditRead         = "audit:read"
+)
+
+// DefaultRolePermissions are used until the role permissions are loaded from
+// AUTH_ROLE_PERMISSIONS or the role_permissions table, which is seeded with the same values
+var DefaultRolePermissions = map[Role][]string{
+	RoleAdmin: {"*"},
+	RoleStaff: {PermissionUsersRead, "cockroach:*"},
+	RoleUser:  {"cockroach:read", "cockroach:write"},
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// PersonalData is what the auth module exports about a user for a data subject request.
+// Password hashes, MFA secrets, provider tokens and the hashes of keys and tokens are never
+// part of it.
+type PersonalData struct {
+	Account     PersonalAccount      `json:"account"`
+	Providers   []PersonalProvider   `json:"providers"`
+	APIKeys     []APIKey             `json:"api_keys"`
+	Sessions    []PersonalSession    `json:"sessions"`
+	AuditEvents []AuditEventResponse `json:"audit_events"`
+}
+
+// PersonalAccount is the auth of a user
+type PersonalAccount struct {
+	ID          string     `json:"id"`
+	Username    string     `json:"username,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	Role        string     `json:"role"`
+	Active      bool       `json:"active"`
+	HasPassword bool       `json:"has_password"`
+	MFAEnabled  bool       `json:"mfa_enabled"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+// PersonalProvider is a provider account linked to a user, with the profile the provider gave
+type PersonalProvider struct {
+	Provider    string     `json:"provider"`
+	ProviderID  string     `json:"provider_id"`
+	Email       string     `json:"email,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	FirstName   string     `json:"first_name,omitempty"`
+	LastName    string     `json:"last_name,omitempty"`
+	NickName    string     `json:"nick_name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	AvatarURL   string     `json:"avatar_url,omitempty"`
+	Location    string     `json:"location,omitempty"`
+	LinkedAt    time.Time  `json:"linked_at"`
+	UnlinkedAt  *time.Time `json:"unlinked_at,omitempty"`
+}
+
+// PersonalSession is a refresh token issued to a user
+type PersonalSession struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
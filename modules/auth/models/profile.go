@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Profile is the current user as they see themselves in GET /auth/me. The password hash,
+// the MFA secret and provider tokens are never part of it.
+type Profile struct {
+	ID          string           `json:"id"`
+	Username    string           `json:"username,omitempty"`
+	Email       string           `json:"email,omitempty"`
+	Role        string           `json:"role"`
+	Permissions []string         `json:"permissions"`
+	HasPassword bool             `json:"has_password"`
+	MFAEnabled  bool             `json:"mfa_enabled"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Providers   []LinkedProvider `json:"providers"`
+}
+
+// UpdateProfileRequest is the body of PATCH /auth/me. Absent fields are left as they are.
+// Changing the email takes the current password, so accounts without one keep the email of
+// their provider.
+type UpdateProfileRequest struct {
+	Username        *string `json:"username" validate:"omitempty,username"`
+	Email           *string `json:"email" validate:"omitempty,email,max=255"`
+	CurrentPassword string  `json:"current_password" validate:"max=128"`
+}
+
+// DeleteAccountRequest is the body of DELETE /auth/me. Accounts with a password confirm the
+// deletion with it.
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"max=128"`
+}
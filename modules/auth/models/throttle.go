@@ -0,0 +1,7 @@
+package models
+
+// Stores counting the failed logins of the login throttle, see AUTH_THROTTLE_STORE
+const (
+	ThrottleStoreMemory   = "memory"
+	ThrottleStorePostgres = "postgres"
+)
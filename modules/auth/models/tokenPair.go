@@ -0,0 +1,39 @@
+package models
+
+// Token delivery modes of the OAuth callback, see AUTH_TOKEN_DELIVERY
+const (
+	TokenDeliveryCode   = "code"
+	TokenDeliveryCookie = "cookie"
+)
+
+// Names of the HttpOnly cookies holding the tokens in the cookie delivery mode
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+)
+
+// TokenPair is the access and refresh token issued after login or a refresh. When MFARequired
+// is set the access token is an mfa_pending token and there is no refresh token yet.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // access token lifetime in seconds
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+}
+
+// RefreshTokenRequest is the body of POST /auth/token/refresh
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest is the optional body of POST /auth/logout
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ExchangeCodeRequest is the body of POST /auth/token
+type ExchangeCodeRequest struct {
+	Code         string `json:"code" validate:"required,max=128"`
+	CodeVerifier string `json:"code_verifier" validate:"required,min=43,max=128"`
+}
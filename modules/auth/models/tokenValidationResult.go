@@ -1,12 +1,13 @@
 package models
 
-import "github.com/golang-jwt/jwt/v5"
-
 // TokenValidationResult represents the result of token validation
 type TokenValidationResult struct {
 	Valid    bool
 	Expired  bool
 	NotExist bool
-	Claims   jwt.MapClaims
+	Revoked  bool
+	Claims   *AccessClaims
 	UserID   string
+	// Scopes limit the permissions of an API key. They are nil for access tokens.
+	Scopes []string
 }
@@ -0,0 +1,219 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"template-golang/config"
+	"time"
+
+	"github.com/markbates/goth"
+	"github.com/markbates/goth/providers/apple"
+	"github.com/markbates/goth/providers/github"
+	"github.com/markbates/goth/providers/google"
+	"github.com/markbates/goth/providers/line"
+	"github.com/markbates/goth/providers/openidConnect"
+	"github.com/spf13/viper"
+)
+
+// Provider types that can be configured in the providers file
+const (
+	TypeGoogle = "google"
+	TypeGitHub = "github"
+	TypeApple  = "apple"
+	TypeLine   = "line"
+	TypeOIDC   = "oidc"
+)
+
+// appleSecretTTL is how long a client secret generated for Apple stays valid. Apple accepts
+// at most six months, so the server has to be restarted within that time.
+const appleSecretTTL = 180 * 24 * time.Hour
+
+var (
+	ErrUnknownProviderType = errors.New("unknown provider type")
+	ErrInvalidProvider     = errors.New("invalid provider config")
+)
+
+// ProviderConfig configures one login provider. String values may reference environment
+// variables as $NAME or ${NAME}, so secrets do not have to be stored in the file.
+type ProviderConfig struct {
+	Name         string   `mapstructure:"name"` // the :provider path segment, defaults to the type
+	Type         string   `mapstructure:"type"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	CallbackURL  string   `mapstructure:"callback_url"`
+	Scopes       []string `mapstructure:"scopes"` // empty uses the defaults of the type
+
+	// DiscoveryURL is the OpenID Connect discovery document, required for the oidc type
+	DiscoveryURL string `mapstructure:"discovery_url"`
+
+	// Apple signs its client secret with a private key. When ClientSecret is empty it is
+	// generated from these at startup.
+	TeamID         string `mapstructure:"team_id"`
+	KeyID          string `mapstructure:"key_id"`
+	PrivateKeyPath string `mapstructure:"private_key_path"`
+}
+
+// defaultScopes are requested when a provider does not configure its own
+var defaultScopes = map[string][]string{
+	TypeGoogle: {"openid", "email", "profile"},
+	TypeGitHub: {"read:user", "user:email"},
+	TypeApple:  {"name", "email"},
+	TypeLine:   {"profile", "openid", "email"},
+	TypeOIDC:   {"openid", "email", "profile"},
+}
+
+// NewProviders builds the providers declared in AUTH_PROVIDERS_FILE, plus LINE from the
+// LINE_* variables unless the file declares a provider named line.
+func NewProviders(conf *config.Config) ([]goth.Provider, error) {
+	configs, err := LoadConfigs(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]goth.Provider, 0, len(configs))
+	for _, cfg := range configs {
+		provider, err := New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider %s: %w", cfg.Name, err)
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// LoadConfigs reads the provider configs from AUTH_PROVIDERS_FILE and the legacy LINE_* variables
+func LoadConfigs(conf *config.Config) ([]ProviderConfig, error) {
+	var configs []ProviderConfig
+
+	if conf.Auth.ProvidersFile != "" {
+		v := viper.New()
+		v.SetConfigFile(conf.Auth.ProvidersFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read providers file: %w", err)
+		}
+		if err := v.UnmarshalKey("providers", &configs); err != nil {
+			return nil, fmt.Errorf("failed to decode providers file: %w", err)
+		}
+	}
+
+	names := make(map[string]bool, len(configs))
+	for i := range configs {
+		cfg := &configs[i]
+		cfg.expandEnv()
+		if cfg.Name == "" {
+			cfg.Name = cfg.Type
+		}
+		cfg.Name = strings.ToLower(cfg.Name)
+
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("%w: duplicate provider name %s", ErrInvalidProvider, cfg.Name)
+		}
+		names[cfg.Name] = true
+	}
+
+	if conf.Auth.LineClientID != "" && !names[TypeLine] {
+		configs = append(configs, ProviderConfig{
+			Name:         TypeLine,
+			Type:         TypeLine,
+			ClientID:     conf.Auth.LineClientID,
+			ClientSecret: conf.Auth.LineClientSecret,
+			CallbackURL:  conf.Auth.LineCallbackURL,
+		})
+	}
+
+	return configs, nil
+}
+
+// New creates the goth provider described by cfg. OIDC providers fetch their discovery
+// document, so this needs the issuer to be reachable.
+func New(cfg ProviderConfig) (goth.Provider, error) {
+	if cfg.ClientID == "" || cfg.CallbackURL == "" {
+		return nil, fmt.Errorf("%w: client_id and callback_url are required", ErrInvalidProvider)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes[cfg.Type]
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+
+	switch cfg.Type {
+	case TypeGoogle:
+		p := google.New(cfg.ClientID, cfg.ClientSecret, cfg.CallbackURL, scopes...)
+		p.SetName(name)
+		return p, nil
+	case TypeGitHub:
+		p := github.New(cfg.ClientID, cfg.ClientSecret, cfg.CallbackURL, scopes...)
+		p.SetName(name)
+		return p, nil
+	case TypeLine:
+		p := line.New(cfg.ClientID, cfg.ClientSecret, cfg.CallbackURL, scopes...)
+		p.SetName(name)
+		return p, nil
+	case TypeApple:
+		secret, err := appleSecret(cfg)
+		if err != nil {
+			return nil, err
+		}
+		p := apple.New(cfg.ClientID, secret, cfg.CallbackURL, nil, scopes...)
+		p.SetName(name)
+		return p, nil
+	case TypeOIDC:
+		if cfg.DiscoveryURL == "" {
+			return nil, fmt.Errorf("%w: discovery_url is required for oidc", ErrInvalidProvider)
+		}
+		p, err := openidConnect.New(cfg.ClientID, cfg.ClientSecret, cfg.CallbackURL, cfg.DiscoveryURL, scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover OpenID configuration: %w", err)
+		}
+		p.SetName(name)
+		return p, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProviderType, cfg.Type)
+	}
+}
+
+// appleSecret returns the configured client secret or signs one with the Apple private key
+func appleSecret(cfg ProviderConfig) (string, error) {
+	if cfg.ClientSecret != "" {
+		return cfg.ClientSecret, nil
+	}
+	if cfg.TeamID == "" || cfg.KeyID == "" || cfg.PrivateKeyPath == "" {
+		return "", fmt.Errorf("%w: apple needs client_secret or team_id, key_id and private_key_path", ErrInvalidProvider)
+	}
+
+	privateKey, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read apple private key: %w", err)
+	}
+
+	now := time.Now()
+	secret, err := apple.MakeSecret(apple.SecretParams{
+		PKCS8PrivateKey: string(privateKey),
+		TeamId:          cfg.TeamID,
+		KeyId:           cfg.KeyID,
+		ClientId:        cfg.ClientID,
+		Iat:             int(now.Unix()),
+		Exp:             int(now.Add(appleSecretTTL).Unix()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign apple client secret: %w", err)
+	}
+	return *secret, nil
+}
+
+func (cfg *ProviderConfig) expandEnv() {
+	for _, value := range []*string{
+		&cfg.ClientID, &cfg.ClientSecret, &cfg.CallbackURL, &cfg.DiscoveryURL,
+		&cfg.TeamID, &cfg.KeyID, &cfg.PrivateKeyPath,
+	} {
+		*value = os.ExpandEnv(*value)
+	}
+}
@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"template-golang/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProvidersFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "providers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfigs_FromFileWithEnvAndLegacyLine(t *testing.T) {
+	t.Setenv("TEST_GOOGLE_SECRET", "google-secret")
+
+	conf := &config.Config{Auth: config.AuthConfig{
+		ProvidersFile: writeProvidersFile(t, `providers:
+  - type: google
+    client_id: google-id
+    client_secret: ${TEST_GOOGLE_SECRET}
+    callback_url: http://localhost:8080/api/v1/auth/google/callback
+  - name: Corp
+    type: oidc
+    client_id: corp-id
+    callback_url: http://localhost:8080/api/v1/auth/corp/callback
+    discovery_url: https://id.example.com/.well-known/openid-configuration
+    scopes: [openid, email]
+`),
+		LineClientID:     "line-id",
+		LineClientSecret: "line-secret",
+		LineCallbackURL:  "http://localhost:8080/api/v1/auth/line/callback",
+	}}
+
+	configs, err := LoadConfigs(conf)
+
+	require.NoError(t, err)
+	require.Len(t, configs, 3)
+	assert.Equal(t, "google", configs[0].Name)
+	assert.Equal(t, "google-secret", configs[0].ClientSecret)
+	assert.Equal(t, "corp", configs[1].Name)
+	assert.Equal(t, []string{"openid", "email"}, configs[1].Scopes)
+	assert.Equal(t, ProviderConfig{
+		Name:         "line",
+		Type:         TypeLine,
+		ClientID:     "line-id",
+		ClientSecret: "line-secret",
+		CallbackURL:  "http://localhost:8080/api/v1/auth/line/callback",
+	}, configs[2])
+}
+
+func TestLoadConfigs_FileOverridesLegacyLine(t *testing.T) {
+	conf := &config.Config{Auth: config.AuthConfig{
+		ProvidersFile: writeProvidersFile(t, `providers:
+  - type: line
+    client_id: file-line-id
+    callback_url: http://localhost:8080/api/v1/auth/line/callback
+`),
+		LineClientID: "env-line-id",
+	}}
+
+	configs, err := LoadConfigs(conf)
+
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "file-line-id", configs[0].ClientID)
+}
+
+func TestLoadConfigs_Errors(t *testing.T) {
+	t.Run("duplicate name", func(t *testing.T) {
+		conf := &config.Config{Auth: config.AuthConfig{ProvidersFile: writeProvidersFile(t, `providers:
+  - type: github
+  - name: GitHub
+    type: github
+`)}}
+
+		_, err := LoadConfigs(conf)
+
+		assert.ErrorIs(t, err, ErrInvalidProvider)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		conf := &config.Config{Auth: config.AuthConfig{ProvidersFile: filepath.Join(t.TempDir(), "missing.yaml")}}
+
+		_, err := LoadConfigs(conf)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 "https://id.example.com",
+			"authorization_endpoint": "https://id.example.com/authorize",
+			"token_endpoint":         "https://id.example.com/token",
+		})
+	}))
+	defer discovery.Close()
+
+	tests := []struct {
+		name     string
+		cfg      ProviderConfig
+		wantName string
+		wantErr  error
+	}{
+		{name: "google", cfg: ProviderConfig{Type: TypeGoogle}, wantName: "google"},
+		{name: "github", cfg: ProviderConfig{Name: "gh", Type: TypeGitHub}, wantName: "gh"},
+		{name: "line", cfg: ProviderConfig{Type: TypeLine}, wantName: "line"},
+		{name: "apple with client secret", cfg: ProviderConfig{Type: TypeApple, ClientSecret: "signed-secret"}, wantName: "apple"},
+		{name: "apple without secret", cfg: ProviderConfig{Type: TypeApple}, wantErr: ErrInvalidProvider},
+		{name: "oidc", cfg: ProviderConfig{Name: "corp", Type: TypeOIDC, DiscoveryURL: discovery.URL}, wantName: "corp"},
+		{name: "oidc without discovery", cfg: ProviderConfig{Type: TypeOIDC}, wantErr: ErrInvalidProvider},
+		{name: "unknown type", cfg: ProviderConfig{Type: "myspace"}, wantErr: ErrUnknownProviderType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.ClientID = "client-id"
+			cfg.CallbackURL = "http://localhost:8080/api/v1/auth/" + cfg.Type + "/callback"
+
+			provider, err := New(cfg)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, provider.Name())
+		})
+	}
+}
+
+func TestNew_RequiresClientIDAndCallbackURL(t *testing.T) {
+	_, err := New(ProviderConfig{Type: TypeGoogle, CallbackURL: "http://localhost:8080/api/v1/auth/google/callback"})
+	assert.ErrorIs(t, err, ErrInvalidProvider)
+
+	_, err = New(ProviderConfig{Type: TypeGoogle, ClientID: "client-id"})
+	assert.ErrorIs(t, err, ErrInvalidProvider)
+}
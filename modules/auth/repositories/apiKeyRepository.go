@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, params db.CreateAPIKeyParams) (*db.APIKey, error)
+	// GetAPIKeyByPrefix returns the key with prefix, including revoked and expired keys
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*db.APIKey, error)
+	// ListAPIKeys lists every key, or only the keys of authID when it is not empty
+	ListAPIKeys(ctx context.Context, authID string) ([]*db.APIKey, error)
+	// RevokeAPIKey revokes the key, keeping the time of an earlier revocation
+	RevokeAPIKey(ctx context.Context, id string) (*db.APIKey, error)
+	// TouchAPIKey sets the last used time of the key to now
+	TouchAPIKey(ctx context.Context, id string) error
+}
+
+type apiKeyRepository struct {
+	queries *db.Queries
+}
+
+func NewAPIKeyRepository(queries *db.Queries) APIKeyRepository {
+	return &apiKeyRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *apiKeyRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *apiKeyRepository) CreateAPIKey(ctx context.Context, params db.CreateAPIKeyParams) (*db.APIKey, error) {
+	key, err := r.q(ctx).CreateAPIKey(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*db.APIKey, error) {
+	key, err := r.q(ctx).GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) ListAPIKeys(ctx context.Context, authID string) ([]*db.APIKey, error) {
+	var filter *string
+	if authID != "" {
+		filter = &authID
+	}
+
+	keys, err := r.q(ctx).ListAPIKeys(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.APIKey, 0, len(keys))
+	for _, key := range keys {
+		keyCopy := key
+		result = append(result, &keyCopy)
+	}
+
+	return result, nil
+}
+
+func (r *apiKeyRepository) RevokeAPIKey(ctx context.Context, id string) (*db.APIKey, error) {
+	key, err := r.q(ctx).RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) TouchAPIKey(ctx context.Context, id string) error {
+	return r.q(ctx).TouchAPIKey(ctx, id)
+}
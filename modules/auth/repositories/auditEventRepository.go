@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+)
+
+type AuditEventRepository interface {
+	CreateAuditEvent(ctx context.Context, params db.CreateAuditEventParams) (*db.AuditEvent, error)
+	// ListAuditEvents returns a page of the events matching params, newest first
+	ListAuditEvents(ctx context.Context, params db.ListAuditEventsParams) ([]*db.AuditEvent, error)
+	CountAuditEvents(ctx context.Context, params db.CountAuditEventsParams) (int64, error)
+}
+
+type auditEventRepository struct {
+	queries *db.Queries
+}
+
+func NewAuditEventRepository(queries *db.Queries) AuditEventRepository {
+	return &auditEventRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *auditEventRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *auditEventRepository) CreateAuditEvent(ctx context.Context, params db.CreateAuditEventParams) (*db.AuditEvent, error) {
+	event, err := r.q(ctx).CreateAuditEvent(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *auditEventRepository) ListAuditEvents(ctx context.Context, params db.ListAuditEventsParams) ([]*db.AuditEvent, error) {
+	events, err := r.q(ctx).ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.AuditEvent, 0, len(events))
+	for _, event := range events {
+		eventCopy := event
+		result = append(result, &eventCopy)
+	}
+
+	return result, nil
+}
+
+func (r *auditEventRepository) CountAuditEvents(ctx context.Context, params db.CountAuditEventsParams) (int64, error) {
+	return r.q(ctx).CountAuditEvents(ctx, params)
+}
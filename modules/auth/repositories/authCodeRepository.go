@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type AuthCodeRepository interface {
+	CreateAuthCode(ctx context.Context, authID string, codeHash string, codeChallenge string, expiresAt time.Time) (*db.AuthCode, error)
+	// ConsumeAuthCode marks the code used, returning pgx.ErrNoRows when it is unknown, expired or already used
+	ConsumeAuthCode(ctx context.Context, codeHash string) (*db.AuthCode, error)
+	// DeleteExpiredAuthCodes deletes the codes that can no longer be exchanged
+	DeleteExpiredAuthCodes(ctx context.Context) (int64, error)
+}
+
+type authCodeRepository struct {
+	queries *db.Queries
+}
+
+func NewAuthCodeRepository(queries *db.Queries) AuthCodeRepository {
+	return &authCodeRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *authCodeRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *authCodeRepository) CreateAuthCode(ctx context.Context, authID string, codeHash string, codeChallenge string, expiresAt time.Time) (*db.AuthCode, error) {
+	code, err := r.q(ctx).CreateAuthCode(ctx, authID, codeHash, codeChallenge, pgtype.Timestamptz{Time: expiresAt, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *authCodeRepository) ConsumeAuthCode(ctx context.Context, codeHash string) (*db.AuthCode, error) {
+	code, err := r.q(ctx).ConsumeAuthCode(ctx, codeHash)
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *authCodeRepository) DeleteExpiredAuthCodes(ctx context.Context) (int64, error) {
+	return r.q(ctx).DeleteExpiredAuthCodes(ctx)
+}
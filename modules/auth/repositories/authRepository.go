@@ -2,132 +2,359 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"template-golang/database"
 	db "template-golang/db/sqlc"
+	"template-golang/pkg/encryption"
+	"template-golang/pkg/logger"
 )
 
 type AuthRepository interface {
 	GetAuthByID(ctx context.Context, id string) (*db.Auth, error)
 	GetAuthByUsername(ctx context.Context, username string) (*db.Auth, error)
 	GetAuthByEmail(ctx context.Context, email string) (*db.Auth, error)
+	// LockAuth returns the auth and locks its row until the transaction in ctx ends
+	LockAuth(ctx context.Context, id string) (*db.Auth, error)
 	CreateAuth(ctx context.Context, username *string, password *string, email *string, role string, active bool) (*db.Auth, error)
 	UpdateAuth(ctx context.Context, params db.UpdateAuthParams) (*db.Auth, error)
+	UpdateAuthPassword(ctx context.Context, id string, passwordHash string) error
 	SoftDeleteAuth(ctx context.Context, id string) error
+	// CloseAuth soft deletes the auth of a user closing their account, frees its username and
+	// email and unlinks its providers, so the user can sign up again. It runs several
+	// statements, callers run it in a transaction.
+	CloseAuth(ctx context.Context, id string) error
 	ListAllAuths(ctx context.Context) ([]*db.Auth, error)
+	// ListAuths returns up to params.Limit auths matching params after the cursor of params, or
+	// before it when params.Backward is set, newest first. Without a cursor it returns the first
+	// ones.
+	ListAuths(ctx context.Context, params db.ListAuthsParams) ([]*db.Auth, error)
+	UpdateAuthRole(ctx context.Context, id string, role string) (*db.Auth, error)
+	UpdateAuthActive(ctx context.Context, id string, active bool) (*db.Auth, error)
+	// RestoreAuth undoes SoftDeleteAuth
+	RestoreAuth(ctx context.Context, id string) (*db.Auth, error)
 	CreateAuthMethod(ctx context.Context, params db.CreateAuthMethodParams) (*db.AuthMethod, error)
 	GetAuthMethodByProviderAndID(ctx context.Context, provider string, providerID string) (*db.AuthMethod, error)
 	GetAuthMethodsByAuthID(ctx context.Context, authID string) ([]*db.AuthMethod, error)
 	UpdateAuthMethod(ctx context.Context, params db.UpdateAuthMethodParams) (*db.AuthMethod, error)
 	SoftDeleteAuthMethod(ctx context.Context, id string) error
+	// ReencryptAuthMethods encrypts with the active key the provider tokens of up to limit auth
+	// methods after afterID that are stored in plaintext or with another key. It returns the ID
+	// of the last auth method it went through, empty when none is left, and how many it
+	// re-encrypted.
+	ReencryptAuthMethods(ctx context.Context, afterID string, limit int) (string, int, error)
 }
 
+// authRepository encrypts the provider tokens of auth methods with keyRing when writing them
+// and decrypts them when reading them, callers only see plaintext tokens. It also decrypts the
+// MFA secrets of auths, which the MFA repository encrypts.
 type authRepository struct {
 	queries *db.Queries
+	keyRing *encryption.KeyRing
 }
 
-func NewAuthRepository(queries *db.Queries) AuthRepository {
+func NewAuthRepository(queries *db.Queries, keyRing *encryption.KeyRing) AuthRepository {
 	return &authRepository{
 		queries: queries,
+		keyRing: keyRing,
 	}
 }
 
+// q returns the queries bound to the transaction in ctx, if any
+func (r *authRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
 func (r *authRepository) GetAuthByID(ctx context.Context, id string) (*db.Auth, error) {
-	auth, err := r.queries.GetAuthByID(ctx, id)
+	auth, err := r.q(ctx).GetAuthByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &auth, nil
+	return r.decryptAuth(auth)
 }
 
 func (r *authRepository) GetAuthByUsername(ctx context.Context, username string) (*db.Auth, error) {
-	auth, err := r.queries.GetAuthByUsername(ctx, &username)
+	auth, err := r.q(ctx).GetAuthByUsername(ctx, &username)
 	if err != nil {
 		return nil, err
 	}
-	return &auth, nil
+	return r.decryptAuth(auth)
 }
 
 func (r *authRepository) GetAuthByEmail(ctx context.Context, email string) (*db.Auth, error) {
-	auth, err := r.queries.GetAuthByEmail(ctx, &email)
+	auth, err := r.q(ctx).GetAuthByEmail(ctx, &email)
 	if err != nil {
 		return nil, err
 	}
-	return &auth, nil
+	return r.decryptAuth(auth)
+}
+
+func (r *authRepository) LockAuth(ctx context.Context, id string) (*db.Auth, error) {
+	auth, err := r.q(ctx).LockAuthByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAuth(auth)
 }
 
 func (r *authRepository) CreateAuth(ctx context.Context, username *string, password *string, email *string, role string, active bool) (*db.Auth, error) {
-	auth, err := r.queries.CreateAuth(ctx, username, password, email, role, active)
+	auth, err := r.q(ctx).CreateAuth(ctx, username, password, email, role, active)
 	if err != nil {
 		return nil, err
 	}
-	return &auth, nil
+	return r.decryptAuth(auth)
 }
 
 func (r *authRepository) UpdateAuth(ctx context.Context, params db.UpdateAuthParams) (*db.Auth, error) {
-	auth, err := r.queries.UpdateAuth(ctx, params)
+	auth, err := r.q(ctx).UpdateAuth(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-	return &auth, nil
+	return r.decryptAuth(auth)
+}
+
+func (r *authRepository) UpdateAuthPassword(ctx context.Context, id string, passwordHash string) error {
+	return r.q(ctx).UpdateAuthPassword(ctx, id, &passwordHash)
 }
 
 func (r *authRepository) SoftDeleteAuth(ctx context.Context, id string) error {
-	return r.queries.SoftDeleteAuth(ctx, id)
+	return r.q(ctx).SoftDeleteAuth(ctx, id)
+}
+
+func (r *authRepository) CloseAuth(ctx context.Context, id string) error {
+	q := r.q(ctx)
+	rows, err := q.CloseAuth(ctx, id)
+	if err != nil || rows == 0 {
+		return err
+	}
+
+	return q.DeleteAuthMethodsByAuthID(ctx, &id)
 }
 
 func (r *authRepository) ListAllAuths(ctx context.Context) ([]*db.Auth, error) {
-	auths, err := r.queries.ListAllAuths(ctx)
+	auths, err := r.q(ctx).ListAllAuths(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []*db.Auth
 	for _, auth := range auths {
-		authCopy := auth
-		result = append(result, &authCopy)
+		authCopy, err := r.decryptAuth(auth)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, authCopy)
 	}
 
 	return result, nil
 }
 
+func (r *authRepository) ListAuths(ctx context.Context, params db.ListAuthsParams) ([]*db.Auth, error) {
+	auths, err := r.q(ctx).ListAuths(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.Auth, 0, len(auths))
+	for _, auth := range auths {
+		authCopy, err := r.decryptAuth(db.Auth(auth))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, authCopy)
+	}
+
+	return result, nil
+}
+
+func (r *authRepository) UpdateAuthRole(ctx context.Context, id string, role string) (*db.Auth, error) {
+	auth, err := r.q(ctx).UpdateAuthRole(ctx, id, role)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAuth(auth)
+}
+
+func (r *authRepository) UpdateAuthActive(ctx context.Context, id string, active bool) (*db.Auth, error) {
+	auth, err := r.q(ctx).UpdateAuthActive(ctx, id, active)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAuth(auth)
+}
+
+func (r *authRepository) RestoreAuth(ctx context.Context, id string) (*db.Auth, error) {
+	auth, err := r.q(ctx).RestoreAuth(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAuth(auth)
+}
+
 func (r *authRepository) CreateAuthMethod(ctx context.Context, params db.CreateAuthMethodParams) (*db.AuthMethod, error) {
-	authMethod, err := r.queries.CreateAuthMethod(ctx, params)
+	var err error
+	if params.AccessToken, err = r.encrypt(params.AccessToken); err != nil {
+		return nil, err
+	}
+	if params.RefreshToken, err = r.encrypt(params.RefreshToken); err != nil {
+		return nil, err
+	}
+	if params.IDToken, err = r.encrypt(params.IDToken); err != nil {
+		return nil, err
+	}
+	if params.AccessTokenSecret, err = r.encrypt(params.AccessTokenSecret); err != nil {
+		return nil, err
+	}
+
+	authMethod, err := r.q(ctx).CreateAuthMethod(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-	return &authMethod, nil
+	return r.decryptAuthMethod(authMethod)
 }
 
 func (r *authRepository) GetAuthMethodByProviderAndID(ctx context.Context, provider string, providerID string) (*db.AuthMethod, error) {
-	authMethod, err := r.queries.GetAuthMethodByProviderAndID(ctx, provider, providerID)
+	authMethod, err := r.q(ctx).GetAuthMethodByProviderAndID(ctx, provider, providerID)
 	if err != nil {
 		return nil, err
 	}
-	return &authMethod, nil
+	return r.decryptAuthMethod(authMethod)
 }
 
 func (r *authRepository) GetAuthMethodsByAuthID(ctx context.Context, authID string) ([]*db.AuthMethod, error) {
-	authMethods, err := r.queries.GetAuthMethodsByAuthID(ctx, &authID)
+	authMethods, err := r.q(ctx).GetAuthMethodsByAuthID(ctx, &authID)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []*db.AuthMethod
 	for _, method := range authMethods {
-		methodCopy := method
-		result = append(result, &methodCopy)
+		methodCopy, err := r.decryptAuthMethod(method)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, methodCopy)
 	}
 
 	return result, nil
 }
 
 func (r *authRepository) UpdateAuthMethod(ctx context.Context, params db.UpdateAuthMethodParams) (*db.AuthMethod, error) {
-	authMethod, err := r.queries.UpdateAuthMethod(ctx, params)
+	var err error
+	if params.AccessToken, err = r.encrypt(params.AccessToken); err != nil {
+		return nil, err
+	}
+	if params.RefreshToken, err = r.encrypt(params.RefreshToken); err != nil {
+		return nil, err
+	}
+	if params.IDToken, err = r.encrypt(params.IDToken); err != nil {
+		return nil, err
+	}
+
+	authMethod, err := r.q(ctx).UpdateAuthMethod(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-	return &authMethod, nil
+	return r.decryptAuthMethod(authMethod)
 }
 
 func (r *authRepository) SoftDeleteAuthMethod(ctx context.Context, id string) error {
-	return r.queries.SoftDeleteAuthMethod(ctx, id)
+	return r.q(ctx).SoftDeleteAuthMethod(ctx, id)
+}
+
+func (r *authRepository) ReencryptAuthMethods(ctx context.Context, afterID string, limit int) (string, int, error) {
+	authMethods, err := r.q(ctx).ListAuthMethodsToReencrypt(ctx, afterID, r.keyRing.ActivePrefix(), int32(limit))
+	if err != nil {
+		return "", 0, err
+	}
+
+	lastID := ""
+	reencrypted := 0
+	for _, method := range authMethods {
+		lastID = method.ID
+
+		// Tokens that cannot be decrypted, e.g. because their key was removed from the key ring,
+		// are left as they are rather than blocking the others
+		decrypted, err := r.decryptAuthMethod(method)
+		if err != nil {
+			logger.Warnf("Failed to re-encrypt the tokens of auth method %s: %v", method.ID, err)
+			continue
+		}
+
+		params := db.UpdateAuthMethodTokensParams{ID: method.ID, UpdatedAt: method.UpdatedAt}
+		if params.AccessToken, err = r.encrypt(decrypted.AccessToken); err != nil {
+			return "", reencrypted, err
+		}
+		if params.RefreshToken, err = r.encrypt(decrypted.RefreshToken); err != nil {
+			return "", reencrypted, err
+		}
+		if params.IDToken, err = r.encrypt(decrypted.IDToken); err != nil {
+			return "", reencrypted, err
+		}
+		if params.AccessTokenSecret, err = r.encrypt(decrypted.AccessTokenSecret); err != nil {
+			return "", reencrypted, err
+		}
+
+		// No row is updated when the tokens were replaced meanwhile, with the active key anyway
+		updated, err := r.q(ctx).UpdateAuthMethodTokens(ctx, params)
+		if err != nil {
+			return "", reencrypted, err
+		}
+		reencrypted += int(updated)
+	}
+
+	return lastID, reencrypted, nil
+}
+
+// encrypt returns token encrypted with the active key, nil stays nil
+func (r *authRepository) encrypt(token *string) (*string, error) {
+	if token == nil {
+		return nil, nil
+	}
+	encrypted, err := r.keyRing.Encrypt(*token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt token: %w", err)
+	}
+	return &encrypted, nil
+}
+
+// decrypt returns the plaintext of token, which may have been stored before encryption was enabled
+func (r *authRepository) decrypt(token *string) (*string, error) {
+	if token == nil {
+		return nil, nil
+	}
+	decrypted, err := r.keyRing.Decrypt(*token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token: %w", err)
+	}
+	return &decrypted, nil
+}
+
+// decryptAuth returns a copy of auth with a plaintext MFA secret
+func (r *authRepository) decryptAuth(auth db.Auth) (*db.Auth, error) {
+	if auth.MFASecret != nil {
+		secret, err := r.keyRing.Decrypt(*auth.MFASecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt mfa secret: %w", err)
+		}
+		auth.MFASecret = &secret
+	}
+	return &auth, nil
+}
+
+// decryptAuthMethod returns a copy of authMethod with plaintext tokens
+func (r *authRepository) decryptAuthMethod(authMethod db.AuthMethod) (*db.AuthMethod, error) {
+	var err error
+	if authMethod.AccessToken, err = r.decrypt(authMethod.AccessToken); err != nil {
+		return nil, err
+	}
+	if authMethod.RefreshToken, err = r.decrypt(authMethod.RefreshToken); err != nil {
+		return nil, err
+	}
+	if authMethod.IDToken, err = r.decrypt(authMethod.IDToken); err != nil {
+		return nil, err
+	}
+	if authMethod.AccessTokenSecret, err = r.decrypt(authMethod.AccessTokenSecret); err != nil {
+		return nil, err
+	}
+	return &authMethod, nil
 }
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LoginAttemptRepository counts failed logins per key for the login throttle. The Postgres
+// implementation shares the counts between replicas, the in-memory one keeps them per process.
+type LoginAttemptRepository interface {
+	// GetLoginAttempt returns pgx.ErrNoRows when key has no failures
+	GetLoginAttempt(ctx context.Context, key string) (*db.LoginAttempt, error)
+	// RecordLoginFailure counts a failure of key, starting over when its last failure is before
+	// windowStart
+	RecordLoginFailure(ctx context.Context, key string, windowStart time.Time) (*db.LoginAttempt, error)
+	// ReserveLoginAttempt counts an attempt of key as failed before it is made and blocks key
+	// for delays[failures-1], the last delay past the end of delays, in one atomic step. It
+	// returns pgx.ErrNoRows when key is blocked, counting nothing.
+	ReserveLoginAttempt(ctx context.Context, key string, windowStart time.Time, delays []time.Duration) (*db.LoginAttempt, error)
+	// ReleaseLoginAttempt takes back an attempt reserved for key that did not fail, and its block
+	ReleaseLoginAttempt(ctx context.Context, key string) error
+	// BlockLoginAttempts blocks key until blockedUntil
+	BlockLoginAttempts(ctx context.Context, key string, blockedUntil time.Time) error
+	DeleteLoginAttempts(ctx context.Context, key string) error
+	// DeleteExpiredLoginAttempts deletes the keys whose last failure is before before and that
+	// are no longer blocked
+	DeleteExpiredLoginAttempts(ctx context.Context, before time.Time) (int64, error)
+}
+
+type loginAttemptRepository struct {
+	queries *db.Queries
+}
+
+func NewLoginAttemptRepository(queries *db.Queries) LoginAttemptRepository {
+	return &loginAttemptRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *loginAttemptRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *loginAttemptRepository) GetLoginAttempt(ctx context.Context, key string) (*db.LoginAttempt, error) {
+	attempt, err := r.q(ctx).GetLoginAttempt(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) RecordLoginFailure(ctx context.Context, key string, windowStart time.Time) (*db.LoginAttempt, error) {
+	attempt, err := r.q(ctx).RecordLoginFailure(ctx, key, pgtype.Timestamptz{Time: windowStart, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) ReserveLoginAttempt(ctx context.Context, key string, windowStart time.Time, delays []time.Duration) (*db.LoginAttempt, error) {
+	delaysMs := make([]int64, 0, len(delays))
+	for _, delay := range delays {
+		delaysMs = append(delaysMs, delay.Milliseconds())
+	}
+
+	attempt, err := r.q(ctx).ReserveLoginAttempt(ctx, key, delaysMs, pgtype.Timestamptz{Time: windowStart, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) ReleaseLoginAttempt(ctx context.Context, key string) error {
+	return r.q(ctx).ReleaseLoginAttempt(ctx, key)
+}
+
+func (r *loginAttemptRepository) BlockLoginAttempts(ctx context.Context, key string, blockedUntil time.Time) error {
+	return r.q(ctx).BlockLoginAttempts(ctx, key, pgtype.Timestamptz{Time: blockedUntil, Valid: true})
+}
+
+func (r *loginAttemptRepository) DeleteLoginAttempts(ctx context.Context, key string) error {
+	return r.q(ctx).DeleteLoginAttempts(ctx, key)
+}
+
+func (r *loginAttemptRepository) DeleteExpiredLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	return r.q(ctx).DeleteExpiredLoginAttempts(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
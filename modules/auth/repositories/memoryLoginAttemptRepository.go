@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// memoryLoginAttemptRepository keeps the login attempts in process memory, for single instance
+// deployments. It behaves like the login_attempts table.
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]db.LoginAttempt
+	now      func() time.Time
+}
+
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{
+		attempts: make(map[string]db.LoginAttempt),
+		now:      time.Now,
+	}
+}
+
+func (r *memoryLoginAttemptRepository) GetLoginAttempt(ctx context.Context, key string) (*db.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &attempt, nil
+}
+
+func (r *memoryLoginAttemptRepository) RecordLoginFailure(ctx context.Context, key string, windowStart time.Time) (*db.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = db.LoginAttempt{Key: key}
+	}
+	if attempt.LastFailureAt.Time.Before(windowStart) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = pgtype.Timestamptz{Time: r.now(), Valid: true}
+
+	r.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (r *memoryLoginAttemptRepository) ReserveLoginAttempt(ctx context.Context, key string, windowStart time.Time, delays []time.Duration) (*db.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = db.LoginAttempt{Key: key}
+	}
+	if attempt.BlockedUntil.Valid && attempt.BlockedUntil.Time.After(now) {
+		return nil, pgx.ErrNoRows
+	}
+	if attempt.LastFailureAt.Time.Before(windowStart) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = pgtype.Timestamptz{Time: now, Valid: true}
+	delay := delays[min(int(attempt.Failures), len(delays))-1]
+	attempt.BlockedUntil = pgtype.Timestamptz{Time: now.Add(delay), Valid: true}
+
+	r.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (r *memoryLoginAttemptRepository) ReleaseLoginAttempt(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.Failures = max(attempt.Failures-1, 0)
+		attempt.BlockedUntil = pgtype.Timestamptz{}
+		r.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) BlockLoginAttempts(ctx context.Context, key string, blockedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.BlockedUntil = pgtype.Timestamptz{Time: blockedUntil, Valid: true}
+		r.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) DeleteLoginAttempts(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) DeleteExpiredLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	var deleted int64
+	for key, attempt := range r.attempts {
+		if attempt.LastFailureAt.Time.Before(before) && (!attempt.BlockedUntil.Valid || attempt.BlockedUntil.Time.Before(now)) {
+			delete(r.attempts, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
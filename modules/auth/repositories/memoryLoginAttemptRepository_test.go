@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemoryLoginAttemptRepository returns a repository with a clock the test controls
+func newTestMemoryLoginAttemptRepository() (*memoryLoginAttemptRepository, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewMemoryLoginAttemptRepository().(*memoryLoginAttemptRepository)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestMemoryLoginAttemptRepository_RecordLoginFailure(t *testing.T) {
+	r, now := newTestMemoryLoginAttemptRepository()
+	ctx := context.Background()
+
+	_, err := r.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	for want := int32(1); want <= 3; want++ {
+		attempt, err := r.RecordLoginFailure(ctx, "ip:1.2.3.4", now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, want, attempt.Failures)
+	}
+
+	// The count starts over once the last failure is out of the window
+	*now = now.Add(2 * time.Minute)
+	attempt, err := r.RecordLoginFailure(ctx, "ip:1.2.3.4", now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), attempt.Failures)
+	assert.Equal(t, *now, attempt.LastFailureAt.Time)
+}
+
+func TestMemoryLoginAttemptRepository_BlockAndDelete(t *testing.T) {
+	r, now := newTestMemoryLoginAttemptRepository()
+	ctx := context.Background()
+
+	// Keys without failures are not blocked
+	require.NoError(t, r.BlockLoginAttempts(ctx, "ip:unknown", now.Add(time.Hour)))
+	_, err := r.GetLoginAttempt(ctx, "ip:unknown")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = r.RecordLoginFailure(ctx, "ip:1.2.3.4", now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, r.BlockLoginAttempts(ctx, "ip:1.2.3.4", now.Add(time.Hour)))
+
+	attempt, err := r.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), attempt.BlockedUntil.Time)
+
+	require.NoError(t, r.DeleteLoginAttempts(ctx, "ip:1.2.3.4"))
+	_, err = r.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestMemoryLoginAttemptRepository_DeleteExpired(t *testing.T) {
+	r, now := newTestMemoryLoginAttemptRepository()
+	ctx := context.Background()
+
+	for _, key := range []string{"ip:old", "ip:blocked"} {
+		_, err := r.RecordLoginFailure(ctx, key, now.Add(-time.Minute))
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.BlockLoginAttempts(ctx, "ip:blocked", now.Add(time.Hour)))
+
+	*now = now.Add(10 * time.Minute)
+	_, err := r.RecordLoginFailure(ctx, "ip:recent", now.Add(-time.Minute))
+	require.NoError(t, err)
+
+	deleted, err := r.DeleteExpiredLoginAttempts(ctx, now.Add(-time.Minute))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	_, err = r.GetLoginAttempt(ctx, "ip:old")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = r.GetLoginAttempt(ctx, "ip:blocked")
+	assert.NoError(t, err)
+	_, err = r.GetLoginAttempt(ctx, "ip:recent")
+	assert.NoError(t, err)
+}
+
+func TestMemoryLoginAttemptRepository_ReserveLoginAttempt(t *testing.T) {
+	r, now := newTestMemoryLoginAttemptRepository()
+	ctx := context.Background()
+	delays := []time.Duration{0, 0, time.Second, time.Minute}
+
+	for range 2 {
+		attempt, err := r.ReserveLoginAttempt(ctx, "ip:1.2.3.4", now.Add(-time.Hour), delays)
+		require.NoError(t, err)
+		assert.Equal(t, *now, attempt.BlockedUntil.Time)
+	}
+
+	// The third attempt blocks the key as if it failed, turning the next one away uncounted
+	attempt, err := r.ReserveLoginAttempt(ctx, "ip:1.2.3.4", now.Add(-time.Hour), delays)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), attempt.Failures)
+	assert.Equal(t, now.Add(time.Second), attempt.BlockedUntil.Time)
+
+	_, err = r.ReserveLoginAttempt(ctx, "ip:1.2.3.4", now.Add(-time.Hour), delays)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// Releasing the attempt takes back its failure and its block
+	require.NoError(t, r.ReleaseLoginAttempt(ctx, "ip:1.2.3.4"))
+	attempt, err = r.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempt.Failures)
+	assert.False(t, attempt.BlockedUntil.Valid)
+
+	// Past the end of delays, the last delay applies
+	for range 3 {
+		*now = now.Add(time.Hour - time.Second)
+		_, err = r.ReserveLoginAttempt(ctx, "ip:1.2.3.4", now.Add(-2*time.Hour), delays)
+		require.NoError(t, err)
+	}
+	attempt, err = r.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), attempt.Failures)
+	assert.Equal(t, now.Add(time.Minute), attempt.BlockedUntil.Time)
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/pkg/encryption"
+	"template-golang/pkg/logger"
+)
+
+type MFARepository interface {
+	// SetMFASecret starts an enrolment of authID, returning pgx.ErrNoRows when MFA is already enabled
+	SetMFASecret(ctx context.Context, authID string, secret string) (*db.Auth, error)
+	// EnableMFA confirms the enrolment of authID, reporting false when there is none to confirm
+	EnableMFA(ctx context.Context, authID string, step int64) (bool, error)
+	// UseMFAStep records step as used, reporting false when it is not later than the last used step
+	UseMFAStep(ctx context.Context, authID string, step int64) (bool, error)
+	DisableMFA(ctx context.Context, authID string) error
+	// ReplaceMFARecoveryCodes replaces the recovery codes of authID with codeHashes
+	ReplaceMFARecoveryCodes(ctx context.Context, authID string, codeHashes []string) error
+	// UseMFARecoveryCode marks the code used, reporting false when it is unknown or already used
+	UseMFARecoveryCode(ctx context.Context, authID string, codeHash string) (bool, error)
+	DeleteMFARecoveryCodes(ctx context.Context, authID string) error
+	// ReencryptMFASecrets encrypts with the active key the MFA secrets of up to limit auths after
+	// afterID that are stored in plaintext or with another key. It returns the ID of the last
+	// auth it went through, empty when none is left, and how many it re-encrypted.
+	ReencryptMFASecrets(ctx context.Context, afterID string, limit int) (string, int, error)
+}
+
+// mfaRepository encrypts the MFA secrets of auths with keyRing when writing them, the auth
+// repository decrypts them when reading auths
+type mfaRepository struct {
+	queries *db.Queries
+	keyRing *encryption.KeyRing
+}
+
+func NewMFARepository(queries *db.Queries, keyRing *encryption.KeyRing) MFARepository {
+	return &mfaRepository{
+		queries: queries,
+		keyRing: keyRing,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *mfaRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *mfaRepository) SetMFASecret(ctx context.Context, authID string, secret string) (*db.Auth, error) {
+	encrypted, err := r.keyRing.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt mfa secret: %w", err)
+	}
+
+	auth, err := r.q(ctx).SetMFASecret(ctx, authID, &encrypted)
+	if err != nil {
+		return nil, err
+	}
+	auth.MFASecret = &secret
+	return &auth, nil
+}
+
+func (r *mfaRepository) EnableMFA(ctx context.Context, authID string, step int64) (bool, error) {
+	rows, err := r.q(ctx).EnableMFA(ctx, authID, &step)
+	return rows > 0, err
+}
+
+func (r *mfaRepository) UseMFAStep(ctx context.Context, authID string, step int64) (bool, error) {
+	rows, err := r.q(ctx).UseMFAStep(ctx, authID, &step)
+	return rows > 0, err
+}
+
+func (r *mfaRepository) DisableMFA(ctx context.Context, authID string) error {
+	return r.q(ctx).DisableMFA(ctx, authID)
+}
+
+func (r *mfaRepository) ReplaceMFARecoveryCodes(ctx context.Context, authID string, codeHashes []string) error {
+	if err := r.q(ctx).DeleteMFARecoveryCodes(ctx, authID); err != nil {
+		return err
+	}
+	for _, codeHash := range codeHashes {
+		if err := r.q(ctx).CreateMFARecoveryCode(ctx, authID, codeHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mfaRepository) UseMFARecoveryCode(ctx context.Context, authID string, codeHash string) (bool, error) {
+	rows, err := r.q(ctx).UseMFARecoveryCode(ctx, authID, codeHash)
+	return rows > 0, err
+}
+
+func (r *mfaRepository) DeleteMFARecoveryCodes(ctx context.Context, authID string) error {
+	return r.q(ctx).DeleteMFARecoveryCodes(ctx, authID)
+}
+
+func (r *mfaRepository) ReencryptMFASecrets(ctx context.Context, afterID string, limit int) (string, int, error) {
+	auths, err := r.q(ctx).ListMFASecretsToReencrypt(ctx, afterID, r.keyRing.ActivePrefix(), int32(limit))
+	if err != nil {
+		return "", 0, err
+	}
+
+	lastID := ""
+	reencrypted := 0
+	for _, auth := range auths {
+		lastID = auth.ID
+
+		// Secrets that cannot be decrypted are left as they are rather than blocking the others
+		secret, err := r.keyRing.Decrypt(*auth.MFASecret)
+		if err != nil {
+			logger.Warnf("Failed to re-encrypt the mfa secret of auth %s: %v", auth.ID, err)
+			continue
+		}
+		encrypted, err := r.keyRing.Encrypt(secret)
+		if err != nil {
+			return "", reencrypted, fmt.Errorf("failed to encrypt mfa secret: %w", err)
+		}
+
+		// No row is updated when the secret was replaced meanwhile, with the active key anyway
+		updated, err := r.q(ctx).UpdateMFASecret(ctx, &encrypted, auth.ID, auth.UpdatedAt)
+		if err != nil {
+			return "", reencrypted, err
+		}
+		reencrypted += int(updated)
+	}
+
+	return lastID, reencrypted, nil
+}
@@ -38,6 +38,63 @@ func (_m *MockAuthRepository) EXPECT() *MockAuthRepository_Expecter {
 	return &MockAuthRepository_Expecter{mock: &_m.Mock}
 }
 
+// CloseAuth provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) CloseAuth(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseAuth")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_CloseAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAuth'
+type MockAuthRepository_CloseAuth_Call struct {
+	*mock.Call
+}
+
+// CloseAuth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAuthRepository_Expecter) CloseAuth(ctx interface{}, id interface{}) *MockAuthRepository_CloseAuth_Call {
+	return &MockAuthRepository_CloseAuth_Call{Call: _e.mock.On("CloseAuth", ctx, id)}
+}
+
+func (_c *MockAuthRepository_CloseAuth_Call) Run(run func(ctx context.Context, id string)) *MockAuthRepository_CloseAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_CloseAuth_Call) Return(err error) *MockAuthRepository_CloseAuth_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_CloseAuth_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockAuthRepository_CloseAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAuth provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) CreateAuth(ctx context.Context, username *string, password *string, email *string, role string, active bool) (*db.Auth, error) {
 	ret := _mock.Called(ctx, username, password, email, role, active)
@@ -606,6 +663,288 @@ func (_c *MockAuthRepository_ListAllAuths_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// ListAuths provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) ListAuths(ctx context.Context, params db.ListAuthsParams) ([]*db.Auth, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuths")
+	}
+
+	var r0 []*db.Auth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ListAuthsParams) ([]*db.Auth, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ListAuthsParams) []*db.Auth); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.Auth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ListAuthsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_ListAuths_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuths'
+type MockAuthRepository_ListAuths_Call struct {
+	*mock.Call
+}
+
+// ListAuths is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params db.ListAuthsParams
+func (_e *MockAuthRepository_Expecter) ListAuths(ctx interface{}, params interface{}) *MockAuthRepository_ListAuths_Call {
+	return &MockAuthRepository_ListAuths_Call{Call: _e.mock.On("ListAuths", ctx, params)}
+}
+
+func (_c *MockAuthRepository_ListAuths_Call) Run(run func(ctx context.Context, params db.ListAuthsParams)) *MockAuthRepository_ListAuths_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.ListAuthsParams
+		if args[1] != nil {
+			arg1 = args[1].(db.ListAuthsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_ListAuths_Call) Return(auths []*db.Auth, err error) *MockAuthRepository_ListAuths_Call {
+	_c.Call.Return(auths, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_ListAuths_Call) RunAndReturn(run func(ctx context.Context, params db.ListAuthsParams) ([]*db.Auth, error)) *MockAuthRepository_ListAuths_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockAuth provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) LockAuth(ctx context.Context, id string) (*db.Auth, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockAuth")
+	}
+
+	var r0 *db.Auth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*db.Auth, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *db.Auth); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Auth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_LockAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockAuth'
+type MockAuthRepository_LockAuth_Call struct {
+	*mock.Call
+}
+
+// LockAuth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAuthRepository_Expecter) LockAuth(ctx interface{}, id interface{}) *MockAuthRepository_LockAuth_Call {
+	return &MockAuthRepository_LockAuth_Call{Call: _e.mock.On("LockAuth", ctx, id)}
+}
+
+func (_c *MockAuthRepository_LockAuth_Call) Run(run func(ctx context.Context, id string)) *MockAuthRepository_LockAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_LockAuth_Call) Return(auth *db.Auth, err error) *MockAuthRepository_LockAuth_Call {
+	_c.Call.Return(auth, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_LockAuth_Call) RunAndReturn(run func(ctx context.Context, id string) (*db.Auth, error)) *MockAuthRepository_LockAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReencryptAuthMethods provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) ReencryptAuthMethods(ctx context.Context, afterID string, limit int) (string, int, error) {
+	ret := _mock.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReencryptAuthMethods")
+	}
+
+	var r0 string
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (string, int, error)); ok {
+		return returnFunc(ctx, afterID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) string); ok {
+		r0 = returnFunc(ctx, afterID, limit)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) int); ok {
+		r1 = returnFunc(ctx, afterID, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = returnFunc(ctx, afterID, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAuthRepository_ReencryptAuthMethods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReencryptAuthMethods'
+type MockAuthRepository_ReencryptAuthMethods_Call struct {
+	*mock.Call
+}
+
+// ReencryptAuthMethods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID string
+//   - limit int
+func (_e *MockAuthRepository_Expecter) ReencryptAuthMethods(ctx interface{}, afterID interface{}, limit interface{}) *MockAuthRepository_ReencryptAuthMethods_Call {
+	return &MockAuthRepository_ReencryptAuthMethods_Call{Call: _e.mock.On("ReencryptAuthMethods", ctx, afterID, limit)}
+}
+
+func (_c *MockAuthRepository_ReencryptAuthMethods_Call) Run(run func(ctx context.Context, afterID string, limit int)) *MockAuthRepository_ReencryptAuthMethods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_ReencryptAuthMethods_Call) Return(s string, n int, err error) *MockAuthRepository_ReencryptAuthMethods_Call {
+	_c.Call.Return(s, n, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_ReencryptAuthMethods_Call) RunAndReturn(run func(ctx context.Context, afterID string, limit int) (string, int, error)) *MockAuthRepository_ReencryptAuthMethods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreAuth provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) RestoreAuth(ctx context.Context, id string) (*db.Auth, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAuth")
+	}
+
+	var r0 *db.Auth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*db.Auth, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *db.Auth); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Auth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_RestoreAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAuth'
+type MockAuthRepository_RestoreAuth_Call struct {
+	*mock.Call
+}
+
+// RestoreAuth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAuthRepository_Expecter) RestoreAuth(ctx interface{}, id interface{}) *MockAuthRepository_RestoreAuth_Call {
+	return &MockAuthRepository_RestoreAuth_Call{Call: _e.mock.On("RestoreAuth", ctx, id)}
+}
+
+func (_c *MockAuthRepository_RestoreAuth_Call) Run(run func(ctx context.Context, id string)) *MockAuthRepository_RestoreAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_RestoreAuth_Call) Return(auth *db.Auth, err error) *MockAuthRepository_RestoreAuth_Call {
+	_c.Call.Return(auth, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_RestoreAuth_Call) RunAndReturn(run func(ctx context.Context, id string) (*db.Auth, error)) *MockAuthRepository_RestoreAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SoftDeleteAuth provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) SoftDeleteAuth(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -788,6 +1127,80 @@ func (_c *MockAuthRepository_UpdateAuth_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// UpdateAuthActive provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) UpdateAuthActive(ctx context.Context, id string, active bool) (*db.Auth, error) {
+	ret := _mock.Called(ctx, id, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthActive")
+	}
+
+	var r0 *db.Auth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (*db.Auth, error)); ok {
+		return returnFunc(ctx, id, active)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) *db.Auth); ok {
+		r0 = returnFunc(ctx, id, active)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Auth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, id, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_UpdateAuthActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthActive'
+type MockAuthRepository_UpdateAuthActive_Call struct {
+	*mock.Call
+}
+
+// UpdateAuthActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - active bool
+func (_e *MockAuthRepository_Expecter) UpdateAuthActive(ctx interface{}, id interface{}, active interface{}) *MockAuthRepository_UpdateAuthActive_Call {
+	return &MockAuthRepository_UpdateAuthActive_Call{Call: _e.mock.On("UpdateAuthActive", ctx, id, active)}
+}
+
+func (_c *MockAuthRepository_UpdateAuthActive_Call) Run(run func(ctx context.Context, id string, active bool)) *MockAuthRepository_UpdateAuthActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdateAuthActive_Call) Return(auth *db.Auth, err error) *MockAuthRepository_UpdateAuthActive_Call {
+	_c.Call.Return(auth, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdateAuthActive_Call) RunAndReturn(run func(ctx context.Context, id string, active bool) (*db.Auth, error)) *MockAuthRepository_UpdateAuthActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateAuthMethod provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) UpdateAuthMethod(ctx context.Context, params db.UpdateAuthMethodParams) (*db.AuthMethod, error) {
 	ret := _mock.Called(ctx, params)
@@ -855,3 +1268,140 @@ func (_c *MockAuthRepository_UpdateAuthMethod_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateAuthPassword provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) UpdateAuthPassword(ctx context.Context, id string, passwordHash string) error {
+	ret := _mock.Called(ctx, id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_UpdateAuthPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthPassword'
+type MockAuthRepository_UpdateAuthPassword_Call struct {
+	*mock.Call
+}
+
+// UpdateAuthPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - passwordHash string
+func (_e *MockAuthRepository_Expecter) UpdateAuthPassword(ctx interface{}, id interface{}, passwordHash interface{}) *MockAuthRepository_UpdateAuthPassword_Call {
+	return &MockAuthRepository_UpdateAuthPassword_Call{Call: _e.mock.On("UpdateAuthPassword", ctx, id, passwordHash)}
+}
+
+func (_c *MockAuthRepository_UpdateAuthPassword_Call) Run(run func(ctx context.Context, id string, passwordHash string)) *MockAuthRepository_UpdateAuthPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdateAuthPassword_Call) Return(err error) *MockAuthRepository_UpdateAuthPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdateAuthPassword_Call) RunAndReturn(run func(ctx context.Context, id string, passwordHash string) error) *MockAuthRepository_UpdateAuthPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAuthRole provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) UpdateAuthRole(ctx context.Context, id string, role string) (*db.Auth, error) {
+	ret := _mock.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthRole")
+	}
+
+	var r0 *db.Auth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*db.Auth, error)); ok {
+		return returnFunc(ctx, id, role)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *db.Auth); ok {
+		r0 = returnFunc(ctx, id, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Auth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_UpdateAuthRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthRole'
+type MockAuthRepository_UpdateAuthRole_Call struct {
+	*mock.Call
+}
+
+// UpdateAuthRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - role string
+func (_e *MockAuthRepository_Expecter) UpdateAuthRole(ctx interface{}, id interface{}, role interface{}) *MockAuthRepository_UpdateAuthRole_Call {
+	return &MockAuthRepository_UpdateAuthRole_Call{Call: _e.mock.On("UpdateAuthRole", ctx, id, role)}
+}
+
+func (_c *MockAuthRepository_UpdateAuthRole_Call) Run(run func(ctx context.Context, id string, role string)) *MockAuthRepository_UpdateAuthRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdateAuthRole_Call) Return(auth *db.Auth, err error) *MockAuthRepository_UpdateAuthRole_Call {
+	_c.Call.Return(auth, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdateAuthRole_Call) RunAndReturn(run func(ctx context.Context, id string, role string) (*db.Auth, error)) *MockAuthRepository_UpdateAuthRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
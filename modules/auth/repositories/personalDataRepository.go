@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+)
+
+// PersonalDataRepository reads and erases everything the auth module stores about an auth,
+// soft-deleted rows included
+type PersonalDataRepository interface {
+	// GetAuth returns the auth, soft-deleted or not
+	GetAuth(ctx context.Context, id string) (*db.Auth, error)
+	// ListAuthMethods returns every auth method of authID, unlinked ones included. Their tokens
+	// are left as stored.
+	ListAuthMethods(ctx context.Context, authID string) ([]*db.AuthMethod, error)
+	ListAPIKeys(ctx context.Context, authID string) ([]*db.APIKey, error)
+	ListRefreshTokens(ctx context.Context, authID string) ([]*db.RefreshToken, error)
+	// ListAuditEvents returns the audit events done by or to authID, oldest first
+	ListAuditEvents(ctx context.Context, authID string) ([]*db.AuditEvent, error)
+	// DeleteAuth deletes the auth and every row referencing it, reporting false when there was
+	// no auth to delete
+	DeleteAuth(ctx context.Context, id string) (bool, error)
+	// AnonymizeAuth strips the auth of its username, email and credentials and deletes its auth
+	// methods, tokens, API keys, auth codes and recovery codes. The auth row stays, deleted and
+	// deactivated. It reports false when there was no auth to anonymize.
+	AnonymizeAuth(ctx context.Context, id string) (bool, error)
+	// AnonymizeAuditEvents removes the identifiers of authID from its audit events, see the
+	// AnonymizeAuditEvents query
+	AnonymizeAuditEvents(ctx context.Context, authID string, identifiers []string) (int64, error)
+}
+
+type personalDataRepository struct {
+	queries *db.Queries
+}
+
+func NewPersonalDataRepository(queries *db.Queries) PersonalDataRepository {
+	return &personalDataRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *personalDataRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *personalDataRepository) GetAuth(ctx context.Context, id string) (*db.Auth, error) {
+	auth, err := r.q(ctx).GetAnyAuthByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+func (r *personalDataRepository) ListAuthMethods(ctx context.Context, authID string) ([]*db.AuthMethod, error) {
+	methods, err := r.q(ctx).ListAllAuthMethodsByAuthID(ctx, &authID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.AuthMethod, 0, len(methods))
+	for _, method := range methods {
+		methodCopy := method
+		result = append(result, &methodCopy)
+	}
+
+	return result, nil
+}
+
+func (r *personalDataRepository) ListAPIKeys(ctx context.Context, authID string) ([]*db.APIKey, error) {
+	keys, err := r.q(ctx).ListAPIKeys(ctx, &authID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.APIKey, 0, len(keys))
+	for _, key := range keys {
+		keyCopy := key
+		result = append(result, &keyCopy)
+	}
+
+	return result, nil
+}
+
+func (r *personalDataRepository) ListRefreshTokens(ctx context.Context, authID string) ([]*db.RefreshToken, error) {
+	tokens, err := r.q(ctx).ListRefreshTokensByAuthID(ctx, authID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.RefreshToken, 0, len(tokens))
+	for _, token := range tokens {
+		tokenCopy := token
+		result = append(result, &tokenCopy)
+	}
+
+	return result, nil
+}
+
+func (r *personalDataRepository) ListAuditEvents(ctx context.Context, authID string) ([]*db.AuditEvent, error) {
+	events, err := r.q(ctx).ListAuditEventsBySubject(ctx, authID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.AuditEvent, 0, len(events))
+	for _, event := range events {
+		eventCopy := event
+		result = append(result, &eventCopy)
+	}
+
+	return result, nil
+}
+
+func (r *personalDataRepository) DeleteAuth(ctx context.Context, id string) (bool, error) {
+	rows, err := r.q(ctx).DeleteAuth(ctx, id)
+	return rows > 0, err
+}
+
+// AnonymizeAuth runs several statements, callers run it in a transaction
+func (r *personalDataRepository) AnonymizeAuth(ctx context.Context, id string) (bool, error) {
+	q := r.q(ctx)
+	rows, err := q.AnonymizeAuth(ctx, id)
+	if err != nil || rows == 0 {
+		return false, err
+	}
+
+	if err := q.DeleteAuthMethodsByAuthID(ctx, &id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteRefreshTokensByAuthID(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteAPIKeysByAuthID(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteAuthCodesByAuthID(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteMFARecoveryCodes(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *personalDataRepository) AnonymizeAuditEvents(ctx context.Context, authID string, identifiers []string) (int64, error) {
+	return r.q(ctx).AnonymizeAuditEvents(ctx, authID, identifiers)
+}
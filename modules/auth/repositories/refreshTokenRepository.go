@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, authID string, familyID string, tokenHash string, expiresAt time.Time) (*db.RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*db.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string) (*db.RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeRefreshTokensByAuthID(ctx context.Context, authID string) error
+}
+
+type refreshTokenRepository struct {
+	queries *db.Queries
+}
+
+func NewRefreshTokenRepository(queries *db.Queries) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *refreshTokenRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, authID string, familyID string, tokenHash string, expiresAt time.Time) (*db.RefreshToken, error) {
+	token, err := r.q(ctx).CreateRefreshToken(ctx, authID, familyID, tokenHash, pgtype.Timestamptz{Time: expiresAt, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*db.RefreshToken, error) {
+	token, err := r.q(ctx).GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed returns pgx.ErrNoRows when the token was already used or revoked
+func (r *refreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string) (*db.RefreshToken, error) {
+	token, err := r.q(ctx).MarkRefreshTokenUsed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return r.q(ctx).RevokeRefreshTokenFamily(ctx, familyID)
+}
+
+func (r *refreshTokenRepository) RevokeRefreshTokensByAuthID(ctx context.Context, authID string) error {
+	return r.q(ctx).RevokeRefreshTokensByAuthID(ctx, authID)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type RevokedTokenRepository interface {
+	RevokeToken(ctx context.Context, jti string, authID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+	// RevokeAuthTokens revokes every access token issued to authID so far and returns the cutoff
+	RevokeAuthTokens(ctx context.Context, authID string) (time.Time, error)
+	// GetAuthTokensRevokedAt returns the cutoff set by RevokeAuthTokens, or nil if there is none
+	GetAuthTokensRevokedAt(ctx context.Context, authID string) (*time.Time, error)
+}
+
+type revokedTokenRepository struct {
+	queries *db.Queries
+}
+
+func NewRevokedTokenRepository(queries *db.Queries) RevokedTokenRepository {
+	return &revokedTokenRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *revokedTokenRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *revokedTokenRepository) RevokeToken(ctx context.Context, jti string, authID string, expiresAt time.Time) error {
+	return r.q(ctx).RevokeToken(ctx, jti, authID, pgtype.Timestamptz{Time: expiresAt, Valid: true})
+}
+
+func (r *revokedTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return r.q(ctx).IsTokenRevoked(ctx, jti)
+}
+
+func (r *revokedTokenRepository) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	return r.q(ctx).DeleteExpiredRevokedTokens(ctx)
+}
+
+func (r *revokedTokenRepository) RevokeAuthTokens(ctx context.Context, authID string) (time.Time, error) {
+	revokedAt, err := r.q(ctx).RevokeAuthTokens(ctx, authID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return revokedAt.Time, nil
+}
+
+func (r *revokedTokenRepository) GetAuthTokensRevokedAt(ctx context.Context, authID string) (*time.Time, error) {
+	revokedAt, err := r.q(ctx).GetAuthTokensRevokedAt(ctx, authID)
+	if err != nil {
+		return nil, err
+	}
+	if !revokedAt.Valid {
+		return nil, nil
+	}
+	return &revokedAt.Time, nil
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+)
+
+type RoleRepository interface {
+	// ListRolePermissions returns the permissions of every role
+	ListRolePermissions(ctx context.Context) ([]*db.RolePermission, error)
+}
+
+type roleRepository struct {
+	queries *db.Queries
+}
+
+func NewRoleRepository(queries *db.Queries) RoleRepository {
+	return &roleRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *roleRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *roleRepository) ListRolePermissions(ctx context.Context) ([]*db.RolePermission, error) {
+	rows, err := r.q(ctx).ListRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.RolePermission, 0, len(rows))
+	for _, row := range rows {
+		rowCopy := row
+		result = append(result, &rowCopy)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SessionRepository interface {
+	// GetSession returns pgx.ErrNoRows when the session is unknown or expired
+	GetSession(ctx context.Context, id string) (*db.Session, error)
+	// SaveSession creates the session or replaces its data and expiry
+	SaveSession(ctx context.Context, id string, data []byte, expiresAt time.Time) error
+	DeleteSession(ctx context.Context, id string) error
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+}
+
+type sessionRepository struct {
+	queries *db.Queries
+}
+
+func NewSessionRepository(queries *db.Queries) SessionRepository {
+	return &sessionRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *sessionRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *sessionRepository) GetSession(ctx context.Context, id string) (*db.Session, error) {
+	session, err := r.q(ctx).GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) SaveSession(ctx context.Context, id string, data []byte, expiresAt time.Time) error {
+	return r.q(ctx).SaveSession(ctx, id, data, pgtype.Timestamptz{Time: expiresAt, Valid: true})
+}
+
+func (r *sessionRepository) DeleteSession(ctx context.Context, id string) error {
+	return r.q(ctx).DeleteSession(ctx, id)
+}
+
+func (r *sessionRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	return r.q(ctx).DeleteExpiredSessions(ctx)
+}
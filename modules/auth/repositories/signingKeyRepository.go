@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/pkg/encryption"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SigningKeyRepository interface {
+	// CreateSigningKey stores the PEM of a private key encrypted
+	CreateSigningKey(ctx context.Context, kid string, algorithm string, privateKeyPEM string) (*db.SigningKey, error)
+	// ListSigningKeys returns the keys that can still verify tokens, newest first, leaving out
+	// those that cannot be decrypted
+	ListSigningKeys(ctx context.Context) ([]*db.SigningKey, error)
+	GetActiveSigningKey(ctx context.Context) (*db.SigningKey, error)
+	RetireSigningKeys(ctx context.Context, exceptKid string, expiresAt time.Time) error
+	DeleteExpiredSigningKeys(ctx context.Context) error
+	// LockSigningKeys holds a lock serializing key rotations until the surrounding transaction ends
+	LockSigningKeys(ctx context.Context) error
+}
+
+// signingKeyRepository encrypts the private keys with keyRing when writing them and decrypts
+// them when reading them, callers only see plaintext PEM. Keys stored in plaintext before
+// encryption was enabled are read as they are, and expire with the next rotations.
+type signingKeyRepository struct {
+	queries *db.Queries
+	keyRing *encryption.KeyRing
+}
+
+func NewSigningKeyRepository(queries *db.Queries, keyRing *encryption.KeyRing) SigningKeyRepository {
+	return &signingKeyRepository{
+		queries: queries,
+		keyRing: keyRing,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *signingKeyRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *signingKeyRepository) CreateSigningKey(ctx context.Context, kid string, algorithm string, privateKeyPEM string) (*db.SigningKey, error) {
+	encrypted, err := r.keyRing.Encrypt(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	key, err := r.q(ctx).CreateSigningKey(ctx, kid, algorithm, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptSigningKey(key)
+}
+
+func (r *signingKeyRepository) ListSigningKeys(ctx context.Context) ([]*db.SigningKey, error) {
+	keys, err := r.q(ctx).ListSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*db.SigningKey
+	for _, key := range keys {
+		// A key whose encryption key was removed from the key ring cannot sign or verify, the
+		// others still can
+		keyCopy, err := r.decryptSigningKey(key)
+		if err != nil {
+			logger.Errorf("Skipping signing key %s: %v", key.Kid, err)
+			continue
+		}
+		result = append(result, keyCopy)
+	}
+
+	return result, nil
+}
+
+func (r *signingKeyRepository) GetActiveSigningKey(ctx context.Context) (*db.SigningKey, error) {
+	key, err := r.q(ctx).GetActiveSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptSigningKey(key)
+}
+
+func (r *signingKeyRepository) RetireSigningKeys(ctx context.Context, exceptKid string, expiresAt time.Time) error {
+	return r.q(ctx).RetireSigningKeys(ctx, exceptKid, pgtype.Timestamptz{Time: expiresAt, Valid: true})
+}
+
+func (r *signingKeyRepository) DeleteExpiredSigningKeys(ctx context.Context) error {
+	return r.q(ctx).DeleteExpiredSigningKeys(ctx)
+}
+
+func (r *signingKeyRepository) LockSigningKeys(ctx context.Context) error {
+	return r.q(ctx).LockSigningKeys(ctx)
+}
+
+// decryptSigningKey returns a copy of key with a plaintext private key
+func (r *signingKeyRepository) decryptSigningKey(key db.SigningKey) (*db.SigningKey, error) {
+	privateKey, err := r.keyRing.Decrypt(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key: %w", err)
+	}
+	key.PrivateKey = privateKey
+	return &key, nil
+}
@@ -0,0 +1,212 @@
+// Package sessions stores the gothic sessions, which hold the OAuth state between the login
+// redirect and the callback, in Postgres so every replica can complete a login
+package sessions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/http"
+	"template-golang/config"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultTTL             = time.Hour
+	defaultCleanupInterval = time.Hour
+
+	// minSecretLength is the least amount of bytes SESSION_SECRET must have
+	minSecretLength = 32
+	// sessionIDBytes is the amount of randomness in a session id
+	sessionIDBytes = 32
+)
+
+// ErrWeakSecret is returned when SESSION_SECRET is missing or too short
+var ErrWeakSecret = fmt.Errorf("SESSION_SECRET must be at least %d bytes", minSecretLength)
+
+// Store is a sessions.Store keeping session values in the sessions table. The cookie only
+// carries the session id, signed and encrypted with keys derived from SESSION_SECRET.
+type Store struct {
+	sessionRepo     repositories.SessionRepository
+	codecs          []securecookie.Codec
+	options         sessions.Options
+	ttl             time.Duration
+	cleanupInterval time.Duration
+}
+
+var _ sessions.Store = (*Store)(nil)
+
+func NewStore(conf *config.Config, sessionRepo repositories.SessionRepository) (*Store, error) {
+	secret := conf.Auth.SessionSecret
+	if len(secret) < minSecretLength {
+		return nil, ErrWeakSecret
+	}
+
+	ttl := conf.Auth.SessionTTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	cleanupInterval := conf.Auth.SessionCleanupInterval
+	if cleanupInterval <= 0 {
+		cleanupInterval = defaultCleanupInterval
+	}
+
+	// HMAC-SHA256 for the signature and AES-256 for the encryption, each with its own key
+	codec := securecookie.New(deriveKey(secret, "session-hash"), deriveKey(secret, "session-block"))
+	codec.MaxAge(int(ttl.Seconds()))
+
+	// Apple posts its callback from another site, which only sends SameSite=None cookies.
+	// Browsers require such cookies to be Secure.
+	sameSite := http.SameSiteLaxMode
+	if conf.Auth.CookieSecure {
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return &Store{
+		sessionRepo: sessionRepo,
+		codecs:      []securecookie.Codec{codec},
+		options: sessions.Options{
+			Path:     "/",
+			Domain:   conf.Auth.CookieDomain,
+			MaxAge:   int(ttl.Seconds()),
+			Secure:   conf.Auth.CookieSecure,
+			HttpOnly: true,
+			SameSite: sameSite,
+		},
+		ttl:             ttl,
+		cleanupInterval: cleanupInterval,
+	}, nil
+}
+
+// deriveKey derives a 32 byte key for purpose from secret
+func deriveKey(secret string, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// Get returns the session of the request for name, caching it for the rest of the request
+func (s *Store) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New loads the session whose id is in the cookie name. A missing, tampered or expired session
+// yields a new empty session.
+func (s *Store) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	options := s.options
+	session.Options = &options
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...); err != nil {
+		return session, nil
+	}
+
+	stored, err := s.sessionRepo.GetSession(r.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return session, nil
+	}
+	if err != nil {
+		return session, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(stored.Data)).Decode(&session.Values); err != nil {
+		return session, fmt.Errorf("failed to decode session: %w", err)
+	}
+	session.ID = id
+	session.IsNew = false
+	return session, nil
+}
+
+// Save stores the session and sets its cookie. A session with a negative MaxAge is deleted.
+func (s *Store) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.sessionRepo.DeleteSession(r.Context(), session.ID); err != nil {
+				return fmt.Errorf("failed to delete session: %w", err)
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		id, err := newSessionID()
+		if err != nil {
+			return err
+		}
+		session.ID = id
+	}
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(session.Values); err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	ttl := s.ttl
+	if session.Options.MaxAge > 0 {
+		ttl = time.Duration(session.Options.MaxAge) * time.Second
+	}
+	if err := s.sessionRepo.SaveSession(r.Context(), session.ID, data.Bytes(), time.Now().Add(ttl)); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return fmt.Errorf("failed to encode session cookie: %w", err)
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// newSessionID returns a random session id
+func newSessionID() (string, error) {
+	b := make([]byte, sessionIDBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Run deletes expired sessions periodically until ctx is done
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := s.sessionRepo.DeleteExpiredSessions(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Failed to delete expired sessions: %v", err)
+			}
+			continue
+		}
+		if deleted > 0 {
+			logger.Infof("Deleted %d expired sessions", deleted)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-session-secret-that-is-long-enough"
+
+func newTestStore(t *testing.T) (*Store, *repoMocks.MockSessionRepository) {
+	sessionRepo := repoMocks.NewMockSessionRepository(t)
+	store, err := NewStore(&config.Config{Auth: config.AuthConfig{
+		SessionSecret: testSecret,
+		SessionTTL:    10 * time.Minute,
+		CookieSecure:  true,
+	}}, sessionRepo)
+	require.NoError(t, err)
+	return store, sessionRepo
+}
+
+// requestWithCookies returns a request carrying the cookies set on w
+func requestWithCookies(w *httptest.ResponseRecorder) *http.Request {
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+	return r
+}
+
+func TestNewStore_RequiresStrongSecret(t *testing.T) {
+	for _, secret := range []string{"", "short"} {
+		_, err := NewStore(&config.Config{Auth: config.AuthConfig{SessionSecret: secret}}, nil)
+		assert.ErrorIs(t, err, ErrWeakSecret)
+	}
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	store, sessionRepo := newTestStore(t)
+
+	var savedID string
+	var savedData []byte
+	sessionRepo.EXPECT().SaveSession(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, id string, data []byte, expiresAt time.Time) error {
+			savedID, savedData = id, data
+			assert.WithinDuration(t, time.Now().Add(10*time.Minute), expiresAt, time.Second)
+			return nil
+		}).Once()
+
+	// A request without a cookie starts a new session
+	session, err := store.New(httptest.NewRequest("GET", "/", nil), "_gothic_session")
+	require.NoError(t, err)
+	assert.True(t, session.IsNew)
+
+	session.Values["line"] = "state"
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(httptest.NewRequest("GET", "/", nil), w, session))
+
+	// The cookie holds the encrypted id only
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.NotContains(t, cookies[0].Value, savedID)
+	assert.NotContains(t, cookies[0].Value, "state")
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+	assert.Equal(t, http.SameSiteNoneMode, cookies[0].SameSite)
+
+	sessionRepo.EXPECT().GetSession(mock.Anything, savedID).Return(&db.Session{ID: savedID, Data: savedData}, nil).Once()
+
+	loaded, err := store.New(requestWithCookies(w), "_gothic_session")
+	require.NoError(t, err)
+	assert.False(t, loaded.IsNew)
+	assert.Equal(t, savedID, loaded.ID)
+	assert.Equal(t, "state", loaded.Values["line"])
+}
+
+func TestStore_New_IgnoresTamperedAndExpiredSessions(t *testing.T) {
+	store, sessionRepo := newTestStore(t)
+
+	// A cookie that was not issued by the store
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "_gothic_session", Value: "forged"})
+	session, err := store.New(r, "_gothic_session")
+	require.NoError(t, err)
+	assert.True(t, session.IsNew)
+
+	// A cookie of a session that expired in the database
+	sessionRepo.EXPECT().SaveSession(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(r, w, session))
+
+	sessionRepo.EXPECT().GetSession(mock.Anything, session.ID).Return(nil, pgx.ErrNoRows).Once()
+	expired, err := store.New(requestWithCookies(w), "_gothic_session")
+	require.NoError(t, err)
+	assert.True(t, expired.IsNew)
+	assert.Empty(t, expired.Values)
+}
+
+func TestStore_New_StorageFailure(t *testing.T) {
+	store, sessionRepo := newTestStore(t)
+
+	sessionRepo.EXPECT().SaveSession(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	session, _ := store.New(httptest.NewRequest("GET", "/", nil), "_gothic_session")
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(httptest.NewRequest("GET", "/", nil), w, session))
+
+	sessionRepo.EXPECT().GetSession(mock.Anything, session.ID).Return(nil, errors.New("db down")).Once()
+	_, err := store.New(requestWithCookies(w), "_gothic_session")
+	assert.Error(t, err)
+}
+
+func TestStore_Save_DeletesWithNegativeMaxAge(t *testing.T) {
+	store, sessionRepo := newTestStore(t)
+
+	session, _ := store.New(httptest.NewRequest("GET", "/", nil), "_gothic_session")
+	session.ID = "session-1"
+	session.Options.MaxAge = -1
+
+	sessionRepo.EXPECT().DeleteSession(mock.Anything, "session-1").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(httptest.NewRequest("GET", "/", nil), w, session))
+
+	cookie := w.Header().Get("Set-Cookie")
+	assert.True(t, strings.HasPrefix(cookie, "_gothic_session=;"), cookie)
+	assert.Contains(t, cookie, "Max-Age=0")
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	db "template-golang/db/sqlc"
+
+	"github.com/markbates/goth"
+)
+
+var (
+	// ErrProviderLinkedElsewhere is returned when the provider account already belongs to another user
+	ErrProviderLinkedElsewhere = errors.New("provider account linked to another user")
+	// ErrProviderAlreadyLinked is returned when the user already linked another account of the provider
+	ErrProviderAlreadyLinked = errors.New("provider already linked")
+	// ErrProviderNotLinked is returned when unlinking a provider the user has not linked
+	ErrProviderNotLinked = errors.New("provider not linked")
+	// ErrLastLoginMethod is returned when unlinking would leave the user without a way to log in
+	ErrLastLoginMethod = errors.New("last login method")
+)
+
+// AccountLinkUsecase manages the provider accounts, stored as auth_methods, a user logs in with
+type AccountLinkUsecase interface {
+	// Link adds the provider account of user to authID. Linking a provider account that is
+	// already linked to authID only refreshes its tokens.
+	Link(ctx context.Context, authID string, user goth.User) error
+	// Unlink removes the provider from authID unless it is the last way to log in, counting the
+	// other linked providers and the password
+	Unlink(ctx context.Context, authID string, provider string) error
+	// LinkedProviders returns the auth methods of authID
+	LinkedProviders(ctx context.Context, authID string) ([]*db.AuthMethod, error)
+}
@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/utils"
+	"template-golang/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/markbates/goth"
+)
+
+type accountLinkUsecaseImpl struct {
+	authRepo  repositories.AuthRepository
+	txManager database.TxManager
+}
+
+func NewAccountLinkUsecase(authRepo repositories.AuthRepository, txManager database.TxManager) AccountLinkUsecase {
+	return &accountLinkUsecaseImpl{
+		authRepo:  authRepo,
+		txManager: txManager,
+	}
+}
+
+func (u *accountLinkUsecaseImpl) Link(ctx context.Context, authID string, gothUser goth.User) error {
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		// Serializes link and unlink requests of the same user
+		if _, err := u.authRepo.LockAuth(ctx, authID); err != nil {
+			return fmt.Errorf("failed to lock auth: %w", err)
+		}
+
+		existing, err := u.authRepo.GetAuthMethodByProviderAndID(ctx, gothUser.Provider, gothUser.UserID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to check existing auth method: %w", err)
+		}
+		if existing != nil {
+			if existing.AuthID == nil || *existing.AuthID != authID {
+				return ErrProviderLinkedElsewhere
+			}
+			return u.updateTokens(ctx, authID, gothUser)
+		}
+
+		authMethods, err := u.authRepo.GetAuthMethodsByAuthID(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to get auth methods: %w", err)
+		}
+		for _, method := range authMethods {
+			if method.Provider == gothUser.Provider {
+				return ErrProviderAlreadyLinked
+			}
+		}
+
+		if _, err := u.authRepo.CreateAuthMethod(ctx, newCreateAuthMethodParams(gothUser, authID)); err != nil {
+			// Lost a race with another user linking the same provider account
+			if isUniqueViolation(err) {
+				return ErrProviderLinkedElsewhere
+			}
+			return fmt.Errorf("failed to create auth method: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Linked %s account to auth %s", gothUser.Provider, authID)
+	return nil
+}
+
+// updateTokens stores the provider tokens of gothUser on its auth method of authID
+func (u *accountLinkUsecaseImpl) updateTokens(ctx context.Context, authID string, gothUser goth.User) error {
+	var expiresAt pgtype.Timestamptz
+	if !gothUser.ExpiresAt.IsZero() {
+		expiresAt = pgtype.Timestamptz{Time: gothUser.ExpiresAt, Valid: true}
+	}
+
+	_, err := u.authRepo.UpdateAuthMethod(ctx, db.UpdateAuthMethodParams{
+		AuthID:       &authID,
+		Provider:     gothUser.Provider,
+		AccessToken:  utils.StringToPtr(gothUser.AccessToken),
+		RefreshToken: utils.StringToPtr(gothUser.RefreshToken),
+		IDToken:      utils.StringToPtr(gothUser.IDToken),
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update auth method: %w", err)
+	}
+	return nil
+}
+
+func (u *accountLinkUsecaseImpl) Unlink(ctx context.Context, authID string, provider string) error {
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		// Locking the auth keeps two concurrent unlinks from removing the last two methods
+		auth, err := u.authRepo.LockAuth(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to lock auth: %w", err)
+		}
+
+		authMethods, err := u.authRepo.GetAuthMethodsByAuthID(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to get auth methods: %w", err)
+		}
+
+		var unlinked *db.AuthMethod
+		for _, method := range authMethods {
+			if method.Provider == provider {
+				unlinked = method
+				break
+			}
+		}
+		if unlinked == nil {
+			return ErrProviderNotLinked
+		}
+
+		remaining := len(authMethods) - 1
+		if auth.Password != nil && *auth.Password != "" {
+			remaining++
+		}
+		if remaining == 0 {
+			return ErrLastLoginMethod
+		}
+
+		if err := u.authRepo.SoftDeleteAuthMethod(ctx, unlinked.ID); err != nil {
+			return fmt.Errorf("failed to delete auth method: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Unlinked %s account from auth %s", provider, authID)
+	return nil
+}
+
+func (u *accountLinkUsecaseImpl) LinkedProviders(ctx context.Context, authID string) ([]*db.AuthMethod, error) {
+	authMethods, err := u.authRepo.GetAuthMethodsByAuthID(ctx, authID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth methods: %w", err)
+	}
+	return authMethods, nil
+}
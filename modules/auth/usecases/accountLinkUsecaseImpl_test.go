@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/markbates/goth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAccountLinkUsecase(t *testing.T) (AccountLinkUsecase, *repoMocks.MockAuthRepository, *dbMocks.MockTxManager) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	txManager := dbMocks.NewMockTxManager(t)
+	return NewAccountLinkUsecase(authRepo, txManager), authRepo, txManager
+}
+
+func TestLink_CreatesAuthMethod(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+	gothUser := goth.User{Provider: "github", UserID: "gh-1"}
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "github", "gh-1").Return(nil, pgx.ErrNoRows).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").
+		Return([]*db.AuthMethod{{ID: "method-1", Provider: "google"}}, nil).Once()
+	authRepo.EXPECT().CreateAuthMethod(mock.Anything, mock.MatchedBy(func(p db.CreateAuthMethodParams) bool {
+		return p.AuthID != nil && *p.AuthID == "auth-1" && p.Provider == "github" && p.ProviderID == "gh-1"
+	})).Return(&db.AuthMethod{ID: "method-2"}, nil).Once()
+
+	err := linkUsecase.Link(context.Background(), "auth-1", gothUser)
+
+	assert.NoError(t, err)
+}
+
+func TestLink_ProviderAccountOfAnotherUser(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+	otherID := "auth-2"
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "github", "gh-1").
+		Return(&db.AuthMethod{ID: "method-9", AuthID: &otherID}, nil).Once()
+
+	err := linkUsecase.Link(context.Background(), "auth-1", goth.User{Provider: "github", UserID: "gh-1"})
+
+	assert.ErrorIs(t, err, ErrProviderLinkedElsewhere)
+}
+
+func TestLink_RelinkUpdatesTokens(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+	authID := "auth-1"
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, authID).Return(&db.Auth{ID: authID}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "github", "gh-1").
+		Return(&db.AuthMethod{ID: "method-1", AuthID: &authID}, nil).Once()
+	authRepo.EXPECT().UpdateAuthMethod(mock.Anything, mock.MatchedBy(func(p db.UpdateAuthMethodParams) bool {
+		return p.AccessToken != nil && *p.AccessToken == "new-token"
+	})).Return(&db.AuthMethod{ID: "method-1"}, nil).Once()
+
+	err := linkUsecase.Link(context.Background(), authID, goth.User{Provider: "github", UserID: "gh-1", AccessToken: "new-token"})
+
+	assert.NoError(t, err)
+}
+
+func TestLink_OtherAccountOfSameProvider(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "github", "gh-2").Return(nil, pgx.ErrNoRows).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").
+		Return([]*db.AuthMethod{{ID: "method-1", Provider: "github", ProviderID: "gh-1"}}, nil).Once()
+
+	err := linkUsecase.Link(context.Background(), "auth-1", goth.User{Provider: "github", UserID: "gh-2"})
+
+	assert.ErrorIs(t, err, ErrProviderAlreadyLinked)
+}
+
+func TestLink_ConcurrentLinkElsewhere(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "github", "gh-1").Return(nil, pgx.ErrNoRows).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").Return(nil, nil).Once()
+	authRepo.EXPECT().CreateAuthMethod(mock.Anything, mock.Anything).
+		Return(nil, &pgconn.PgError{Code: sqlStateUniqueViolation}).Once()
+
+	err := linkUsecase.Link(context.Background(), "auth-1", goth.User{Provider: "github", UserID: "gh-1"})
+
+	assert.ErrorIs(t, err, ErrProviderLinkedElsewhere)
+}
+
+func TestUnlink_SoftDeletesAuthMethod(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").Return([]*db.AuthMethod{
+		{ID: "method-1", Provider: "google"},
+		{ID: "method-2", Provider: "github"},
+	}, nil).Once()
+	authRepo.EXPECT().SoftDeleteAuthMethod(mock.Anything, "method-2").Return(nil).Once()
+
+	err := linkUsecase.Unlink(context.Background(), "auth-1", "github")
+
+	assert.NoError(t, err)
+}
+
+func TestUnlink_LastLoginMethod(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").
+		Return([]*db.AuthMethod{{ID: "method-1", Provider: "github"}}, nil).Once()
+
+	err := linkUsecase.Unlink(context.Background(), "auth-1", "github")
+
+	assert.ErrorIs(t, err, ErrLastLoginMethod)
+}
+
+func TestUnlink_LastProviderWithPassword(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+	hash := "$2a$10$hash"
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Password: &hash}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").
+		Return([]*db.AuthMethod{{ID: "method-1", Provider: "github"}}, nil).Once()
+	authRepo.EXPECT().SoftDeleteAuthMethod(mock.Anything, "method-1").Return(nil).Once()
+
+	err := linkUsecase.Unlink(context.Background(), "auth-1", "github")
+
+	assert.NoError(t, err)
+}
+
+func TestUnlink_ProviderNotLinked(t *testing.T) {
+	linkUsecase, authRepo, txManager := setupAccountLinkUsecase(t)
+
+	runInTx(txManager)
+	authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").
+		Return([]*db.AuthMethod{{ID: "method-1", Provider: "google"}}, nil).Once()
+
+	err := linkUsecase.Unlink(context.Background(), "auth-1", "github")
+
+	assert.ErrorIs(t, err, ErrProviderNotLinked)
+}
@@ -0,0 +1,14 @@
+package usecases
+
+import "context"
+
+// AccountStatusStore tells whether accounts may still use the tokens and API keys issued to
+// them. Statuses are read from the auths table and cached in memory for
+// AUTH_ACCOUNT_STATUS_CACHE_TTL, so deactivating or deleting an account locks it out of every
+// replica within that time.
+type AccountStatusStore interface {
+	// IsActive reports whether authID exists, is not deleted and is active
+	IsActive(ctx context.Context, authID string) (bool, error)
+	// Run deletes expired statuses from the cache periodically until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"template-golang/config"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/cache"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const accountStatusCleanupInterval = 10 * time.Minute
+
+type accountStatusStoreImpl struct {
+	authRepo repositories.AuthRepository
+
+	statuses *cache.TTLCache[string, bool]
+}
+
+func NewAccountStatusStore(conf *config.Config, authRepo repositories.AuthRepository) AccountStatusStore {
+	return &accountStatusStoreImpl{
+		authRepo: authRepo,
+		statuses: cache.NewTTLCache[string, bool](conf.Auth.AccountStatusCacheTTL),
+	}
+}
+
+func (s *accountStatusStoreImpl) IsActive(ctx context.Context, authID string) (bool, error) {
+	if active, ok := s.statuses.Get(authID); ok {
+		return active, nil
+	}
+
+	// GetAuthByID skips deleted auths
+	active := false
+	auth, err := s.authRepo.GetAuthByID(ctx, authID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return false, fmt.Errorf("failed to get account status: %w", err)
+	default:
+		active = auth.Active
+	}
+
+	s.statuses.Set(authID, active)
+	return active, nil
+}
+
+func (s *accountStatusStoreImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(accountStatusCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.statuses.DeleteExpired()
+	}
+}
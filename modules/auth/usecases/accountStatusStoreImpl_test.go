@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatusStore_IsActive(t *testing.T) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	store := NewAccountStatusStore(&config.Config{Auth: config.AuthConfig{AccountStatusCacheTTL: time.Minute}}, authRepo)
+
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "active").Return(&db.Auth{ID: "active", Active: true}, nil).Once()
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "deactivated").Return(&db.Auth{ID: "deactivated", Active: false}, nil).Once()
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "deleted").Return(nil, pgx.ErrNoRows).Once()
+
+	expected := map[string]bool{"active": true, "deactivated": false, "deleted": false}
+	// The second round is served from the cache
+	for range 2 {
+		for authID, want := range expected {
+			active, err := store.IsActive(context.Background(), authID)
+			require.NoError(t, err)
+			assert.Equal(t, want, active, authID)
+		}
+	}
+}
+
+func TestAccountStatusStore_NoCache(t *testing.T) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	store := NewAccountStatusStore(&config.Config{}, authRepo)
+
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Active: true}, nil).Once()
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Active: false}, nil).Once()
+
+	active, err := store.IsActive(context.Background(), "auth-1")
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	active, err = store.IsActive(context.Background(), "auth-1")
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestAccountStatusStore_Error(t *testing.T) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	store := NewAccountStatusStore(&config.Config{Auth: config.AuthConfig{AccountStatusCacheTTL: time.Minute}}, authRepo)
+
+	// Errors are not cached
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(nil, errors.New("db down")).Once()
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Active: true}, nil).Once()
+
+	_, err := store.IsActive(context.Background(), "auth-1")
+	assert.Error(t, err)
+
+	active, err := store.IsActive(context.Background(), "auth-1")
+	require.NoError(t, err)
+	assert.True(t, active)
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+)
+
+// APIKeyUsecase manages API keys of machine-to-machine clients and validates the keys they
+// present. A valid key authenticates as its owner, limited to the scopes of the key.
+type APIKeyUsecase interface {
+	// CreateKey stores a new key for req.AuthID and returns it. The key cannot be read again.
+	// It gives authz.ErrForbidden when the caller does not hold every scope of the key and
+	// every permission of the role of its owner.
+	CreateKey(ctx context.Context, req models.CreateAPIKeyRequest) (*db.APIKey, string, error)
+	// ListKeys lists every key, or only the keys of authID when it is not empty
+	ListKeys(ctx context.Context, authID string) ([]*db.APIKey, error)
+	// RevokeKey revokes the key with id, returning pgx.ErrNoRows when it does not exist
+	RevokeKey(ctx context.Context, id string) (*db.APIKey, error)
+	// ValidateKey checks key the way ValidateJWT checks an access token. Keys of deactivated
+	// or deleted auths are reported as revoked.
+	ValidateKey(ctx context.Context, key string) (*models.TokenValidationResult, error)
+}
@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/authz"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	apiKeyPrefixBytes = 6
+	// apiKeyTouchInterval limits how often the last used time of a key is written
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeyUsecaseImpl struct {
+	apiKeyRepo      repositories.APIKeyRepository
+	authRepo        repositories.AuthRepository
+	permissionStore PermissionStore
+}
+
+func NewAPIKeyUsecase(apiKeyRepo repositories.APIKeyRepository, authRepo repositories.AuthRepository, permissionStore PermissionStore) APIKeyUsecase {
+	return &apiKeyUsecaseImpl{
+		apiKeyRepo:      apiKeyRepo,
+		authRepo:        authRepo,
+		permissionStore: permissionStore,
+	}
+}
+
+func (u *apiKeyUsecaseImpl) CreateKey(ctx context.Context, req models.CreateAPIKeyRequest) (*db.APIKey, string, error) {
+	if err := authz.Require(ctx, models.PermissionAPIKeysWrite); err != nil {
+		return nil, "", err
+	}
+	// A key never grants more than its creator holds, whether through its scopes or its owner
+	if err := authz.Require(ctx, req.Scopes...); err != nil {
+		return nil, "", err
+	}
+
+	owner, err := u.authRepo.GetAuthByID(ctx, req.AuthID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get auth: %w", err)
+	}
+	caller, _ := authz.FromContext(ctx)
+	for _, permission := range u.permissionStore.Permissions(models.Role(owner.Role)) {
+		if !caller.Has(permission) {
+			return nil, "", fmt.Errorf("%w: the role of the owner grants %s", authz.ErrForbidden, permission)
+		}
+	}
+
+	prefix, key, err := newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	params := db.CreateAPIKeyParams{
+		AuthID:  req.AuthID,
+		Name:    req.Name,
+		Prefix:  prefix,
+		KeyHash: hashRefreshToken(key),
+		Scopes:  req.Scopes,
+	}
+	if req.ExpiresAt != nil {
+		params.ExpiresAt = pgtype.Timestamptz{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	apiKey, err := u.apiKeyRepo.CreateAPIKey(ctx, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to store api key: %w", err)
+	}
+
+	logger.Infof("Created api key %s for auth %s", apiKey.ID, apiKey.AuthID)
+	return apiKey, key, nil
+}
+
+func (u *apiKeyUsecaseImpl) ListKeys(ctx context.Context, authID string) ([]*db.APIKey, error) {
+	if err := authz.Require(ctx, models.PermissionAPIKeysRead); err != nil {
+		return nil, err
+	}
+
+	keys, err := u.apiKeyRepo.ListAPIKeys(ctx, authID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (u *apiKeyUsecaseImpl) RevokeKey(ctx context.Context, id string) (*db.APIKey, error) {
+	if err := authz.Require(ctx, models.PermissionAPIKeysWrite); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := u.apiKeyRepo.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	logger.Infof("Revoked api key %s", id)
+	return apiKey, nil
+}
+
+func (u *apiKeyUsecaseImpl) ValidateKey(ctx context.Context, key string) (*models.TokenValidationResult, error) {
+	result := &models.TokenValidationResult{}
+
+	if key == "" {
+		result.NotExist = true
+		return result, nil
+	}
+
+	prefix, _, ok := strings.Cut(key, ".")
+	if !ok || prefix == "" {
+		return result, nil
+	}
+
+	apiKey, err := u.apiKeyRepo.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashRefreshToken(key)), []byte(apiKey.KeyHash)) != 1 {
+		return result, nil
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt.Valid {
+		result.Revoked = true
+		return result, nil
+	}
+	if apiKey.ExpiresAt.Valid && !apiKey.ExpiresAt.Time.After(now) {
+		result.Expired = true
+		return result, nil
+	}
+
+	// GetAuthByID skips deleted auths
+	auth, err := u.authRepo.GetAuthByID(ctx, apiKey.AuthID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		result.Revoked = true
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to get auth: %w", err)
+	}
+	if !auth.Active {
+		result.Revoked = true
+		return result, nil
+	}
+
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= apiKeyTouchInterval {
+		if err := u.apiKeyRepo.TouchAPIKey(ctx, apiKey.ID); err != nil {
+			logger.Errorf("Failed to update last used time of api key %s: %v", apiKey.ID, err)
+		}
+	}
+
+	claims := &models.AccessClaims{
+		Role: models.Role(auth.Role),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: auth.ID,
+		},
+	}
+	if auth.Email != nil {
+		claims.Email = *auth.Email
+	}
+	if apiKey.ExpiresAt.Valid {
+		claims.ExpiresAt = jwt.NewNumericDate(apiKey.ExpiresAt.Time)
+	}
+
+	result.Valid = true
+	result.Claims = claims
+	result.UserID = auth.ID
+	result.Scopes = apiKey.Scopes
+
+	return result, nil
+}
+
+// newAPIKey returns a random key and its prefix. The secret has the same randomness as
+// refresh tokens and never contains the "." separating it from the prefix.
+func newAPIKey() (string, string, error) {
+	b := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	prefix := hex.EncodeToString(b)
+
+	secret, err := newRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	return prefix, prefix + "." + secret, nil
+}
@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"template-golang/pkg/authz"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyUsecaseMocks struct {
+	apiKeyRepo *repoMocks.MockAPIKeyRepository
+	authRepo   *repoMocks.MockAuthRepository
+}
+
+func setupAPIKeyUsecase(t *testing.T) (APIKeyUsecase, apiKeyUsecaseMocks) {
+	m := apiKeyUsecaseMocks{
+		apiKeyRepo: repoMocks.NewMockAPIKeyRepository(t),
+		authRepo:   repoMocks.NewMockAuthRepository(t),
+	}
+	return NewAPIKeyUsecase(m.apiKeyRepo, m.authRepo, NewPermissionStore(&config.Config{}, nil)), m
+}
+
+func TestCreateKey_StoresHash(t *testing.T) {
+	apiKeys, m := setupAPIKeyUsecase(t)
+
+	expiresAt := time.Now().Add(time.Hour)
+	var stored db.CreateAPIKeyParams
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: string(models.RoleUser)}, nil).Once()
+	m.apiKeyRepo.EXPECT().CreateAPIKey(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, params db.CreateAPIKeyParams) (*db.APIKey, error) {
+		stored = params
+		return &db.APIKey{ID: "key-1", AuthID: params.AuthID, Prefix: params.Prefix, Scopes: params.Scopes}, nil
+	}).Once()
+
+	apiKey, key, err := apiKeys.CreateKey(adminCtx, models.CreateAPIKeyRequest{
+		AuthID:    "auth-1",
+		Name:      "sensor",
+		Scopes:    []string{"cockroach:write"},
+		ExpiresAt: &expiresAt,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", apiKey.ID)
+	assert.Equal(t, stored.Prefix+".", key[:len(stored.Prefix)+1])
+	assert.Equal(t, hashRefreshToken(key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, key)
+	assert.True(t, stored.ExpiresAt.Valid)
+	assert.Equal(t, []string{"cockroach:write"}, stored.Scopes)
+}
+
+func TestCreateKey_UnknownUser(t *testing.T) {
+	apiKeys, m := setupAPIKeyUsecase(t)
+
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "missing").Return(nil, pgx.ErrNoRows).Once()
+
+	_, _, err := apiKeys.CreateKey(adminCtx, models.CreateAPIKeyRequest{AuthID: "missing", Name: "sensor", Scopes: []string{"cockroach:write"}})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCreateKey_RequiresPermission(t *testing.T) {
+	apiKeys, _ := setupAPIKeyUsecase(t)
+
+	staffCtx := authz.WithPrincipal(context.Background(), authz.Principal{
+		UserID:      "staff-1",
+		Permissions: []string{models.PermissionUsersRead},
+	})
+
+	_, _, err := apiKeys.CreateKey(staffCtx, models.CreateAPIKeyRequest{AuthID: "auth-1"})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	_, err = apiKeys.RevokeKey(staffCtx, "key-1")
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+// keyManagerCtx is a principal allowed to manage API keys without being an admin, e.g. a role
+// granted api_keys:write through AUTH_ROLE_PERMISSIONS or an API key scoped to it
+var keyManagerCtx = authz.WithPrincipal(context.Background(), authz.Principal{
+	UserID:      "manager-1",
+	Role:        string(models.RoleStaff),
+	Permissions: []string{models.PermissionAPIKeysWrite, "cockroach:*"},
+})
+
+func TestCreateKey_RefusesScopesTheCallerLacks(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+	}{
+		{"wildcard", []string{authz.Wildcard}},
+		{"other resource", []string{"cockroach:write", models.PermissionUsersWrite}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeys, _ := setupAPIKeyUsecase(t)
+
+			_, _, err := apiKeys.CreateKey(keyManagerCtx, models.CreateAPIKeyRequest{AuthID: "auth-1", Name: "sensor", Scopes: tt.scopes})
+
+			assert.ErrorIs(t, err, authz.ErrForbidden)
+		})
+	}
+}
+
+func TestCreateKey_RefusesOwnersGrantedMore(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        models.Role
+		expectError bool
+	}{
+		{"admin owner", models.RoleAdmin, true},
+		{"staff owner", models.RoleStaff, true},
+		{"user owner", models.RoleUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeys, m := setupAPIKeyUsecase(t)
+			m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: string(tt.role)}, nil).Once()
+			if !tt.expectError {
+				m.apiKeyRepo.EXPECT().CreateAPIKey(mock.Anything, mock.Anything).Return(&db.APIKey{ID: "key-1", AuthID: "auth-1"}, nil).Once()
+			}
+
+			_, _, err := apiKeys.CreateKey(keyManagerCtx, models.CreateAPIKeyRequest{AuthID: "auth-1", Name: "sensor", Scopes: []string{"cockroach:write"}})
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, authz.ErrForbidden)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	const key = "abc123.secret"
+	hash := hashRefreshToken(key)
+	past := pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+	recent := pgtype.Timestamptz{Time: time.Now().Add(-time.Second), Valid: true}
+
+	tests := []struct {
+		name       string
+		key        string
+		setupMocks func(m apiKeyUsecaseMocks)
+		expected   models.TokenValidationResult
+	}{
+		{
+			name: "valid key is touched",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", AuthID: "auth-1", KeyHash: hash, Scopes: []string{"cockroach:write"}}, nil).Once()
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "user", Active: true}, nil).Once()
+				m.apiKeyRepo.EXPECT().TouchAPIKey(mock.Anything, "key-1").Return(nil).Once()
+			},
+			expected: models.TokenValidationResult{Valid: true, UserID: "auth-1", Scopes: []string{"cockroach:write"}},
+		},
+		{
+			name: "recently used key is not touched",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", AuthID: "auth-1", KeyHash: hash, Scopes: []string{}, LastUsedAt: recent}, nil).Once()
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "user", Active: true}, nil).Once()
+			},
+			expected: models.TokenValidationResult{Valid: true, UserID: "auth-1", Scopes: []string{}},
+		},
+		{
+			name:       "empty key",
+			key:        "",
+			setupMocks: func(m apiKeyUsecaseMocks) {},
+			expected:   models.TokenValidationResult{NotExist: true},
+		},
+		{
+			name:       "malformed key",
+			key:        "no-separator",
+			setupMocks: func(m apiKeyUsecaseMocks) {},
+			expected:   models.TokenValidationResult{},
+		},
+		{
+			name: "unknown prefix",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(nil, pgx.ErrNoRows).Once()
+			},
+			expected: models.TokenValidationResult{},
+		},
+		{
+			name: "wrong secret",
+			key:  "abc123.guess",
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", KeyHash: hash}, nil).Once()
+			},
+			expected: models.TokenValidationResult{},
+		},
+		{
+			name: "revoked key",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", KeyHash: hash, RevokedAt: past}, nil).Once()
+			},
+			expected: models.TokenValidationResult{Revoked: true},
+		},
+		{
+			name: "expired key",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", KeyHash: hash, ExpiresAt: past}, nil).Once()
+			},
+			expected: models.TokenValidationResult{Expired: true},
+		},
+		{
+			name: "deactivated owner",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", AuthID: "auth-1", KeyHash: hash}, nil).Once()
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Active: false}, nil).Once()
+			},
+			expected: models.TokenValidationResult{Revoked: true},
+		},
+		{
+			name: "deleted owner",
+			key:  key,
+			setupMocks: func(m apiKeyUsecaseMocks) {
+				m.apiKeyRepo.EXPECT().GetAPIKeyByPrefix(mock.Anything, "abc123").Return(&db.APIKey{ID: "key-1", AuthID: "auth-1", KeyHash: hash}, nil).Once()
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(nil, pgx.ErrNoRows).Once()
+			},
+			expected: models.TokenValidationResult{Revoked: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeys, m := setupAPIKeyUsecase(t)
+			tt.setupMocks(m)
+
+			result, err := apiKeys.ValidateKey(context.Background(), tt.key)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected.Valid, result.Valid)
+			assert.Equal(t, tt.expected.NotExist, result.NotExist)
+			assert.Equal(t, tt.expected.Expired, result.Expired)
+			assert.Equal(t, tt.expected.Revoked, result.Revoked)
+			assert.Equal(t, tt.expected.UserID, result.UserID)
+			assert.Equal(t, tt.expected.Scopes, result.Scopes)
+			if tt.expected.Valid {
+				assert.Equal(t, tt.expected.UserID, result.Claims.Subject)
+				assert.Equal(t, models.RoleUser, result.Claims.Role)
+			}
+		})
+	}
+}
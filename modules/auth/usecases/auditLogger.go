@@ -0,0 +1,20 @@
+package usecases
+
+import (
+	"context"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+)
+
+// AuditLogger records security relevant events, such as logins, role changes and deletions, in
+// the audit_events table, and lets admins query them
+type AuditLogger interface {
+	// Log records event. The actor, IP address, user agent and request ID that event leaves
+	// empty are taken from the pkg/context values of ctx. Errors are logged rather than
+	// returned, so a failing audit log never fails the audited action. Events logged inside a
+	// transaction are rolled back with it.
+	Log(ctx context.Context, event models.AuditEvent)
+	// ListEvents returns a page of the events matching filter, newest first, and the number of
+	// all matching events
+	ListEvents(ctx context.Context, filter models.AuditEventFilter, limit int, offset int) ([]*db.AuditEvent, int, error)
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/utils"
+	"template-golang/pkg/authz"
+	pkgContext "template-golang/pkg/context"
+	"template-golang/pkg/logger"
+)
+
+// maxAuditUserAgentLength bounds the user agent stored with an event, it is sent by the client
+const maxAuditUserAgentLength = 512
+
+type auditLoggerImpl struct {
+	auditEventRepo repositories.AuditEventRepository
+}
+
+func NewAuditLogger(auditEventRepo repositories.AuditEventRepository) AuditLogger {
+	return &auditLoggerImpl{
+		auditEventRepo: auditEventRepo,
+	}
+}
+
+func (a *auditLoggerImpl) Log(ctx context.Context, event models.AuditEvent) {
+	if event.ActorID == "" {
+		event.ActorID = contextString(ctx, pkgContext.UserIDKey)
+	}
+	if event.IPAddress == "" {
+		event.IPAddress = contextString(ctx, pkgContext.IPAddressKey)
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = contextString(ctx, pkgContext.UserAgentKey)
+	}
+	if event.RequestID == "" {
+		event.RequestID = contextString(ctx, pkgContext.RequestIDKey)
+	}
+	if len(event.UserAgent) > maxAuditUserAgentLength {
+		event.UserAgent = event.UserAgent[:maxAuditUserAgentLength]
+	}
+
+	metadata := []byte("{}")
+	if len(event.Metadata) > 0 {
+		var err error
+		if metadata, err = json.Marshal(event.Metadata); err != nil {
+			logger.Errorf("Failed to encode metadata of audit event %s: %v", event.Action, err)
+			metadata = []byte("{}")
+		}
+	}
+
+	_, err := a.auditEventRepo.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		ActorID:    utils.StringToPtr(event.ActorID),
+		Action:     event.Action,
+		TargetType: utils.StringToPtr(event.TargetType),
+		TargetID:   utils.StringToPtr(event.TargetID),
+		Outcome:    event.Outcome,
+		IpAddress:  utils.StringToPtr(event.IPAddress),
+		UserAgent:  utils.StringToPtr(event.UserAgent),
+		RequestID:  utils.StringToPtr(event.RequestID),
+		Metadata:   metadata,
+	})
+	if err != nil {
+		logger.Errorf("Failed to record audit event %s %s of actor %q on %s %q: %v",
+			event.Action, event.Outcome, event.ActorID, event.TargetType, event.TargetID, err)
+	}
+}
+
+func (a *auditLoggerImpl) ListEvents(ctx context.Context, filter models.AuditEventFilter, limit int, offset int) ([]*db.AuditEvent, int, error) {
+	if err := authz.Require(ctx, models.PermissionAuditRead); err != nil {
+		return nil, 0, err
+	}
+
+	params := db.CountAuditEventsParams{
+		ActorID:     utils.StringToPtr(filter.ActorID),
+		Action:      utils.StringToPtr(filter.Action),
+		TargetType:  utils.StringToPtr(filter.TargetType),
+		TargetID:    utils.StringToPtr(filter.TargetID),
+		Outcome:     utils.StringToPtr(filter.Outcome),
+		IpAddress:   utils.StringToPtr(filter.IPAddress),
+		CreatedFrom: timestamptz(filter.CreatedFrom),
+		CreatedTo:   timestamptz(filter.CreatedTo),
+	}
+
+	total, err := a.auditEventRepo.CountAuditEvents(ctx, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	if total == 0 {
+		return []*db.AuditEvent{}, 0, nil
+	}
+
+	events, err := a.auditEventRepo.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		ActorID:     params.ActorID,
+		Action:      params.Action,
+		TargetType:  params.TargetType,
+		TargetID:    params.TargetID,
+		Outcome:     params.Outcome,
+		IpAddress:   params.IpAddress,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		Limit:       int32(limit),
+		Offset:      int32(offset),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, int(total), nil
+}
+
+// contextString returns the string value of key in ctx, "" when there is none
+func contextString(ctx context.Context, key pkgContext.ContextKey) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"template-golang/pkg/authz"
+	pkgContext "template-golang/pkg/context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditLogger_LogFillsClientFromContext(t *testing.T) {
+	auditEventRepo := repoMocks.NewMockAuditEventRepository(t)
+	auditLogger := NewAuditLogger(auditEventRepo)
+
+	ctx := context.WithValue(context.Background(), pkgContext.UserIDKey, "admin-1")
+	ctx = context.WithValue(ctx, pkgContext.IPAddressKey, "203.0.113.7")
+	ctx = context.WithValue(ctx, pkgContext.UserAgentKey, strings.Repeat("a", 600))
+	ctx = context.WithValue(ctx, pkgContext.RequestIDKey, "request-1")
+
+	auditEventRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(p db.CreateAuditEventParams) bool {
+		return *p.ActorID == "admin-1" && p.Action == models.AuditActionUserDelete &&
+			*p.TargetType == models.AuditTargetUser && *p.TargetID == "auth-1" && p.Outcome == models.AuditOutcomeSuccess &&
+			*p.IpAddress == "203.0.113.7" && len(*p.UserAgent) == maxAuditUserAgentLength && *p.RequestID == "request-1" &&
+			string(p.Metadata) == `{"role":"staff"}`
+	})).Return(&db.AuditEvent{ID: "event-1"}, nil).Once()
+
+	auditLogger.Log(ctx, models.AuditEvent{
+		Action:     models.AuditActionUserDelete,
+		TargetType: models.AuditTargetUser,
+		TargetID:   "auth-1",
+		Outcome:    models.AuditOutcomeSuccess,
+		Metadata:   map[string]any{"role": "staff"},
+	})
+}
+
+func TestAuditLogger_LogKeepsEventFields(t *testing.T) {
+	auditEventRepo := repoMocks.NewMockAuditEventRepository(t)
+	auditLogger := NewAuditLogger(auditEventRepo)
+
+	ctx := context.WithValue(context.Background(), pkgContext.UserIDKey, "admin-1")
+
+	// A new user acts on their own, whoever the context says
+	auditEventRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.MatchedBy(func(p db.CreateAuditEventParams) bool {
+		return *p.ActorID == "auth-1" && p.TargetType == nil && p.IpAddress == nil && string(p.Metadata) == "{}"
+	})).Return(&db.AuditEvent{ID: "event-1"}, nil).Once()
+
+	auditLogger.Log(ctx, models.AuditEvent{ActorID: "auth-1", Action: models.AuditActionUserCreate, Outcome: models.AuditOutcomeSuccess})
+}
+
+func TestAuditLogger_LogSwallowsErrors(t *testing.T) {
+	auditEventRepo := repoMocks.NewMockAuditEventRepository(t)
+	auditLogger := NewAuditLogger(auditEventRepo)
+
+	auditEventRepo.EXPECT().CreateAuditEvent(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	assert.NotPanics(t, func() {
+		auditLogger.Log(context.Background(), models.AuditEvent{Action: models.AuditActionLogin, Outcome: models.AuditOutcomeFailure})
+	})
+}
+
+func TestAuditLogger_ListEvents(t *testing.T) {
+	auditEventRepo := repoMocks.NewMockAuditEventRepository(t)
+	auditLogger := NewAuditLogger(auditEventRepo)
+
+	filter := models.AuditEventFilter{
+		ActorID:     "admin-1",
+		Outcome:     models.AuditOutcomeFailure,
+		CreatedFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	auditEventRepo.EXPECT().CountAuditEvents(mock.Anything, mock.MatchedBy(func(p db.CountAuditEventsParams) bool {
+		return *p.ActorID == "admin-1" && *p.Outcome == "failure" && p.Action == nil && p.CreatedFrom.Valid && !p.CreatedTo.Valid
+	})).Return(int64(12), nil).Once()
+	auditEventRepo.EXPECT().ListAuditEvents(mock.Anything, mock.MatchedBy(func(p db.ListAuditEventsParams) bool {
+		return *p.ActorID == "admin-1" && p.Limit == 10 && p.Offset == 10
+	})).Return([]*db.AuditEvent{{ID: "event-11"}, {ID: "event-12"}}, nil).Once()
+
+	events, total, err := auditLogger.ListEvents(adminCtx, filter, 10, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, total)
+	assert.Len(t, events, 2)
+}
+
+func TestAuditLogger_ListEventsRequiresPermission(t *testing.T) {
+	auditLogger := NewAuditLogger(repoMocks.NewMockAuditEventRepository(t))
+	staffCtx := authz.WithPrincipal(context.Background(), authz.Principal{
+		UserID:      "staff-1",
+		Role:        string(models.RoleStaff),
+		Permissions: models.DefaultRolePermissions[models.RoleStaff],
+	})
+
+	_, _, err := auditLogger.ListEvents(staffCtx, models.AuditEventFilter{}, 10, 0)
+
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/modules/auth/models"
+)
+
+var (
+	// ErrInvalidAuthCode is returned when an authorization code is unknown, expired or already
+	// used, or when the code verifier does not match its challenge
+	ErrInvalidAuthCode = errors.New("invalid authorization code")
+	// ErrInvalidCodeChallenge is returned when a PKCE code challenge is not a S256 challenge
+	ErrInvalidCodeChallenge = errors.New("invalid code challenge")
+)
+
+// AuthCodeUsecase hands the result of an OAuth callback to the frontend as a short-lived
+// one-time code, so tokens never appear in a redirect URL. The code is bound to a PKCE S256
+// challenge and only the holder of the matching verifier can exchange it for tokens.
+type AuthCodeUsecase interface {
+	// Issue stores a new code for authID bound to codeChallenge and returns it
+	Issue(ctx context.Context, authID string, codeChallenge string) (string, error)
+	// Exchange consumes code and issues a token pair when codeVerifier matches its challenge
+	Exchange(ctx context.Context, code string, codeVerifier string) (*models.TokenPair, error)
+	// Run deletes expired and used codes periodically until ctx is done
+	Run(ctx context.Context)
+}
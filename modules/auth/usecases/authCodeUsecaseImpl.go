@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"template-golang/config"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultAuthCodeTTL             = time.Minute
+	defaultAuthCodeCleanupInterval = 10 * time.Minute
+)
+
+var (
+	// codeChallengePattern matches base64url(SHA-256(verifier)) without padding
+	codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+	// codeVerifierPattern matches a verifier as defined by RFC 7636 section 4.1
+	codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{43,128}$`)
+)
+
+type authCodeUsecaseImpl struct {
+	jwtUsecase   JWTUsecase
+	authCodeRepo repositories.AuthCodeRepository
+	ttl          time.Duration
+}
+
+func NewAuthCodeUsecase(conf *config.Config, jwtUsecase JWTUsecase, authCodeRepo repositories.AuthCodeRepository) AuthCodeUsecase {
+	ttl := conf.Auth.AuthCodeTTL
+	if ttl <= 0 {
+		ttl = defaultAuthCodeTTL
+	}
+
+	return &authCodeUsecaseImpl{
+		jwtUsecase:   jwtUsecase,
+		authCodeRepo: authCodeRepo,
+		ttl:          ttl,
+	}
+}
+
+// ValidCodeChallenge reports whether challenge is a well-formed PKCE S256 code challenge
+func ValidCodeChallenge(challenge string) bool {
+	return codeChallengePattern.MatchString(challenge)
+}
+
+func (u *authCodeUsecaseImpl) Issue(ctx context.Context, authID string, codeChallenge string) (string, error) {
+	if !ValidCodeChallenge(codeChallenge) {
+		return "", ErrInvalidCodeChallenge
+	}
+
+	// Codes have the same randomness as refresh tokens
+	code, err := newRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := u.authCodeRepo.CreateAuthCode(ctx, authID, hashRefreshToken(code), codeChallenge, time.Now().Add(u.ttl)); err != nil {
+		return "", fmt.Errorf("failed to store auth code: %w", err)
+	}
+	return code, nil
+}
+
+func (u *authCodeUsecaseImpl) Exchange(ctx context.Context, code string, codeVerifier string) (*models.TokenPair, error) {
+	if code == "" || !codeVerifierPattern.MatchString(codeVerifier) {
+		return nil, ErrInvalidAuthCode
+	}
+
+	// The code is consumed before the verifier is checked, so a wrong verifier burns it
+	authCode, err := u.authCodeRepo.ConsumeAuthCode(ctx, hashRefreshToken(code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidAuthCode
+		}
+		return nil, fmt.Errorf("failed to consume auth code: %w", err)
+	}
+
+	sum := sha256.Sum256([]byte(codeVerifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	if subtle.ConstantTimeCompare([]byte(challenge), []byte(authCode.CodeChallenge)) != 1 {
+		return nil, ErrInvalidAuthCode
+	}
+
+	return u.jwtUsecase.IssueTokens(ctx, authCode.AuthID)
+}
+
+func (u *authCodeUsecaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(defaultAuthCodeCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := u.authCodeRepo.DeleteExpiredAuthCodes(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Failed to delete expired auth codes: %v", err)
+			}
+			continue
+		}
+		if deleted > 0 {
+			logger.Infof("Deleted %d expired auth codes", deleted)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// The verifier and challenge of RFC 7636 appendix B
+const (
+	testCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func setupAuthCodeUsecase(t *testing.T) (AuthCodeUsecase, *repoMocks.MockAuthCodeRepository, *stubTokenIssuer) {
+	authCodeRepo := repoMocks.NewMockAuthCodeRepository(t)
+	issuer := &stubTokenIssuer{}
+	conf := &config.Config{Auth: config.AuthConfig{AuthCodeTTL: 30 * time.Second}}
+	return NewAuthCodeUsecase(conf, issuer, authCodeRepo), authCodeRepo, issuer
+}
+
+func TestAuthCodeUsecase_Issue(t *testing.T) {
+	u, authCodeRepo, _ := setupAuthCodeUsecase(t)
+
+	var storedHash string
+	authCodeRepo.EXPECT().CreateAuthCode(mock.Anything, "auth-1", mock.Anything, testCodeChallenge, mock.Anything).
+		RunAndReturn(func(ctx context.Context, authID string, codeHash string, codeChallenge string, expiresAt time.Time) (*db.AuthCode, error) {
+			storedHash = codeHash
+			assert.WithinDuration(t, time.Now().Add(30*time.Second), expiresAt, time.Second)
+			return &db.AuthCode{}, nil
+		}).Once()
+
+	code, err := u.Issue(context.Background(), "auth-1", testCodeChallenge)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+	// Only the hash of the code is stored
+	assert.Equal(t, hashRefreshToken(code), storedHash)
+}
+
+func TestAuthCodeUsecase_Issue_InvalidChallenge(t *testing.T) {
+	u, _, _ := setupAuthCodeUsecase(t)
+
+	for _, challenge := range []string{"", "too-short", testCodeChallenge + "=", testCodeVerifier[:42] + "+"} {
+		_, err := u.Issue(context.Background(), "auth-1", challenge)
+		assert.ErrorIs(t, err, ErrInvalidCodeChallenge, challenge)
+	}
+}
+
+func TestAuthCodeUsecase_Exchange(t *testing.T) {
+	u, authCodeRepo, issuer := setupAuthCodeUsecase(t)
+
+	authCodeRepo.EXPECT().ConsumeAuthCode(mock.Anything, hashRefreshToken("code")).
+		Return(&db.AuthCode{AuthID: "auth-1", CodeChallenge: testCodeChallenge}, nil).Once()
+
+	tokens, err := u.Exchange(context.Background(), "code", testCodeVerifier)
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-auth-1", tokens.AccessToken)
+	assert.Equal(t, []string{"auth-1"}, issuer.issued)
+}
+
+func TestAuthCodeUsecase_Exchange_WrongVerifier(t *testing.T) {
+	u, authCodeRepo, issuer := setupAuthCodeUsecase(t)
+
+	authCodeRepo.EXPECT().ConsumeAuthCode(mock.Anything, hashRefreshToken("code")).
+		Return(&db.AuthCode{AuthID: "auth-1", CodeChallenge: testCodeChallenge}, nil).Once()
+
+	_, err := u.Exchange(context.Background(), "code", "x"+testCodeVerifier[1:])
+
+	assert.ErrorIs(t, err, ErrInvalidAuthCode)
+	assert.Empty(t, issuer.issued)
+}
+
+func TestAuthCodeUsecase_Exchange_UnknownOrUsedCode(t *testing.T) {
+	u, authCodeRepo, _ := setupAuthCodeUsecase(t)
+
+	authCodeRepo.EXPECT().ConsumeAuthCode(mock.Anything, hashRefreshToken("used")).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := u.Exchange(context.Background(), "used", testCodeVerifier)
+
+	assert.ErrorIs(t, err, ErrInvalidAuthCode)
+}
+
+func TestAuthCodeUsecase_Exchange_MalformedVerifier(t *testing.T) {
+	u, _, _ := setupAuthCodeUsecase(t)
+
+	// Rejected without consuming the code
+	_, err := u.Exchange(context.Background(), "code", "short")
+
+	assert.ErrorIs(t, err, ErrInvalidAuthCode)
+}
+
+func TestAuthCodeUsecase_Exchange_StorageFailure(t *testing.T) {
+	u, authCodeRepo, _ := setupAuthCodeUsecase(t)
+
+	authCodeRepo.EXPECT().ConsumeAuthCode(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	_, err := u.Exchange(context.Background(), "code", testCodeVerifier)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidAuthCode)
+}
@@ -1,13 +1,50 @@
 package usecases
 
 import (
+	"context"
+	"errors"
+	db "template-golang/db/sqlc"
 	"template-golang/modules/auth/models"
 
 	"github.com/markbates/goth"
 )
 
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again.
+	// Every token in its family is revoked before it is returned.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrAccountExists is returned when a new provider account has the email of an existing
+	// account it was not linked to. The user has to log in and link the provider instead.
+	ErrAccountExists = errors.New("account exists")
+	// ErrAccountDisabled is returned when a login reaches an account that was deactivated or
+	// deleted
+	ErrAccountDisabled = errors.New("account disabled")
+)
+
 type JWTUsecase interface {
-	GenerateJWT(userID string) (string, error)
-	ValidateJWT(tokenString string) (*models.TokenValidationResult, error)
-	UpsertUser(user goth.User, role ...models.Role) error
+	// GenerateJWT signs an access token for authID with claims loaded from its auths and auth_methods rows
+	GenerateJWT(ctx context.Context, authID string) (string, error)
+	// ValidateJWT validates an access token. mfa_pending tokens, which have another audience,
+	// are invalid.
+	ValidateJWT(ctx context.Context, tokenString string) (*models.TokenValidationResult, error)
+	// ValidateMFAJWT is ValidateJWT for the MFA routes, which also accept mfa_pending tokens
+	ValidateMFAJWT(ctx context.Context, tokenString string) (*models.TokenValidationResult, error)
+	// UpsertUser returns the account of the provider account of user, creating it or linking it by
+	// verified email (AUTH_EMAIL_AUTO_LINK) when the provider account is new. Creations and links
+	// are recorded in the audit log. Deactivated and deleted accounts give ErrAccountDisabled.
+	UpsertUser(ctx context.Context, user goth.User, role ...models.Role) (*db.Auth, error)
+	// IssueTokens starts a new refresh token family for authID and returns its first token pair.
+	// When authID has MFA enabled, or its role requires MFA, it returns an mfa_pending token
+	// without refresh token instead.
+	IssueTokens(ctx context.Context, authID string) (*models.TokenPair, error)
+	// IssueMFAVerifiedTokens is IssueTokens once the second factor of authID was verified
+	IssueMFAVerifiedTokens(ctx context.Context, authID string) (*models.TokenPair, error)
+	// RefreshTokens rotates refreshToken, returning a new pair in the same family
+	RefreshTokens(ctx context.Context, refreshToken string) (*models.TokenPair, error)
+	// Logout revokes the access token described by claims and, when given, the family of refreshToken
+	Logout(ctx context.Context, claims *models.AccessClaims, refreshToken string) error
+	// RevokeAllTokens revokes every access and refresh token issued to authID so far
+	RevokeAllTokens(ctx context.Context, authID string) error
 }
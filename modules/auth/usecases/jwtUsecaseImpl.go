@@ -2,102 +2,213 @@ package usecases
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"template-golang/config"
+	"template-golang/database"
 	db "template-golang/db/sqlc"
 	"template-golang/modules/auth/models"
 	"template-golang/modules/auth/repositories"
 	"template-golang/modules/auth/utils"
+	"template-golang/pkg/logger"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/markbates/goth"
 )
 
+const (
+	defaultIssuer          = "template-golang"
+	defaultAudience        = "template-golang-api"
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	// mfaPendingAudienceSuffix makes the audience of mfa_pending tokens, so services verifying
+	// access tokens through the JWKS refuse them
+	mfaPendingAudienceSuffix = ":mfa_pending"
+
+	// refreshTokenBytes is the amount of randomness in an opaque refresh token
+	refreshTokenBytes = 32
+)
+
 type jwtUsecaseImpl struct {
-	privateKey *ecdsa.PrivateKey
-	publicKey  *ecdsa.PublicKey
-	authRepo   repositories.AuthRepository
+	keySet           KeySet
+	revocationStore  RevocationStore
+	auditLogger      AuditLogger
+	issuer           string
+	audience         string
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+	emailAutoLink    bool
+	mfaRequiredRoles map[models.Role]bool
+	mfaPendingTTL    time.Duration
+	authRepo         repositories.AuthRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	txManager        database.TxManager
 }
 
-func NewJWTUsecase(conf *config.Config, authRepo repositories.AuthRepository) JWTUsecase {
-	privateKey := loadPrivateKey(conf.Auth.PrivateKeyPath)
-	publicKey := &privateKey.PublicKey
+func NewJWTUsecase(conf *config.Config, keySet KeySet, revocationStore RevocationStore, auditLogger AuditLogger, authRepo repositories.AuthRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository, txManager database.TxManager) JWTUsecase {
+	issuer := conf.Auth.JWTIssuer
+	if issuer == "" {
+		issuer = defaultIssuer
+	}
+	audience := conf.Auth.JWTAudience
+	if audience == "" {
+		audience = defaultAudience
+	}
+	accessTokenTTL := conf.Auth.AccessTokenTTL
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = defaultAccessTokenTTL
+	}
+	refreshTokenTTL := conf.Auth.RefreshTokenTTL
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = defaultRefreshTokenTTL
+	}
+	mfaPendingTTL := conf.Auth.MFAPendingTTL
+	if mfaPendingTTL <= 0 {
+		mfaPendingTTL = defaultMFAPendingTTL
+	}
 
 	return &jwtUsecaseImpl{
-		privateKey: privateKey,
-		publicKey:  publicKey,
-		authRepo:   authRepo,
+		keySet:           keySet,
+		revocationStore:  revocationStore,
+		auditLogger:      auditLogger,
+		issuer:           issuer,
+		audience:         audience,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
+		emailAutoLink:    conf.Auth.EmailAutoLink,
+		mfaRequiredRoles: parseMFARequiredRoles(conf.Auth.MFARequiredRoles),
+		mfaPendingTTL:    mfaPendingTTL,
+		authRepo:         authRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		txManager:        txManager,
 	}
 }
 
-// we use panic because if not have private key, we cannot run the server
-func loadPrivateKey(path string) *ecdsa.PrivateKey {
-	// init key
-	var keyByteArray []byte
-	var key *ecdsa.PrivateKey
-
-	// Validate and clean the path to prevent directory traversal
-	cleanPath := filepath.Clean(path)
-	absPath, err := filepath.Abs(cleanPath)
+func (a *jwtUsecaseImpl) GenerateJWT(ctx context.Context, authID string) (string, error) {
+	auth, err := a.authRepo.GetAuthByID(ctx, authID)
 	if err != nil {
-		panic(fmt.Errorf("failed to resolve absolute path: %w", err))
+		return "", fmt.Errorf("failed to get auth: %w", err)
 	}
+	return a.generateJWT(ctx, auth)
+}
 
-	// Basic security check - prevent access to system directories
-	if strings.Contains(absPath, "/etc/") || strings.Contains(absPath, "/usr/") ||
-		strings.Contains(absPath, "/var/") || strings.Contains(absPath, "/root/") ||
-		strings.Contains(absPath, "/home/") && !strings.Contains(absPath, "/home/"+os.Getenv("USER")) {
-		panic(fmt.Errorf("invalid path: access to system directories not allowed"))
+// generateJWT signs an access token for auth, which is already loaded
+func (a *jwtUsecaseImpl) generateJWT(ctx context.Context, auth *db.Auth) (string, error) {
+	authMethods, err := a.authRepo.GetAuthMethodsByAuthID(ctx, auth.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth methods: %w", err)
 	}
 
-	// Load the private key from a file
-	keyByteArray, err = os.ReadFile(absPath) // #nosec G304 -- Path is validated to prevent directory traversal and system file access
+	return a.signClaims(a.newAccessClaims(auth, authMethods))
+}
+
+// signClaims signs claims with the current signing key
+func (a *jwtUsecaseImpl) signClaims(claims *models.AccessClaims) (string, error) {
+	signingKey, err := a.keySet.SigningKey()
 	if err != nil {
-		panic(fmt.Errorf("failed to read private key: %w", err))
+		return "", err
 	}
 
-	// Parse the private key
-	key, err = jwt.ParseECPrivateKeyFromPEM(keyByteArray)
+	// Create a new JWT token, naming the key so verifiers can pick it from the JWKS
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = signingKey.Kid
+
+	// Sign the token with the private key
+	signedString, err := token.SignedString(signingKey.PrivateKey)
 	if err != nil {
-		panic(fmt.Errorf("failed to parse private key: %w", err))
+		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
 
-	return key
+	return signedString, nil
 }
 
-func (a *jwtUsecaseImpl) GenerateJWT(userID string) (string, error) {
-	// TODO: implement MapClaims
-	// Create a new JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
-		"sub":       userID,
-		"name":      "John",
-		"last_name": "Doe",
-		"iss":       "my-auth-server-issuer",
-		"foo":       2,
-	})
+// newAccessClaims builds the access token claims for auth. Profile fields missing on the
+// auth record are taken from the first auth method that has them.
+func (a *jwtUsecaseImpl) newAccessClaims(auth *db.Auth, authMethods []*db.AuthMethod) *models.AccessClaims {
+	now := time.Now()
+
+	claims := &models.AccessClaims{
+		Role: models.Role(auth.Role),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   auth.ID,
+			Issuer:    a.issuer,
+			Audience:  jwt.ClaimStrings{a.audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(a.accessTokenTTL)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	if auth.Email != nil {
+		claims.Email = *auth.Email
+	}
 
-	// Set expiration time (e.g., 24 hours from now)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["exp"] = jwt.NewNumericDate(time.Now().Add(24 * time.Hour))
+	for _, method := range authMethods {
+		if claims.Email == "" && method.Email != nil {
+			claims.Email = *method.Email
+		}
+		if claims.Name == "" {
+			claims.Name = displayName(method)
+		}
+	}
 
-	// Sign the token with the private key
-	signedString, err := token.SignedString(a.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+	return claims
+}
+
+// displayName returns the best available name of the user behind an auth method
+func displayName(method *db.AuthMethod) string {
+	if method.Name != nil && *method.Name != "" {
+		return *method.Name
 	}
 
-	return signedString, nil
+	var parts []string
+	for _, part := range []*string{method.FirstName, method.LastName} {
+		if part != nil && *part != "" {
+			parts = append(parts, *part)
+		}
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, " ")
+	}
+
+	if method.NickName != nil {
+		return *method.NickName
+	}
+	return ""
+}
+
+func (a *jwtUsecaseImpl) ValidateJWT(ctx context.Context, tokenString string) (*models.TokenValidationResult, error) {
+	return a.validateJWT(ctx, tokenString, a.audience)
 }
 
-func (a *jwtUsecaseImpl) ValidateJWT(tokenString string) (*models.TokenValidationResult, error) {
+func (a *jwtUsecaseImpl) ValidateMFAJWT(ctx context.Context, tokenString string) (*models.TokenValidationResult, error) {
+	result, err := a.validateJWT(ctx, tokenString, a.audience)
+	if errors.Is(err, jwt.ErrTokenInvalidAudience) {
+		return a.validateJWT(ctx, tokenString, a.mfaPendingAudience())
+	}
+	return result, err
+}
+
+// mfaPendingAudience is the audience of mfa_pending tokens
+func (a *jwtUsecaseImpl) mfaPendingAudience() string {
+	return a.audience + mfaPendingAudienceSuffix
+}
+
+// validateJWT validates an access token for audience, which is an mfa_pending token when
+// audience is the mfa_pending one
+func (a *jwtUsecaseImpl) validateJWT(ctx context.Context, tokenString string, audience string) (*models.TokenValidationResult, error) {
 	result := &models.TokenValidationResult{
 		Valid:    false,
 		Expired:  false,
@@ -112,16 +223,24 @@ func (a *jwtUsecaseImpl) ValidateJWT(tokenString string) (*models.TokenValidatio
 		return result, nil
 	}
 
-	// Parse and validate the token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	// Parse and validate the token, including its issuer and audience
+	claims := &models.AccessClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		// Validate the signing method
 		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
-		// FIXME: check this
-		return a.publicKey, nil
-	})
+		// Tokens issued before key rotation have no kid and are verified with the key file
+		kid, _ := token.Header["kid"].(string)
+		return a.keySet.VerificationKey(kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(a.issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 
 	if err != nil {
 		// Check if error is due to token expiration
@@ -129,32 +248,33 @@ func (a *jwtUsecaseImpl) ValidateJWT(tokenString string) (*models.TokenValidatio
 			result.Expired = true
 			return result, nil
 		}
-		// Other validation errors (malformed token, invalid signature, etc.)
+		// Other validation errors (malformed token, invalid signature, wrong issuer or audience, etc.)
 		return result, fmt.Errorf("failed to parse token: %w", err)
 	}
 
-	// Check if token is valid and extract claims
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		result.Valid = true
-		result.Claims = claims
-
-		// Extract user ID from sub claim
-		if sub, exists := claims["sub"]; exists {
-			if userID, ok := sub.(string); ok {
-				result.UserID = userID
-			}
-		}
+	// A token without a subject does not identify anyone, and only mfa_pending tokens carry
+	// their audience
+	if !token.Valid || claims.Subject == "" || claims.MFAPending != (audience == a.mfaPendingAudience()) {
+		return result, nil
+	}
 
+	revoked, err := a.revocationStore.IsRevoked(ctx, claims)
+	if err != nil {
+		return result, err
+	}
+	if revoked {
+		result.Revoked = true
 		return result, nil
 	}
 
-	// Token is not valid
+	result.Valid = true
+	result.Claims = claims
+	result.UserID = claims.Subject
+
 	return result, nil
 }
 
-func (a *jwtUsecaseImpl) UpsertUser(gothUser goth.User, role ...models.Role) error {
-	ctx := context.Background()
-
+func (a *jwtUsecaseImpl) UpsertUser(ctx context.Context, gothUser goth.User, role ...models.Role) (*db.Auth, error) {
 	// Set default role if none provided
 	userRole := models.RoleUser
 	if len(role) > 0 {
@@ -165,18 +285,26 @@ func (a *jwtUsecaseImpl) UpsertUser(gothUser goth.User, role ...models.Role) err
 	existingAuthMethod, err := a.authRepo.GetAuthMethodByProviderAndID(ctx, gothUser.Provider, gothUser.UserID)
 
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		return fmt.Errorf("failed to check existing auth method: %w", err)
+		return nil, fmt.Errorf("failed to check existing auth method: %w", err)
 	}
 
 	var auth *db.Auth
 
 	if existingAuthMethod != nil {
 		// User exists, get the auth record
-		if existingAuthMethod.AuthID != nil {
-			_, err = a.authRepo.GetAuthByID(ctx, *existingAuthMethod.AuthID)
-			if err != nil {
-				return fmt.Errorf("failed to get existing auth: %w", err)
-			}
+		if existingAuthMethod.AuthID == nil {
+			return nil, fmt.Errorf("auth method %s has no auth", existingAuthMethod.ID)
+		}
+		// GetAuthByID skips deleted auths
+		auth, err = a.authRepo.GetAuthByID(ctx, *existingAuthMethod.AuthID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountDisabled
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get existing auth: %w", err)
+		}
+		if !auth.Active {
+			return nil, ErrAccountDisabled
 		}
 
 		// Update the auth method with new tokens
@@ -199,49 +327,330 @@ func (a *jwtUsecaseImpl) UpsertUser(gothUser goth.User, role ...models.Role) err
 
 		_, err = a.authRepo.UpdateAuthMethod(ctx, updateParams)
 		if err != nil {
-			return fmt.Errorf("failed to update auth method: %w", err)
+			return nil, fmt.Errorf("failed to update auth method: %w", err)
 		}
 	} else {
-		// Create new auth record
-		auth, err = a.authRepo.CreateAuth(ctx,
-			utils.StringToPtr(gothUser.Email), // username
-			nil,                               // password (nil for OAuth users)
-			utils.StringToPtr(gothUser.Email), // email
-			string(userRole),                  // role
-			true,                              // active
-		)
+		// Create the auth record and its first auth method atomically so a failure
+		// in between cannot leave an orphan auths row
+		linked := false
+		err = a.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+			auth, err = a.autoLinkAuth(ctx, gothUser)
+			if err != nil {
+				return err
+			}
+
+			linked = auth != nil
+			if !linked {
+				auth, err = a.authRepo.CreateAuth(ctx,
+					utils.StringToPtr(gothUser.Email), // username
+					nil,                               // password (nil for OAuth users)
+					utils.StringToPtr(gothUser.Email), // email
+					string(userRole),                  // role
+					true,                              // active
+				)
+				if err != nil {
+					// The email belongs to an account that was not linked automatically
+					if isUniqueViolation(err) {
+						return ErrAccountExists
+					}
+					return fmt.Errorf("failed to create auth: %w", err)
+				}
+			}
+
+			if _, err := a.authRepo.CreateAuthMethod(ctx, newCreateAuthMethodParams(gothUser, auth.ID)); err != nil {
+				return fmt.Errorf("failed to create auth method: %w", err)
+			}
+
+			return nil
+		})
+		if errors.Is(err, ErrAccountExists) {
+			a.auditLogger.Log(ctx, models.AuditEvent{
+				Action:   models.AuditActionUserCreate,
+				Outcome:  models.AuditOutcomeFailure,
+				Metadata: map[string]any{"provider": gothUser.Provider, "reason": "account_exists"},
+			})
+		}
 		if err != nil {
-			return fmt.Errorf("failed to create auth: %w", err)
-		}
-
-		// Create auth method
-		authMethod := utils.GothUserToAuthMethod(gothUser, auth.ID)
-
-		createParams := db.CreateAuthMethodParams{
-			AuthID:            authMethod.AuthID,
-			Provider:          authMethod.Provider,
-			ProviderID:        authMethod.ProviderID,
-			Email:             authMethod.Email,
-			UserID:            authMethod.UserID,
-			Name:              authMethod.Name,
-			FirstName:         authMethod.FirstName,
-			LastName:          authMethod.LastName,
-			NickName:          authMethod.NickName,
-			Description:       authMethod.Description,
-			AvatarUrl:         authMethod.AvatarUrl,
-			Location:          authMethod.Location,
-			AccessToken:       authMethod.AccessToken,
-			RefreshToken:      authMethod.RefreshToken,
-			IDToken:           authMethod.IDToken,
-			ExpiresAt:         authMethod.ExpiresAt,
-			AccessTokenSecret: authMethod.AccessTokenSecret,
-		}
-
-		_, err = a.authRepo.CreateAuthMethod(ctx, createParams)
+			return nil, err
+		}
+
+		// Logged once the transaction is committed, so a rollback cannot take the event along
+		action := models.AuditActionUserCreate
+		if linked {
+			action = models.AuditActionProviderLink
+		}
+		a.auditLogger.Log(ctx, models.AuditEvent{
+			ActorID:    auth.ID,
+			Action:     action,
+			TargetType: models.AuditTargetUser,
+			TargetID:   auth.ID,
+			Outcome:    models.AuditOutcomeSuccess,
+			Metadata:   map[string]any{"provider": gothUser.Provider, "auto_link": linked},
+		})
+	}
+
+	return auth, nil
+}
+
+// autoLinkAuth returns the account that gothUser logs into by its verified email when
+// AUTH_EMAIL_AUTO_LINK is on, or nil when a new account has to be created. Accounts with a
+// password are never returned, since nothing proves their owner controls the email, and
+// deactivated accounts give ErrAccountDisabled.
+func (a *jwtUsecaseImpl) autoLinkAuth(ctx context.Context, gothUser goth.User) (*db.Auth, error) {
+	if !a.emailAutoLink || !utils.EmailVerified(gothUser) {
+		return nil, nil
+	}
+
+	auth, err := a.authRepo.GetAuthByEmail(ctx, gothUser.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth by email: %w", err)
+	}
+	if auth.Password != nil && *auth.Password != "" {
+		return nil, nil
+	}
+	if !auth.Active {
+		return nil, ErrAccountDisabled
+	}
+
+	// Another account of the same provider is already linked, which would have to be unlinked first
+	authMethods, err := a.authRepo.GetAuthMethodsByAuthID(ctx, auth.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth methods: %w", err)
+	}
+	for _, method := range authMethods {
+		if method.Provider == gothUser.Provider {
+			return nil, nil
+		}
+	}
+
+	logger.Infof("Linking %s account to auth %s by verified email", gothUser.Provider, auth.ID)
+	return auth, nil
+}
+
+// newCreateAuthMethodParams returns the params that store gothUser as an auth method of authID
+func newCreateAuthMethodParams(gothUser goth.User, authID string) db.CreateAuthMethodParams {
+	authMethod := utils.GothUserToAuthMethod(gothUser, authID)
+
+	return db.CreateAuthMethodParams{
+		AuthID:            authMethod.AuthID,
+		Provider:          authMethod.Provider,
+		ProviderID:        authMethod.ProviderID,
+		Email:             authMethod.Email,
+		UserID:            authMethod.UserID,
+		Name:              authMethod.Name,
+		FirstName:         authMethod.FirstName,
+		LastName:          authMethod.LastName,
+		NickName:          authMethod.NickName,
+		Description:       authMethod.Description,
+		AvatarUrl:         authMethod.AvatarUrl,
+		Location:          authMethod.Location,
+		AccessToken:       authMethod.AccessToken,
+		RefreshToken:      authMethod.RefreshToken,
+		IDToken:           authMethod.IDToken,
+		ExpiresAt:         authMethod.ExpiresAt,
+		AccessTokenSecret: authMethod.AccessTokenSecret,
+	}
+}
+
+func (a *jwtUsecaseImpl) IssueTokens(ctx context.Context, authID string) (*models.TokenPair, error) {
+	auth, err := a.authRepo.GetAuthByID(ctx, authID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.MFAEnabledAt.Valid || a.mfaRequiredRoles[models.Role(auth.Role)] {
+		return a.issueMFAPendingToken(auth)
+	}
+	return a.issueTokenPair(ctx, auth, uuid.NewString())
+}
+
+func (a *jwtUsecaseImpl) IssueMFAVerifiedTokens(ctx context.Context, authID string) (*models.TokenPair, error) {
+	auth, err := a.authRepo.GetAuthByID(ctx, authID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+	return a.issueTokenPair(ctx, auth, uuid.NewString())
+}
+
+// issueMFAPendingToken signs a short-lived mfa_pending token for auth. It has no refresh token,
+// so the login cannot go on without the second factor. It has an audience of its own and no
+// role, so nothing taking access tokens mistakes it for one.
+func (a *jwtUsecaseImpl) issueMFAPendingToken(auth *db.Auth) (*models.TokenPair, error) {
+	now := time.Now()
+	claims := &models.AccessClaims{
+		MFAPending: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   auth.ID,
+			Issuer:    a.issuer,
+			Audience:  jwt.ClaimStrings{a.mfaPendingAudience()},
+			ExpiresAt: jwt.NewNumericDate(now.Add(a.mfaPendingTTL)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	accessToken, err := a.signClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(a.mfaPendingTTL.Seconds()),
+		MFARequired: true,
+	}, nil
+}
+
+func (a *jwtUsecaseImpl) RefreshTokens(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var (
+		pair   *models.TokenPair
+		reused *db.RefreshToken
+	)
+
+	err := a.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		pair, reused = nil, nil
+
+		stored, err := a.refreshTokenRepo.GetRefreshTokenByHash(ctx, hashRefreshToken(refreshToken))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get refresh token: %w", err)
+		}
+
+		if stored.RevokedAt.Valid || !stored.ExpiresAt.Time.After(time.Now()) {
+			return ErrInvalidRefreshToken
+		}
+
+		// A used token means either the client or an attacker holds a stale copy, so the
+		// whole family is revoked. The transaction still commits so the revocation sticks.
+		if stored.UsedAt.Valid {
+			reused = stored
+			return a.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+		}
+
+		if _, err := a.refreshTokenRepo.MarkRefreshTokenUsed(ctx, stored.ID); err != nil {
+			// Lost a race with a concurrent refresh of the same token
+			if errors.Is(err, pgx.ErrNoRows) {
+				reused = stored
+				return a.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+			}
+			return fmt.Errorf("failed to mark refresh token used: %w", err)
+		}
+
+		// GetAuthByID skips deleted auths, which like deactivated ones cannot refresh
+		auth, err := a.authRepo.GetAuthByID(ctx, stored.AuthID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidRefreshToken
+		}
 		if err != nil {
-			return fmt.Errorf("failed to create auth method: %w", err)
+			return fmt.Errorf("failed to get auth: %w", err)
+		}
+		if !auth.Active {
+			return ErrInvalidRefreshToken
 		}
+
+		pair, err = a.issueTokenPair(ctx, auth, stored.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused != nil {
+		logger.Warnf("Refresh token reuse detected for auth %s, revoked token family %s", reused.AuthID, reused.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+
+	return pair, nil
+}
+
+func (a *jwtUsecaseImpl) Logout(ctx context.Context, claims *models.AccessClaims, refreshToken string) error {
+	if err := a.revocationStore.Revoke(ctx, claims); err != nil {
+		return err
 	}
 
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := a.refreshTokenRepo.GetRefreshTokenByHash(ctx, hashRefreshToken(refreshToken))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	// Only the owner of the refresh token can end its session
+	if stored.AuthID != claims.Subject {
+		return nil
+	}
+
+	if err := a.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
 	return nil
 }
+
+func (a *jwtUsecaseImpl) RevokeAllTokens(ctx context.Context, authID string) error {
+	return a.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		if err := a.revocationStore.RevokeAll(ctx, authID); err != nil {
+			return err
+		}
+
+		if err := a.refreshTokenRepo.RevokeRefreshTokensByAuthID(ctx, authID); err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+		return nil
+	})
+}
+
+// issueTokenPair signs an access token for auth and stores a new refresh token in familyID
+func (a *jwtUsecaseImpl) issueTokenPair(ctx context.Context, auth *db.Auth, familyID string) (*models.TokenPair, error) {
+	accessToken, err := a.generateJWT(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(a.refreshTokenTTL)
+	if _, err := a.refreshTokenRepo.CreateRefreshToken(ctx, auth.ID, familyID, hashRefreshToken(refreshToken), expiresAt); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(a.accessTokenTTL.Seconds()),
+	}, nil
+}
+
+// newRefreshToken returns a random opaque token safe to use in URLs
+func newRefreshToken() (string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashRefreshToken returns the hex SHA-256 of token, which is what the database stores
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,54 +1,710 @@
 package usecases
 
 import (
+	"context"
+	"errors"
 	"template-golang/config"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"template-golang/modules/auth/usecases/mocks"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/markbates/goth"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func setupJWTUsecase(t *testing.T) JWTUsecase {
-	conf := &config.Config{
+func newTestConfig() *config.Config {
+	return &config.Config{
 		Auth: config.AuthConfig{
 			PrivateKeyPath: "../../../config/ecdsa_private_key_test.pem",
+			JWTIssuer:      "test-issuer",
+			JWTAudience:    "test-audience",
 		},
 	}
+}
+
+func setupJWTUsecase(t *testing.T) JWTUsecase {
+	conf := newTestConfig()
+	revokedTokenRepo := repoMocks.NewMockRevokedTokenRepository(t)
+	expectNotRevoked(revokedTokenRepo)
+	return NewJWTUsecase(conf, NewKeySet(conf, nil, nil), NewRevocationStore(conf, revokedTokenRepo), nil, nil, nil, nil)
+}
+
+// expectNotRevoked reports every token and auth as not revoked
+func expectNotRevoked(revokedTokenRepo *repoMocks.MockRevokedTokenRepository) {
+	revokedTokenRepo.EXPECT().IsTokenRevoked(mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	revokedTokenRepo.EXPECT().GetAuthTokensRevokedAt(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+}
 
-	return NewJWTUsecase(conf, nil)
+// expectAuthLookup mocks the rows GenerateJWT reads to build the claims
+func expectAuthLookup(m jwtUsecaseMocks, auth *db.Auth, authMethods ...*db.AuthMethod) {
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, auth.ID).Return(auth, nil).Once()
+	m.authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, auth.ID).Return(authMethods, nil).Once()
 }
 
 func TestGenerateJWT(t *testing.T) {
-	jwtUsecase := setupJWTUsecase(t)
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectNotRevoked(m.revokedTokenRepo)
 
-	userID := "test-user-123"
-	token, err := jwtUsecase.GenerateJWT(userID)
+	email := "john@example.com"
+	name := "John Doe"
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Email: &email, Role: "admin"}, &db.AuthMethod{Name: &name})
 
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "auth-1")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), token)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "auth-1", result.UserID)
+	assert.Equal(t, models.RoleAdmin, result.Claims.Role)
+	assert.Equal(t, email, result.Claims.Email)
+	assert.Equal(t, name, result.Claims.Name)
+	assert.Equal(t, "test-issuer", result.Claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"test-audience"}, result.Claims.Audience)
+	assert.NotEmpty(t, result.Claims.ID)
+	assert.NotNil(t, result.Claims.IssuedAt)
+	assert.NotNil(t, result.Claims.NotBefore)
+	assert.NotNil(t, result.Claims.ExpiresAt)
+}
+
+func TestGenerateJWT_ProfileFromAuthMethods(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectNotRevoked(m.revokedTokenRepo)
+
+	email := "line@example.com"
+	firstName, lastName := "Jane", "Doe"
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Role: "user"},
+		&db.AuthMethod{Email: &email, FirstName: &firstName, LastName: &lastName})
+
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "auth-1")
+	assert.NoError(t, err)
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, email, result.Claims.Email)
+	assert.Equal(t, "Jane Doe", result.Claims.Name)
+}
+
+func TestGenerateJWT_UnknownAuth(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "missing").Return(nil, pgx.ErrNoRows).Once()
+
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Empty(t, token)
 }
 
 func TestValidateJWT_ValidToken(t *testing.T) {
-	jwtUsecase := setupJWTUsecase(t)
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectNotRevoked(m.revokedTokenRepo)
+	expectAuthLookup(m, &db.Auth{ID: "test-user-123", Role: "user"})
 
 	// Generate a valid token first
-	userID := "test-user-123"
-	token, err := jwtUsecase.GenerateJWT(userID)
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "test-user-123")
 	assert.NoError(t, err)
 
 	// Validate the token
-	result, err := jwtUsecase.ValidateJWT(token)
+	result, err := jwtUsecase.ValidateJWT(context.Background(), token)
 	assert.NoError(t, err)
 	assert.True(t, result.Valid)
 	assert.False(t, result.Expired)
 	assert.False(t, result.NotExist)
 }
 
+func TestValidateJWT_RejectsWrongIssuerOrAudience(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(conf *config.Config)
+	}{
+		{
+			name:   "wrong issuer",
+			modify: func(conf *config.Config) { conf.Auth.JWTIssuer = "other-issuer" },
+		},
+		{
+			name:   "wrong audience",
+			modify: func(conf *config.Config) { conf.Auth.JWTAudience = "other-audience" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Sign with the same key but a different issuer or audience
+			conf := newTestConfig()
+			tt.modify(conf)
+
+			authRepo := repoMocks.NewMockAuthRepository(t)
+			authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "user"}, nil).Once()
+			authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").Return(nil, nil).Once()
+
+			token, err := NewJWTUsecase(conf, NewKeySet(conf, nil, nil), nil, nil, authRepo, nil, nil).GenerateJWT(context.Background(), "auth-1")
+			assert.NoError(t, err)
+
+			result, err := setupJWTUsecase(t).ValidateJWT(context.Background(), token)
+
+			assert.Error(t, err)
+			assert.False(t, result.Valid)
+		})
+	}
+}
+
 func TestValidateJWT_InvalidToken(t *testing.T) {
 	jwtUsecase := setupJWTUsecase(t)
 
 	// Test with invalid token
-	_, err := jwtUsecase.ValidateJWT("invalid-token")
+	_, err := jwtUsecase.ValidateJWT(context.Background(), "invalid-token")
 	// The implementation returns an error for malformed tokens
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse token")
 }
+
+type jwtUsecaseMocks struct {
+	authRepo         *repoMocks.MockAuthRepository
+	refreshTokenRepo *repoMocks.MockRefreshTokenRepository
+	revokedTokenRepo *repoMocks.MockRevokedTokenRepository
+	auditLogger      *mocks.MockAuditLogger
+	txManager        *dbMocks.MockTxManager
+}
+
+func setupJWTUsecaseWithMocks(t *testing.T) (JWTUsecase, jwtUsecaseMocks) {
+	m := jwtUsecaseMocks{
+		authRepo:         repoMocks.NewMockAuthRepository(t),
+		refreshTokenRepo: repoMocks.NewMockRefreshTokenRepository(t),
+		revokedTokenRepo: repoMocks.NewMockRevokedTokenRepository(t),
+		auditLogger:      mocks.NewMockAuditLogger(t),
+		txManager:        dbMocks.NewMockTxManager(t),
+	}
+
+	conf := newTestConfig()
+	revocationStore := NewRevocationStore(conf, m.revokedTokenRepo)
+	return NewJWTUsecase(conf, NewKeySet(conf, nil, nil), revocationStore, m.auditLogger, m.authRepo, m.refreshTokenRepo, m.txManager), m
+}
+
+// runInTx makes the mocked TxManager execute the unit of work like a real transaction would
+func runInTx(txManager *dbMocks.MockTxManager) {
+	txManager.EXPECT().WithTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context, q *db.Queries) error) error {
+			return fn(ctx, nil)
+		}).Once()
+}
+
+func TestUpsertUser_NewUserCreatedInTransaction(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	gothUser := goth.User{Provider: "line", UserID: "line-123", Email: "john@example.com"}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "line", "line-123").Return(nil, pgx.ErrNoRows).Once()
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().CreateAuth(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "user", true).
+		Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	m.authRepo.EXPECT().CreateAuthMethod(mock.Anything, mock.MatchedBy(func(p db.CreateAuthMethodParams) bool {
+		return p.AuthID != nil && *p.AuthID == "auth-1" && p.Provider == "line" && p.ProviderID == "line-123"
+	})).Return(&db.AuthMethod{ID: "method-1"}, nil).Once()
+	m.auditLogger.EXPECT().Log(mock.Anything, models.AuditEvent{
+		ActorID:    "auth-1",
+		Action:     models.AuditActionUserCreate,
+		TargetType: models.AuditTargetUser,
+		TargetID:   "auth-1",
+		Outcome:    models.AuditOutcomeSuccess,
+		Metadata:   map[string]any{"provider": "line", "auto_link": false},
+	}).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "auth-1", auth.ID)
+}
+
+func TestUpsertUser_AuthMethodFailureRollsBack(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	gothUser := goth.User{Provider: "line", UserID: "line-123"}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "line", "line-123").Return(nil, pgx.ErrNoRows).Once()
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().CreateAuth(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "user", true).
+		Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	m.authRepo.EXPECT().CreateAuthMethod(mock.Anything, mock.Anything).Return(nil, errors.New("insert failed")).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.Error(t, err)
+	assert.Nil(t, auth)
+	assert.Contains(t, err.Error(), "failed to create auth method")
+}
+
+func TestUpsertUser_ExistingUserReturnsAuth(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	authID := "auth-1"
+	gothUser := goth.User{Provider: "line", UserID: "line-123", AccessToken: "new-access-token"}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "line", "line-123").
+		Return(&db.AuthMethod{ID: "method-1", AuthID: &authID}, nil).Once()
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, authID).Return(&db.Auth{ID: authID, Active: true}, nil).Once()
+	m.authRepo.EXPECT().UpdateAuthMethod(mock.Anything, mock.Anything).Return(&db.AuthMethod{ID: "method-1"}, nil).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.NoError(t, err)
+	assert.Equal(t, authID, auth.ID)
+}
+
+func TestUpsertUser_DisabledAccountRefused(t *testing.T) {
+	authID := "auth-1"
+	tests := []struct {
+		name string
+		auth *db.Auth
+		err  error
+	}{
+		{"deactivated", &db.Auth{ID: authID, Active: false}, nil},
+		{"deleted", nil, pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+			gothUser := goth.User{Provider: "line", UserID: "line-123", AccessToken: "new-access-token"}
+
+			m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "line", "line-123").
+				Return(&db.AuthMethod{ID: "method-1", AuthID: &authID}, nil).Once()
+			m.authRepo.EXPECT().GetAuthByID(mock.Anything, authID).Return(tt.auth, tt.err).Once()
+
+			// The tokens of the provider are not stored for a disabled account
+			auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+			assert.ErrorIs(t, err, ErrAccountDisabled)
+			assert.Nil(t, auth)
+		})
+	}
+}
+
+func TestUpsertUser_AutoLinksVerifiedEmail(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	jwtUsecase.(*jwtUsecaseImpl).emailAutoLink = true
+
+	gothUser := goth.User{
+		Provider: "google",
+		UserID:   "google-1",
+		Email:    "john@example.com",
+		RawData:  map[string]interface{}{"email_verified": true},
+	}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "google", "google-1").Return(nil, pgx.ErrNoRows).Once()
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().GetAuthByEmail(mock.Anything, "john@example.com").Return(&db.Auth{ID: "auth-1", Active: true}, nil).Once()
+	m.authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").
+		Return([]*db.AuthMethod{{ID: "method-1", Provider: "github"}}, nil).Once()
+	m.authRepo.EXPECT().CreateAuthMethod(mock.Anything, mock.MatchedBy(func(p db.CreateAuthMethodParams) bool {
+		return p.AuthID != nil && *p.AuthID == "auth-1" && p.Provider == "google"
+	})).Return(&db.AuthMethod{ID: "method-2"}, nil).Once()
+	m.auditLogger.EXPECT().Log(mock.Anything, mock.MatchedBy(func(e models.AuditEvent) bool {
+		return e.Action == models.AuditActionProviderLink && e.TargetID == "auth-1" && e.Metadata["auto_link"] == true
+	})).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "auth-1", auth.ID)
+}
+
+func TestUpsertUser_ExistingEmailWithoutAutoLink(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	gothUser := goth.User{
+		Provider: "google",
+		UserID:   "google-1",
+		Email:    "john@example.com",
+		RawData:  map[string]interface{}{"email_verified": true},
+	}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "google", "google-1").Return(nil, pgx.ErrNoRows).Once()
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().CreateAuth(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "user", true).
+		Return(nil, &pgconn.PgError{Code: sqlStateUniqueViolation}).Once()
+	m.auditLogger.EXPECT().Log(mock.Anything, mock.MatchedBy(func(e models.AuditEvent) bool {
+		return e.Action == models.AuditActionUserCreate && e.Outcome == models.AuditOutcomeFailure
+	})).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.ErrorIs(t, err, ErrAccountExists)
+	assert.Nil(t, auth)
+}
+
+func TestUpsertUser_NoAutoLinkToPasswordAccount(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	jwtUsecase.(*jwtUsecaseImpl).emailAutoLink = true
+	hash := "$2a$10$hash"
+
+	gothUser := goth.User{
+		Provider: "google",
+		UserID:   "google-1",
+		Email:    "john@example.com",
+		RawData:  map[string]interface{}{"email_verified": true},
+	}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "google", "google-1").Return(nil, pgx.ErrNoRows).Once()
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().GetAuthByEmail(mock.Anything, "john@example.com").Return(&db.Auth{ID: "auth-1", Password: &hash}, nil).Once()
+	m.authRepo.EXPECT().CreateAuth(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "user", true).
+		Return(nil, &pgconn.PgError{Code: sqlStateUniqueViolation}).Once()
+	m.auditLogger.EXPECT().Log(mock.Anything, mock.MatchedBy(func(e models.AuditEvent) bool {
+		return e.Action == models.AuditActionUserCreate && e.Outcome == models.AuditOutcomeFailure
+	})).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.ErrorIs(t, err, ErrAccountExists)
+	assert.Nil(t, auth)
+}
+
+func TestUpsertUser_NoAutoLinkToDeactivatedAccount(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	jwtUsecase.(*jwtUsecaseImpl).emailAutoLink = true
+
+	gothUser := goth.User{
+		Provider: "google",
+		UserID:   "google-1",
+		Email:    "john@example.com",
+		RawData:  map[string]interface{}{"email_verified": true},
+	}
+
+	m.authRepo.EXPECT().GetAuthMethodByProviderAndID(mock.Anything, "google", "google-1").Return(nil, pgx.ErrNoRows).Once()
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().GetAuthByEmail(mock.Anything, "john@example.com").Return(&db.Auth{ID: "auth-1", Active: false}, nil).Once()
+
+	auth, err := jwtUsecase.UpsertUser(context.Background(), gothUser)
+
+	assert.ErrorIs(t, err, ErrAccountDisabled)
+	assert.Nil(t, auth)
+}
+
+func TestIssueTokens_StoresHashedRefreshToken(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectNotRevoked(m.revokedTokenRepo)
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Role: "user"})
+
+	var storedHash, familyID string
+	m.refreshTokenRepo.EXPECT().CreateRefreshToken(mock.Anything, "auth-1", mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, authID string, family string, tokenHash string, expiresAt time.Time) (*db.RefreshToken, error) {
+			storedHash, familyID = tokenHash, family
+			assert.WithinDuration(t, time.Now().Add(defaultRefreshTokenTTL), expiresAt, time.Minute)
+			return &db.RefreshToken{ID: "rt-1"}, nil
+		}).Once()
+
+	pair, err := jwtUsecase.IssueTokens(context.Background(), "auth-1")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.Equal(t, "Bearer", pair.TokenType)
+	assert.Equal(t, int64(defaultAccessTokenTTL.Seconds()), pair.ExpiresIn)
+	assert.NotEmpty(t, familyID)
+	assert.Equal(t, hashRefreshToken(pair.RefreshToken), storedHash)
+	assert.NotEqual(t, pair.RefreshToken, storedHash)
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "auth-1", result.UserID)
+}
+
+func TestIssueTokens_MFAPending(t *testing.T) {
+	tests := []struct {
+		name          string
+		requiredRoles string
+		auth          *db.Auth
+	}{
+		{
+			name: "mfa enabled",
+			auth: &db.Auth{ID: "auth-1", Role: "user", MFAEnabledAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
+		},
+		{
+			name:          "mfa required by role",
+			requiredRoles: "admin",
+			auth:          &db.Auth{ID: "auth-1", Role: "admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := newTestConfig()
+			conf.Auth.MFARequiredRoles = tt.requiredRoles
+			authRepo := repoMocks.NewMockAuthRepository(t)
+			revokedTokenRepo := repoMocks.NewMockRevokedTokenRepository(t)
+			expectNotRevoked(revokedTokenRepo)
+			// No refresh token is stored, so the refresh token repository is never called
+			jwtUsecase := NewJWTUsecase(conf, NewKeySet(conf, nil, nil), NewRevocationStore(conf, revokedTokenRepo), nil, authRepo,
+				repoMocks.NewMockRefreshTokenRepository(t), nil)
+
+			authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(tt.auth, nil).Once()
+
+			pair, err := jwtUsecase.IssueTokens(context.Background(), "auth-1")
+
+			assert.NoError(t, err)
+			assert.True(t, pair.MFARequired)
+			assert.Empty(t, pair.RefreshToken)
+			assert.Equal(t, int64(defaultMFAPendingTTL.Seconds()), pair.ExpiresIn)
+
+			// Only the MFA routes take it, and it carries no role for other services to trust
+			_, err = jwtUsecase.ValidateJWT(context.Background(), pair.AccessToken)
+			assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+
+			result, err := jwtUsecase.ValidateMFAJWT(context.Background(), pair.AccessToken)
+			assert.NoError(t, err)
+			assert.True(t, result.Valid)
+			assert.True(t, result.Claims.MFAPending)
+			assert.Empty(t, result.Claims.Role)
+			assert.Equal(t, jwt.ClaimStrings{conf.Auth.JWTAudience + mfaPendingAudienceSuffix}, result.Claims.Audience)
+
+			raw := jwt.MapClaims{}
+			_, _, err = jwt.NewParser().ParseUnverified(pair.AccessToken, raw)
+			require.NoError(t, err)
+			assert.NotContains(t, raw, "role")
+		})
+	}
+}
+
+func TestRefreshTokens_RotatesWithinFamily(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	stored := &db.RefreshToken{
+		ID:        "rt-1",
+		AuthID:    "auth-1",
+		FamilyID:  "family-1",
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	runInTx(m.txManager)
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Role: "user", Active: true})
+	m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, hashRefreshToken("old-token")).Return(stored, nil).Once()
+	m.refreshTokenRepo.EXPECT().MarkRefreshTokenUsed(mock.Anything, "rt-1").Return(stored, nil).Once()
+	m.refreshTokenRepo.EXPECT().CreateRefreshToken(mock.Anything, "auth-1", "family-1", mock.Anything, mock.Anything).
+		Return(&db.RefreshToken{ID: "rt-2"}, nil).Once()
+
+	pair, err := jwtUsecase.RefreshTokens(context.Background(), "old-token")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEqual(t, "old-token", pair.RefreshToken)
+}
+
+func TestRefreshTokens_DeactivatedAccount(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	stored := &db.RefreshToken{
+		ID:        "rt-1",
+		AuthID:    "auth-1",
+		FamilyID:  "family-1",
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	runInTx(m.txManager)
+	m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, hashRefreshToken("old-token")).Return(stored, nil).Once()
+	m.refreshTokenRepo.EXPECT().MarkRefreshTokenUsed(mock.Anything, "rt-1").Return(stored, nil).Once()
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "user", Active: false}, nil).Once()
+
+	pair, err := jwtUsecase.RefreshTokens(context.Background(), "old-token")
+
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Nil(t, pair)
+}
+
+func TestRefreshTokens_ReuseRevokesFamily(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	stored := &db.RefreshToken{
+		ID:        "rt-1",
+		AuthID:    "auth-1",
+		FamilyID:  "family-1",
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+		UsedAt:    pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true},
+	}
+
+	runInTx(m.txManager)
+	m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(stored, nil).Once()
+	m.refreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(mock.Anything, "family-1").Return(nil).Once()
+
+	pair, err := jwtUsecase.RefreshTokens(context.Background(), "old-token")
+
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Nil(t, pair)
+}
+
+func TestRefreshTokens_ConcurrentRotationRevokesFamily(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	stored := &db.RefreshToken{
+		ID:        "rt-1",
+		AuthID:    "auth-1",
+		FamilyID:  "family-1",
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	runInTx(m.txManager)
+	m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(stored, nil).Once()
+	m.refreshTokenRepo.EXPECT().MarkRefreshTokenUsed(mock.Anything, "rt-1").Return(nil, pgx.ErrNoRows).Once()
+	m.refreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(mock.Anything, "family-1").Return(nil).Once()
+
+	_, err := jwtUsecase.RefreshTokens(context.Background(), "old-token")
+
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+}
+
+func TestRefreshTokens_RejectsUnknownExpiredAndRevokedTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored *db.RefreshToken
+		err    error
+	}{
+		{
+			name: "unknown token",
+			err:  pgx.ErrNoRows,
+		},
+		{
+			name: "expired token",
+			stored: &db.RefreshToken{
+				ID:        "rt-1",
+				ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true},
+			},
+		},
+		{
+			name: "revoked token",
+			stored: &db.RefreshToken{
+				ID:        "rt-1",
+				ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+				RevokedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+			runInTx(m.txManager)
+			m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(tt.stored, tt.err).Once()
+
+			pair, err := jwtUsecase.RefreshTokens(context.Background(), "some-token")
+
+			assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+			assert.Nil(t, pair)
+		})
+	}
+}
+
+func TestRefreshTokens_EmptyToken(t *testing.T) {
+	jwtUsecase, _ := setupJWTUsecaseWithMocks(t)
+
+	_, err := jwtUsecase.RefreshTokens(context.Background(), "")
+
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestValidateJWT_RevokedToken(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Role: "user"})
+
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "auth-1")
+	assert.NoError(t, err)
+
+	m.revokedTokenRepo.EXPECT().IsTokenRevoked(mock.Anything, mock.Anything).Return(true, nil).Once()
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Revoked)
+	assert.False(t, result.Valid)
+	assert.Nil(t, result.Claims)
+}
+
+func TestValidateJWT_FailsClosedWhenRevocationCheckFails(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Role: "user"})
+
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "auth-1")
+	assert.NoError(t, err)
+
+	m.revokedTokenRepo.EXPECT().IsTokenRevoked(mock.Anything, mock.Anything).Return(false, errors.New("db down")).Once()
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), token)
+
+	assert.Error(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestLogout_RevokesAccessTokenAndRefreshFamily(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{
+		ID:        "jti-1",
+		Subject:   "auth-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}}
+	stored := &db.RefreshToken{ID: "rt-1", AuthID: "auth-1", FamilyID: "family-1"}
+
+	m.revokedTokenRepo.EXPECT().RevokeToken(mock.Anything, "jti-1", "auth-1", claims.ExpiresAt.Time).Return(nil).Once()
+	m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, hashRefreshToken("refresh")).Return(stored, nil).Once()
+	m.refreshTokenRepo.EXPECT().RevokeRefreshTokenFamily(mock.Anything, "family-1").Return(nil).Once()
+
+	err := jwtUsecase.Logout(context.Background(), claims, "refresh")
+
+	assert.NoError(t, err)
+}
+
+func TestLogout_IgnoresRefreshTokenOfAnotherUser(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	claims := &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1", Subject: "auth-1"}}
+	stored := &db.RefreshToken{ID: "rt-1", AuthID: "auth-2", FamilyID: "family-2"}
+
+	m.revokedTokenRepo.EXPECT().RevokeToken(mock.Anything, "jti-1", "auth-1", mock.Anything).Return(nil).Once()
+	m.refreshTokenRepo.EXPECT().GetRefreshTokenByHash(mock.Anything, hashRefreshToken("refresh")).Return(stored, nil).Once()
+
+	err := jwtUsecase.Logout(context.Background(), claims, "refresh")
+
+	assert.NoError(t, err)
+}
+
+func TestLogout_TokenWithoutID(t *testing.T) {
+	jwtUsecase, _ := setupJWTUsecaseWithMocks(t)
+
+	err := jwtUsecase.Logout(context.Background(), &models.AccessClaims{}, "")
+
+	assert.ErrorIs(t, err, ErrTokenNotRevocable)
+}
+
+func TestRevokeAllTokens_RevokesAccessAndRefreshTokensInTransaction(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	runInTx(m.txManager)
+	m.revokedTokenRepo.EXPECT().RevokeAuthTokens(mock.Anything, "auth-1").Return(time.Now(), nil).Once()
+	m.refreshTokenRepo.EXPECT().RevokeRefreshTokensByAuthID(mock.Anything, "auth-1").Return(nil).Once()
+
+	err := jwtUsecase.RevokeAllTokens(context.Background(), "auth-1")
+
+	assert.NoError(t, err)
+}
+
+func TestRevokeAllTokens_UnknownAuth(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+
+	runInTx(m.txManager)
+	m.revokedTokenRepo.EXPECT().RevokeAuthTokens(mock.Anything, "missing").Return(time.Time{}, pgx.ErrNoRows).Once()
+
+	err := jwtUsecase.RevokeAllTokens(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"template-golang/modules/auth/models"
+)
+
+var (
+	// ErrNoSigningKey is returned when neither the database nor the key file provides a signing key
+	ErrNoSigningKey = errors.New("no signing key available")
+	// ErrUnknownKeyID is returned when a token names a kid that is not in the key set
+	ErrUnknownKeyID = errors.New("unknown signing key")
+	// ErrKeyRotationUnavailable is returned when rotating a key set that only has the key file
+	ErrKeyRotationUnavailable = errors.New("key rotation requires a signing key repository")
+)
+
+// KeySet holds the keys that sign and verify access tokens. One key is active for signing,
+// older keys are kept for verification until the tokens they signed have expired.
+type KeySet interface {
+	// SigningKey returns the active key new tokens are signed with
+	SigningKey() (*models.SigningKey, error)
+	// VerificationKey returns the public key for kid. An empty kid selects the key file,
+	// which signed tokens before they carried a kid.
+	VerificationKey(kid string) (*ecdsa.PublicKey, error)
+	// JWKS returns the public keys of every key that can still verify tokens
+	JWKS() *models.JWKS
+	// Refresh reloads the keys stored in the database
+	Refresh(ctx context.Context) error
+	// Rotate makes a new key active. The previous key keeps verifying until its tokens expire.
+	Rotate(ctx context.Context) (*models.SigningKey, error)
+	// Run rotates keys on schedule and picks up rotations made by other replicas until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,424 @@
+package usecases
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"template-golang/config"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultKeyRefreshInterval = time.Minute
+
+	// onDemandRefreshInterval limits how often an unknown kid triggers a reload,
+	// so tokens with made-up kids cannot flood the database
+	onDemandRefreshInterval = 5 * time.Second
+	onDemandRefreshTimeout  = 5 * time.Second
+)
+
+type keySetImpl struct {
+	signingKeyRepo repositories.SigningKeyRepository
+	txManager      database.TxManager
+
+	rotationInterval time.Duration
+	refreshInterval  time.Duration
+	// verifyWindow is how long a retired key keeps verifying: tokens it signed live for the
+	// access token TTL, and replicas may keep signing with it until their next refresh
+	verifyWindow time.Duration
+
+	fileKey *models.SigningKey
+
+	mu              sync.RWMutex
+	active          *models.SigningKey
+	activeCreatedAt time.Time // zero when the key file is active
+	publicKeys      map[string]*ecdsa.PublicKey
+	jwks            *models.JWKS
+	lastRefresh     time.Time
+}
+
+// NewKeySet creates a key set from the key at PRIVATE_KEY_PATH and the keys stored through
+// signingKeyRepo. signingKeyRepo may be nil to only use the key file.
+func NewKeySet(conf *config.Config, signingKeyRepo repositories.SigningKeyRepository, txManager database.TxManager) KeySet {
+	accessTokenTTL := conf.Auth.AccessTokenTTL
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = defaultAccessTokenTTL
+	}
+	refreshInterval := conf.Auth.KeyRefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultKeyRefreshInterval
+	}
+
+	k := &keySetImpl{
+		signingKeyRepo:   signingKeyRepo,
+		txManager:        txManager,
+		rotationInterval: conf.Auth.KeyRotationInterval,
+		refreshInterval:  refreshInterval,
+		verifyWindow:     accessTokenTTL + refreshInterval,
+	}
+
+	if conf.Auth.PrivateKeyPath != "" {
+		privateKey := loadPrivateKey(conf.Auth.PrivateKeyPath)
+		k.fileKey = &models.SigningKey{
+			Kid:        keyThumbprint(&privateKey.PublicKey),
+			PrivateKey: privateKey,
+		}
+	}
+
+	k.load(nil)
+
+	return k
+}
+
+// we use panic because if not have private key, we cannot run the server
+func loadPrivateKey(path string) *ecdsa.PrivateKey {
+	// init key
+	var keyByteArray []byte
+	var key *ecdsa.PrivateKey
+
+	// Validate and clean the path to prevent directory traversal
+	cleanPath := filepath.Clean(path)
+	absPath, err := filepath.Abs(cleanPath)
+	if err != nil {
+		panic(fmt.Errorf("failed to resolve absolute path: %w", err))
+	}
+
+	// Basic security check - prevent access to system directories
+	if strings.Contains(absPath, "/etc/") || strings.Contains(absPath, "/usr/") ||
+		strings.Contains(absPath, "/var/") || strings.Contains(absPath, "/root/") ||
+		strings.Contains(absPath, "/home/") && !strings.Contains(absPath, "/home/"+os.Getenv("USER")) {
+		panic(fmt.Errorf("invalid path: access to system directories not allowed"))
+	}
+
+	// Load the private key from a file
+	keyByteArray, err = os.ReadFile(absPath) // #nosec G304 -- Path is validated to prevent directory traversal and system file access
+	if err != nil {
+		panic(fmt.Errorf("failed to read private key: %w", err))
+	}
+
+	// Parse the private key
+	key, err = jwt.ParseECPrivateKeyFromPEM(keyByteArray)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse private key: %w", err))
+	}
+
+	// Tokens are signed with ES256, which requires a P-256 key
+	if key.Curve != elliptic.P256() {
+		panic(fmt.Errorf("invalid private key: ES256 requires a P-256 key, got %s", key.Curve.Params().Name))
+	}
+
+	return key
+}
+
+func (k *keySetImpl) SigningKey() (*models.SigningKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.active == nil {
+		return nil, ErrNoSigningKey
+	}
+	return k.active, nil
+}
+
+func (k *keySetImpl) VerificationKey(kid string) (*ecdsa.PublicKey, error) {
+	if kid == "" {
+		// Tokens without a kid were signed with the key file, until it was retired
+		if k.fileKey == nil {
+			return nil, fmt.Errorf("%w: token has no kid", ErrUnknownKeyID)
+		}
+		if key, ok := k.publicKey(k.fileKey.Kid); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: token has no kid and the key file is retired", ErrUnknownKeyID)
+	}
+
+	if key, ok := k.publicKey(kid); ok {
+		return key, nil
+	}
+
+	// Another replica may have rotated since our last refresh
+	if k.claimOnDemandRefresh() {
+		ctx, cancel := context.WithTimeout(context.Background(), onDemandRefreshTimeout)
+		defer cancel()
+
+		if err := k.Refresh(ctx); err != nil {
+			logger.Errorf("Failed to refresh signing keys: %v", err)
+		}
+		if key, ok := k.publicKey(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, kid)
+}
+
+func (k *keySetImpl) publicKey(kid string) (*ecdsa.PublicKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.publicKeys[kid]
+	return key, ok
+}
+
+// claimOnDemandRefresh reports whether the caller may reload keys now, and if so records the attempt
+func (k *keySetImpl) claimOnDemandRefresh() bool {
+	if k.signingKeyRepo == nil {
+		return false
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if time.Since(k.lastRefresh) < onDemandRefreshInterval {
+		return false
+	}
+	k.lastRefresh = time.Now()
+	return true
+}
+
+func (k *keySetImpl) JWKS() *models.JWKS {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.jwks
+}
+
+func (k *keySetImpl) Refresh(ctx context.Context) error {
+	if k.signingKeyRepo == nil {
+		return nil
+	}
+
+	rows, err := k.signingKeyRepo.ListSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	k.load(rows)
+
+	k.mu.Lock()
+	k.lastRefresh = time.Now()
+	k.mu.Unlock()
+
+	return nil
+}
+
+// load replaces the key set with rows, newest first, plus the key file until it is retired
+func (k *keySetImpl) load(rows []*db.SigningKey) {
+	var (
+		active          *models.SigningKey
+		activeCreatedAt time.Time
+		oldestCreatedAt time.Time
+		publicKeys      = make(map[string]*ecdsa.PublicKey)
+		jwks            = &models.JWKS{Keys: []models.JWK{}}
+	)
+
+	for _, row := range rows {
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(row.PrivateKey))
+		if err != nil {
+			logger.Errorf("Skipping signing key %s: %v", row.Kid, err)
+			continue
+		}
+
+		if active == nil && !row.RetiredAt.Valid {
+			active = &models.SigningKey{Kid: row.Kid, PrivateKey: privateKey}
+			activeCreatedAt = row.CreatedAt.Time
+		}
+		oldestCreatedAt = row.CreatedAt.Time
+
+		publicKeys[row.Kid] = &privateKey.PublicKey
+		jwks.Keys = append(jwks.Keys, newJWK(row.Kid, &privateKey.PublicKey))
+	}
+
+	// The key file stops signing once a database key is active, and its tokens have expired
+	// when the verify window has passed since. Stored keys are only deleted after expiring, so
+	// the oldest of them came at the earliest when the key file stopped signing.
+	fileKeyRetired := active != nil && time.Since(oldestCreatedAt) >= k.verifyWindow
+	if k.fileKey != nil && !fileKeyRetired {
+		if _, ok := publicKeys[k.fileKey.Kid]; !ok {
+			publicKeys[k.fileKey.Kid] = &k.fileKey.PrivateKey.PublicKey
+			jwks.Keys = append(jwks.Keys, newJWK(k.fileKey.Kid, &k.fileKey.PrivateKey.PublicKey))
+		}
+		if active == nil {
+			active = k.fileKey
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.active = active
+	k.activeCreatedAt = activeCreatedAt
+	k.publicKeys = publicKeys
+	k.jwks = jwks
+}
+
+func (k *keySetImpl) Rotate(ctx context.Context) (*models.SigningKey, error) {
+	return k.rotate(ctx, true)
+}
+
+// rotate creates a new active key in the database. Unless force is set, it does nothing when
+// another replica already rotated within the rotation interval.
+func (k *keySetImpl) rotate(ctx context.Context, force bool) (*models.SigningKey, error) {
+	if k.signingKeyRepo == nil {
+		return nil, ErrKeyRotationUnavailable
+	}
+
+	var rotated *models.SigningKey
+
+	err := k.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		rotated = nil
+
+		if err := k.signingKeyRepo.LockSigningKeys(ctx); err != nil {
+			return fmt.Errorf("failed to lock signing keys: %w", err)
+		}
+
+		if !force {
+			current, err := k.signingKeyRepo.GetActiveSigningKey(ctx)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("failed to get active signing key: %w", err)
+			}
+			if current != nil && time.Since(current.CreatedAt.Time) < k.rotationInterval {
+				return nil
+			}
+		}
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return fmt.Errorf("failed to generate signing key: %w", err)
+		}
+
+		der, err := x509.MarshalECPrivateKey(privateKey)
+		if err != nil {
+			return fmt.Errorf("failed to encode signing key: %w", err)
+		}
+		privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+		kid := keyThumbprint(&privateKey.PublicKey)
+		if _, err := k.signingKeyRepo.CreateSigningKey(ctx, kid, jwt.SigningMethodES256.Alg(), string(privateKeyPEM)); err != nil {
+			return fmt.Errorf("failed to store signing key: %w", err)
+		}
+
+		if err := k.signingKeyRepo.RetireSigningKeys(ctx, kid, time.Now().Add(k.verifyWindow)); err != nil {
+			return fmt.Errorf("failed to retire signing keys: %w", err)
+		}
+
+		if err := k.signingKeyRepo.DeleteExpiredSigningKeys(ctx); err != nil {
+			return fmt.Errorf("failed to delete expired signing keys: %w", err)
+		}
+
+		rotated = &models.SigningKey{Kid: kid, PrivateKey: privateKey}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if rotated != nil {
+		logger.Infof("Rotated signing key, new kid %s", rotated.Kid)
+	}
+
+	return rotated, nil
+}
+
+func (k *keySetImpl) Run(ctx context.Context) {
+	if k.signingKeyRepo == nil {
+		return
+	}
+
+	ticker := time.NewTicker(k.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := k.maintain(ctx); err != nil && ctx.Err() == nil {
+			logger.Errorf("Failed to maintain signing keys: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// maintain rotates the active key when it is older than the rotation interval, otherwise
+// reloads keys so rotations made elsewhere are picked up
+func (k *keySetImpl) maintain(ctx context.Context) error {
+	if k.rotationDue() {
+		_, err := k.rotate(ctx, false)
+		return err
+	}
+	return k.Refresh(ctx)
+}
+
+func (k *keySetImpl) rotationDue() bool {
+	if k.rotationInterval <= 0 {
+		return false
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.activeCreatedAt.IsZero() || time.Since(k.activeCreatedAt) >= k.rotationInterval
+}
+
+// newJWK encodes an ES256 public key as a JWK
+func newJWK(kid string, publicKey *ecdsa.PublicKey) models.JWK {
+	x, y := publicKeyCoordinates(publicKey)
+
+	return models.JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   x,
+		Y:   y,
+		Kid: kid,
+		Use: "sig",
+		Alg: jwt.SigningMethodES256.Alg(),
+	}
+}
+
+// keyThumbprint returns the RFC 7638 thumbprint of publicKey, used as its kid
+func keyThumbprint(publicKey *ecdsa.PublicKey) string {
+	x, y := publicKeyCoordinates(publicKey)
+
+	// Members in lexicographic order, no whitespace
+	canonical := `{"crv":"P-256","kty":"EC","x":"` + x + `","y":"` + y + `"}`
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// publicKeyCoordinates returns the base64url encoded x and y coordinates of a P-256 key
+func publicKeyCoordinates(publicKey *ecdsa.PublicKey) (string, string) {
+	// Uncompressed point: 0x04 || x || y
+	point, err := publicKey.Bytes()
+	if err != nil {
+		panic(fmt.Errorf("invalid signing key: %w", err))
+	}
+
+	size := (len(point) - 1) / 2
+	return base64.RawURLEncoding.EncodeToString(point[1 : 1+size]),
+		base64.RawURLEncoding.EncodeToString(point[1+size:])
+}
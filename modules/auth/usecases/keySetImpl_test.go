@@ -0,0 +1,226 @@
+package usecases
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newSigningKeyRow generates a key stored the way rotate stores it
+func newSigningKeyRow(t *testing.T, createdAt time.Time, retired bool) *db.SigningKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	return &db.SigningKey{
+		Kid:        keyThumbprint(&privateKey.PublicKey),
+		CreatedAt:  pgtype.Timestamptz{Time: createdAt, Valid: true},
+		Algorithm:  "ES256",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})),
+		RetiredAt:  pgtype.Timestamptz{Time: createdAt, Valid: retired},
+	}
+}
+
+func setupKeySetWithMocks(t *testing.T) (*keySetImpl, *repoMocks.MockSigningKeyRepository, *dbMocks.MockTxManager) {
+	conf := newTestConfig()
+	conf.Auth.KeyRotationInterval = 24 * time.Hour
+
+	signingKeyRepo := repoMocks.NewMockSigningKeyRepository(t)
+	txManager := dbMocks.NewMockTxManager(t)
+
+	return NewKeySet(conf, signingKeyRepo, txManager).(*keySetImpl), signingKeyRepo, txManager
+}
+
+func TestKeySet_FileKeyOnly(t *testing.T) {
+	keySet := NewKeySet(newTestConfig(), nil, nil)
+
+	signingKey, err := keySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, keyThumbprint(&signingKey.PrivateKey.PublicKey), signingKey.Kid)
+
+	jwks := keySet.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, signingKey.Kid, jwks.Keys[0].Kid)
+	assert.Equal(t, "EC", jwks.Keys[0].Kty)
+	assert.Equal(t, "P-256", jwks.Keys[0].Crv)
+	assert.Equal(t, "sig", jwks.Keys[0].Use)
+	assert.Equal(t, "ES256", jwks.Keys[0].Alg)
+
+	// Tokens without a kid are verified with the key file
+	legacyKey, err := keySet.VerificationKey("")
+	require.NoError(t, err)
+	assert.True(t, legacyKey.Equal(&signingKey.PrivateKey.PublicKey))
+
+	_, err = keySet.VerificationKey("unknown")
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+
+	_, err = keySet.Rotate(context.Background())
+	assert.ErrorIs(t, err, ErrKeyRotationUnavailable)
+}
+
+func TestKeySet_RefreshUsesNewestActiveDatabaseKey(t *testing.T) {
+	keySet, signingKeyRepo, _ := setupKeySetWithMocks(t)
+
+	active := newSigningKeyRow(t, time.Now(), false)
+	retired := newSigningKeyRow(t, time.Now().Add(-48*time.Hour), true)
+	signingKeyRepo.EXPECT().ListSigningKeys(mock.Anything).Return([]*db.SigningKey{active, retired}, nil).Once()
+
+	require.NoError(t, keySet.Refresh(context.Background()))
+
+	signingKey, err := keySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, active.Kid, signingKey.Kid)
+
+	// Both database keys verify. The key file stopped signing before the retired key was
+	// created, longer ago than tokens live, so it is retired.
+	assert.Len(t, keySet.JWKS().Keys, 2)
+	_, err = keySet.VerificationKey(retired.Kid)
+	assert.NoError(t, err)
+	_, err = keySet.VerificationKey("")
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+	assert.False(t, keySet.rotationDue())
+}
+
+func TestKeySet_KeyFileVerifiesUntilItsTokensExpire(t *testing.T) {
+	keySet, signingKeyRepo, _ := setupKeySetWithMocks(t)
+	fileKid := keySet.fileKey.Kid
+
+	// The first database key was just rotated in, tokens of the key file are still alive
+	first := newSigningKeyRow(t, time.Now().Add(-time.Minute), false)
+	signingKeyRepo.EXPECT().ListSigningKeys(mock.Anything).Return([]*db.SigningKey{first}, nil).Once()
+	require.NoError(t, keySet.Refresh(context.Background()))
+
+	signingKey, err := keySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, first.Kid, signingKey.Kid)
+	assert.Len(t, keySet.JWKS().Keys, 2)
+	_, err = keySet.VerificationKey(fileKid)
+	assert.NoError(t, err)
+	_, err = keySet.VerificationKey("")
+	assert.NoError(t, err)
+
+	// Once the verify window has passed, they have expired
+	first.CreatedAt.Time = time.Now().Add(-keySet.verifyWindow)
+	signingKeyRepo.EXPECT().ListSigningKeys(mock.Anything).Return([]*db.SigningKey{first}, nil).Once()
+	require.NoError(t, keySet.Refresh(context.Background()))
+
+	require.Len(t, keySet.JWKS().Keys, 1)
+	assert.Equal(t, first.Kid, keySet.JWKS().Keys[0].Kid)
+	_, ok := keySet.publicKey(fileKid)
+	assert.False(t, ok)
+	_, err = keySet.VerificationKey("")
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+func TestKeySet_UnknownKidRefreshesOnDemand(t *testing.T) {
+	keySet, signingKeyRepo, _ := setupKeySetWithMocks(t)
+
+	// A key rotated in by another replica
+	rotated := newSigningKeyRow(t, time.Now(), false)
+	signingKeyRepo.EXPECT().ListSigningKeys(mock.Anything).Return([]*db.SigningKey{rotated}, nil).Once()
+
+	_, err := keySet.VerificationKey(rotated.Kid)
+	assert.NoError(t, err)
+
+	// Further unknown kids within the refresh interval do not hit the database
+	_, err = keySet.VerificationKey("made-up")
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+func TestKeySet_RotateStoresAndActivatesNewKey(t *testing.T) {
+	keySet, signingKeyRepo, txManager := setupKeySetWithMocks(t)
+
+	var stored *db.SigningKey
+	runInTx(txManager)
+	signingKeyRepo.EXPECT().LockSigningKeys(mock.Anything).Return(nil).Once()
+	signingKeyRepo.EXPECT().CreateSigningKey(mock.Anything, mock.Anything, "ES256", mock.Anything).
+		RunAndReturn(func(ctx context.Context, kid string, algorithm string, privateKeyPEM string) (*db.SigningKey, error) {
+			stored = &db.SigningKey{
+				Kid:        kid,
+				CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+				Algorithm:  algorithm,
+				PrivateKey: privateKeyPEM,
+			}
+			return stored, nil
+		}).Once()
+	signingKeyRepo.EXPECT().RetireSigningKeys(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, exceptKid string, expiresAt time.Time) error {
+			assert.Equal(t, stored.Kid, exceptKid)
+			assert.WithinDuration(t, time.Now().Add(keySet.verifyWindow), expiresAt, time.Minute)
+			return nil
+		}).Once()
+	signingKeyRepo.EXPECT().DeleteExpiredSigningKeys(mock.Anything).Return(nil).Once()
+	signingKeyRepo.EXPECT().ListSigningKeys(mock.Anything).
+		RunAndReturn(func(ctx context.Context) ([]*db.SigningKey, error) {
+			return []*db.SigningKey{stored}, nil
+		}).Once()
+
+	rotated, err := keySet.Rotate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, stored.Kid, rotated.Kid)
+	assert.Equal(t, keyThumbprint(&rotated.PrivateKey.PublicKey), rotated.Kid)
+
+	signingKey, err := keySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, rotated.Kid, signingKey.Kid)
+}
+
+func TestKeySet_ScheduledRotationSkipsWhenAnotherReplicaRotated(t *testing.T) {
+	keySet, signingKeyRepo, txManager := setupKeySetWithMocks(t)
+
+	// Only the key file is loaded, so this replica thinks rotation is due
+	assert.True(t, keySet.rotationDue())
+
+	fresh := newSigningKeyRow(t, time.Now().Add(-time.Minute), false)
+	runInTx(txManager)
+	signingKeyRepo.EXPECT().LockSigningKeys(mock.Anything).Return(nil).Once()
+	signingKeyRepo.EXPECT().GetActiveSigningKey(mock.Anything).Return(fresh, nil).Once()
+	signingKeyRepo.EXPECT().ListSigningKeys(mock.Anything).Return([]*db.SigningKey{fresh}, nil).Once()
+
+	require.NoError(t, keySet.maintain(context.Background()))
+
+	signingKey, err := keySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, fresh.Kid, signingKey.Kid)
+	assert.False(t, keySet.rotationDue())
+}
+
+func TestJWT_SignedWithKidAndVerifiedAfterRotation(t *testing.T) {
+	jwtUsecase, m := setupJWTUsecaseWithMocks(t)
+	expectNotRevoked(m.revokedTokenRepo)
+	expectAuthLookup(m, &db.Auth{ID: "auth-1", Role: "user"})
+
+	token, err := jwtUsecase.GenerateJWT(context.Background(), "auth-1")
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, parsed.Header["kid"])
+
+	// A token without a kid, as issued before keys had ids, still verifies with the key file
+	signingKey, err := jwtUsecase.(*jwtUsecaseImpl).keySet.SigningKey()
+	require.NoError(t, err)
+	claims := jwtUsecase.(*jwtUsecaseImpl).newAccessClaims(&db.Auth{ID: "auth-1", Role: "user"}, nil)
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(signingKey.PrivateKey)
+	require.NoError(t, err)
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), legacy)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+}
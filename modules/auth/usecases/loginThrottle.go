@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// LoginThrottle guards the login routes against brute force. Failures are counted per key, an
+// IP or an account, in the login_attempts table or in memory depending on AUTH_THROTTLE_STORE.
+// Past AUTH_THROTTLE_FREE_ATTEMPTS failures a key is blocked for an exponentially growing
+// delay, and AUTH_THROTTLE_LOCKOUT_ATTEMPTS failures lock it out for
+// AUTH_THROTTLE_LOCKOUT_DURATION.
+type LoginThrottle interface {
+	// Check returns how long the longest blocked of keys stays blocked, zero when none is
+	Check(ctx context.Context, keys ...string) (time.Duration, error)
+	// CheckCached is Check without reading the store, for guards running on every request. It
+	// only knows the blocks set by Fail on this replica.
+	CheckCached(keys ...string) time.Duration
+	// Fail counts a failed attempt for each of keys and returns how long the caller must wait
+	// before the next attempt, zero when it may retry right away
+	Fail(ctx context.Context, keys ...string) (time.Duration, error)
+	// Reserve counts an attempt for each of keys as failed before it is made, blocking the keys
+	// as Fail would, so concurrent attempts cannot all get past a check before any fails. It
+	// returns how long the longest blocked of keys stays blocked, reserving nothing, or zero when
+	// the attempt may go ahead. An attempt that did not fail is taken back with Release or Reset.
+	Reserve(ctx context.Context, keys ...string) (time.Duration, error)
+	// Release takes back the attempt reserved for keys, when it did not fail
+	Release(ctx context.Context, keys ...string) error
+	// Reset forgets the failures of keys, after a successful login
+	Reset(ctx context.Context, keys ...string) error
+	// Run deletes forgotten failures periodically until ctx is done
+	Run(ctx context.Context)
+}
+
+// ThrottleIPKey is the throttle key of a client IP
+func ThrottleIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// ThrottleTokenIPKey is the throttle key of a client IP presenting invalid tokens or API keys,
+// counted apart from its logins so a stale token does not lock out the logins of a shared IP
+func ThrottleTokenIPKey(ip string) string {
+	return "token-ip:" + ip
+}
+
+// ThrottleAccountKey is the throttle key of an account, by auth id or by the username or email
+// a login was attempted with
+func ThrottleAccountKey(account string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(account))
+}
@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"template-golang/config"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultThrottleFreeAttempts    = 5
+	defaultThrottleBaseDelay       = time.Second
+	defaultThrottleLockoutAttempts = 10
+	defaultThrottleLockoutDuration = 15 * time.Minute
+	defaultThrottleWindow          = 15 * time.Minute
+
+	loginAttemptCleanupInterval = 10 * time.Minute
+)
+
+type loginThrottleImpl struct {
+	loginAttemptRepo repositories.LoginAttemptRepository
+
+	freeAttempts    int
+	baseDelay       time.Duration
+	lockoutAttempts int
+	lockoutDuration time.Duration
+	window          time.Duration
+	// delays[i] is how long a key with i+1 failures is blocked, the last delay is the lockout
+	delays []time.Duration
+
+	// blockedUntil holds the blocks set by Fail for CheckCached
+	mu           sync.Mutex
+	blockedUntil map[string]time.Time
+
+	now func() time.Time
+}
+
+func NewLoginThrottle(conf *config.Config, loginAttemptRepo repositories.LoginAttemptRepository) LoginThrottle {
+	freeAttempts := conf.Auth.ThrottleFreeAttempts
+	if freeAttempts <= 0 {
+		freeAttempts = defaultThrottleFreeAttempts
+	}
+	baseDelay := conf.Auth.ThrottleBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultThrottleBaseDelay
+	}
+	lockoutAttempts := conf.Auth.ThrottleLockoutAttempts
+	if lockoutAttempts <= freeAttempts {
+		lockoutAttempts = max(defaultThrottleLockoutAttempts, freeAttempts+1)
+	}
+	lockoutDuration := conf.Auth.ThrottleLockoutDuration
+	if lockoutDuration <= 0 {
+		lockoutDuration = defaultThrottleLockoutDuration
+	}
+	window := conf.Auth.ThrottleWindow
+	if window <= 0 {
+		window = defaultThrottleWindow
+	}
+
+	throttle := &loginThrottleImpl{
+		loginAttemptRepo: loginAttemptRepo,
+		freeAttempts:     freeAttempts,
+		baseDelay:        baseDelay,
+		lockoutAttempts:  lockoutAttempts,
+		lockoutDuration:  lockoutDuration,
+		window:           window,
+		blockedUntil:     make(map[string]time.Time),
+		now:              time.Now,
+	}
+	for failures := 1; failures <= lockoutAttempts; failures++ {
+		throttle.delays = append(throttle.delays, throttle.delay(failures))
+	}
+	return throttle
+}
+
+func (t *loginThrottleImpl) Check(ctx context.Context, keys ...string) (time.Duration, error) {
+	now := t.now()
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		attempt, err := t.loginAttemptRepo.GetLoginAttempt(ctx, key)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get login attempts: %w", err)
+		}
+
+		if attempt.BlockedUntil.Valid {
+			retryAfter = max(retryAfter, attempt.BlockedUntil.Time.Sub(now))
+		}
+	}
+	return retryAfter, nil
+}
+
+func (t *loginThrottleImpl) CheckCached(keys ...string) time.Duration {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		if until, ok := t.blockedUntil[key]; ok {
+			retryAfter = max(retryAfter, until.Sub(now))
+		}
+	}
+	return retryAfter
+}
+
+func (t *loginThrottleImpl) Fail(ctx context.Context, keys ...string) (time.Duration, error) {
+	now := t.now()
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		attempt, err := t.loginAttemptRepo.RecordLoginFailure(ctx, key, now.Add(-t.window))
+		if err != nil {
+			return 0, fmt.Errorf("failed to record login failure: %w", err)
+		}
+
+		delay := t.delay(int(attempt.Failures))
+		if delay <= 0 {
+			continue
+		}
+		if int(attempt.Failures) >= t.lockoutAttempts {
+			logger.Warnf("Locked out %s for %s after %d failed attempts", key, delay, attempt.Failures)
+		}
+
+		if err := t.loginAttemptRepo.BlockLoginAttempts(ctx, key, now.Add(delay)); err != nil {
+			return 0, fmt.Errorf("failed to block login attempts: %w", err)
+		}
+		t.mu.Lock()
+		t.blockedUntil[key] = now.Add(delay)
+		t.mu.Unlock()
+		retryAfter = max(retryAfter, delay)
+	}
+	return retryAfter, nil
+}
+
+func (t *loginThrottleImpl) Reserve(ctx context.Context, keys ...string) (time.Duration, error) {
+	windowStart := t.now().Add(-t.window)
+
+	for i, key := range keys {
+		_, err := t.loginAttemptRepo.ReserveLoginAttempt(ctx, key, windowStart, t.delays)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The attempt is not made, so the keys reserved before are not attempted either
+			if err := t.Release(ctx, keys[:i]...); err != nil {
+				return 0, err
+			}
+			retryAfter, err := t.Check(ctx, key)
+			if err != nil {
+				return 0, err
+			}
+			// The block may have ended since the key was turned away
+			return max(retryAfter, time.Second), nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to reserve login attempt: %w", err)
+		}
+	}
+	return 0, nil
+}
+
+func (t *loginThrottleImpl) Release(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := t.loginAttemptRepo.ReleaseLoginAttempt(ctx, key); err != nil {
+			return fmt.Errorf("failed to release login attempt: %w", err)
+		}
+	}
+	return nil
+}
+
+// delay returns how long a key with failures is blocked: nothing for the free attempts, then
+// the base delay doubled by each further failure, capped by the lockout
+func (t *loginThrottleImpl) delay(failures int) time.Duration {
+	if failures >= t.lockoutAttempts {
+		return t.lockoutDuration
+	}
+	if failures <= t.freeAttempts {
+		return 0
+	}
+
+	delay := t.baseDelay
+	for range failures - t.freeAttempts - 1 {
+		delay *= 2
+		if delay >= t.lockoutDuration {
+			return t.lockoutDuration
+		}
+	}
+	return delay
+}
+
+func (t *loginThrottleImpl) Reset(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := t.loginAttemptRepo.DeleteLoginAttempts(ctx, key); err != nil {
+			return fmt.Errorf("failed to reset login attempts: %w", err)
+		}
+		t.mu.Lock()
+		delete(t.blockedUntil, key)
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// forgetEndedBlocks drops the cached blocks that are over
+func (t *loginThrottleImpl) forgetEndedBlocks() {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, until := range t.blockedUntil {
+		if !until.After(now) {
+			delete(t.blockedUntil, key)
+		}
+	}
+}
+
+func (t *loginThrottleImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(loginAttemptCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		t.forgetEndedBlocks()
+		deleted, err := t.loginAttemptRepo.DeleteExpiredLoginAttempts(ctx, t.now().Add(-t.window))
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Failed to delete expired login attempts: %v", err)
+			}
+			continue
+		}
+		if deleted > 0 {
+			logger.Infof("Deleted %d expired login attempts", deleted)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/repositories"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoginThrottle(loginAttemptRepo repositories.LoginAttemptRepository) (*loginThrottleImpl, time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	conf := &config.Config{Auth: config.AuthConfig{
+		ThrottleFreeAttempts:    3,
+		ThrottleBaseDelay:       time.Second,
+		ThrottleLockoutAttempts: 8,
+		ThrottleLockoutDuration: 10 * time.Second,
+		ThrottleWindow:          time.Minute,
+	}}
+	throttle := NewLoginThrottle(conf, loginAttemptRepo).(*loginThrottleImpl)
+	throttle.now = func() time.Time { return now }
+	return throttle, now
+}
+
+func TestLoginThrottle_Delay(t *testing.T) {
+	throttle, _ := newTestLoginThrottle(nil)
+
+	expected := map[int]time.Duration{
+		1: 0,
+		3: 0,
+		4: time.Second,
+		5: 2 * time.Second,
+		6: 4 * time.Second,
+		7: 8 * time.Second,
+		// The lockout caps the backoff
+		8:  10 * time.Second,
+		20: 10 * time.Second,
+	}
+	for failures, delay := range expected {
+		assert.Equal(t, delay, throttle.delay(failures), "failures: %d", failures)
+	}
+}
+
+func TestLoginThrottle_DelayCappedBeforeLockout(t *testing.T) {
+	throttle := NewLoginThrottle(&config.Config{Auth: config.AuthConfig{
+		ThrottleFreeAttempts:    1,
+		ThrottleBaseDelay:       time.Minute,
+		ThrottleLockoutAttempts: 50,
+		ThrottleLockoutDuration: 5 * time.Minute,
+	}}, nil).(*loginThrottleImpl)
+
+	assert.Equal(t, 4*time.Minute, throttle.delay(4))
+	assert.Equal(t, 5*time.Minute, throttle.delay(5))
+	assert.Equal(t, 5*time.Minute, throttle.delay(49))
+}
+
+func TestLoginThrottle_Defaults(t *testing.T) {
+	throttle := NewLoginThrottle(&config.Config{}, nil).(*loginThrottleImpl)
+
+	assert.Equal(t, defaultThrottleFreeAttempts, throttle.freeAttempts)
+	assert.Equal(t, defaultThrottleLockoutAttempts, throttle.lockoutAttempts)
+	assert.Equal(t, defaultThrottleLockoutDuration, throttle.lockoutDuration)
+	assert.Equal(t, defaultThrottleWindow, throttle.window)
+}
+
+func TestLoginThrottle_FailBlocksPastFreeAttempts(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, now := newTestLoginThrottle(loginAttemptRepo)
+
+	loginAttemptRepo.EXPECT().RecordLoginFailure(mock.Anything, "ip:1.2.3.4", now.Add(-time.Minute)).
+		Return(&db.LoginAttempt{Key: "ip:1.2.3.4", Failures: 2}, nil).Once()
+	loginAttemptRepo.EXPECT().RecordLoginFailure(mock.Anything, "account:john", now.Add(-time.Minute)).
+		Return(&db.LoginAttempt{Key: "account:john", Failures: 5}, nil).Once()
+	loginAttemptRepo.EXPECT().BlockLoginAttempts(mock.Anything, "account:john", now.Add(2*time.Second)).Return(nil).Once()
+
+	retryAfter, err := throttle.Fail(context.Background(), "ip:1.2.3.4", "account:john")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, retryAfter)
+}
+
+func TestLoginThrottle_FailError(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, _ := newTestLoginThrottle(loginAttemptRepo)
+
+	loginAttemptRepo.EXPECT().RecordLoginFailure(mock.Anything, "ip:1.2.3.4", mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	_, err := throttle.Fail(context.Background(), "ip:1.2.3.4")
+
+	assert.Error(t, err)
+}
+
+func TestLoginThrottle_Check(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, now := newTestLoginThrottle(loginAttemptRepo)
+
+	loginAttemptRepo.EXPECT().GetLoginAttempt(mock.Anything, "ip:unknown").Return(nil, pgx.ErrNoRows).Once()
+	loginAttemptRepo.EXPECT().GetLoginAttempt(mock.Anything, "ip:expired").Return(&db.LoginAttempt{
+		Failures:     4,
+		BlockedUntil: pgtype.Timestamptz{Time: now.Add(-time.Second), Valid: true},
+	}, nil).Once()
+	loginAttemptRepo.EXPECT().GetLoginAttempt(mock.Anything, "account:john").Return(&db.LoginAttempt{
+		Failures:     8,
+		BlockedUntil: pgtype.Timestamptz{Time: now.Add(7 * time.Second), Valid: true},
+	}, nil).Once()
+
+	retryAfter, err := throttle.Check(context.Background(), "ip:unknown", "ip:expired", "account:john")
+
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Second, retryAfter)
+}
+
+func TestLoginThrottle_CheckCached(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, now := newTestLoginThrottle(loginAttemptRepo)
+
+	loginAttemptRepo.EXPECT().RecordLoginFailure(mock.Anything, "token-ip:1.2.3.4", mock.Anything).
+		Return(&db.LoginAttempt{Key: "token-ip:1.2.3.4", Failures: 4}, nil).Once()
+	loginAttemptRepo.EXPECT().BlockLoginAttempts(mock.Anything, "token-ip:1.2.3.4", now.Add(time.Second)).Return(nil).Once()
+	loginAttemptRepo.EXPECT().DeleteLoginAttempts(mock.Anything, "token-ip:1.2.3.4").Return(nil).Once()
+
+	// Only the blocks set by Fail are known, the store is never read
+	assert.Zero(t, throttle.CheckCached("token-ip:1.2.3.4"))
+
+	_, err := throttle.Fail(context.Background(), "token-ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, throttle.CheckCached("token-ip:5.6.7.8", "token-ip:1.2.3.4"))
+
+	// Ended blocks are forgotten
+	throttle.now = func() time.Time { return now.Add(time.Second) }
+	throttle.forgetEndedBlocks()
+	assert.Zero(t, throttle.CheckCached("token-ip:1.2.3.4"))
+	assert.Empty(t, throttle.blockedUntil)
+
+	throttle.blockedUntil["token-ip:1.2.3.4"] = now.Add(time.Minute)
+	require.NoError(t, throttle.Reset(context.Background(), "token-ip:1.2.3.4"))
+	assert.Zero(t, throttle.CheckCached("token-ip:1.2.3.4"))
+}
+
+func TestLoginThrottle_Reserve(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, now := newTestLoginThrottle(loginAttemptRepo)
+
+	delays := []time.Duration{0, 0, 0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+	loginAttemptRepo.EXPECT().ReserveLoginAttempt(mock.Anything, "ip:1.2.3.4", now.Add(-time.Minute), delays).
+		Return(&db.LoginAttempt{Key: "ip:1.2.3.4", Failures: 2}, nil).Once()
+	loginAttemptRepo.EXPECT().ReserveLoginAttempt(mock.Anything, "account:john", now.Add(-time.Minute), delays).
+		Return(&db.LoginAttempt{Key: "account:john", Failures: 5}, nil).Once()
+
+	retryAfter, err := throttle.Reserve(context.Background(), "ip:1.2.3.4", "account:john")
+
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
+
+func TestLoginThrottle_ReserveBlocked(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, now := newTestLoginThrottle(loginAttemptRepo)
+
+	loginAttemptRepo.EXPECT().ReserveLoginAttempt(mock.Anything, "ip:1.2.3.4", mock.Anything, mock.Anything).
+		Return(&db.LoginAttempt{Key: "ip:1.2.3.4", Failures: 1}, nil).Once()
+	loginAttemptRepo.EXPECT().ReserveLoginAttempt(mock.Anything, "account:john", mock.Anything, mock.Anything).
+		Return(nil, pgx.ErrNoRows).Once()
+	// The IP reserved before the blocked account is not attempted
+	loginAttemptRepo.EXPECT().ReleaseLoginAttempt(mock.Anything, "ip:1.2.3.4").Return(nil).Once()
+	loginAttemptRepo.EXPECT().GetLoginAttempt(mock.Anything, "account:john").Return(&db.LoginAttempt{
+		Failures:     6,
+		BlockedUntil: pgtype.Timestamptz{Time: now.Add(4 * time.Second), Valid: true},
+	}, nil).Once()
+
+	retryAfter, err := throttle.Reserve(context.Background(), "ip:1.2.3.4", "account:john")
+
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Second, retryAfter)
+}
+
+func TestLoginThrottle_ReserveConcurrent(t *testing.T) {
+	throttle := NewLoginThrottle(&config.Config{Auth: config.AuthConfig{ThrottleFreeAttempts: 3}}, repositories.NewMemoryLoginAttemptRepository())
+	ctx := context.Background()
+
+	// A burst of attempts at once gets the free attempts and the one starting the backoff
+	// through, not every attempt that would pass a check before the first failure
+	var wg sync.WaitGroup
+	var allowed atomic.Int32
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			retryAfter, err := throttle.Reserve(ctx, "ip:1.2.3.4", "account:john")
+			assert.NoError(t, err)
+			if retryAfter == 0 {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(4), allowed.Load())
+	retryAfter, err := throttle.Check(ctx, "ip:1.2.3.4", "account:john")
+	require.NoError(t, err)
+	assert.InDelta(t, defaultThrottleBaseDelay, retryAfter, float64(100*time.Millisecond))
+
+	// A released attempt did not fail, so it neither counts nor blocks
+	require.NoError(t, throttle.Release(ctx, "ip:1.2.3.4", "account:john"))
+	retryAfter, err = throttle.Reserve(ctx, "ip:1.2.3.4", "account:john")
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
+
+func TestLoginThrottle_Reset(t *testing.T) {
+	loginAttemptRepo := repoMocks.NewMockLoginAttemptRepository(t)
+	throttle, _ := newTestLoginThrottle(loginAttemptRepo)
+
+	loginAttemptRepo.EXPECT().DeleteLoginAttempts(mock.Anything, "account:john").Return(nil).Once()
+
+	assert.NoError(t, throttle.Reset(context.Background(), "account:john"))
+}
+
+func TestLoginThrottle_MemoryStore(t *testing.T) {
+	throttle := NewLoginThrottle(&config.Config{Auth: config.AuthConfig{ThrottleFreeAttempts: 2}}, repositories.NewMemoryLoginAttemptRepository())
+	ctx := context.Background()
+
+	for range 2 {
+		retryAfter, err := throttle.Fail(ctx, "ip:1.2.3.4")
+		require.NoError(t, err)
+		assert.Zero(t, retryAfter)
+	}
+	retryAfter, err := throttle.Check(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	retryAfter, err = throttle.Fail(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, defaultThrottleBaseDelay, retryAfter)
+
+	retryAfter, err = throttle.Check(ctx, "ip:5.6.7.8", "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.InDelta(t, defaultThrottleBaseDelay, retryAfter, float64(100*time.Millisecond))
+
+	require.NoError(t, throttle.Reset(ctx, "ip:1.2.3.4"))
+	retryAfter, err = throttle.Check(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
+
+func TestThrottleKeys(t *testing.T) {
+	assert.Equal(t, "ip:203.0.113.7", ThrottleIPKey("203.0.113.7"))
+	assert.Equal(t, "token-ip:203.0.113.7", ThrottleTokenIPKey("203.0.113.7"))
+	assert.Equal(t, "account:john@example.com", ThrottleAccountKey(" John@Example.com "))
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/modules/auth/models"
+)
+
+var (
+	// ErrInvalidMFACode is returned when a code is neither the current TOTP code nor an unused
+	// recovery code. A TOTP code is only accepted once.
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+	// ErrMFAAlreadyEnabled is returned when enrolling an account that has MFA enabled
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	// ErrMFANotEnabled is returned when a code is checked for an account without MFA
+	ErrMFANotEnabled = errors.New("mfa not enabled")
+	// ErrMFANotPending is returned when verifying a code for a login that is not waiting for one
+	ErrMFANotPending = errors.New("mfa not pending")
+	// ErrMFARequired is returned when disabling MFA of an account whose role requires it
+	ErrMFARequired = errors.New("mfa required")
+)
+
+// MFAUsecase manages TOTP (RFC 6238) MFA. Logins of accounts with MFA enabled, or whose role is
+// in AUTH_MFA_REQUIRED_ROLES, return an mfa_pending token that Verify, or Confirm during the
+// first enrolment, exchanges for a token pair.
+type MFAUsecase interface {
+	// Enroll starts an enrolment of authID and returns its secret. MFA is only enabled once
+	// Confirm receives a code of the secret.
+	Enroll(ctx context.Context, authID string) (*models.MFAEnrollment, error)
+	// Confirm enables MFA for the subject of claims with a TOTP code of its enrolment and
+	// returns new recovery codes. It completes the login when claims are mfa_pending.
+	Confirm(ctx context.Context, claims *models.AccessClaims, code string) (*models.MFAConfirmation, error)
+	// Verify checks a TOTP or recovery code for the mfa_pending login of claims and issues its
+	// token pair
+	Verify(ctx context.Context, claims *models.AccessClaims, code string) (*models.TokenPair, error)
+	// RegenerateRecoveryCodes replaces the recovery codes of authID after checking code
+	RegenerateRecoveryCodes(ctx context.Context, authID string, code string) ([]string, error)
+	// Disable turns MFA off for authID after checking code
+	Disable(ctx context.Context, authID string, code string) error
+}
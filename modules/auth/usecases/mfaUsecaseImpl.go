@@ -0,0 +1,291 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"template-golang/config"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/totp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultMFAIssuer     = "template-golang"
+	defaultMFAPendingTTL = 5 * time.Minute
+
+	// totpSkew is how many 30 second steps a code may be off, for clock drift
+	totpSkew = 1
+	// recoveryCodeCount recovery codes of recoveryCodeBytes randomness are issued at a time
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type mfaUsecaseImpl struct {
+	jwtUsecase       JWTUsecase
+	authRepo         repositories.AuthRepository
+	mfaRepo          repositories.MFARepository
+	txManager        database.TxManager
+	issuer           string
+	mfaRequiredRoles map[models.Role]bool
+}
+
+func NewMFAUsecase(conf *config.Config, jwtUsecase JWTUsecase, authRepo repositories.AuthRepository, mfaRepo repositories.MFARepository,
+	txManager database.TxManager) MFAUsecase {
+	issuer := conf.Auth.MFAIssuer
+	if issuer == "" {
+		issuer = defaultMFAIssuer
+	}
+
+	return &mfaUsecaseImpl{
+		jwtUsecase:       jwtUsecase,
+		authRepo:         authRepo,
+		mfaRepo:          mfaRepo,
+		txManager:        txManager,
+		issuer:           issuer,
+		mfaRequiredRoles: parseMFARequiredRoles(conf.Auth.MFARequiredRoles),
+	}
+}
+
+// parseMFARequiredRoles parses the comma separated roles of AUTH_MFA_REQUIRED_ROLES
+func parseMFARequiredRoles(value string) map[models.Role]bool {
+	roles := make(map[models.Role]bool)
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles[models.Role(role)] = true
+		}
+	}
+	return roles
+}
+
+func (u *mfaUsecaseImpl) Enroll(ctx context.Context, authID string) (*models.MFAEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := u.mfaRepo.SetMFASecret(ctx, authID, secret)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store mfa secret: %w", err)
+	}
+
+	return &models.MFAEnrollment{
+		Secret: secret,
+		URI:    totp.URI(u.issuer, accountLabel(auth), secret),
+	}, nil
+}
+
+// accountLabel names auth in authenticator apps
+func accountLabel(auth *db.Auth) string {
+	if auth.Email != nil && *auth.Email != "" {
+		return *auth.Email
+	}
+	if auth.Username != nil && *auth.Username != "" {
+		return *auth.Username
+	}
+	return auth.ID
+}
+
+func (u *mfaUsecaseImpl) Confirm(ctx context.Context, claims *models.AccessClaims, code string) (*models.MFAConfirmation, error) {
+	auth, err := u.authRepo.GetAuthByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+	if auth.MFAEnabledAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if auth.MFASecret == nil {
+		return nil, ErrMFANotEnabled
+	}
+
+	// Recovery codes do not exist yet, only a TOTP code confirms the authenticator works
+	step, ok := totp.Validate(*auth.MFASecret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	var recoveryCodes []string
+	err = u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		enabled, err := u.mfaRepo.EnableMFA(ctx, auth.ID, step)
+		if err != nil {
+			return fmt.Errorf("failed to enable mfa: %w", err)
+		}
+		// A concurrent confirmation or a new enrolment got there first
+		if !enabled {
+			return ErrInvalidMFACode
+		}
+
+		recoveryCodes, err = u.replaceRecoveryCodes(ctx, auth.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Enabled mfa for auth %s", auth.ID)
+
+	confirmation := &models.MFAConfirmation{RecoveryCodes: recoveryCodes}
+	if claims.MFAPending {
+		confirmation.Tokens, err = u.completeLogin(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return confirmation, nil
+}
+
+func (u *mfaUsecaseImpl) Verify(ctx context.Context, claims *models.AccessClaims, code string) (*models.TokenPair, error) {
+	if !claims.MFAPending {
+		return nil, ErrMFANotPending
+	}
+
+	auth, err := u.authRepo.GetAuthByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+	if err := u.checkCode(ctx, auth, code); err != nil {
+		return nil, err
+	}
+
+	return u.completeLogin(ctx, claims)
+}
+
+// completeLogin revokes the mfa_pending token of claims, so it cannot be verified again, and
+// issues the token pair of the login
+func (u *mfaUsecaseImpl) completeLogin(ctx context.Context, claims *models.AccessClaims) (*models.TokenPair, error) {
+	if err := u.jwtUsecase.Logout(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return u.jwtUsecase.IssueMFAVerifiedTokens(ctx, claims.Subject)
+}
+
+func (u *mfaUsecaseImpl) RegenerateRecoveryCodes(ctx context.Context, authID string, code string) ([]string, error) {
+	auth, err := u.authRepo.GetAuthByID(ctx, authID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	var recoveryCodes []string
+	err = u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		if err := u.checkCode(ctx, auth, code); err != nil {
+			return err
+		}
+
+		recoveryCodes, err = u.replaceRecoveryCodes(ctx, authID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (u *mfaUsecaseImpl) Disable(ctx context.Context, authID string, code string) error {
+	auth, err := u.authRepo.GetAuthByID(ctx, authID)
+	if err != nil {
+		return fmt.Errorf("failed to get auth: %w", err)
+	}
+	if u.mfaRequiredRoles[models.Role(auth.Role)] {
+		return ErrMFARequired
+	}
+
+	err = u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		if err := u.checkCode(ctx, auth, code); err != nil {
+			return err
+		}
+
+		if err := u.mfaRepo.DisableMFA(ctx, authID); err != nil {
+			return fmt.Errorf("failed to disable mfa: %w", err)
+		}
+		if err := u.mfaRepo.DeleteMFARecoveryCodes(ctx, authID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Disabled mfa for auth %s", authID)
+	return nil
+}
+
+// checkCode accepts a TOTP code of auth that was not used before, or else one of its unused
+// recovery codes, using it up
+func (u *mfaUsecaseImpl) checkCode(ctx context.Context, auth *db.Auth, code string) error {
+	if !auth.MFAEnabledAt.Valid || auth.MFASecret == nil {
+		return ErrMFANotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(*auth.MFASecret, code, time.Now(), totpSkew); ok {
+		used, err := u.mfaRepo.UseMFAStep(ctx, auth.ID, step)
+		if err != nil {
+			return fmt.Errorf("failed to use mfa code: %w", err)
+		}
+		if !used {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := u.mfaRepo.UseMFARecoveryCode(ctx, auth.ID, hashRefreshToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+
+	logger.Infof("Auth %s used a recovery code", auth.ID)
+	return nil
+}
+
+// replaceRecoveryCodes stores the hashes of new recovery codes of authID and returns the codes
+func (u *mfaUsecaseImpl) replaceRecoveryCodes(ctx context.Context, authID string) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRefreshToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := u.mfaRepo.ReplaceMFARecoveryCodes(ctx, authID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// newRecoveryCode returns a random code such as "abcdefgh-ijklmnop"
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+	return code[:8] + "-" + code[8:16], nil
+}
+
+// normalizeRecoveryCode ignores case, dashes and spaces, which users get wrong when typing codes
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
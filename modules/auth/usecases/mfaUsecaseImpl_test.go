@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"context"
+	"template-golang/config"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	jwtMocks "template-golang/modules/auth/usecases/mocks"
+	"template-golang/pkg/totp"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testMFASecret = "JBSWY3DPEHPK3PXP"
+
+type mfaUsecaseMocks struct {
+	jwtUsecase *jwtMocks.MockJWTUsecase
+	authRepo   *repoMocks.MockAuthRepository
+	mfaRepo    *repoMocks.MockMFARepository
+	txManager  *dbMocks.MockTxManager
+}
+
+func setupMFAUsecase(t *testing.T, requiredRoles string) (MFAUsecase, mfaUsecaseMocks) {
+	m := mfaUsecaseMocks{
+		jwtUsecase: jwtMocks.NewMockJWTUsecase(t),
+		authRepo:   repoMocks.NewMockAuthRepository(t),
+		mfaRepo:    repoMocks.NewMockMFARepository(t),
+		txManager:  dbMocks.NewMockTxManager(t),
+	}
+	conf := &config.Config{Auth: config.AuthConfig{MFARequiredRoles: requiredRoles}}
+	return NewMFAUsecase(conf, m.jwtUsecase, m.authRepo, m.mfaRepo, m.txManager), m
+}
+
+// mfaAuth returns an auth with MFA enabled on testMFASecret
+func mfaAuth(role models.Role) *db.Auth {
+	secret := testMFASecret
+	return &db.Auth{
+		ID:           "auth-1",
+		Role:         string(role),
+		MFASecret:    &secret,
+		MFAEnabledAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+}
+
+func currentCode(t *testing.T) (string, int64) {
+	step := totp.Step(time.Now())
+	code, err := totp.Code(testMFASecret, step)
+	require.NoError(t, err)
+	return code, step
+}
+
+func pendingClaims() *models.AccessClaims {
+	return &models.AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1", ID: "jti-1"},
+		MFAPending:       true,
+	}
+}
+
+func TestEnroll(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "")
+
+	email := "john@example.com"
+	m.mfaRepo.EXPECT().SetMFASecret(mock.Anything, "auth-1", mock.Anything).
+		RunAndReturn(func(_ context.Context, authID string, secret string) (*db.Auth, error) {
+			return &db.Auth{ID: authID, Email: &email, MFASecret: &secret}, nil
+		}).Once()
+
+	enrollment, err := mfa.Enroll(context.Background(), "auth-1")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/")
+	assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+	assert.Contains(t, enrollment.URI, "john@example.com")
+}
+
+func TestEnroll_AlreadyEnabled(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "")
+
+	m.mfaRepo.EXPECT().SetMFASecret(mock.Anything, "auth-1", mock.Anything).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := mfa.Enroll(context.Background(), "auth-1")
+
+	assert.ErrorIs(t, err, ErrMFAAlreadyEnabled)
+}
+
+func TestConfirm_EnablesAndCompletesPendingLogin(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "admin")
+
+	secret := testMFASecret
+	code, step := currentCode(t)
+	claims := pendingClaims()
+	pair := &models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}
+
+	var storedHashes []string
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "admin", MFASecret: &secret}, nil).Once()
+	runInTx(m.txManager)
+	m.mfaRepo.EXPECT().EnableMFA(mock.Anything, "auth-1", step).Return(true, nil).Once()
+	m.mfaRepo.EXPECT().ReplaceMFARecoveryCodes(mock.Anything, "auth-1", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, codeHashes []string) error {
+			storedHashes = codeHashes
+			return nil
+		}).Once()
+	m.jwtUsecase.EXPECT().Logout(mock.Anything, claims, "").Return(nil).Once()
+	m.jwtUsecase.EXPECT().IssueMFAVerifiedTokens(mock.Anything, "auth-1").Return(pair, nil).Once()
+
+	confirmation, err := mfa.Confirm(context.Background(), claims, code)
+
+	require.NoError(t, err)
+	assert.Len(t, confirmation.RecoveryCodes, recoveryCodeCount)
+	assert.Equal(t, pair, confirmation.Tokens)
+	require.Len(t, storedHashes, recoveryCodeCount)
+	assert.Equal(t, hashRefreshToken(normalizeRecoveryCode(confirmation.RecoveryCodes[0])), storedHashes[0])
+}
+
+func TestConfirm_InvalidCode(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "")
+
+	secret := testMFASecret
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", MFASecret: &secret}, nil).Once()
+
+	_, err := mfa.Confirm(context.Background(), &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}}, "000000x")
+
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+}
+
+func TestConfirm_NoEnrolment(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "")
+
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+
+	_, err := mfa.Confirm(context.Background(), pendingClaims(), "123456")
+
+	assert.ErrorIs(t, err, ErrMFANotEnabled)
+}
+
+func TestVerify(t *testing.T) {
+	code, step := currentCode(t)
+	const recoveryCode = "abcdefgh-ijklmnop"
+
+	tests := []struct {
+		name        string
+		claims      *models.AccessClaims
+		code        string
+		setupMocks  func(m mfaUsecaseMocks)
+		expectedErr error
+	}{
+		{
+			name:   "totp code",
+			claims: pendingClaims(),
+			code:   code,
+			setupMocks: func(m mfaUsecaseMocks) {
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleUser), nil).Once()
+				m.mfaRepo.EXPECT().UseMFAStep(mock.Anything, "auth-1", step).Return(true, nil).Once()
+				m.jwtUsecase.EXPECT().Logout(mock.Anything, mock.Anything, "").Return(nil).Once()
+				m.jwtUsecase.EXPECT().IssueMFAVerifiedTokens(mock.Anything, "auth-1").Return(&models.TokenPair{AccessToken: "access"}, nil).Once()
+			},
+		},
+		{
+			name:   "replayed totp code",
+			claims: pendingClaims(),
+			code:   code,
+			setupMocks: func(m mfaUsecaseMocks) {
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleUser), nil).Once()
+				m.mfaRepo.EXPECT().UseMFAStep(mock.Anything, "auth-1", step).Return(false, nil).Once()
+			},
+			expectedErr: ErrInvalidMFACode,
+		},
+		{
+			name:   "recovery code",
+			claims: pendingClaims(),
+			code:   " ABCDEFGH-ijklmnop ",
+			setupMocks: func(m mfaUsecaseMocks) {
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleUser), nil).Once()
+				m.mfaRepo.EXPECT().UseMFARecoveryCode(mock.Anything, "auth-1", hashRefreshToken(normalizeRecoveryCode(recoveryCode))).Return(true, nil).Once()
+				m.jwtUsecase.EXPECT().Logout(mock.Anything, mock.Anything, "").Return(nil).Once()
+				m.jwtUsecase.EXPECT().IssueMFAVerifiedTokens(mock.Anything, "auth-1").Return(&models.TokenPair{AccessToken: "access"}, nil).Once()
+			},
+		},
+		{
+			name:   "used recovery code",
+			claims: pendingClaims(),
+			code:   recoveryCode,
+			setupMocks: func(m mfaUsecaseMocks) {
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleUser), nil).Once()
+				m.mfaRepo.EXPECT().UseMFARecoveryCode(mock.Anything, "auth-1", mock.Anything).Return(false, nil).Once()
+			},
+			expectedErr: ErrInvalidMFACode,
+		},
+		{
+			name:   "mfa not enabled",
+			claims: pendingClaims(),
+			code:   code,
+			setupMocks: func(m mfaUsecaseMocks) {
+				m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+			},
+			expectedErr: ErrMFANotEnabled,
+		},
+		{
+			name:        "login not pending",
+			claims:      &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "auth-1"}},
+			code:        code,
+			setupMocks:  func(m mfaUsecaseMocks) {},
+			expectedErr: ErrMFANotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mfa, m := setupMFAUsecase(t, "")
+			tt.setupMocks(m)
+
+			pair, err := mfa.Verify(context.Background(), tt.claims, tt.code)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, pair)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "access", pair.AccessToken)
+		})
+	}
+}
+
+func TestRegenerateRecoveryCodes(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "")
+
+	code, step := currentCode(t)
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleUser), nil).Once()
+	runInTx(m.txManager)
+	m.mfaRepo.EXPECT().UseMFAStep(mock.Anything, "auth-1", step).Return(true, nil).Once()
+	m.mfaRepo.EXPECT().ReplaceMFARecoveryCodes(mock.Anything, "auth-1", mock.Anything).Return(nil).Once()
+
+	codes, err := mfa.RegenerateRecoveryCodes(context.Background(), "auth-1", code)
+
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+}
+
+func TestDisable(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "admin")
+
+	code, step := currentCode(t)
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleUser), nil).Once()
+	runInTx(m.txManager)
+	m.mfaRepo.EXPECT().UseMFAStep(mock.Anything, "auth-1", step).Return(true, nil).Once()
+	m.mfaRepo.EXPECT().DisableMFA(mock.Anything, "auth-1").Return(nil).Once()
+	m.mfaRepo.EXPECT().DeleteMFARecoveryCodes(mock.Anything, "auth-1").Return(nil).Once()
+
+	assert.NoError(t, mfa.Disable(context.Background(), "auth-1", code))
+}
+
+func TestDisable_RequiredByRole(t *testing.T) {
+	mfa, m := setupMFAUsecase(t, "staff, admin")
+
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(mfaAuth(models.RoleAdmin), nil).Once()
+
+	assert.ErrorIs(t, mfa.Disable(context.Background(), "auth-1", "123456"), ErrMFARequired)
+}
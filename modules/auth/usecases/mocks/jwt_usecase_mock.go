@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	"context"
+	"template-golang/db/sqlc"
 	"template-golang/modules/auth/models"
 
 	"github.com/markbates/goth"
@@ -39,8 +41,8 @@ func (_m *MockJWTUsecase) EXPECT() *MockJWTUsecase_Expecter {
 }
 
 // GenerateJWT provides a mock function for the type MockJWTUsecase
-func (_mock *MockJWTUsecase) GenerateJWT(userID string) (string, error) {
-	ret := _mock.Called(userID)
+func (_mock *MockJWTUsecase) GenerateJWT(ctx context.Context, authID string) (string, error) {
+	ret := _mock.Called(ctx, authID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateJWT")
@@ -48,16 +50,16 @@ func (_mock *MockJWTUsecase) GenerateJWT(userID string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, authID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, authID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, authID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,19 +72,25 @@ type MockJWTUsecase_GenerateJWT_Call struct {
 }
 
 // GenerateJWT is a helper method to define mock.On call
-//   - userID string
-func (_e *MockJWTUsecase_Expecter) GenerateJWT(userID interface{}) *MockJWTUsecase_GenerateJWT_Call {
-	return &MockJWTUsecase_GenerateJWT_Call{Call: _e.mock.On("GenerateJWT", userID)}
+//   - ctx context.Context
+//   - authID string
+func (_e *MockJWTUsecase_Expecter) GenerateJWT(ctx interface{}, authID interface{}) *MockJWTUsecase_GenerateJWT_Call {
+	return &MockJWTUsecase_GenerateJWT_Call{Call: _e.mock.On("GenerateJWT", ctx, authID)}
 }
 
-func (_c *MockJWTUsecase_GenerateJWT_Call) Run(run func(userID string)) *MockJWTUsecase_GenerateJWT_Call {
+func (_c *MockJWTUsecase_GenerateJWT_Call) Run(run func(ctx context.Context, authID string)) *MockJWTUsecase_GenerateJWT_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -93,18 +101,342 @@ func (_c *MockJWTUsecase_GenerateJWT_Call) Return(s string, err error) *MockJWTU
 	return _c
 }
 
-func (_c *MockJWTUsecase_GenerateJWT_Call) RunAndReturn(run func(userID string) (string, error)) *MockJWTUsecase_GenerateJWT_Call {
+func (_c *MockJWTUsecase_GenerateJWT_Call) RunAndReturn(run func(ctx context.Context, authID string) (string, error)) *MockJWTUsecase_GenerateJWT_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueMFAVerifiedTokens provides a mock function for the type MockJWTUsecase
+func (_mock *MockJWTUsecase) IssueMFAVerifiedTokens(ctx context.Context, authID string) (*models.TokenPair, error) {
+	ret := _mock.Called(ctx, authID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueMFAVerifiedTokens")
+	}
+
+	var r0 *models.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.TokenPair, error)); ok {
+		return returnFunc(ctx, authID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.TokenPair); ok {
+		r0 = returnFunc(ctx, authID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TokenPair)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, authID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJWTUsecase_IssueMFAVerifiedTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueMFAVerifiedTokens'
+type MockJWTUsecase_IssueMFAVerifiedTokens_Call struct {
+	*mock.Call
+}
+
+// IssueMFAVerifiedTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authID string
+func (_e *MockJWTUsecase_Expecter) IssueMFAVerifiedTokens(ctx interface{}, authID interface{}) *MockJWTUsecase_IssueMFAVerifiedTokens_Call {
+	return &MockJWTUsecase_IssueMFAVerifiedTokens_Call{Call: _e.mock.On("IssueMFAVerifiedTokens", ctx, authID)}
+}
+
+func (_c *MockJWTUsecase_IssueMFAVerifiedTokens_Call) Run(run func(ctx context.Context, authID string)) *MockJWTUsecase_IssueMFAVerifiedTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJWTUsecase_IssueMFAVerifiedTokens_Call) Return(tokenPair *models.TokenPair, err error) *MockJWTUsecase_IssueMFAVerifiedTokens_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockJWTUsecase_IssueMFAVerifiedTokens_Call) RunAndReturn(run func(ctx context.Context, authID string) (*models.TokenPair, error)) *MockJWTUsecase_IssueMFAVerifiedTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueTokens provides a mock function for the type MockJWTUsecase
+func (_mock *MockJWTUsecase) IssueTokens(ctx context.Context, authID string) (*models.TokenPair, error) {
+	ret := _mock.Called(ctx, authID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueTokens")
+	}
+
+	var r0 *models.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.TokenPair, error)); ok {
+		return returnFunc(ctx, authID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.TokenPair); ok {
+		r0 = returnFunc(ctx, authID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TokenPair)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, authID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJWTUsecase_IssueTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueTokens'
+type MockJWTUsecase_IssueTokens_Call struct {
+	*mock.Call
+}
+
+// IssueTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authID string
+func (_e *MockJWTUsecase_Expecter) IssueTokens(ctx interface{}, authID interface{}) *MockJWTUsecase_IssueTokens_Call {
+	return &MockJWTUsecase_IssueTokens_Call{Call: _e.mock.On("IssueTokens", ctx, authID)}
+}
+
+func (_c *MockJWTUsecase_IssueTokens_Call) Run(run func(ctx context.Context, authID string)) *MockJWTUsecase_IssueTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJWTUsecase_IssueTokens_Call) Return(tokenPair *models.TokenPair, err error) *MockJWTUsecase_IssueTokens_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockJWTUsecase_IssueTokens_Call) RunAndReturn(run func(ctx context.Context, authID string) (*models.TokenPair, error)) *MockJWTUsecase_IssueTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockJWTUsecase
+func (_mock *MockJWTUsecase) Logout(ctx context.Context, claims *models.AccessClaims, refreshToken string) error {
+	ret := _mock.Called(ctx, claims, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AccessClaims, string) error); ok {
+		r0 = returnFunc(ctx, claims, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJWTUsecase_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockJWTUsecase_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claims *models.AccessClaims
+//   - refreshToken string
+func (_e *MockJWTUsecase_Expecter) Logout(ctx interface{}, claims interface{}, refreshToken interface{}) *MockJWTUsecase_Logout_Call {
+	return &MockJWTUsecase_Logout_Call{Call: _e.mock.On("Logout", ctx, claims, refreshToken)}
+}
+
+func (_c *MockJWTUsecase_Logout_Call) Run(run func(ctx context.Context, claims *models.AccessClaims, refreshToken string)) *MockJWTUsecase_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.AccessClaims
+		if args[1] != nil {
+			arg1 = args[1].(*models.AccessClaims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJWTUsecase_Logout_Call) Return(err error) *MockJWTUsecase_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJWTUsecase_Logout_Call) RunAndReturn(run func(ctx context.Context, claims *models.AccessClaims, refreshToken string) error) *MockJWTUsecase_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshTokens provides a mock function for the type MockJWTUsecase
+func (_mock *MockJWTUsecase) RefreshTokens(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokens")
+	}
+
+	var r0 *models.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.TokenPair, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.TokenPair); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TokenPair)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJWTUsecase_RefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokens'
+type MockJWTUsecase_RefreshTokens_Call struct {
+	*mock.Call
+}
+
+// RefreshTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockJWTUsecase_Expecter) RefreshTokens(ctx interface{}, refreshToken interface{}) *MockJWTUsecase_RefreshTokens_Call {
+	return &MockJWTUsecase_RefreshTokens_Call{Call: _e.mock.On("RefreshTokens", ctx, refreshToken)}
+}
+
+func (_c *MockJWTUsecase_RefreshTokens_Call) Run(run func(ctx context.Context, refreshToken string)) *MockJWTUsecase_RefreshTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJWTUsecase_RefreshTokens_Call) Return(tokenPair *models.TokenPair, err error) *MockJWTUsecase_RefreshTokens_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockJWTUsecase_RefreshTokens_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*models.TokenPair, error)) *MockJWTUsecase_RefreshTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAllTokens provides a mock function for the type MockJWTUsecase
+func (_mock *MockJWTUsecase) RevokeAllTokens(ctx context.Context, authID string) error {
+	ret := _mock.Called(ctx, authID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, authID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJWTUsecase_RevokeAllTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllTokens'
+type MockJWTUsecase_RevokeAllTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeAllTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authID string
+func (_e *MockJWTUsecase_Expecter) RevokeAllTokens(ctx interface{}, authID interface{}) *MockJWTUsecase_RevokeAllTokens_Call {
+	return &MockJWTUsecase_RevokeAllTokens_Call{Call: _e.mock.On("RevokeAllTokens", ctx, authID)}
+}
+
+func (_c *MockJWTUsecase_RevokeAllTokens_Call) Run(run func(ctx context.Context, authID string)) *MockJWTUsecase_RevokeAllTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJWTUsecase_RevokeAllTokens_Call) Return(err error) *MockJWTUsecase_RevokeAllTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJWTUsecase_RevokeAllTokens_Call) RunAndReturn(run func(ctx context.Context, authID string) error) *MockJWTUsecase_RevokeAllTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpsertUser provides a mock function for the type MockJWTUsecase
-func (_mock *MockJWTUsecase) UpsertUser(user goth.User, role ...models.Role) error {
+func (_mock *MockJWTUsecase) UpsertUser(ctx context.Context, user goth.User, role ...models.Role) (*db.Auth, error) {
 	var tmpRet mock.Arguments
 	if len(role) > 0 {
-		tmpRet = _mock.Called(user, role)
+		tmpRet = _mock.Called(ctx, user, role)
 	} else {
-		tmpRet = _mock.Called(user)
+		tmpRet = _mock.Called(ctx, user)
 	}
 	ret := tmpRet
 
@@ -112,13 +444,24 @@ func (_mock *MockJWTUsecase) UpsertUser(user goth.User, role ...models.Role) err
 		panic("no return value specified for UpsertUser")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(goth.User, ...models.Role) error); ok {
-		r0 = returnFunc(user, role...)
+	var r0 *db.Auth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, goth.User, ...models.Role) (*db.Auth, error)); ok {
+		return returnFunc(ctx, user, role...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, goth.User, ...models.Role) *db.Auth); ok {
+		r0 = returnFunc(ctx, user, role...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Auth)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, goth.User, ...models.Role) error); ok {
+		r1 = returnFunc(ctx, user, role...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockJWTUsecase_UpsertUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUser'
@@ -127,46 +470,52 @@ type MockJWTUsecase_UpsertUser_Call struct {
 }
 
 // UpsertUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - user goth.User
 //   - role ...models.Role
-func (_e *MockJWTUsecase_Expecter) UpsertUser(user interface{}, role ...interface{}) *MockJWTUsecase_UpsertUser_Call {
+func (_e *MockJWTUsecase_Expecter) UpsertUser(ctx interface{}, user interface{}, role ...interface{}) *MockJWTUsecase_UpsertUser_Call {
 	return &MockJWTUsecase_UpsertUser_Call{Call: _e.mock.On("UpsertUser",
-		append([]interface{}{user}, role...)...)}
+		append([]interface{}{ctx, user}, role...)...)}
 }
 
-func (_c *MockJWTUsecase_UpsertUser_Call) Run(run func(user goth.User, role ...models.Role)) *MockJWTUsecase_UpsertUser_Call {
+func (_c *MockJWTUsecase_UpsertUser_Call) Run(run func(ctx context.Context, user goth.User, role ...models.Role)) *MockJWTUsecase_UpsertUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 goth.User
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(goth.User)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 goth.User
+		if args[1] != nil {
+			arg1 = args[1].(goth.User)
 		}
-		var arg1 []models.Role
+		var arg2 []models.Role
 		var variadicArgs []models.Role
-		if len(args) > 1 {
-			variadicArgs = args[1].([]models.Role)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]models.Role)
 		}
-		arg1 = variadicArgs
+		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
+			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockJWTUsecase_UpsertUser_Call) Return(err error) *MockJWTUsecase_UpsertUser_Call {
-	_c.Call.Return(err)
+func (_c *MockJWTUsecase_UpsertUser_Call) Return(auth *db.Auth, err error) *MockJWTUsecase_UpsertUser_Call {
+	_c.Call.Return(auth, err)
 	return _c
 }
 
-func (_c *MockJWTUsecase_UpsertUser_Call) RunAndReturn(run func(user goth.User, role ...models.Role) error) *MockJWTUsecase_UpsertUser_Call {
+func (_c *MockJWTUsecase_UpsertUser_Call) RunAndReturn(run func(ctx context.Context, user goth.User, role ...models.Role) (*db.Auth, error)) *MockJWTUsecase_UpsertUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ValidateJWT provides a mock function for the type MockJWTUsecase
-func (_mock *MockJWTUsecase) ValidateJWT(tokenString string) (*models.TokenValidationResult, error) {
-	ret := _mock.Called(tokenString)
+func (_mock *MockJWTUsecase) ValidateJWT(ctx context.Context, tokenString string) (*models.TokenValidationResult, error) {
+	ret := _mock.Called(ctx, tokenString)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateJWT")
@@ -174,18 +523,18 @@ func (_mock *MockJWTUsecase) ValidateJWT(tokenString string) (*models.TokenValid
 
 	var r0 *models.TokenValidationResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*models.TokenValidationResult, error)); ok {
-		return returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.TokenValidationResult, error)); ok {
+		return returnFunc(ctx, tokenString)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *models.TokenValidationResult); ok {
-		r0 = returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.TokenValidationResult); ok {
+		r0 = returnFunc(ctx, tokenString)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TokenValidationResult)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenString)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -198,19 +547,25 @@ type MockJWTUsecase_ValidateJWT_Call struct {
 }
 
 // ValidateJWT is a helper method to define mock.On call
+//   - ctx context.Context
 //   - tokenString string
-func (_e *MockJWTUsecase_Expecter) ValidateJWT(tokenString interface{}) *MockJWTUsecase_ValidateJWT_Call {
-	return &MockJWTUsecase_ValidateJWT_Call{Call: _e.mock.On("ValidateJWT", tokenString)}
+func (_e *MockJWTUsecase_Expecter) ValidateJWT(ctx interface{}, tokenString interface{}) *MockJWTUsecase_ValidateJWT_Call {
+	return &MockJWTUsecase_ValidateJWT_Call{Call: _e.mock.On("ValidateJWT", ctx, tokenString)}
 }
 
-func (_c *MockJWTUsecase_ValidateJWT_Call) Run(run func(tokenString string)) *MockJWTUsecase_ValidateJWT_Call {
+func (_c *MockJWTUsecase_ValidateJWT_Call) Run(run func(ctx context.Context, tokenString string)) *MockJWTUsecase_ValidateJWT_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -221,7 +576,75 @@ func (_c *MockJWTUsecase_ValidateJWT_Call) Return(tokenValidationResult *models.
 	return _c
 }
 
-func (_c *MockJWTUsecase_ValidateJWT_Call) RunAndReturn(run func(tokenString string) (*models.TokenValidationResult, error)) *MockJWTUsecase_ValidateJWT_Call {
+func (_c *MockJWTUsecase_ValidateJWT_Call) RunAndReturn(run func(ctx context.Context, tokenString string) (*models.TokenValidationResult, error)) *MockJWTUsecase_ValidateJWT_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateMFAJWT provides a mock function for the type MockJWTUsecase
+func (_mock *MockJWTUsecase) ValidateMFAJWT(ctx context.Context, tokenString string) (*models.TokenValidationResult, error) {
+	ret := _mock.Called(ctx, tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateMFAJWT")
+	}
+
+	var r0 *models.TokenValidationResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.TokenValidationResult, error)); ok {
+		return returnFunc(ctx, tokenString)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.TokenValidationResult); ok {
+		r0 = returnFunc(ctx, tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TokenValidationResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJWTUsecase_ValidateMFAJWT_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateMFAJWT'
+type MockJWTUsecase_ValidateMFAJWT_Call struct {
+	*mock.Call
+}
+
+// ValidateMFAJWT is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenString string
+func (_e *MockJWTUsecase_Expecter) ValidateMFAJWT(ctx interface{}, tokenString interface{}) *MockJWTUsecase_ValidateMFAJWT_Call {
+	return &MockJWTUsecase_ValidateMFAJWT_Call{Call: _e.mock.On("ValidateMFAJWT", ctx, tokenString)}
+}
+
+func (_c *MockJWTUsecase_ValidateMFAJWT_Call) Run(run func(ctx context.Context, tokenString string)) *MockJWTUsecase_ValidateMFAJWT_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJWTUsecase_ValidateMFAJWT_Call) Return(tokenValidationResult *models.TokenValidationResult, err error) *MockJWTUsecase_ValidateMFAJWT_Call {
+	_c.Call.Return(tokenValidationResult, err)
+	return _c
+}
+
+func (_c *MockJWTUsecase_ValidateMFAJWT_Call) RunAndReturn(run func(ctx context.Context, tokenString string) (*models.TokenValidationResult, error)) *MockJWTUsecase_ValidateMFAJWT_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/modules/auth/models"
+)
+
+var (
+	// ErrInvalidCredentials is returned for every failed login or password check, whether the
+	// account is unknown, has no password, is inactive or the password is wrong
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrRegistrationFailed is returned when the username or email is already taken. It does
+	// not say which one.
+	ErrRegistrationFailed = errors.New("registration failed")
+)
+
+// PasswordUsecase authenticates local accounts with a username or email and a password
+type PasswordUsecase interface {
+	// Register creates a local account and returns its first token pair
+	Register(ctx context.Context, req models.RegisterRequest) (*models.TokenPair, error)
+	Login(ctx context.Context, req models.LoginRequest) (*models.TokenPair, error)
+	ChangePassword(ctx context.Context, authID string, req models.ChangePasswordRequest) error
+}
@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/password"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const sqlStateUniqueViolation = "23505"
+
+// isUniqueViolation reports whether err was caused by a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation
+}
+
+type passwordUsecaseImpl struct {
+	jwtUsecase JWTUsecase
+	authRepo   repositories.AuthRepository
+
+	hash func(plain string) (string, error)
+
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+func NewPasswordUsecase(jwtUsecase JWTUsecase, authRepo repositories.AuthRepository) PasswordUsecase {
+	return &passwordUsecaseImpl{
+		jwtUsecase: jwtUsecase,
+		authRepo:   authRepo,
+		hash:       password.Hash,
+	}
+}
+
+func (u *passwordUsecaseImpl) Register(ctx context.Context, req models.RegisterRequest) (*models.TokenPair, error) {
+	hash, err := u.hash(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	username := strings.ToLower(req.Username)
+	var email *string
+	if req.Email != "" {
+		normalized := strings.ToLower(strings.TrimSpace(req.Email))
+		email = &normalized
+	}
+
+	auth, err := u.authRepo.CreateAuth(ctx, &username, &hash, email, string(models.RoleUser), true)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrRegistrationFailed
+		}
+		return nil, fmt.Errorf("failed to create auth: %w", err)
+	}
+
+	return u.jwtUsecase.IssueTokens(ctx, auth.ID)
+}
+
+func (u *passwordUsecaseImpl) Login(ctx context.Context, req models.LoginRequest) (*models.TokenPair, error) {
+	auth, err := u.findAuth(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.verify(auth, req.Password); err != nil {
+		return nil, err
+	}
+
+	// Upgrade bcrypt or outdated argon2id hashes while the plain password is at hand
+	if password.NeedsRehash(*auth.Password) {
+		if hash, err := u.hash(req.Password); err != nil {
+			logger.Warnf("Failed to rehash password for auth %s: %v", auth.ID, err)
+		} else if err := u.authRepo.UpdateAuthPassword(ctx, auth.ID, hash); err != nil {
+			logger.Warnf("Failed to store rehashed password for auth %s: %v", auth.ID, err)
+		}
+	}
+
+	return u.jwtUsecase.IssueTokens(ctx, auth.ID)
+}
+
+func (u *passwordUsecaseImpl) ChangePassword(ctx context.Context, authID string, req models.ChangePasswordRequest) error {
+	auth, err := u.authRepo.GetAuthByID(ctx, authID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		auth = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if err := u.verify(auth, req.CurrentPassword); err != nil {
+		return err
+	}
+
+	hash, err := u.hash(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := u.authRepo.UpdateAuthPassword(ctx, auth.ID, hash); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
+
+// findAuth looks identifier up as an email when it contains an @, otherwise as a username.
+// An unknown account returns a nil auth so the caller still spends the time of a hash check.
+func (u *passwordUsecaseImpl) findAuth(ctx context.Context, identifier string) (*db.Auth, error) {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+
+	var auth *db.Auth
+	var err error
+	if strings.Contains(identifier, "@") {
+		auth, err = u.authRepo.GetAuthByEmail(ctx, identifier)
+	} else {
+		auth, err = u.authRepo.GetAuthByUsername(ctx, identifier)
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+	return auth, nil
+}
+
+// verify checks plain against the password of auth. Accounts that are missing, inactive or
+// have no password are checked against a dummy hash, so every failure takes about as long
+// and returns ErrInvalidCredentials.
+func (u *passwordUsecaseImpl) verify(auth *db.Auth, plain string) error {
+	encoded := u.getDummyHash()
+	usable := auth != nil && auth.Active && auth.Password != nil && *auth.Password != ""
+	if usable {
+		encoded = *auth.Password
+	}
+
+	ok, err := password.Verify(plain, encoded)
+	if err != nil {
+		logger.Errorf("Failed to verify password: %v", err)
+		return ErrInvalidCredentials
+	}
+	if !ok || !usable {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+func (u *passwordUsecaseImpl) getDummyHash() string {
+	u.dummyHashOnce.Do(func() {
+		hash, err := u.hash("dummy-password")
+		if err != nil {
+			logger.Errorf("Failed to create dummy password hash: %v", err)
+		}
+		u.dummyHash = hash
+	})
+	return u.dummyHash
+}
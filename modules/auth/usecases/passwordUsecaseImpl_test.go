@@ -0,0 +1,186 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"template-golang/pkg/password"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// stubTokenIssuer records the auths tokens were issued for
+type stubTokenIssuer struct {
+	JWTUsecase
+	issued []string
+}
+
+func (s *stubTokenIssuer) IssueTokens(ctx context.Context, authID string) (*models.TokenPair, error) {
+	s.issued = append(s.issued, authID)
+	return &models.TokenPair{AccessToken: "access-" + authID, RefreshToken: "refresh-" + authID}, nil
+}
+
+// fastParams keep the tests fast, they are weaker than password.DefaultParams
+var fastParams = password.Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func setupPasswordUsecase(t *testing.T) (*passwordUsecaseImpl, *repoMocks.MockAuthRepository, *stubTokenIssuer) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	issuer := &stubTokenIssuer{}
+
+	u := NewPasswordUsecase(issuer, authRepo).(*passwordUsecaseImpl)
+	u.hash = func(plain string) (string, error) {
+		return password.HashWithParams(plain, fastParams)
+	}
+	return u, authRepo, issuer
+}
+
+func hashedAuth(t *testing.T, id string, plain string) *db.Auth {
+	hash, err := password.Hash(plain)
+	require.NoError(t, err)
+	return &db.Auth{ID: id, Password: &hash, Active: true}
+}
+
+func TestPasswordUsecase_Register(t *testing.T) {
+	u, authRepo, issuer := setupPasswordUsecase(t)
+
+	authRepo.EXPECT().CreateAuth(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "user", true).
+		RunAndReturn(func(ctx context.Context, username *string, hash *string, email *string, role string, active bool) (*db.Auth, error) {
+			assert.Equal(t, "john_doe", *username)
+			assert.Equal(t, "john@example.com", *email)
+			ok, err := password.Verify("S3cret!pass", *hash)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			return &db.Auth{ID: "auth-1"}, nil
+		}).Once()
+
+	tokens, err := u.Register(context.Background(), models.RegisterRequest{
+		Username: "John_Doe",
+		Email:    " John@Example.com",
+		Password: "S3cret!pass",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-auth-1", tokens.AccessToken)
+	assert.Equal(t, []string{"auth-1"}, issuer.issued)
+}
+
+func TestPasswordUsecase_Register_Taken(t *testing.T) {
+	u, authRepo, issuer := setupPasswordUsecase(t)
+
+	authRepo.EXPECT().CreateAuth(mock.Anything, mock.Anything, mock.Anything, (*string)(nil), "user", true).
+		Return(nil, &pgconn.PgError{Code: "23505"}).Once()
+
+	_, err := u.Register(context.Background(), models.RegisterRequest{Username: "john", Password: "S3cret!pass"})
+
+	assert.ErrorIs(t, err, ErrRegistrationFailed)
+	assert.Empty(t, issuer.issued)
+}
+
+func TestPasswordUsecase_Login(t *testing.T) {
+	u, authRepo, issuer := setupPasswordUsecase(t)
+
+	authRepo.EXPECT().GetAuthByUsername(mock.Anything, "john").Return(hashedAuth(t, "auth-1", "S3cret!pass"), nil).Once()
+
+	tokens, err := u.Login(context.Background(), models.LoginRequest{Username: "John", Password: "S3cret!pass"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-auth-1", tokens.AccessToken)
+	assert.Equal(t, []string{"auth-1"}, issuer.issued)
+}
+
+func TestPasswordUsecase_Login_ByEmailRehashesBcrypt(t *testing.T) {
+	u, authRepo, _ := setupPasswordUsecase(t)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("S3cret!pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+	hash := string(legacy)
+
+	authRepo.EXPECT().GetAuthByEmail(mock.Anything, "john@example.com").
+		Return(&db.Auth{ID: "auth-1", Password: &hash, Active: true}, nil).Once()
+	authRepo.EXPECT().UpdateAuthPassword(mock.Anything, "auth-1", mock.MatchedBy(func(hash string) bool {
+		ok, err := password.Verify("S3cret!pass", hash)
+		return err == nil && ok
+	})).Return(nil).Once()
+
+	_, err = u.Login(context.Background(), models.LoginRequest{Username: "john@example.com", Password: "S3cret!pass"})
+
+	assert.NoError(t, err)
+}
+
+func TestPasswordUsecase_Login_FailuresAreUniform(t *testing.T) {
+	inactive := hashedAuth(t, "auth-2", "S3cret!pass")
+	inactive.Active = false
+
+	tests := []struct {
+		name     string
+		auth     *db.Auth
+		err      error
+		password string
+	}{
+		{name: "unknown account", err: pgx.ErrNoRows, password: "S3cret!pass"},
+		{name: "wrong password", auth: hashedAuth(t, "auth-1", "S3cret!pass"), password: "wrong"},
+		{name: "inactive account", auth: inactive, password: "S3cret!pass"},
+		{name: "account without password", auth: &db.Auth{ID: "auth-3", Active: true}, password: "S3cret!pass"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, authRepo, issuer := setupPasswordUsecase(t)
+			authRepo.EXPECT().GetAuthByUsername(mock.Anything, "john").Return(tt.auth, tt.err).Once()
+
+			tokens, err := u.Login(context.Background(), models.LoginRequest{Username: "john", Password: tt.password})
+
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+			assert.Nil(t, tokens)
+			assert.Empty(t, issuer.issued)
+		})
+	}
+}
+
+func TestPasswordUsecase_Login_StorageFailure(t *testing.T) {
+	u, authRepo, _ := setupPasswordUsecase(t)
+	authRepo.EXPECT().GetAuthByUsername(mock.Anything, "john").Return(nil, errors.New("db down")).Once()
+
+	_, err := u.Login(context.Background(), models.LoginRequest{Username: "john", Password: "S3cret!pass"})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestPasswordUsecase_ChangePassword(t *testing.T) {
+	u, authRepo, _ := setupPasswordUsecase(t)
+
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(hashedAuth(t, "auth-1", "S3cret!pass"), nil).Once()
+	authRepo.EXPECT().UpdateAuthPassword(mock.Anything, "auth-1", mock.MatchedBy(func(hash string) bool {
+		ok, err := password.Verify("N3w!password", hash)
+		return err == nil && ok
+	})).Return(nil).Once()
+
+	err := u.ChangePassword(context.Background(), "auth-1", models.ChangePasswordRequest{
+		CurrentPassword: "S3cret!pass",
+		NewPassword:     "N3w!password",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestPasswordUsecase_ChangePassword_WrongCurrentPassword(t *testing.T) {
+	u, authRepo, _ := setupPasswordUsecase(t)
+
+	authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(hashedAuth(t, "auth-1", "S3cret!pass"), nil).Once()
+
+	err := u.ChangePassword(context.Background(), "auth-1", models.ChangePasswordRequest{
+		CurrentPassword: "wrong",
+		NewPassword:     "N3w!password",
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
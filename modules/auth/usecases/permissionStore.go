@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/modules/auth/models"
+)
+
+// ErrInvalidRolePermissions is returned when AUTH_ROLE_PERMISSIONS cannot be parsed
+var ErrInvalidRolePermissions = errors.New("invalid AUTH_ROLE_PERMISSIONS")
+
+// PermissionStore maps roles to the permissions they grant. The mapping comes from
+// AUTH_ROLE_PERMISSIONS when set, otherwise from the role_permissions table.
+type PermissionStore interface {
+	// Permissions returns the permissions granted to role, none for unknown roles
+	Permissions(role models.Role) []string
+	// Refresh reloads the mapping
+	Refresh(ctx context.Context) error
+	// Run reloads the role_permissions table periodically until ctx is done, so changes made
+	// in the database are picked up without a restart
+	Run(ctx context.Context)
+}
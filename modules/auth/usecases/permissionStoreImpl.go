@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"template-golang/config"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"time"
+)
+
+const defaultPermissionsRefreshInterval = time.Minute
+
+type permissionStoreImpl struct {
+	roleRepo        repositories.RoleRepository
+	configured      string
+	refreshInterval time.Duration
+
+	mu          sync.RWMutex
+	permissions map[models.Role][]string
+}
+
+// NewPermissionStore returns a store that grants models.DefaultRolePermissions until Refresh
+// loads the configured mapping. Without AUTH_ROLE_PERMISSIONS and roleRepo the defaults stay.
+func NewPermissionStore(conf *config.Config, roleRepo repositories.RoleRepository) PermissionStore {
+	refreshInterval := conf.Auth.PermissionsRefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultPermissionsRefreshInterval
+	}
+
+	return &permissionStoreImpl{
+		roleRepo:        roleRepo,
+		configured:      conf.Auth.RolePermissions,
+		refreshInterval: refreshInterval,
+		permissions:     models.DefaultRolePermissions,
+	}
+}
+
+func (s *permissionStoreImpl) Permissions(role models.Role) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.permissions[role]
+}
+
+func (s *permissionStoreImpl) Refresh(ctx context.Context) error {
+	var permissions map[models.Role][]string
+	switch {
+	case s.configured != "":
+		parsed, err := parseRolePermissions(s.configured)
+		if err != nil {
+			return err
+		}
+		permissions = parsed
+	case s.roleRepo != nil:
+		rows, err := s.roleRepo.ListRolePermissions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list role permissions: %w", err)
+		}
+		permissions = make(map[models.Role][]string)
+		for _, row := range rows {
+			role := models.Role(row.Role)
+			permissions[role] = append(permissions[role], row.Permission)
+		}
+	default:
+		return nil
+	}
+
+	s.mu.Lock()
+	s.permissions = permissions
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *permissionStoreImpl) Run(ctx context.Context) {
+	// The configured mapping never changes
+	if s.configured != "" || s.roleRepo == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.Errorf("Failed to refresh role permissions: %v", err)
+		}
+	}
+}
+
+// parseRolePermissions parses "role=permission,permission;role=permission"
+func parseRolePermissions(value string) (map[models.Role][]string, error) {
+	permissions := make(map[models.Role][]string)
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		role, list, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("%w: %q is not role=permissions", ErrInvalidRolePermissions, entry)
+		}
+
+		granted := []string{}
+		for _, permission := range strings.Split(list, ",") {
+			if permission = strings.TrimSpace(permission); permission != "" {
+				granted = append(granted, permission)
+			}
+		}
+		permissions[models.Role(role)] = granted
+	}
+	return permissions, nil
+}
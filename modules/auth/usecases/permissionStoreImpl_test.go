@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPermissionStore_Defaults(t *testing.T) {
+	store := NewPermissionStore(&config.Config{}, nil)
+
+	assert.NoError(t, store.Refresh(context.Background()))
+	assert.Equal(t, []string{"*"}, store.Permissions(models.RoleAdmin))
+	assert.Contains(t, store.Permissions(models.RoleStaff), models.PermissionUsersRead)
+	assert.Empty(t, store.Permissions(models.Role("unknown")))
+}
+
+func TestPermissionStore_FromConfig(t *testing.T) {
+	conf := &config.Config{Auth: config.AuthConfig{
+		RolePermissions: "admin=*; staff = users:read, users:write ;user=",
+	}}
+	store := NewPermissionStore(conf, nil)
+
+	assert.NoError(t, store.Refresh(context.Background()))
+	assert.Equal(t, []string{"*"}, store.Permissions(models.RoleAdmin))
+	assert.Equal(t, []string{"users:read", "users:write"}, store.Permissions(models.RoleStaff))
+	assert.Empty(t, store.Permissions(models.RoleUser))
+}
+
+func TestPermissionStore_InvalidConfig(t *testing.T) {
+	conf := &config.Config{Auth: config.AuthConfig{RolePermissions: "admin"}}
+	store := NewPermissionStore(conf, nil)
+
+	err := store.Refresh(context.Background())
+
+	assert.ErrorIs(t, err, ErrInvalidRolePermissions)
+	// The defaults stay in place
+	assert.Equal(t, []string{"*"}, store.Permissions(models.RoleAdmin))
+}
+
+func TestPermissionStore_FromDatabase(t *testing.T) {
+	roleRepo := repoMocks.NewMockRoleRepository(t)
+	roleRepo.EXPECT().ListRolePermissions(mock.Anything).Return([]*db.RolePermission{
+		{Role: "admin", Permission: "*"},
+		{Role: "staff", Permission: "cockroach:*"},
+		{Role: "staff", Permission: "users:read"},
+	}, nil).Once()
+
+	store := NewPermissionStore(&config.Config{}, roleRepo)
+
+	assert.NoError(t, store.Refresh(context.Background()))
+	assert.Equal(t, []string{"cockroach:*", "users:read"}, store.Permissions(models.RoleStaff))
+	assert.Empty(t, store.Permissions(models.RoleUser))
+}
+
+func TestPermissionStore_DatabaseError(t *testing.T) {
+	roleRepo := repoMocks.NewMockRoleRepository(t)
+	roleRepo.EXPECT().ListRolePermissions(mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	store := NewPermissionStore(&config.Config{}, roleRepo)
+
+	assert.Error(t, store.Refresh(context.Background()))
+	assert.Equal(t, []string{"*"}, store.Permissions(models.RoleAdmin))
+}
@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"context"
+	"template-golang/pkg/personaldata"
+)
+
+// PersonalData exports and erases what the auth module stores about a user: the auth, its
+// provider accounts, API keys, sessions and audit events. It implements the
+// personaldata.Exporter and personaldata.Eraser of the auth module, which is registered before
+// every other module, so it is erased last.
+type PersonalData interface {
+	// ExportPersonalData returns the models.PersonalData of authID, soft-deleted or not, and
+	// pgx.ErrNoRows when there is no such auth
+	ExportPersonalData(ctx context.Context, authID string) (any, error)
+	// ErasePersonalData deletes the auth with every row referencing it, or with
+	// personaldata.ErasureAnonymize strips it of its username, email and credentials and
+	// deletes its provider accounts, tokens and keys. Audit events stay in both modes, without
+	// the identifiers of the user and the clients they used.
+	ErasePersonalData(ctx context.Context, authID string, mode personaldata.ErasureMode) error
+}
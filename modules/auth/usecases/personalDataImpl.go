@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/personaldata"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type personalDataImpl struct {
+	personalDataRepo repositories.PersonalDataRepository
+	loginThrottle    LoginThrottle
+	txManager        database.TxManager
+}
+
+func NewPersonalData(personalDataRepo repositories.PersonalDataRepository, loginThrottle LoginThrottle, txManager database.TxManager) PersonalData {
+	return &personalDataImpl{
+		personalDataRepo: personalDataRepo,
+		loginThrottle:    loginThrottle,
+		txManager:        txManager,
+	}
+}
+
+func (p *personalDataImpl) ExportPersonalData(ctx context.Context, authID string) (any, error) {
+	var data models.PersonalData
+	err := p.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		auth, err := p.personalDataRepo.GetAuth(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to get auth: %w", err)
+		}
+		methods, err := p.personalDataRepo.ListAuthMethods(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to list auth methods: %w", err)
+		}
+		keys, err := p.personalDataRepo.ListAPIKeys(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to list api keys: %w", err)
+		}
+		tokens, err := p.personalDataRepo.ListRefreshTokens(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to list refresh tokens: %w", err)
+		}
+		events, err := p.personalDataRepo.ListAuditEvents(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		data = models.PersonalData{
+			Account:     exportAccount(auth),
+			Providers:   make([]models.PersonalProvider, 0, len(methods)),
+			APIKeys:     make([]models.APIKey, 0, len(keys)),
+			Sessions:    make([]models.PersonalSession, 0, len(tokens)),
+			AuditEvents: make([]models.AuditEventResponse, 0, len(events)),
+		}
+		for _, method := range methods {
+			data.Providers = append(data.Providers, exportProvider(method))
+		}
+		for _, key := range keys {
+			data.APIKeys = append(data.APIKeys, exportAPIKey(key))
+		}
+		for _, token := range tokens {
+			data.Sessions = append(data.Sessions, models.PersonalSession{
+				CreatedAt: token.CreatedAt.Time,
+				ExpiresAt: token.ExpiresAt.Time,
+				UsedAt:    timePtr(token.UsedAt.Time, token.UsedAt.Valid),
+				RevokedAt: timePtr(token.RevokedAt.Time, token.RevokedAt.Valid),
+			})
+		}
+		for _, event := range events {
+			data.AuditEvents = append(data.AuditEvents, exportAuditEvent(event, authID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *personalDataImpl) ErasePersonalData(ctx context.Context, authID string, mode personaldata.ErasureMode) error {
+	identifiers := []string{}
+	err := p.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		identifiers = identifiers[:0]
+
+		auth, err := p.personalDataRepo.GetAuth(ctx, authID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get auth: %w", err)
+		}
+		// An auth deleted by an earlier erasure only leaves audit events behind
+		if auth != nil {
+			identifiers = appendIdentifier(identifiers, auth.Username)
+			identifiers = appendIdentifier(identifiers, auth.Email)
+			methods, err := p.personalDataRepo.ListAuthMethods(ctx, authID)
+			if err != nil {
+				return fmt.Errorf("failed to list auth methods: %w", err)
+			}
+			for _, method := range methods {
+				identifiers = appendIdentifier(identifiers, method.Email)
+			}
+		}
+
+		if _, err := p.personalDataRepo.AnonymizeAuditEvents(ctx, authID, identifiers); err != nil {
+			return fmt.Errorf("failed to anonymize audit events: %w", err)
+		}
+		if auth == nil {
+			return nil
+		}
+
+		if mode == personaldata.ErasureAnonymize {
+			_, err = p.personalDataRepo.AnonymizeAuth(ctx, authID)
+		} else {
+			_, err = p.personalDataRepo.DeleteAuth(ctx, authID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to erase auth: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Failed logins are counted under the username or email they were attempted with
+	keys := []string{ThrottleAccountKey(authID)}
+	for _, identifier := range identifiers {
+		keys = append(keys, ThrottleAccountKey(identifier))
+	}
+	if err := p.loginThrottle.Reset(ctx, keys...); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+
+	logger.Infof("Erased the personal data of auth %s (%s)", authID, mode)
+	return nil
+}
+
+// appendIdentifier appends the lowercase value to identifiers, unless empty or already there
+func appendIdentifier(identifiers []string, value *string) []string {
+	if value == nil {
+		return identifiers
+	}
+	identifier := strings.ToLower(strings.TrimSpace(*value))
+	if identifier == "" || slices.Contains(identifiers, identifier) {
+		return identifiers
+	}
+	return append(identifiers, identifier)
+}
+
+func exportAccount(auth *db.Auth) models.PersonalAccount {
+	return models.PersonalAccount{
+		ID:          auth.ID,
+		Username:    stringValue(auth.Username),
+		Email:       stringValue(auth.Email),
+		Role:        auth.Role,
+		Active:      auth.Active,
+		HasPassword: auth.Password != nil && *auth.Password != "",
+		MFAEnabled:  auth.MFAEnabledAt.Valid,
+		CreatedAt:   auth.CreatedAt.Time,
+		UpdatedAt:   auth.UpdatedAt.Time,
+		DeletedAt:   timePtr(auth.DeletedAt.Time, auth.DeletedAt.Valid),
+	}
+}
+
+func exportProvider(method *db.AuthMethod) models.PersonalProvider {
+	return models.PersonalProvider{
+		Provider:    method.Provider,
+		ProviderID:  method.ProviderID,
+		Email:       stringValue(method.Email),
+		UserID:      stringValue(method.UserID),
+		Name:        stringValue(method.Name),
+		FirstName:   stringValue(method.FirstName),
+		LastName:    stringValue(method.LastName),
+		NickName:    stringValue(method.NickName),
+		Description: stringValue(method.Description),
+		AvatarURL:   stringValue(method.AvatarUrl),
+		Location:    stringValue(method.Location),
+		LinkedAt:    method.CreatedAt.Time,
+		UnlinkedAt:  timePtr(method.DeletedAt.Time, method.DeletedAt.Valid),
+	}
+}
+
+func exportAPIKey(key *db.APIKey) models.APIKey {
+	return models.APIKey{
+		ID:         key.ID,
+		AuthID:     key.AuthID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt.Time,
+		ExpiresAt:  timePtr(key.ExpiresAt.Time, key.ExpiresAt.Valid),
+		LastUsedAt: timePtr(key.LastUsedAt.Time, key.LastUsedAt.Valid),
+		RevokedAt:  timePtr(key.RevokedAt.Time, key.RevokedAt.Valid),
+	}
+}
+
+// exportAuditEvent returns the audit event of authID. The client of the events others did to
+// them, such as an admin changing their role, is the actor's personal data and is left out.
+func exportAuditEvent(event *db.AuditEvent, authID string) models.AuditEventResponse {
+	auditEvent := models.AuditEventResponse{
+		ID:         event.ID,
+		CreatedAt:  event.CreatedAt.Time,
+		ActorID:    stringValue(event.ActorID),
+		Action:     event.Action,
+		TargetType: stringValue(event.TargetType),
+		TargetID:   stringValue(event.TargetID),
+		Outcome:    event.Outcome,
+		IPAddress:  stringValue(event.IpAddress),
+		UserAgent:  stringValue(event.UserAgent),
+		RequestID:  stringValue(event.RequestID),
+		Metadata:   event.Metadata,
+	}
+	if stringValue(event.ActorID) != authID {
+		auditEvent.IPAddress = ""
+		auditEvent.UserAgent = ""
+	}
+	if len(auditEvent.Metadata) == 0 {
+		auditEvent.Metadata = []byte("{}")
+	}
+	return auditEvent
+}
+
+// stringValue returns the string s points to, empty for nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// timePtr returns &t when valid, nil otherwise
+func timePtr(t time.Time, valid bool) *time.Time {
+	if !valid {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	jwtMocks "template-golang/modules/auth/usecases/mocks"
+	"template-golang/pkg/personaldata"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type personalDataMocks struct {
+	personalDataRepo *repoMocks.MockPersonalDataRepository
+	loginThrottle    *jwtMocks.MockLoginThrottle
+	txManager        *dbMocks.MockTxManager
+}
+
+func setupPersonalData(t *testing.T) (PersonalData, personalDataMocks) {
+	m := personalDataMocks{
+		personalDataRepo: repoMocks.NewMockPersonalDataRepository(t),
+		loginThrottle:    jwtMocks.NewMockLoginThrottle(t),
+		txManager:        dbMocks.NewMockTxManager(t),
+	}
+	return NewPersonalData(m.personalDataRepo, m.loginThrottle, m.txManager), m
+}
+
+func TestPersonalData_Export(t *testing.T) {
+	personalData, m := setupPersonalData(t)
+
+	username, email, hash, secret := "john", "john@example.com", "password-hash", "mfa-secret"
+	accessToken, refreshToken := "provider-access-token", "provider-refresh-token"
+	auth := &db.Auth{ID: "auth-1", Username: &username, Email: &email, Password: &hash, MFASecret: &secret, Role: "user", Active: true}
+	auth.MFAEnabledAt.Valid = true
+
+	runInTx(m.txManager)
+	m.personalDataRepo.EXPECT().GetAuth(mock.Anything, "auth-1").Return(auth, nil).Once()
+	m.personalDataRepo.EXPECT().ListAuthMethods(mock.Anything, "auth-1").Return([]*db.AuthMethod{
+		{Provider: "github", ProviderID: "gh-1", Email: &email, AccessToken: &accessToken, RefreshToken: &refreshToken},
+	}, nil).Once()
+	m.personalDataRepo.EXPECT().ListAPIKeys(mock.Anything, "auth-1").Return([]*db.APIKey{
+		{ID: "key-1", AuthID: "auth-1", Name: "ci", Prefix: "tgk_abc", KeyHash: "key-hash"},
+	}, nil).Once()
+	m.personalDataRepo.EXPECT().ListRefreshTokens(mock.Anything, "auth-1").Return([]*db.RefreshToken{
+		{ID: "rt-1", AuthID: "auth-1", TokenHash: "token-hash"},
+	}, nil).Once()
+	m.personalDataRepo.EXPECT().ListAuditEvents(mock.Anything, "auth-1").Return([]*db.AuditEvent{
+		{ID: "event-1", Action: models.AuditActionLogin, Outcome: models.AuditOutcomeSuccess},
+	}, nil).Once()
+
+	data, err := personalData.ExportPersonalData(context.Background(), "auth-1")
+	require.NoError(t, err)
+
+	exported := data.(models.PersonalData)
+	assert.Equal(t, "john", exported.Account.Username)
+	assert.True(t, exported.Account.HasPassword)
+	assert.True(t, exported.Account.MFAEnabled)
+	assert.Len(t, exported.Providers, 1)
+	assert.Len(t, exported.APIKeys, 1)
+	assert.Len(t, exported.Sessions, 1)
+	assert.Len(t, exported.AuditEvents, 1)
+
+	raw, err := json.Marshal(exported)
+	require.NoError(t, err)
+	for _, secret := range []string{hash, secret, accessToken, refreshToken, "key-hash", "token-hash"} {
+		assert.NotContains(t, string(raw), secret)
+	}
+}
+
+func TestPersonalData_ExportAuditEventClients(t *testing.T) {
+	personalData, m := setupPersonalData(t)
+
+	subject, admin := "auth-1", "admin-1"
+	userIP, userAgent := "198.51.100.7", "user-browser"
+	adminIP, adminAgent := "203.0.113.9", "admin-browser"
+
+	runInTx(m.txManager)
+	m.personalDataRepo.EXPECT().GetAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "user", Active: true}, nil).Once()
+	m.personalDataRepo.EXPECT().ListAuthMethods(mock.Anything, "auth-1").Return(nil, nil).Once()
+	m.personalDataRepo.EXPECT().ListAPIKeys(mock.Anything, "auth-1").Return(nil, nil).Once()
+	m.personalDataRepo.EXPECT().ListRefreshTokens(mock.Anything, "auth-1").Return(nil, nil).Once()
+	m.personalDataRepo.EXPECT().ListAuditEvents(mock.Anything, "auth-1").Return([]*db.AuditEvent{
+		{ID: "event-1", ActorID: &subject, Action: models.AuditActionLogin, Outcome: models.AuditOutcomeSuccess, IpAddress: &userIP, UserAgent: &userAgent},
+		{ID: "event-2", ActorID: &admin, Action: models.AuditActionUserRoleChange, TargetID: &subject, Outcome: models.AuditOutcomeSuccess, IpAddress: &adminIP, UserAgent: &adminAgent},
+	}, nil).Once()
+
+	data, err := personalData.ExportPersonalData(context.Background(), "auth-1")
+	require.NoError(t, err)
+
+	events := data.(models.PersonalData).AuditEvents
+	require.Len(t, events, 2)
+	assert.Equal(t, userIP, events[0].IPAddress)
+	assert.Equal(t, userAgent, events[0].UserAgent)
+	// The admin who acted on the account keeps their client to themselves
+	assert.Equal(t, admin, events[1].ActorID)
+	assert.Empty(t, events[1].IPAddress)
+	assert.Empty(t, events[1].UserAgent)
+}
+
+func TestPersonalData_ExportUnknownAuth(t *testing.T) {
+	personalData, m := setupPersonalData(t)
+
+	runInTx(m.txManager)
+	m.personalDataRepo.EXPECT().GetAuth(mock.Anything, "auth-1").Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := personalData.ExportPersonalData(context.Background(), "auth-1")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestPersonalData_Erase(t *testing.T) {
+	username, email, providerEmail := "john", "john@example.com", "John@GitHub.example"
+
+	tests := []struct {
+		name string
+		mode personaldata.ErasureMode
+	}{
+		{"delete", personaldata.ErasureDelete},
+		{"anonymize", personaldata.ErasureAnonymize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			personalData, m := setupPersonalData(t)
+
+			runInTx(m.txManager)
+			m.personalDataRepo.EXPECT().GetAuth(mock.Anything, "auth-1").
+				Return(&db.Auth{ID: "auth-1", Username: &username, Email: &email}, nil).Once()
+			m.personalDataRepo.EXPECT().ListAuthMethods(mock.Anything, "auth-1").Return([]*db.AuthMethod{
+				{Provider: "github", Email: &providerEmail},
+				{Provider: "google", Email: &email},
+			}, nil).Once()
+			m.personalDataRepo.EXPECT().AnonymizeAuditEvents(mock.Anything, "auth-1", []string{username, email, "john@github.example"}).Return(3, nil).Once()
+			if tt.mode == personaldata.ErasureDelete {
+				m.personalDataRepo.EXPECT().DeleteAuth(mock.Anything, "auth-1").Return(true, nil).Once()
+			} else {
+				m.personalDataRepo.EXPECT().AnonymizeAuth(mock.Anything, "auth-1").Return(true, nil).Once()
+			}
+			m.loginThrottle.EXPECT().Reset(mock.Anything,
+				[]string{"account:auth-1", "account:john", "account:john@example.com", "account:john@github.example"}).Return(nil).Once()
+
+			assert.NoError(t, personalData.ErasePersonalData(context.Background(), "auth-1", tt.mode))
+		})
+	}
+}
+
+func TestPersonalData_EraseDeletedAuth(t *testing.T) {
+	personalData, m := setupPersonalData(t)
+
+	// Running an erasure again only scrubs the audit events left behind
+	runInTx(m.txManager)
+	m.personalDataRepo.EXPECT().GetAuth(mock.Anything, "auth-1").Return(nil, pgx.ErrNoRows).Once()
+	m.personalDataRepo.EXPECT().AnonymizeAuditEvents(mock.Anything, "auth-1", []string{}).Return(0, nil).Once()
+	m.loginThrottle.EXPECT().Reset(mock.Anything, []string{"account:auth-1"}).Return(nil).Once()
+
+	assert.NoError(t, personalData.ErasePersonalData(context.Background(), "auth-1", personaldata.ErasureDelete))
+}
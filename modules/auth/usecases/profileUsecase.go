@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+)
+
+var (
+	// ErrProfileTaken is returned when the new username or email belongs to another account. It
+	// does not say which one.
+	ErrProfileTaken = errors.New("username or email taken")
+	// ErrAdminSelfDelete is returned when a user whose role may manage users deletes their own account,
+	// which could leave nobody to manage them
+	ErrAdminSelfDelete = errors.New("admins cannot delete themselves")
+)
+
+// ProfileUsecase lets users see and change their own account. Methods return pgx.ErrNoRows
+// when the account no longer exists.
+type ProfileUsecase interface {
+	// GetProfile returns the account of authID and its auth methods
+	GetProfile(ctx context.Context, authID string) (*db.Auth, []*db.AuthMethod, error)
+	// UpdateProfile changes the username or email of authID. Email changes give
+	// ErrInvalidCredentials unless req carries the current password.
+	UpdateProfile(ctx context.Context, authID string, req models.UpdateProfileRequest) (*db.Auth, error)
+	// DeleteAccount soft deletes the account of authID, frees its username, email and providers
+	// for a new account and revokes its tokens. Accounts with a password give
+	// ErrInvalidCredentials unless it is passed.
+	DeleteAccount(ctx context.Context, authID string, password string) error
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/utils"
+	"template-golang/pkg/authz"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/password"
+)
+
+type profileUsecaseImpl struct {
+	jwtUsecase      JWTUsecase
+	authRepo        repositories.AuthRepository
+	permissionStore PermissionStore
+	txManager       database.TxManager
+}
+
+func NewProfileUsecase(jwtUsecase JWTUsecase, authRepo repositories.AuthRepository, permissionStore PermissionStore, txManager database.TxManager) ProfileUsecase {
+	return &profileUsecaseImpl{
+		jwtUsecase:      jwtUsecase,
+		authRepo:        authRepo,
+		permissionStore: permissionStore,
+		txManager:       txManager,
+	}
+}
+
+func (u *profileUsecaseImpl) GetProfile(ctx context.Context, authID string) (*db.Auth, []*db.AuthMethod, error) {
+	auth, err := u.authRepo.GetAuthByID(ctx, authID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	authMethods, err := u.authRepo.GetAuthMethodsByAuthID(ctx, authID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get auth methods: %w", err)
+	}
+
+	return auth, authMethods, nil
+}
+
+func (u *profileUsecaseImpl) UpdateProfile(ctx context.Context, authID string, req models.UpdateProfileRequest) (*db.Auth, error) {
+	var auth *db.Auth
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		current, err := u.authRepo.LockAuth(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to lock auth: %w", err)
+		}
+
+		params := db.UpdateAuthParams{
+			ID:       current.ID,
+			Username: current.Username,
+			Password: current.Password,
+			Email:    current.Email,
+			Role:     current.Role,
+			Active:   current.Active,
+		}
+		if req.Username != nil {
+			username := strings.ToLower(*req.Username)
+			params.Username = &username
+		}
+		if req.Email != nil {
+			// An empty email removes it
+			params.Email = utils.StringToPtr(strings.ToLower(strings.TrimSpace(*req.Email)))
+			if !sameString(params.Email, current.Email) {
+				// The email logs in and links providers, so only the owner of the password may change it
+				if err := verifyPassword(current, req.CurrentPassword); err != nil {
+					return err
+				}
+			}
+		}
+
+		auth, err = u.authRepo.UpdateAuth(ctx, params)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return ErrProfileTaken
+			}
+			return fmt.Errorf("failed to update auth: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auth, nil
+}
+
+func (u *profileUsecaseImpl) DeleteAccount(ctx context.Context, authID string, plain string) error {
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		auth, err := u.authRepo.LockAuth(ctx, authID)
+		if err != nil {
+			return fmt.Errorf("failed to lock auth: %w", err)
+		}
+		// Decided by the role of the account rather than the principal, whose permissions an API
+		// key or a reduced-scope session may have narrowed
+		account := authz.Principal{Role: auth.Role, Permissions: u.permissionStore.Permissions(models.Role(auth.Role))}
+		if account.Has(models.PermissionUsersWrite) {
+			return ErrAdminSelfDelete
+		}
+		if auth.Password != nil && *auth.Password != "" {
+			if err := verifyPassword(auth, plain); err != nil {
+				return err
+			}
+		}
+
+		if err := u.jwtUsecase.RevokeAllTokens(ctx, authID); err != nil {
+			return err
+		}
+
+		if err := u.authRepo.CloseAuth(ctx, authID); err != nil {
+			return fmt.Errorf("failed to delete auth: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Auth %s deleted their account", authID)
+	return nil
+}
+
+// verifyPassword returns ErrInvalidCredentials unless plain is the password of auth
+func verifyPassword(auth *db.Auth, plain string) error {
+	if auth.Password == nil || *auth.Password == "" || plain == "" {
+		return ErrInvalidCredentials
+	}
+
+	ok, err := password.Verify(plain, *auth.Password)
+	if err != nil {
+		logger.Errorf("Failed to verify password: %v", err)
+		return ErrInvalidCredentials
+	}
+	if !ok {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// sameString reports whether a and b are both nil or point to equal strings
+func sameString(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
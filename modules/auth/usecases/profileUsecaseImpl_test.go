@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"template-golang/config"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	jwtMocks "template-golang/modules/auth/usecases/mocks"
+	"template-golang/pkg/authz"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type profileUsecaseMocks struct {
+	jwtUsecase *jwtMocks.MockJWTUsecase
+	authRepo   *repoMocks.MockAuthRepository
+	txManager  *dbMocks.MockTxManager
+}
+
+func setupProfileUsecase(t *testing.T) (ProfileUsecase, profileUsecaseMocks) {
+	m := profileUsecaseMocks{
+		jwtUsecase: jwtMocks.NewMockJWTUsecase(t),
+		authRepo:   repoMocks.NewMockAuthRepository(t),
+		txManager:  dbMocks.NewMockTxManager(t),
+	}
+	return NewProfileUsecase(m.jwtUsecase, m.authRepo, NewPermissionStore(&config.Config{}, nil), m.txManager), m
+}
+
+func TestProfile_GetProfile(t *testing.T) {
+	profile, m := setupProfileUsecase(t)
+
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	m.authRepo.EXPECT().GetAuthMethodsByAuthID(mock.Anything, "auth-1").Return([]*db.AuthMethod{{Provider: "line"}}, nil).Once()
+
+	auth, authMethods, err := profile.GetProfile(context.Background(), "auth-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "auth-1", auth.ID)
+	assert.Len(t, authMethods, 1)
+}
+
+func TestProfile_GetProfileDeleted(t *testing.T) {
+	profile, m := setupProfileUsecase(t)
+
+	m.authRepo.EXPECT().GetAuthByID(mock.Anything, "auth-1").Return(nil, pgx.ErrNoRows).Once()
+
+	_, _, err := profile.GetProfile(context.Background(), "auth-1")
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestProfile_UpdateUsername(t *testing.T) {
+	profile, m := setupProfileUsecase(t)
+	email := "john@example.com"
+	username := "John_Doe"
+
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Email: &email, Role: "user", Active: true}, nil).Once()
+	m.authRepo.EXPECT().UpdateAuth(mock.Anything, mock.MatchedBy(func(p db.UpdateAuthParams) bool {
+		return *p.Username == "john_doe" && *p.Email == email && p.Role == "user" && p.Active
+	})).Return(&db.Auth{ID: "auth-1"}, nil).Once()
+
+	// The unchanged email needs no password
+	auth, err := profile.UpdateProfile(context.Background(), "auth-1", models.UpdateProfileRequest{Username: &username, Email: &email})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "auth-1", auth.ID)
+}
+
+func TestProfile_UpdateEmailNeedsPassword(t *testing.T) {
+	newEmail := " New@Example.com "
+	tests := []struct {
+		name     string
+		auth     *db.Auth
+		password string
+		err      error
+	}{
+		{"right password", hashedAuth(t, "auth-1", "S3cret!pass"), "S3cret!pass", nil},
+		{"wrong password", hashedAuth(t, "auth-1", "S3cret!pass"), "wrong", ErrInvalidCredentials},
+		{"no password", &db.Auth{ID: "auth-1", Active: true}, "", ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, m := setupProfileUsecase(t)
+
+			runInTx(m.txManager)
+			m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(tt.auth, nil).Once()
+			if tt.err == nil {
+				m.authRepo.EXPECT().UpdateAuth(mock.Anything, mock.MatchedBy(func(p db.UpdateAuthParams) bool {
+					return *p.Email == "new@example.com" && p.Password == tt.auth.Password
+				})).Return(&db.Auth{ID: "auth-1"}, nil).Once()
+			}
+
+			_, err := profile.UpdateProfile(context.Background(), "auth-1", models.UpdateProfileRequest{Email: &newEmail, CurrentPassword: tt.password})
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestProfile_UpdateTaken(t *testing.T) {
+	profile, m := setupProfileUsecase(t)
+	username := "taken"
+
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	m.authRepo.EXPECT().UpdateAuth(mock.Anything, mock.Anything).Return(nil, &pgconn.PgError{Code: sqlStateUniqueViolation}).Once()
+
+	_, err := profile.UpdateProfile(context.Background(), "auth-1", models.UpdateProfileRequest{Username: &username})
+
+	assert.ErrorIs(t, err, ErrProfileTaken)
+}
+
+func TestProfile_DeleteAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     *db.Auth
+		password string
+		err      error
+	}{
+		{"provider account", &db.Auth{ID: "auth-1", Active: true}, "", nil},
+		{"right password", hashedAuth(t, "auth-1", "S3cret!pass"), "S3cret!pass", nil},
+		{"wrong password", hashedAuth(t, "auth-1", "S3cret!pass"), "wrong", ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, m := setupProfileUsecase(t)
+
+			runInTx(m.txManager)
+			m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(tt.auth, nil).Once()
+			if tt.err == nil {
+				m.jwtUsecase.EXPECT().RevokeAllTokens(mock.Anything, "auth-1").Return(nil).Once()
+				m.authRepo.EXPECT().CloseAuth(mock.Anything, "auth-1").Return(nil).Once()
+			}
+
+			err := profile.DeleteAccount(context.Background(), "auth-1", tt.password)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestProfile_DeleteAccountAdmin(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"admin session", adminCtx},
+		// The principal of a scoped key cannot manage users, but the account it acts for can
+		{"scoped API key", authz.WithPrincipal(context.Background(), authz.Principal{
+			UserID:      "admin-1",
+			Role:        string(models.RoleAdmin),
+			Permissions: []string{"cockroach:write"},
+			APIKey:      true,
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, m := setupProfileUsecase(t)
+
+			runInTx(m.txManager)
+			m.authRepo.EXPECT().LockAuth(mock.Anything, "admin-1").Return(&db.Auth{ID: "admin-1", Role: string(models.RoleAdmin), Active: true}, nil).Once()
+
+			err := profile.DeleteAccount(tt.ctx, "admin-1", "")
+
+			assert.ErrorIs(t, err, ErrAdminSelfDelete)
+		})
+	}
+}
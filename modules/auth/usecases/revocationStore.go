@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/modules/auth/models"
+)
+
+// ErrTokenNotRevocable is returned when a token has no jti to revoke it by
+var ErrTokenNotRevocable = errors.New("token has no jti")
+
+// RevocationStore records access tokens revoked before they expire. Revocations are stored in
+// the database and cached in memory, so other replicas honor them within the cache TTL.
+type RevocationStore interface {
+	// Revoke revokes the token described by claims until it expires
+	Revoke(ctx context.Context, claims *models.AccessClaims) error
+	// RevokeAll revokes every access token issued to authID before the current second
+	RevokeAll(ctx context.Context, authID string) error
+	// IsRevoked reports whether the token described by claims has been revoked
+	IsRevoked(ctx context.Context, claims *models.AccessClaims) (bool, error)
+	// Run deletes revocations of expired tokens periodically until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"template-golang/config"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/cache"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const defaultRevocationCleanupInterval = time.Hour
+
+// authRevocation is the cached revocation state of an auth
+type authRevocation struct {
+	revokedAt time.Time // tokens issued before this second are revoked, zero when never set
+	deleted   bool      // the auth no longer exists, so none of its tokens are valid
+}
+
+type revocationStoreImpl struct {
+	revokedTokenRepo repositories.RevokedTokenRepository
+
+	accessTokenTTL  time.Duration
+	cacheTTL        time.Duration
+	cleanupInterval time.Duration
+
+	revokedTokens   *cache.TTLCache[string, bool]
+	authRevocations *cache.TTLCache[string, authRevocation]
+}
+
+func NewRevocationStore(conf *config.Config, revokedTokenRepo repositories.RevokedTokenRepository) RevocationStore {
+	accessTokenTTL := conf.Auth.AccessTokenTTL
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = defaultAccessTokenTTL
+	}
+	cleanupInterval := conf.Auth.RevocationCleanupInterval
+	if cleanupInterval <= 0 {
+		cleanupInterval = defaultRevocationCleanupInterval
+	}
+
+	return &revocationStoreImpl{
+		revokedTokenRepo: revokedTokenRepo,
+		accessTokenTTL:   accessTokenTTL,
+		cacheTTL:         conf.Auth.RevocationCacheTTL,
+		cleanupInterval:  cleanupInterval,
+		revokedTokens:    cache.NewTTLCache[string, bool](conf.Auth.RevocationCacheTTL),
+		authRevocations:  cache.NewTTLCache[string, authRevocation](conf.Auth.RevocationCacheTTL),
+	}
+}
+
+func (s *revocationStoreImpl) Revoke(ctx context.Context, claims *models.AccessClaims) error {
+	if claims.ID == "" {
+		return ErrTokenNotRevocable
+	}
+
+	expiresAt := s.expiresAt(claims)
+	if err := s.revokedTokenRepo.RevokeToken(ctx, claims.ID, claims.Subject, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	// A revocation never goes away, so it can stay cached until the token expires
+	s.revokedTokens.SetWithTTL(claims.ID, true, time.Until(expiresAt))
+	return nil
+}
+
+func (s *revocationStoreImpl) RevokeAll(ctx context.Context, authID string) error {
+	revokedAt, err := s.revokedTokenRepo.RevokeAuthTokens(ctx, authID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	s.authRevocations.Set(authID, authRevocation{revokedAt: revokedAt.Truncate(time.Second)})
+	return nil
+}
+
+func (s *revocationStoreImpl) IsRevoked(ctx context.Context, claims *models.AccessClaims) (bool, error) {
+	revoked, err := s.isTokenRevoked(ctx, claims)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	revocation, err := s.authRevocation(ctx, claims.Subject)
+	if err != nil {
+		return false, err
+	}
+	if revocation.deleted {
+		return true, nil
+	}
+	if revocation.revokedAt.IsZero() {
+		return false, nil
+	}
+
+	// iat has second precision and the cutoff is stored truncated to the second, so a token
+	// issued right after the revocation, in the same second, is still accepted
+	if claims.IssuedAt == nil {
+		return true, nil
+	}
+	return claims.IssuedAt.Before(revocation.revokedAt), nil
+}
+
+func (s *revocationStoreImpl) isTokenRevoked(ctx context.Context, claims *models.AccessClaims) (bool, error) {
+	if claims.ID == "" {
+		return false, nil
+	}
+
+	if revoked, ok := s.revokedTokens.Get(claims.ID); ok {
+		return revoked, nil
+	}
+
+	revoked, err := s.revokedTokenRepo.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	if revoked {
+		s.revokedTokens.SetWithTTL(claims.ID, true, time.Until(s.expiresAt(claims)))
+	} else {
+		s.revokedTokens.Set(claims.ID, false)
+	}
+	return revoked, nil
+}
+
+func (s *revocationStoreImpl) authRevocation(ctx context.Context, authID string) (authRevocation, error) {
+	if revocation, ok := s.authRevocations.Get(authID); ok {
+		return revocation, nil
+	}
+
+	var revocation authRevocation
+	revokedAt, err := s.revokedTokenRepo.GetAuthTokensRevokedAt(ctx, authID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		revocation.deleted = true
+	case err != nil:
+		return revocation, fmt.Errorf("failed to check auth revocation: %w", err)
+	case revokedAt != nil:
+		revocation.revokedAt = revokedAt.Truncate(time.Second)
+	}
+
+	s.authRevocations.Set(authID, revocation)
+	return revocation, nil
+}
+
+// expiresAt returns when the token described by claims expires
+func (s *revocationStoreImpl) expiresAt(claims *models.AccessClaims) time.Time {
+	if claims.ExpiresAt != nil {
+		return claims.ExpiresAt.Time
+	}
+	return time.Now().Add(s.accessTokenTTL)
+}
+
+func (s *revocationStoreImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := s.revokedTokenRepo.DeleteExpiredRevokedTokens(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Failed to delete expired revoked tokens: %v", err)
+			}
+			continue
+		}
+		if deleted > 0 {
+			logger.Infof("Deleted %d expired revoked tokens", deleted)
+		}
+
+		s.revokedTokens.DeleteExpired()
+		s.authRevocations.DeleteExpired()
+	}
+}
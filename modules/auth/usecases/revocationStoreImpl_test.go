@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"template-golang/config"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupRevocationStore(t *testing.T) (RevocationStore, *repoMocks.MockRevokedTokenRepository) {
+	conf := &config.Config{
+		Auth: config.AuthConfig{RevocationCacheTTL: time.Minute},
+	}
+	revokedTokenRepo := repoMocks.NewMockRevokedTokenRepository(t)
+	return NewRevocationStore(conf, revokedTokenRepo), revokedTokenRepo
+}
+
+func newClaims(jti string, authID string, issuedAt time.Time) *models.AccessClaims {
+	return &models.AccessClaims{RegisteredClaims: jwt.RegisteredClaims{
+		ID:        jti,
+		Subject:   authID,
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		ExpiresAt: jwt.NewNumericDate(issuedAt.Add(15 * time.Minute)),
+	}}
+}
+
+func TestRevocationStore_IsRevoked_CachesLookups(t *testing.T) {
+	store, repo := setupRevocationStore(t)
+	claims := newClaims("jti-1", "auth-1", time.Now())
+
+	repo.EXPECT().IsTokenRevoked(mock.Anything, "jti-1").Return(false, nil).Once()
+	repo.EXPECT().GetAuthTokensRevokedAt(mock.Anything, "auth-1").Return(nil, nil).Once()
+
+	for range 3 {
+		revoked, err := store.IsRevoked(context.Background(), claims)
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	}
+}
+
+func TestRevocationStore_Revoke(t *testing.T) {
+	store, repo := setupRevocationStore(t)
+	claims := newClaims("jti-1", "auth-1", time.Now())
+
+	repo.EXPECT().RevokeToken(mock.Anything, "jti-1", "auth-1", claims.ExpiresAt.Time).Return(nil).Once()
+
+	assert.NoError(t, store.Revoke(context.Background(), claims))
+
+	// The revocation is served from the cache on this replica
+	revoked, err := store.IsRevoked(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRevocationStore_Revoke_TokenWithoutID(t *testing.T) {
+	store, _ := setupRevocationStore(t)
+
+	err := store.Revoke(context.Background(), newClaims("", "auth-1", time.Now()))
+
+	assert.ErrorIs(t, err, ErrTokenNotRevocable)
+}
+
+func TestRevocationStore_RevokeAll_RevokesTokensIssuedBeforeCutoff(t *testing.T) {
+	store, repo := setupRevocationStore(t)
+	cutoff := time.Now().Truncate(time.Second)
+
+	// A cutoff stored before it was truncated to the second is truncated on load
+	repo.EXPECT().RevokeAuthTokens(mock.Anything, "auth-1").Return(cutoff.Add(700*time.Millisecond), nil).Once()
+	repo.EXPECT().IsTokenRevoked(mock.Anything, mock.Anything).Return(false, nil)
+
+	assert.NoError(t, store.RevokeAll(context.Background(), "auth-1"))
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{name: "issued before cutoff", issuedAt: cutoff.Add(-time.Minute), revoked: true},
+		{name: "issued the second before cutoff", issuedAt: cutoff.Add(-time.Second), revoked: true},
+		{name: "issued in the same second", issuedAt: cutoff.Add(900 * time.Millisecond), revoked: false},
+		{name: "issued after cutoff", issuedAt: cutoff.Add(time.Second), revoked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, err := store.IsRevoked(context.Background(), newClaims(tt.name, "auth-1", tt.issuedAt))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.revoked, revoked)
+		})
+	}
+}
+
+func TestRevocationStore_IsRevoked_DeletedAuth(t *testing.T) {
+	store, repo := setupRevocationStore(t)
+
+	repo.EXPECT().IsTokenRevoked(mock.Anything, "jti-1").Return(false, nil).Once()
+	repo.EXPECT().GetAuthTokensRevokedAt(mock.Anything, "auth-1").Return(nil, pgx.ErrNoRows).Once()
+
+	revoked, err := store.IsRevoked(context.Background(), newClaims("jti-1", "auth-1", time.Now()))
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
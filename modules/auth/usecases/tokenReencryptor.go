@@ -0,0 +1,14 @@
+package usecases
+
+import "context"
+
+// TokenReencryptor moves the provider tokens stored in auth_methods and the MFA secrets stored
+// in auths to the active key of AUTH_TOKEN_ENCRYPTION_KEYS after a rotation, and encrypts the
+// ones stored in plaintext before encryption was enabled
+type TokenReencryptor interface {
+	// Reencrypt goes once through the auth methods and the MFA secrets and returns how many
+	// rows it re-encrypted
+	Reencrypt(ctx context.Context) (int, error)
+	// Run re-encrypts right away and then every AUTH_TOKEN_REENCRYPT_INTERVAL until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"template-golang/config"
+	"template-golang/modules/auth/repositories"
+	"template-golang/pkg/logger"
+	"time"
+)
+
+const (
+	defaultTokenReencryptInterval = time.Hour
+
+	tokenReencryptBatchSize = 100
+)
+
+type tokenReencryptorImpl struct {
+	authRepo repositories.AuthRepository
+	mfaRepo  repositories.MFARepository
+
+	interval time.Duration
+}
+
+func NewTokenReencryptor(conf *config.Config, authRepo repositories.AuthRepository, mfaRepo repositories.MFARepository) TokenReencryptor {
+	interval := conf.Auth.TokenReencryptInterval
+	if interval <= 0 {
+		interval = defaultTokenReencryptInterval
+	}
+
+	return &tokenReencryptorImpl{
+		authRepo: authRepo,
+		mfaRepo:  mfaRepo,
+		interval: interval,
+	}
+}
+
+func (r *tokenReencryptorImpl) Reencrypt(ctx context.Context) (int, error) {
+	total, err := reencryptBatches(ctx, r.authRepo.ReencryptAuthMethods)
+	if err != nil {
+		return total, fmt.Errorf("failed to re-encrypt auth method tokens: %w", err)
+	}
+
+	secrets, err := reencryptBatches(ctx, r.mfaRepo.ReencryptMFASecrets)
+	total += secrets
+	if err != nil {
+		return total, fmt.Errorf("failed to re-encrypt mfa secrets: %w", err)
+	}
+	return total, nil
+}
+
+// reencryptBatches calls reencrypt batch after batch until it went through every row
+func reencryptBatches(ctx context.Context, reencrypt func(ctx context.Context, afterID string, limit int) (string, int, error)) (int, error) {
+	total := 0
+	afterID := ""
+	for {
+		lastID, reencrypted, err := reencrypt(ctx, afterID, tokenReencryptBatchSize)
+		total += reencrypted
+		if err != nil || lastID == "" {
+			return total, err
+		}
+		afterID = lastID
+	}
+}
+
+func (r *tokenReencryptorImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		reencrypted, err := r.Reencrypt(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Errorf("Failed to re-encrypt provider tokens: %v", err)
+		}
+		if reencrypted > 0 {
+			logger.Infof("Re-encrypted the provider tokens and mfa secrets of %d rows", reencrypted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"template-golang/config"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenReencryptor_Reencrypt(t *testing.T) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	mfaRepo := repoMocks.NewMockMFARepository(t)
+	reencryptor := NewTokenReencryptor(&config.Config{}, authRepo, mfaRepo)
+
+	authRepo.EXPECT().ReencryptAuthMethods(mock.Anything, "", tokenReencryptBatchSize).Return("method-100", 98, nil).Once()
+	authRepo.EXPECT().ReencryptAuthMethods(mock.Anything, "method-100", tokenReencryptBatchSize).Return("method-130", 30, nil).Once()
+	authRepo.EXPECT().ReencryptAuthMethods(mock.Anything, "method-130", tokenReencryptBatchSize).Return("", 0, nil).Once()
+	mfaRepo.EXPECT().ReencryptMFASecrets(mock.Anything, "", tokenReencryptBatchSize).Return("auth-7", 3, nil).Once()
+	mfaRepo.EXPECT().ReencryptMFASecrets(mock.Anything, "auth-7", tokenReencryptBatchSize).Return("", 0, nil).Once()
+
+	reencrypted, err := reencryptor.Reencrypt(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 131, reencrypted)
+}
+
+func TestTokenReencryptor_ReencryptError(t *testing.T) {
+	authRepo := repoMocks.NewMockAuthRepository(t)
+	reencryptor := NewTokenReencryptor(&config.Config{}, authRepo, repoMocks.NewMockMFARepository(t))
+
+	authRepo.EXPECT().ReencryptAuthMethods(mock.Anything, "", tokenReencryptBatchSize).Return("method-100", 100, nil).Once()
+	authRepo.EXPECT().ReencryptAuthMethods(mock.Anything, "method-100", tokenReencryptBatchSize).Return("", 4, errors.New("db down")).Once()
+
+	reencrypted, err := reencryptor.Reencrypt(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 104, reencrypted)
+}
+
+func TestTokenReencryptor_Defaults(t *testing.T) {
+	reencryptor := NewTokenReencryptor(&config.Config{}, nil, nil).(*tokenReencryptorImpl)
+
+	assert.Equal(t, defaultTokenReencryptInterval, reencryptor.interval)
+}
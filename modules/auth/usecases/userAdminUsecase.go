@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+)
+
+// UserAdminUsecase manages the accounts of all users on behalf of admins. Methods return
+// pgx.ErrNoRows for unknown users.
+type UserAdminUsecase interface {
+	// ListUsers returns up to limit users matching filter after or before cursor, newest first.
+	// A nil cursor starts from the newest user.
+	ListUsers(ctx context.Context, filter models.UserFilter, cursor *models.UserCursor, limit int) ([]*db.Auth, error)
+	// GetUser returns a user that is not deleted and their auth methods
+	GetUser(ctx context.Context, id string) (*db.Auth, []*db.AuthMethod, error)
+	// SetRole changes the role of a user. Their tokens are revoked, since access tokens
+	// carry the old role.
+	SetRole(ctx context.Context, id string, role models.Role) (*db.Auth, error)
+	// SetActive activates or deactivates a user. Deactivating revokes their tokens.
+	SetActive(ctx context.Context, id string, active bool) (*db.Auth, error)
+	// DeleteUser soft deletes a user and revokes their tokens
+	DeleteUser(ctx context.Context, id string) error
+	// RestoreUser undoes DeleteUser
+	RestoreUser(ctx context.Context, id string) (*db.Auth, error)
+}
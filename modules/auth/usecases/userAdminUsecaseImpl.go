@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/utils"
+	"template-golang/pkg/authz"
+	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type userAdminUsecaseImpl struct {
+	jwtUsecase JWTUsecase
+	authRepo   repositories.AuthRepository
+	txManager  database.TxManager
+}
+
+func NewUserAdminUsecase(jwtUsecase JWTUsecase, authRepo repositories.AuthRepository, txManager database.TxManager) UserAdminUsecase {
+	return &userAdminUsecaseImpl{
+		jwtUsecase: jwtUsecase,
+		authRepo:   authRepo,
+		txManager:  txManager,
+	}
+}
+
+func (u *userAdminUsecaseImpl) ListUsers(ctx context.Context, filter models.UserFilter, cursor *models.UserCursor, limit int) ([]*db.Auth, error) {
+	if err := authz.Require(ctx, models.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	params := db.ListAuthsParams{
+		Deleted:     filter.Deleted,
+		Role:        utils.StringToPtr(filter.Role),
+		Active:      filter.Active,
+		Provider:    utils.StringToPtr(filter.Provider),
+		CreatedFrom: timestamptz(filter.CreatedFrom),
+		CreatedTo:   timestamptz(filter.CreatedTo),
+		Limit:       int32(limit),
+	}
+	if cursor != nil {
+		params.Backward = cursor.Before
+		params.CursorCreatedAt = timestamptz(cursor.CreatedAt)
+		params.CursorID = &cursor.ID
+	}
+
+	auths, err := u.authRepo.ListAuths(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auths: %w", err)
+	}
+
+	return auths, nil
+}
+
+func (u *userAdminUsecaseImpl) GetUser(ctx context.Context, id string) (*db.Auth, []*db.AuthMethod, error) {
+	if err := authz.Require(ctx, models.PermissionUsersRead); err != nil {
+		return nil, nil, err
+	}
+
+	auth, err := u.authRepo.GetAuthByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	authMethods, err := u.authRepo.GetAuthMethodsByAuthID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get auth methods: %w", err)
+	}
+
+	return auth, authMethods, nil
+}
+
+func (u *userAdminUsecaseImpl) SetRole(ctx context.Context, id string, role models.Role) (*db.Auth, error) {
+	if err := authz.Require(ctx, models.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	var auth *db.Auth
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		current, err := u.authRepo.LockAuth(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to lock auth: %w", err)
+		}
+		if current.Role == string(role) {
+			auth = current
+			return nil
+		}
+
+		auth, err = u.authRepo.UpdateAuthRole(ctx, id, string(role))
+		if err != nil {
+			return fmt.Errorf("failed to update role: %w", err)
+		}
+		return u.jwtUsecase.RevokeAllTokens(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Set role of auth %s to %s", id, role)
+	return auth, nil
+}
+
+func (u *userAdminUsecaseImpl) SetActive(ctx context.Context, id string, active bool) (*db.Auth, error) {
+	if err := authz.Require(ctx, models.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	var auth *db.Auth
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		var err error
+		auth, err = u.authRepo.UpdateAuthActive(ctx, id, active)
+		if err != nil {
+			return fmt.Errorf("failed to update active: %w", err)
+		}
+		if active {
+			return nil
+		}
+		return u.jwtUsecase.RevokeAllTokens(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Set active of auth %s to %t", id, active)
+	return auth, nil
+}
+
+func (u *userAdminUsecaseImpl) DeleteUser(ctx context.Context, id string) error {
+	if err := authz.Require(ctx, models.PermissionUsersWrite); err != nil {
+		return err
+	}
+
+	err := u.txManager.WithTx(ctx, func(ctx context.Context, _ *db.Queries) error {
+		if _, err := u.authRepo.LockAuth(ctx, id); err != nil {
+			return fmt.Errorf("failed to lock auth: %w", err)
+		}
+
+		if err := u.jwtUsecase.RevokeAllTokens(ctx, id); err != nil {
+			return err
+		}
+
+		if err := u.authRepo.SoftDeleteAuth(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete auth: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Deleted auth %s", id)
+	return nil
+}
+
+func (u *userAdminUsecaseImpl) RestoreUser(ctx context.Context, id string) (*db.Auth, error) {
+	if err := authz.Require(ctx, models.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	auth, err := u.authRepo.RestoreAuth(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore auth: %w", err)
+	}
+
+	logger.Infof("Restored auth %s", id)
+	return auth, nil
+}
+
+// timestamptz returns t as a timestamptz that is NULL for the zero time
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	dbMocks "template-golang/database/mocks"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	repoMocks "template-golang/modules/auth/repositories/mocks"
+	jwtMocks "template-golang/modules/auth/usecases/mocks"
+	"template-golang/pkg/authz"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var adminCtx = authz.WithPrincipal(context.Background(), authz.Principal{
+	UserID:      "admin-1",
+	Role:        string(models.RoleAdmin),
+	Permissions: []string{authz.Wildcard},
+})
+
+type userAdminUsecaseMocks struct {
+	jwtUsecase *jwtMocks.MockJWTUsecase
+	authRepo   *repoMocks.MockAuthRepository
+	txManager  *dbMocks.MockTxManager
+}
+
+func setupUserAdminUsecase(t *testing.T) (UserAdminUsecase, userAdminUsecaseMocks) {
+	m := userAdminUsecaseMocks{
+		jwtUsecase: jwtMocks.NewMockJWTUsecase(t),
+		authRepo:   repoMocks.NewMockAuthRepository(t),
+		txManager:  dbMocks.NewMockTxManager(t),
+	}
+	return NewUserAdminUsecase(m.jwtUsecase, m.authRepo, m.txManager), m
+}
+
+func TestListUsers_AppliesFilter(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	active := true
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.UserFilter{Role: "staff", Active: &active, Provider: "github", CreatedFrom: from}
+
+	m.authRepo.EXPECT().ListAuths(mock.Anything, mock.MatchedBy(func(p db.ListAuthsParams) bool {
+		return *p.Role == "staff" && *p.Active && *p.Provider == "github" &&
+			p.CreatedFrom.Valid && p.CreatedFrom.Time.Equal(from) && !p.CreatedTo.Valid && !p.Deleted &&
+			p.Limit == 11 && !p.Backward && p.CursorID == nil && !p.CursorCreatedAt.Valid
+	})).Return([]*db.Auth{{ID: "auth-11"}, {ID: "auth-12"}}, nil).Once()
+
+	auths, err := userAdmin.ListUsers(adminCtx, filter, nil, 11)
+
+	assert.NoError(t, err)
+	assert.Len(t, auths, 2)
+}
+
+func TestListUsers_AfterCursor(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &models.UserCursor{CreatedAt: createdAt, ID: "auth-10"}
+
+	m.authRepo.EXPECT().ListAuths(mock.Anything, mock.MatchedBy(func(p db.ListAuthsParams) bool {
+		return !p.Backward && p.CursorCreatedAt.Valid && p.CursorCreatedAt.Time.Equal(createdAt) && *p.CursorID == "auth-10" && p.Limit == 11
+	})).Return([]*db.Auth{{ID: "auth-11"}}, nil).Once()
+
+	auths, err := userAdmin.ListUsers(adminCtx, models.UserFilter{}, cursor, 11)
+
+	assert.NoError(t, err)
+	assert.Len(t, auths, 1)
+}
+
+func TestListUsers_BeforeCursor(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &models.UserCursor{CreatedAt: createdAt, ID: "auth-10", Before: true}
+
+	m.authRepo.EXPECT().ListAuths(mock.Anything, mock.MatchedBy(func(p db.ListAuthsParams) bool {
+		return *p.Role == "user" && p.Backward && p.CursorCreatedAt.Time.Equal(createdAt) && *p.CursorID == "auth-10" && p.Limit == 11
+	})).Return([]*db.Auth{{ID: "auth-9"}}, nil).Once()
+
+	auths, err := userAdmin.ListUsers(adminCtx, models.UserFilter{Role: "user"}, cursor, 11)
+
+	assert.NoError(t, err)
+	assert.Len(t, auths, 1)
+}
+
+func TestSetRole_RevokesTokens(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "user"}, nil).Once()
+	m.authRepo.EXPECT().UpdateAuthRole(mock.Anything, "auth-1", "staff").Return(&db.Auth{ID: "auth-1", Role: "staff"}, nil).Once()
+	m.jwtUsecase.EXPECT().RevokeAllTokens(mock.Anything, "auth-1").Return(nil).Once()
+
+	auth, err := userAdmin.SetRole(adminCtx, "auth-1", models.RoleStaff)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "staff", auth.Role)
+}
+
+func TestSetRole_SameRoleKeepsTokens(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1", Role: "staff"}, nil).Once()
+
+	auth, err := userAdmin.SetRole(adminCtx, "auth-1", models.RoleStaff)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "staff", auth.Role)
+}
+
+func TestSetActive(t *testing.T) {
+	t.Run("deactivate revokes tokens", func(t *testing.T) {
+		userAdmin, m := setupUserAdminUsecase(t)
+
+		runInTx(m.txManager)
+		m.authRepo.EXPECT().UpdateAuthActive(mock.Anything, "auth-1", false).Return(&db.Auth{ID: "auth-1"}, nil).Once()
+		m.jwtUsecase.EXPECT().RevokeAllTokens(mock.Anything, "auth-1").Return(nil).Once()
+
+		auth, err := userAdmin.SetActive(adminCtx, "auth-1", false)
+
+		assert.NoError(t, err)
+		assert.False(t, auth.Active)
+	})
+
+	t.Run("activate", func(t *testing.T) {
+		userAdmin, m := setupUserAdminUsecase(t)
+
+		runInTx(m.txManager)
+		m.authRepo.EXPECT().UpdateAuthActive(mock.Anything, "auth-1", true).Return(&db.Auth{ID: "auth-1", Active: true}, nil).Once()
+
+		auth, err := userAdmin.SetActive(adminCtx, "auth-1", true)
+
+		assert.NoError(t, err)
+		assert.True(t, auth.Active)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		userAdmin, m := setupUserAdminUsecase(t)
+
+		runInTx(m.txManager)
+		m.authRepo.EXPECT().UpdateAuthActive(mock.Anything, "missing", false).Return(nil, pgx.ErrNoRows).Once()
+
+		_, err := userAdmin.SetActive(adminCtx, "missing", false)
+
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestDeleteUser_RevokesTokensAndSoftDeletes(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().LockAuth(mock.Anything, "auth-1").Return(&db.Auth{ID: "auth-1"}, nil).Once()
+	m.jwtUsecase.EXPECT().RevokeAllTokens(mock.Anything, "auth-1").Return(nil).Once()
+	m.authRepo.EXPECT().SoftDeleteAuth(mock.Anything, "auth-1").Return(nil).Once()
+
+	err := userAdmin.DeleteUser(adminCtx, "auth-1")
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteUser_UnknownUser(t *testing.T) {
+	userAdmin, m := setupUserAdminUsecase(t)
+
+	runInTx(m.txManager)
+	m.authRepo.EXPECT().LockAuth(mock.Anything, "missing").Return(nil, pgx.ErrNoRows).Once()
+
+	err := userAdmin.DeleteUser(adminCtx, "missing")
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUserAdmin_RequiresPermissions(t *testing.T) {
+	userAdmin, _ := setupUserAdminUsecase(t)
+
+	staffCtx := authz.WithPrincipal(context.Background(), authz.Principal{
+		UserID:      "staff-1",
+		Role:        string(models.RoleStaff),
+		Permissions: []string{models.PermissionUsersRead},
+	})
+
+	_, err := userAdmin.SetRole(staffCtx, "auth-1", models.RoleAdmin)
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	err = userAdmin.DeleteUser(staffCtx, "auth-1")
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	_, err = userAdmin.ListUsers(context.Background(), models.UserFilter{}, nil, 11)
+	assert.ErrorIs(t, err, authz.ErrUnauthenticated)
+}
@@ -39,6 +39,25 @@ func GothUserToAuthMethod(gothUser goth.User, authID string) *db.AuthMethod {
 	}
 }
 
+// EmailVerified reports whether the provider vouched for the email of gothUser. OpenID Connect
+// providers send the email_verified claim and Google sends verified_email. Providers that send
+// neither are treated as unverified.
+func EmailVerified(gothUser goth.User) bool {
+	if gothUser.Email == "" {
+		return false
+	}
+
+	for _, key := range []string{"email_verified", "verified_email"} {
+		switch verified := gothUser.RawData[key].(type) {
+		case bool:
+			return verified
+		case string:
+			return verified == "true"
+		}
+	}
+	return false
+}
+
 // StringToPtr converts string to *string, returns nil for empty strings
 func StringToPtr(s string) *string {
 	if s == "" {
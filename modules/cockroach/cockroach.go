@@ -4,6 +4,8 @@ import (
 	"template-golang/modules/cockroach/handlers"
 	"template-golang/modules/cockroach/repositories"
 	"template-golang/modules/cockroach/usecases"
+
+	"github.com/gin-gonic/gin"
 )
 
 // Dependencies contains all dependencies for the module
@@ -13,3 +15,11 @@ type Cockroach struct {
 	Messaging  repositories.CockroachMessaging
 	Usecase    usecases.CockroachUsecase
 }
+
+func (m *Cockroach) Name() string {
+	return "cockroach"
+}
+
+func (m *Cockroach) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.Handler.Routes(routerGroup)
+}
@@ -9,6 +9,26 @@ type (
 		CreatedAt time.Time `json:"createdAt"`
 	}
 
+	UpdateCockroachDto struct {
+		Id     uint32 `json:"id"`
+		Amount uint32 `json:"amount"`
+	}
+
+	// CockroachFilterDto filters cockroaches by creation time, from inclusive to exclusive.
+	// Zero times do not filter.
+	CockroachFilterDto struct {
+		CreatedFrom time.Time `json:"createdFrom"`
+		CreatedTo   time.Time `json:"createdTo"`
+	}
+
+	// CockroachCursorDto is the cockroach a page of the cockroaches starts after, newest first,
+	// or before when Before is set
+	CockroachCursorDto struct {
+		CreatedAt time.Time `json:"createdAt"`
+		Id        uint32    `json:"id"`
+		Before    bool      `json:"before"`
+	}
+
 	Cockroach struct {
 		Id        uint32    `json:"id"`
 		Amount    uint32    `json:"amount"`
@@ -4,4 +4,9 @@ import "github.com/gin-gonic/gin"
 
 type CockroachHandler interface {
 	DetectCockroach(c *gin.Context)
+	GetCockroach(c *gin.Context)
+	GetCockroaches(c *gin.Context)
+	UpdateCockroach(c *gin.Context)
+	DeleteCockroach(c *gin.Context)
+	Routes(routerGroup *gin.RouterGroup)
 }
@@ -1,21 +1,35 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	authMiddlewares "template-golang/modules/auth/middlewares"
+	"template-golang/modules/cockroach/entities"
 	"template-golang/modules/cockroach/models"
 	"template-golang/modules/cockroach/usecases"
+	pkgErrors "template-golang/pkg/errors"
+	"template-golang/pkg/response"
+	pkgValidator "template-golang/pkg/validator"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
 )
 
+// cockroachList names the list of GET /cockroach, which its cursors are bound to
+const cockroachList = "cockroaches"
+
 type cockroachHttpHandler struct {
 	cockroachUsecase usecases.CockroachUsecase
+	authMiddleware   authMiddlewares.AuthMiddleware
 }
 
-func NewCockroachHttpHandler(cockroachUsecase usecases.CockroachUsecase) CockroachHandler {
+func NewCockroachHttpHandler(cockroachUsecase usecases.CockroachUsecase, authMiddleware authMiddlewares.AuthMiddleware) CockroachHandler {
 	return &cockroachHttpHandler{
 		cockroachUsecase: cockroachUsecase,
+		authMiddleware:   authMiddleware,
 	}
 }
 
@@ -63,3 +77,180 @@ func (h *cockroachHttpHandler) DetectCockroach(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Success 🪳🪳🪳"})
 }
+
+// GetCockroach godoc
+// @Summary Get a cockroach sighting
+// @Tags cockroach
+// @Produce json
+// @Param id path int true "Cockroach ID"
+// @Success 200 {object} response.Response{data=entities.Cockroach}
+// @Failure 404 {object} response.Response
+// @Router /cockroach/{id} [get]
+func (h *cockroachHttpHandler) GetCockroach(c *gin.Context) {
+	id, ok := cockroachID(c)
+	if !ok {
+		return
+	}
+
+	cockroach, err := h.cockroachUsecase.GetCockroach(c.Request.Context(), id)
+	if err != nil {
+		respondCockroachError(c, err, "Failed to retrieve cockroach")
+		return
+	}
+
+	response.Success(c, cockroach)
+}
+
+// GetCockroaches godoc
+// @Summary List cockroach sightings
+// @Description Lists cockroach sightings newest first, filtered by creation time. The next and prev cursors of meta page through the list.
+// @Tags cockroach
+// @Produce json
+// @Param cursor query string false "Cursor of the page, from meta.next or meta.prev"
+// @Param limit query int false "Page size, at most 100"
+// @Param created_from query string false "RFC 3339 time, inclusive"
+// @Param created_to query string false "RFC 3339 time, exclusive"
+// @Success 200 {object} response.Response{data=[]entities.Cockroach}
+// @Failure 400 {object} response.Response
+// @Router /cockroach [get]
+func (h *cockroachHttpHandler) GetCockroaches(c *gin.Context) {
+	var filter models.CockroachFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		response.BadRequest(c, "created_from must be before created_to")
+		return
+	}
+
+	pagination, err := response.GetCursorPaginationFromContext(c, cockroachList, filter)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	cursor, ok := cockroachCursor(pagination.Cursor)
+	if !ok {
+		response.BadRequest(c, "Invalid cursor")
+		return
+	}
+
+	cockroaches, err := h.cockroachUsecase.ListCockroaches(c.Request.Context(), filter, cursor, pagination.FetchLimit())
+	if err != nil {
+		respondCockroachError(c, err, "Failed to retrieve cockroaches")
+		return
+	}
+
+	page, next, prev := response.CursorPage(cockroaches, pagination, func(cockroach *entities.Cockroach) (time.Time, string) {
+		return cockroach.CreatedAt, strconv.FormatUint(uint64(cockroach.Id), 10)
+	})
+	response.CursorPaginated(c, page, pagination, next, prev)
+}
+
+// UpdateCockroach godoc
+// @Summary Update the amount of a cockroach sighting
+// @Tags cockroach
+// @Accept json
+// @Produce json
+// @Param id path int true "Cockroach ID"
+// @Param request body models.UpdateCockroachData true "Request body"
+// @Success 200 {object} response.Response{data=entities.Cockroach}
+// @Failure 404 {object} response.Response
+// @Router /cockroach/{id} [patch]
+func (h *cockroachHttpHandler) UpdateCockroach(c *gin.Context) {
+	id, ok := cockroachID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCockroachData
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body")
+		return
+	}
+	if errs := pkgValidator.ValidateStruct(req); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+
+	cockroach, err := h.cockroachUsecase.UpdateCockroach(c.Request.Context(), id, &req)
+	if err != nil {
+		respondCockroachError(c, err, "Failed to update cockroach")
+		return
+	}
+
+	response.SuccessWithMessage(c, "cockroach updated", cockroach)
+}
+
+// DeleteCockroach godoc
+// @Summary Delete a cockroach sighting
+// @Tags cockroach
+// @Param id path int true "Cockroach ID"
+// @Success 204
+// @Failure 404 {object} response.Response
+// @Router /cockroach/{id} [delete]
+func (h *cockroachHttpHandler) DeleteCockroach(c *gin.Context) {
+	id, ok := cockroachID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cockroachUsecase.DeleteCockroach(c.Request.Context(), id); err != nil {
+		respondCockroachError(c, err, "Failed to delete cockroach")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// cockroachID parses the :id of the request, responding 400 when it is not a valid id
+func cockroachID(c *gin.Context) (uint32, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		response.BadRequest(c, "Invalid cockroach id")
+		return 0, false
+	}
+	return uint32(id), true
+}
+
+// cockroachCursor returns the cockroach of a pagination cursor, false when the cursor is not the
+// one of a cockroach
+func cockroachCursor(cursor *response.Cursor) (*entities.CockroachCursorDto, bool) {
+	if cursor == nil {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(cursor.ID, 10, 32)
+	if err != nil {
+		return nil, false
+	}
+	return &entities.CockroachCursorDto{
+		CreatedAt: cursor.CreatedAt,
+		Id:        uint32(id),
+		Before:    cursor.Direction == response.CursorPrev,
+	}, true
+}
+
+// respondCockroachError responds 404 for unknown cockroaches, the status of application
+// errors and 500 with message otherwise
+func respondCockroachError(c *gin.Context, err error, message string) {
+	var appErr *pkgErrors.AppError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		response.NotFound(c, "Cockroach not found")
+	case errors.As(err, &appErr):
+		response.Error(c, appErr)
+	default:
+		_ = c.Error(err)
+		response.InternalServerError(c, message)
+	}
+}
+
+func (h *cockroachHttpHandler) Routes(routerGroup *gin.RouterGroup) {
+	cockroachRouters := routerGroup.Group("/cockroach")
+	cockroachRouters.Use(h.authMiddleware.AcceptAPIKeys(), h.authMiddleware.Handle())
+	cockroachRouters.POST("", h.authMiddleware.Requires(models.PermissionCockroachWrite), h.DetectCockroach)
+	cockroachRouters.GET("", h.authMiddleware.Requires(models.PermissionCockroachRead), h.GetCockroaches)
+	cockroachRouters.GET("/:id", h.authMiddleware.Requires(models.PermissionCockroachRead), h.GetCockroach)
+	cockroachRouters.PATCH("/:id", h.authMiddleware.Requires(models.PermissionCockroachWrite), h.UpdateCockroach)
+	cockroachRouters.DELETE("/:id", h.authMiddleware.Requires(models.PermissionCockroachDelete), h.DeleteCockroach)
+}
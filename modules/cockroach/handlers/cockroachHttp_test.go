@@ -6,13 +6,21 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	authMocks "template-golang/modules/auth/middlewares/mocks"
+	"template-golang/modules/cockroach/entities"
 	"template-golang/modules/cockroach/models"
 	"template-golang/modules/cockroach/usecases/mocks"
+	pkgErrors "template-golang/pkg/errors"
+	"template-golang/pkg/response"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDetectCockroach(t *testing.T) {
@@ -119,7 +127,7 @@ func TestDetectCockroach(t *testing.T) {
 
 			// Setup router
 			r := gin.New()
-			handler := NewCockroachHttpHandler(mockUsecase)
+			handler := NewCockroachHttpHandler(mockUsecase, nil)
 			r.POST("/detect-cockroach", handler.DetectCockroach)
 
 			if !tt.skipSetupMock {
@@ -139,3 +147,352 @@ func TestDetectCockroach(t *testing.T) {
 		})
 	}
 }
+
+// serve runs the request through a router handling route with handler
+func serve(handler gin.HandlerFunc, route string, method string, path string, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, route, handler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetCockroach(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMocks     func(*mocks.MockCockroachUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name: "success",
+			path: "/cockroach/7",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().GetCockroach(mock.Anything, uint32(7)).
+					Return(&entities.Cockroach{Id: 7, Amount: 3, CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":7`, `"amount":3`},
+		},
+		{
+			name: "not found",
+			path: "/cockroach/7",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().GetCockroach(mock.Anything, uint32(7)).Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   []string{`"message":"Cockroach not found"`},
+		},
+		{
+			name:           "invalid id",
+			path:           "/cockroach/abc",
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Invalid cockroach id"`},
+		},
+		{
+			name:           "zero id",
+			path:           "/cockroach/0",
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "id out of range",
+			path: "/cockroach/4294967295",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().GetCockroach(mock.Anything, uint32(4294967295)).
+					Return(nil, pkgErrors.BadRequest("id exceeds maximum allowed value"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "repository error",
+			path: "/cockroach/7",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().GetCockroach(mock.Anything, uint32(7)).Return(nil, errors.New("connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   []string{`"message":"Failed to retrieve cockroach"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockCockroachUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := NewCockroachHttpHandler(mockUsecase, nil)
+
+			w := serve(handler.GetCockroach, "/cockroach/:id", http.MethodGet, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestGetCockroaches(t *testing.T) {
+	createdFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	rangeFilter := response.CursorFilterHash(models.CockroachFilter{CreatedFrom: createdFrom, CreatedTo: createdTo})
+	nextCursor := response.EncodeCursor(response.Cursor{CreatedAt: createdAt, ID: "5", Direction: response.CursorNext, List: cockroachList, Filter: rangeFilter})
+	userCursor := response.EncodeCursor(response.Cursor{CreatedAt: createdAt, ID: "0b5f7e1c-7c1f-4c5e-9f0e-2d7c1c1b1a11", Direction: response.CursorNext, List: "users", Filter: response.CursorFilterHash(models.CockroachFilter{})})
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func(*mocks.MockCockroachUsecase)
+		expectedStatus int
+		expectedBody   []string
+		expectedNext   bool
+		expectedPrev   bool
+	}{
+		{
+			name:  "first page",
+			query: "?limit=2",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().ListCockroaches(mock.Anything, models.CockroachFilter{}, (*entities.CockroachCursorDto)(nil), 3).
+					Return([]*entities.Cockroach{{Id: 3, CreatedAt: createdAt}, {Id: 2, CreatedAt: createdAt}, {Id: 1, CreatedAt: createdAt}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":3`, `"id":2`, `"limit":2`},
+			expectedNext:   true,
+		},
+		{
+			name:  "page after a cursor in a created range",
+			query: "?cursor=" + nextCursor + "&created_from=2024-05-01T00:00:00Z&created_to=2024-06-01T00:00:00Z",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().ListCockroaches(mock.Anything, models.CockroachFilter{CreatedFrom: createdFrom, CreatedTo: createdTo},
+					&entities.CockroachCursorDto{CreatedAt: createdAt, Id: 5}, 11).
+					Return([]*entities.Cockroach{{Id: 4, CreatedAt: createdAt}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":4`},
+			expectedPrev:   true,
+		},
+		{
+			name:           "tampered cursor",
+			query:          "?cursor=" + nextCursor + "x",
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "cursor of another list",
+			query:          "?cursor=" + userCursor,
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Invalid cursor"`},
+		},
+		{
+			name:           "cursor of another filter",
+			query:          "?cursor=" + nextCursor,
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Invalid cursor"`},
+		},
+		{
+			name:           "invalid created_from",
+			query:          "?created_from=yesterday",
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty range",
+			query:          "?created_from=2024-06-01T00:00:00Z&created_to=2024-05-01T00:00:00Z",
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"created_from must be before created_to"`},
+		},
+		{
+			name:  "repository error",
+			query: "",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().ListCockroaches(mock.Anything, models.CockroachFilter{}, (*entities.CockroachCursorDto)(nil), 11).Return(nil, errors.New("connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockCockroachUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := NewCockroachHttpHandler(mockUsecase, nil)
+
+			w := serve(handler.GetCockroaches, "/cockroach", http.MethodGet, "/cockroach"+tt.query, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+			if w.Code == http.StatusOK {
+				var body response.Response
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedNext, body.Meta.Next != "")
+				assert.Equal(t, tt.expectedPrev, body.Meta.Prev != "")
+			}
+		})
+	}
+}
+
+func TestUpdateCockroach(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		setupMocks     func(*mocks.MockCockroachUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name: "success",
+			path: "/cockroach/7",
+			body: `{"amount":5}`,
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().UpdateCockroach(mock.Anything, uint32(7), &models.UpdateCockroachData{Amount: 5}).
+					Return(&entities.Cockroach{Id: 7, Amount: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"id":7`, `"amount":5`},
+		},
+		{
+			name: "not found",
+			path: "/cockroach/7",
+			body: `{"amount":5}`,
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().UpdateCockroach(mock.Anything, uint32(7), &models.UpdateCockroachData{Amount: 5}).Return(nil, pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "zero amount",
+			path:           "/cockroach/7",
+			body:           `{"amount":0}`,
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"Validation failed"`},
+		},
+		{
+			name:           "invalid body",
+			path:           "/cockroach/7",
+			body:           `{"amount":"five"}`,
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id",
+			path:           "/cockroach/-1",
+			body:           `{"amount":5}`,
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockCockroachUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := NewCockroachHttpHandler(mockUsecase, nil)
+
+			w := serve(handler.UpdateCockroach, "/cockroach/:id", http.MethodPatch, tt.path, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestDeleteCockroach(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMocks     func(*mocks.MockCockroachUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			path: "/cockroach/7",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().DeleteCockroach(mock.Anything, uint32(7)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "not found",
+			path: "/cockroach/7",
+			setupMocks: func(m *mocks.MockCockroachUsecase) {
+				m.EXPECT().DeleteCockroach(mock.Anything, uint32(7)).Return(pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid id",
+			path:           "/cockroach/abc",
+			setupMocks:     func(m *mocks.MockCockroachUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockCockroachUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := NewCockroachHttpHandler(mockUsecase, nil)
+
+			w := serve(handler.DeleteCockroach, "/cockroach/:id", http.MethodDelete, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestCockroachHttpHandler_Routes(t *testing.T) {
+	mockAuthMiddleware := authMocks.NewMockAuthMiddleware(t)
+	mockAuthMiddleware.On("AcceptAPIKeys").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+	mockAuthMiddleware.On("Handle").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}))
+	for _, permission := range []string{models.PermissionCockroachRead, models.PermissionCockroachWrite, models.PermissionCockroachDelete} {
+		mockAuthMiddleware.On("Requires", []string{permission}).Return(gin.HandlerFunc(func(c *gin.Context) {
+			c.Next()
+		}))
+	}
+
+	handler := NewCockroachHttpHandler(mocks.NewMockCockroachUsecase(t), mockAuthMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.Routes(router.Group("/api/v1"))
+
+	routeMap := make(map[string]bool)
+	for _, route := range router.Routes() {
+		routeMap[route.Method+" "+route.Path] = true
+	}
+	for _, expected := range []string{
+		"POST /api/v1/cockroach",
+		"GET /api/v1/cockroach",
+		"GET /api/v1/cockroach/:id",
+		"PATCH /api/v1/cockroach/:id",
+		"DELETE /api/v1/cockroach/:id",
+	} {
+		assert.True(t, routeMap[expected], "Route %s should be registered", expected)
+	}
+
+	// Detections need a key holding cockroach:write like the other writes
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/cockroach", strings.NewReader(`{"amount":3}`)))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
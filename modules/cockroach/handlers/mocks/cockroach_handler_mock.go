@@ -36,6 +36,46 @@ func (_m *MockCockroachHandler) EXPECT() *MockCockroachHandler_Expecter {
 	return &MockCockroachHandler_Expecter{mock: &_m.Mock}
 }
 
+// DeleteCockroach provides a mock function for the type MockCockroachHandler
+func (_mock *MockCockroachHandler) DeleteCockroach(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockCockroachHandler_DeleteCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCockroach'
+type MockCockroachHandler_DeleteCockroach_Call struct {
+	*mock.Call
+}
+
+// DeleteCockroach is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockCockroachHandler_Expecter) DeleteCockroach(c interface{}) *MockCockroachHandler_DeleteCockroach_Call {
+	return &MockCockroachHandler_DeleteCockroach_Call{Call: _e.mock.On("DeleteCockroach", c)}
+}
+
+func (_c *MockCockroachHandler_DeleteCockroach_Call) Run(run func(c *gin.Context)) *MockCockroachHandler_DeleteCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachHandler_DeleteCockroach_Call) Return() *MockCockroachHandler_DeleteCockroach_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCockroachHandler_DeleteCockroach_Call) RunAndReturn(run func(c *gin.Context)) *MockCockroachHandler_DeleteCockroach_Call {
+	_c.Run(run)
+	return _c
+}
+
 // DetectCockroach provides a mock function for the type MockCockroachHandler
 func (_mock *MockCockroachHandler) DetectCockroach(c *gin.Context) {
 	_mock.Called(c)
@@ -75,3 +115,163 @@ func (_c *MockCockroachHandler_DetectCockroach_Call) RunAndReturn(run func(c *gi
 	_c.Run(run)
 	return _c
 }
+
+// GetCockroach provides a mock function for the type MockCockroachHandler
+func (_mock *MockCockroachHandler) GetCockroach(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockCockroachHandler_GetCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCockroach'
+type MockCockroachHandler_GetCockroach_Call struct {
+	*mock.Call
+}
+
+// GetCockroach is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockCockroachHandler_Expecter) GetCockroach(c interface{}) *MockCockroachHandler_GetCockroach_Call {
+	return &MockCockroachHandler_GetCockroach_Call{Call: _e.mock.On("GetCockroach", c)}
+}
+
+func (_c *MockCockroachHandler_GetCockroach_Call) Run(run func(c *gin.Context)) *MockCockroachHandler_GetCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachHandler_GetCockroach_Call) Return() *MockCockroachHandler_GetCockroach_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCockroachHandler_GetCockroach_Call) RunAndReturn(run func(c *gin.Context)) *MockCockroachHandler_GetCockroach_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetCockroaches provides a mock function for the type MockCockroachHandler
+func (_mock *MockCockroachHandler) GetCockroaches(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockCockroachHandler_GetCockroaches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCockroaches'
+type MockCockroachHandler_GetCockroaches_Call struct {
+	*mock.Call
+}
+
+// GetCockroaches is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockCockroachHandler_Expecter) GetCockroaches(c interface{}) *MockCockroachHandler_GetCockroaches_Call {
+	return &MockCockroachHandler_GetCockroaches_Call{Call: _e.mock.On("GetCockroaches", c)}
+}
+
+func (_c *MockCockroachHandler_GetCockroaches_Call) Run(run func(c *gin.Context)) *MockCockroachHandler_GetCockroaches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachHandler_GetCockroaches_Call) Return() *MockCockroachHandler_GetCockroaches_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCockroachHandler_GetCockroaches_Call) RunAndReturn(run func(c *gin.Context)) *MockCockroachHandler_GetCockroaches_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Routes provides a mock function for the type MockCockroachHandler
+func (_mock *MockCockroachHandler) Routes(routerGroup *gin.RouterGroup) {
+	_mock.Called(routerGroup)
+	return
+}
+
+// MockCockroachHandler_Routes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Routes'
+type MockCockroachHandler_Routes_Call struct {
+	*mock.Call
+}
+
+// Routes is a helper method to define mock.On call
+//   - routerGroup *gin.RouterGroup
+func (_e *MockCockroachHandler_Expecter) Routes(routerGroup interface{}) *MockCockroachHandler_Routes_Call {
+	return &MockCockroachHandler_Routes_Call{Call: _e.mock.On("Routes", routerGroup)}
+}
+
+func (_c *MockCockroachHandler_Routes_Call) Run(run func(routerGroup *gin.RouterGroup)) *MockCockroachHandler_Routes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.RouterGroup
+		if args[0] != nil {
+			arg0 = args[0].(*gin.RouterGroup)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachHandler_Routes_Call) Return() *MockCockroachHandler_Routes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCockroachHandler_Routes_Call) RunAndReturn(run func(routerGroup *gin.RouterGroup)) *MockCockroachHandler_Routes_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateCockroach provides a mock function for the type MockCockroachHandler
+func (_mock *MockCockroachHandler) UpdateCockroach(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockCockroachHandler_UpdateCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCockroach'
+type MockCockroachHandler_UpdateCockroach_Call struct {
+	*mock.Call
+}
+
+// UpdateCockroach is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockCockroachHandler_Expecter) UpdateCockroach(c interface{}) *MockCockroachHandler_UpdateCockroach_Call {
+	return &MockCockroachHandler_UpdateCockroach_Call{Call: _e.mock.On("UpdateCockroach", c)}
+}
+
+func (_c *MockCockroachHandler_UpdateCockroach_Call) Run(run func(c *gin.Context)) *MockCockroachHandler_UpdateCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachHandler_UpdateCockroach_Call) Return() *MockCockroachHandler_UpdateCockroach_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCockroachHandler_UpdateCockroach_Call) RunAndReturn(run func(c *gin.Context)) *MockCockroachHandler_UpdateCockroach_Call {
+	_c.Run(run)
+	return _c
+}
@@ -1,5 +1,26 @@
 package models
 
+import "time"
+
+// Permissions checked by the cockroach module
+const (
+	PermissionCockroachRead   = "cockroach:read"
+	PermissionCockroachWrite  = "cockroach:write"
+	PermissionCockroachDelete = "cockroach:delete"
+)
+
 type AddCockroachData struct {
 	Amount uint32 `json:"amount" validate:"required,gt=0"`
 }
+
+// UpdateCockroachData is the body of PATCH /cockroach/:id
+type UpdateCockroachData struct {
+	Amount uint32 `json:"amount" validate:"required,gt=0"`
+}
+
+// CockroachFilter is the query of GET /cockroach, from inclusive to exclusive. Zero fields do
+// not filter.
+type CockroachFilter struct {
+	CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
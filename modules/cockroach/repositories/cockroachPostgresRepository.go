@@ -3,10 +3,15 @@ package repositories
 import (
 	"context"
 	"math"
+	"template-golang/database"
 	db "template-golang/db/sqlc"
 	"template-golang/modules/cockroach/entities"
 	"template-golang/pkg/errors"
 	"template-golang/pkg/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type cockroachPostgresRepository struct {
@@ -17,12 +22,17 @@ func NewPostgresRepository(queries *db.Queries) CockroachRepository {
 	return &cockroachPostgresRepository{queries: queries}
 }
 
+// q returns the queries bound to the transaction in ctx, if any
+func (r *cockroachPostgresRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
 func (r *cockroachPostgresRepository) InsertCockroachData(ctx context.Context, in *entities.InsertCockroachDto) (*entities.Cockroach, error) {
 	if in.Amount > math.MaxInt32 {
 		return nil, errors.BadRequest("amount exceeds maximum allowed value")
 	}
 
-	cockroach, err := r.queries.CreateCockroach(ctx, int32(in.Amount))
+	cockroach, err := r.q(ctx).CreateCockroach(ctx, int32(in.Amount))
 	if err != nil {
 		logger.Errorf("InsertCockroachData: %v", err)
 		return nil, err
@@ -47,7 +57,7 @@ func (r *cockroachPostgresRepository) GetCockroachByID(ctx context.Context, id u
 		return nil, errors.BadRequest("id exceeds maximum allowed value")
 	}
 
-	cockroach, err := r.queries.GetCockroachByID(ctx, int32(id))
+	cockroach, err := r.q(ctx).GetCockroachByID(ctx, int32(id))
 	if err != nil {
 		logger.Errorf("GetCockroachByID: %v", err)
 		return nil, err
@@ -66,14 +76,30 @@ func (r *cockroachPostgresRepository) GetCockroachByID(ctx context.Context, id u
 	return result, nil
 }
 
-func (r *cockroachPostgresRepository) ListCockroaches(ctx context.Context) ([]*entities.Cockroach, error) {
-	cockroaches, err := r.queries.ListCockroaches(ctx)
+func (r *cockroachPostgresRepository) ListCockroaches(ctx context.Context, filter *entities.CockroachFilterDto, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error) {
+	if cursor != nil && cursor.Id > math.MaxInt32 {
+		return nil, errors.BadRequest("id exceeds maximum allowed value")
+	}
+
+	params := db.ListCockroachesParams{
+		CreatedFrom: timestamptz(filter.CreatedFrom),
+		CreatedTo:   timestamptz(filter.CreatedTo),
+		Limit:       int32(limit),
+	}
+	if cursor != nil {
+		cursorID := int32(cursor.Id)
+		params.Backward = cursor.Before
+		params.CursorCreatedAt = timestamptz(cursor.CreatedAt)
+		params.CursorID = &cursorID
+	}
+
+	cockroaches, err := r.q(ctx).ListCockroaches(ctx, params)
 	if err != nil {
 		logger.Errorf("ListCockroaches: %v", err)
 		return nil, err
 	}
 
-	var result []*entities.Cockroach
+	result := make([]*entities.Cockroach, 0, len(cockroaches))
 	for _, c := range cockroaches {
 		if c.ID < 0 || c.Amount < 0 {
 			return nil, errors.Internal("invalid negative values returned from database")
@@ -88,3 +114,52 @@ func (r *cockroachPostgresRepository) ListCockroaches(ctx context.Context) ([]*e
 
 	return result, nil
 }
+
+func (r *cockroachPostgresRepository) UpdateCockroach(ctx context.Context, in *entities.UpdateCockroachDto) (*entities.Cockroach, error) {
+	if in.Id > math.MaxInt32 {
+		return nil, errors.BadRequest("id exceeds maximum allowed value")
+	}
+	if in.Amount > math.MaxInt32 {
+		return nil, errors.BadRequest("amount exceeds maximum allowed value")
+	}
+
+	cockroach, err := r.q(ctx).UpdateCockroach(ctx, int32(in.Id), int32(in.Amount))
+	if err != nil {
+		logger.Errorf("UpdateCockroach: %v", err)
+		return nil, err
+	}
+
+	if cockroach.ID < 0 || cockroach.Amount < 0 {
+		return nil, errors.Internal("invalid negative values returned from database")
+	}
+
+	result := &entities.Cockroach{
+		Id:        uint32(cockroach.ID),
+		Amount:    uint32(cockroach.Amount),
+		CreatedAt: cockroach.CreatedAt.Time,
+	}
+
+	return result, nil
+}
+
+func (r *cockroachPostgresRepository) DeleteCockroach(ctx context.Context, id uint32) error {
+	if id > math.MaxInt32 {
+		return errors.BadRequest("id exceeds maximum allowed value")
+	}
+
+	deleted, err := r.q(ctx).DeleteCockroach(ctx, int32(id))
+	if err != nil {
+		logger.Errorf("DeleteCockroach: %v", err)
+		return err
+	}
+	if deleted == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// timestamptz returns t as a nullable timestamp, NULL for the zero time
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
@@ -8,5 +8,10 @@ import (
 type CockroachRepository interface {
 	InsertCockroachData(ctx context.Context, in *entities.InsertCockroachDto) (*entities.Cockroach, error)
 	GetCockroachByID(ctx context.Context, id uint32) (*entities.Cockroach, error)
-	ListCockroaches(ctx context.Context) ([]*entities.Cockroach, error)
+	// ListCockroaches returns up to limit cockroaches matching filter after or before cursor,
+	// newest first. A nil cursor starts from the newest cockroach.
+	ListCockroaches(ctx context.Context, filter *entities.CockroachFilterDto, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error)
+	UpdateCockroach(ctx context.Context, in *entities.UpdateCockroachDto) (*entities.Cockroach, error)
+	// DeleteCockroach returns pgx.ErrNoRows when there was no cockroach to delete
+	DeleteCockroach(ctx context.Context, id uint32) error
 }
@@ -38,6 +38,63 @@ func (_m *MockCockroachRepository) EXPECT() *MockCockroachRepository_Expecter {
 	return &MockCockroachRepository_Expecter{mock: &_m.Mock}
 }
 
+// DeleteCockroach provides a mock function for the type MockCockroachRepository
+func (_mock *MockCockroachRepository) DeleteCockroach(ctx context.Context, id uint32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCockroach")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCockroachRepository_DeleteCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCockroach'
+type MockCockroachRepository_DeleteCockroach_Call struct {
+	*mock.Call
+}
+
+// DeleteCockroach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+func (_e *MockCockroachRepository_Expecter) DeleteCockroach(ctx interface{}, id interface{}) *MockCockroachRepository_DeleteCockroach_Call {
+	return &MockCockroachRepository_DeleteCockroach_Call{Call: _e.mock.On("DeleteCockroach", ctx, id)}
+}
+
+func (_c *MockCockroachRepository_DeleteCockroach_Call) Run(run func(ctx context.Context, id uint32)) *MockCockroachRepository_DeleteCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachRepository_DeleteCockroach_Call) Return(err error) *MockCockroachRepository_DeleteCockroach_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCockroachRepository_DeleteCockroach_Call) RunAndReturn(run func(ctx context.Context, id uint32) error) *MockCockroachRepository_DeleteCockroach_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCockroachByID provides a mock function for the type MockCockroachRepository
 func (_mock *MockCockroachRepository) GetCockroachByID(ctx context.Context, id uint32) (*entities.Cockroach, error) {
 	ret := _mock.Called(ctx, id)
@@ -175,8 +232,8 @@ func (_c *MockCockroachRepository_InsertCockroachData_Call) RunAndReturn(run fun
 }
 
 // ListCockroaches provides a mock function for the type MockCockroachRepository
-func (_mock *MockCockroachRepository) ListCockroaches(ctx context.Context) ([]*entities.Cockroach, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockCockroachRepository) ListCockroaches(ctx context.Context, filter *entities.CockroachFilterDto, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error) {
+	ret := _mock.Called(ctx, filter, cursor, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListCockroaches")
@@ -184,18 +241,18 @@ func (_mock *MockCockroachRepository) ListCockroaches(ctx context.Context) ([]*e
 
 	var r0 []*entities.Cockroach
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.Cockroach, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.CockroachFilterDto, *entities.CockroachCursorDto, int) ([]*entities.Cockroach, error)); ok {
+		return returnFunc(ctx, filter, cursor, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.Cockroach); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.CockroachFilterDto, *entities.CockroachCursorDto, int) []*entities.Cockroach); ok {
+		r0 = returnFunc(ctx, filter, cursor, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.Cockroach)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.CockroachFilterDto, *entities.CockroachCursorDto, int) error); ok {
+		r1 = returnFunc(ctx, filter, cursor, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -209,18 +266,36 @@ type MockCockroachRepository_ListCockroaches_Call struct {
 
 // ListCockroaches is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockCockroachRepository_Expecter) ListCockroaches(ctx interface{}) *MockCockroachRepository_ListCockroaches_Call {
-	return &MockCockroachRepository_ListCockroaches_Call{Call: _e.mock.On("ListCockroaches", ctx)}
+//   - filter *entities.CockroachFilterDto
+//   - cursor *entities.CockroachCursorDto
+//   - limit int
+func (_e *MockCockroachRepository_Expecter) ListCockroaches(ctx interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockCockroachRepository_ListCockroaches_Call {
+	return &MockCockroachRepository_ListCockroaches_Call{Call: _e.mock.On("ListCockroaches", ctx, filter, cursor, limit)}
 }
 
-func (_c *MockCockroachRepository_ListCockroaches_Call) Run(run func(ctx context.Context)) *MockCockroachRepository_ListCockroaches_Call {
+func (_c *MockCockroachRepository_ListCockroaches_Call) Run(run func(ctx context.Context, filter *entities.CockroachFilterDto, cursor *entities.CockroachCursorDto, limit int)) *MockCockroachRepository_ListCockroaches_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 *entities.CockroachFilterDto
+		if args[1] != nil {
+			arg1 = args[1].(*entities.CockroachFilterDto)
+		}
+		var arg2 *entities.CockroachCursorDto
+		if args[2] != nil {
+			arg2 = args[2].(*entities.CockroachCursorDto)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -231,7 +306,75 @@ func (_c *MockCockroachRepository_ListCockroaches_Call) Return(cockroachs []*ent
 	return _c
 }
 
-func (_c *MockCockroachRepository_ListCockroaches_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.Cockroach, error)) *MockCockroachRepository_ListCockroaches_Call {
+func (_c *MockCockroachRepository_ListCockroaches_Call) RunAndReturn(run func(ctx context.Context, filter *entities.CockroachFilterDto, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error)) *MockCockroachRepository_ListCockroaches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCockroach provides a mock function for the type MockCockroachRepository
+func (_mock *MockCockroachRepository) UpdateCockroach(ctx context.Context, in *entities.UpdateCockroachDto) (*entities.Cockroach, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCockroach")
+	}
+
+	var r0 *entities.Cockroach
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.UpdateCockroachDto) (*entities.Cockroach, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.UpdateCockroachDto) *entities.Cockroach); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Cockroach)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.UpdateCockroachDto) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCockroachRepository_UpdateCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCockroach'
+type MockCockroachRepository_UpdateCockroach_Call struct {
+	*mock.Call
+}
+
+// UpdateCockroach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *entities.UpdateCockroachDto
+func (_e *MockCockroachRepository_Expecter) UpdateCockroach(ctx interface{}, in interface{}) *MockCockroachRepository_UpdateCockroach_Call {
+	return &MockCockroachRepository_UpdateCockroach_Call{Call: _e.mock.On("UpdateCockroach", ctx, in)}
+}
+
+func (_c *MockCockroachRepository_UpdateCockroach_Call) Run(run func(ctx context.Context, in *entities.UpdateCockroachDto)) *MockCockroachRepository_UpdateCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.UpdateCockroachDto
+		if args[1] != nil {
+			arg1 = args[1].(*entities.UpdateCockroachDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachRepository_UpdateCockroach_Call) Return(cockroach *entities.Cockroach, err error) *MockCockroachRepository_UpdateCockroach_Call {
+	_c.Call.Return(cockroach, err)
+	return _c
+}
+
+func (_c *MockCockroachRepository_UpdateCockroach_Call) RunAndReturn(run func(ctx context.Context, in *entities.UpdateCockroachDto) (*entities.Cockroach, error)) *MockCockroachRepository_UpdateCockroach_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,7 +1,19 @@
 package usecases
 
-import "template-golang/modules/cockroach/models"
+import (
+	"context"
+	"template-golang/modules/cockroach/entities"
+	"template-golang/modules/cockroach/models"
+)
 
+// CockroachUsecase records cockroach sightings. Methods taking an id return pgx.ErrNoRows for
+// unknown cockroaches.
 type CockroachUsecase interface {
 	ProcessData(data *models.AddCockroachData) error
+	GetCockroach(ctx context.Context, id uint32) (*entities.Cockroach, error)
+	// ListCockroaches returns up to limit cockroaches matching filter after or before cursor,
+	// newest first. A nil cursor starts from the newest cockroach.
+	ListCockroaches(ctx context.Context, filter models.CockroachFilter, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error)
+	UpdateCockroach(ctx context.Context, id uint32, in *models.UpdateCockroachData) (*entities.Cockroach, error)
+	DeleteCockroach(ctx context.Context, id uint32) error
 }
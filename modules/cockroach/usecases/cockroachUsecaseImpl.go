@@ -46,3 +46,29 @@ func (u *cockroachUsecaseImpl) ProcessData(in *models.AddCockroachData) error {
 
 	return nil
 }
+
+func (u *cockroachUsecaseImpl) GetCockroach(ctx context.Context, id uint32) (*entities.Cockroach, error) {
+	return u.cockroachRepository.GetCockroachByID(ctx, id)
+}
+
+func (u *cockroachUsecaseImpl) ListCockroaches(ctx context.Context, filter models.CockroachFilter, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error) {
+	filterDto := &entities.CockroachFilterDto{
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+	}
+
+	return u.cockroachRepository.ListCockroaches(ctx, filterDto, cursor, limit)
+}
+
+func (u *cockroachUsecaseImpl) UpdateCockroach(ctx context.Context, id uint32, in *models.UpdateCockroachData) (*entities.Cockroach, error) {
+	updateCockroachData := &entities.UpdateCockroachDto{
+		Id:     id,
+		Amount: in.Amount,
+	}
+
+	return u.cockroachRepository.UpdateCockroach(ctx, updateCockroachData)
+}
+
+func (u *cockroachUsecaseImpl) DeleteCockroach(ctx context.Context, id uint32) error {
+	return u.cockroachRepository.DeleteCockroach(ctx, id)
+}
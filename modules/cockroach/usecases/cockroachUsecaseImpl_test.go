@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"template-golang/modules/cockroach/entities"
+	"template-golang/modules/cockroach/models"
+	repoMocks "template-golang/modules/cockroach/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCockroachUsecase_ListCockroaches(t *testing.T) {
+	ctx := context.Background()
+	createdFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &entities.CockroachCursorDto{CreatedAt: createdFrom.Add(time.Hour), Id: 7, Before: true}
+
+	repo := repoMocks.NewMockCockroachRepository(t)
+	repo.EXPECT().ListCockroaches(ctx, &entities.CockroachFilterDto{CreatedFrom: createdFrom}, cursor, 11).
+		Return([]*entities.Cockroach{{Id: 8}, {Id: 9}}, nil)
+	usecase := NewCockroachUsecaseImpl(repo, nil)
+
+	cockroaches, err := usecase.ListCockroaches(ctx, models.CockroachFilter{CreatedFrom: createdFrom}, cursor, 11)
+
+	require.NoError(t, err)
+	assert.Len(t, cockroaches, 2)
+}
+
+func TestCockroachUsecase_UpdateCockroach(t *testing.T) {
+	ctx := context.Background()
+	repo := repoMocks.NewMockCockroachRepository(t)
+	repo.EXPECT().UpdateCockroach(ctx, &entities.UpdateCockroachDto{Id: 7, Amount: 5}).Return(nil, pgx.ErrNoRows)
+	usecase := NewCockroachUsecaseImpl(repo, nil)
+
+	_, err := usecase.UpdateCockroach(ctx, 7, &models.UpdateCockroachData{Amount: 5})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCockroachUsecase_DeleteCockroach(t *testing.T) {
+	ctx := context.Background()
+	repo := repoMocks.NewMockCockroachRepository(t)
+	repo.EXPECT().DeleteCockroach(mock.Anything, uint32(7)).Return(nil)
+	usecase := NewCockroachUsecaseImpl(repo, nil)
+
+	assert.NoError(t, usecase.DeleteCockroach(ctx, 7))
+}
@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	"context"
+	"template-golang/modules/cockroach/entities"
 	"template-golang/modules/cockroach/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -37,6 +39,211 @@ func (_m *MockCockroachUsecase) EXPECT() *MockCockroachUsecase_Expecter {
 	return &MockCockroachUsecase_Expecter{mock: &_m.Mock}
 }
 
+// DeleteCockroach provides a mock function for the type MockCockroachUsecase
+func (_mock *MockCockroachUsecase) DeleteCockroach(ctx context.Context, id uint32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCockroach")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCockroachUsecase_DeleteCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCockroach'
+type MockCockroachUsecase_DeleteCockroach_Call struct {
+	*mock.Call
+}
+
+// DeleteCockroach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+func (_e *MockCockroachUsecase_Expecter) DeleteCockroach(ctx interface{}, id interface{}) *MockCockroachUsecase_DeleteCockroach_Call {
+	return &MockCockroachUsecase_DeleteCockroach_Call{Call: _e.mock.On("DeleteCockroach", ctx, id)}
+}
+
+func (_c *MockCockroachUsecase_DeleteCockroach_Call) Run(run func(ctx context.Context, id uint32)) *MockCockroachUsecase_DeleteCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachUsecase_DeleteCockroach_Call) Return(err error) *MockCockroachUsecase_DeleteCockroach_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCockroachUsecase_DeleteCockroach_Call) RunAndReturn(run func(ctx context.Context, id uint32) error) *MockCockroachUsecase_DeleteCockroach_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCockroach provides a mock function for the type MockCockroachUsecase
+func (_mock *MockCockroachUsecase) GetCockroach(ctx context.Context, id uint32) (*entities.Cockroach, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCockroach")
+	}
+
+	var r0 *entities.Cockroach
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) (*entities.Cockroach, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) *entities.Cockroach); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Cockroach)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCockroachUsecase_GetCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCockroach'
+type MockCockroachUsecase_GetCockroach_Call struct {
+	*mock.Call
+}
+
+// GetCockroach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+func (_e *MockCockroachUsecase_Expecter) GetCockroach(ctx interface{}, id interface{}) *MockCockroachUsecase_GetCockroach_Call {
+	return &MockCockroachUsecase_GetCockroach_Call{Call: _e.mock.On("GetCockroach", ctx, id)}
+}
+
+func (_c *MockCockroachUsecase_GetCockroach_Call) Run(run func(ctx context.Context, id uint32)) *MockCockroachUsecase_GetCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachUsecase_GetCockroach_Call) Return(cockroach *entities.Cockroach, err error) *MockCockroachUsecase_GetCockroach_Call {
+	_c.Call.Return(cockroach, err)
+	return _c
+}
+
+func (_c *MockCockroachUsecase_GetCockroach_Call) RunAndReturn(run func(ctx context.Context, id uint32) (*entities.Cockroach, error)) *MockCockroachUsecase_GetCockroach_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCockroaches provides a mock function for the type MockCockroachUsecase
+func (_mock *MockCockroachUsecase) ListCockroaches(ctx context.Context, filter models.CockroachFilter, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error) {
+	ret := _mock.Called(ctx, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCockroaches")
+	}
+
+	var r0 []*entities.Cockroach
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.CockroachFilter, *entities.CockroachCursorDto, int) ([]*entities.Cockroach, error)); ok {
+		return returnFunc(ctx, filter, cursor, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.CockroachFilter, *entities.CockroachCursorDto, int) []*entities.Cockroach); ok {
+		r0 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Cockroach)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.CockroachFilter, *entities.CockroachCursorDto, int) error); ok {
+		r1 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCockroachUsecase_ListCockroaches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCockroaches'
+type MockCockroachUsecase_ListCockroaches_Call struct {
+	*mock.Call
+}
+
+// ListCockroaches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.CockroachFilter
+//   - cursor *entities.CockroachCursorDto
+//   - limit int
+func (_e *MockCockroachUsecase_Expecter) ListCockroaches(ctx interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockCockroachUsecase_ListCockroaches_Call {
+	return &MockCockroachUsecase_ListCockroaches_Call{Call: _e.mock.On("ListCockroaches", ctx, filter, cursor, limit)}
+}
+
+func (_c *MockCockroachUsecase_ListCockroaches_Call) Run(run func(ctx context.Context, filter models.CockroachFilter, cursor *entities.CockroachCursorDto, limit int)) *MockCockroachUsecase_ListCockroaches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.CockroachFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.CockroachFilter)
+		}
+		var arg2 *entities.CockroachCursorDto
+		if args[2] != nil {
+			arg2 = args[2].(*entities.CockroachCursorDto)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachUsecase_ListCockroaches_Call) Return(cockroachs []*entities.Cockroach, err error) *MockCockroachUsecase_ListCockroaches_Call {
+	_c.Call.Return(cockroachs, err)
+	return _c
+}
+
+func (_c *MockCockroachUsecase_ListCockroaches_Call) RunAndReturn(run func(ctx context.Context, filter models.CockroachFilter, cursor *entities.CockroachCursorDto, limit int) ([]*entities.Cockroach, error)) *MockCockroachUsecase_ListCockroaches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProcessData provides a mock function for the type MockCockroachUsecase
 func (_mock *MockCockroachUsecase) ProcessData(data *models.AddCockroachData) error {
 	ret := _mock.Called(data)
@@ -87,3 +294,77 @@ func (_c *MockCockroachUsecase_ProcessData_Call) RunAndReturn(run func(data *mod
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateCockroach provides a mock function for the type MockCockroachUsecase
+func (_mock *MockCockroachUsecase) UpdateCockroach(ctx context.Context, id uint32, in *models.UpdateCockroachData) (*entities.Cockroach, error) {
+	ret := _mock.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCockroach")
+	}
+
+	var r0 *entities.Cockroach
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, *models.UpdateCockroachData) (*entities.Cockroach, error)); ok {
+		return returnFunc(ctx, id, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, *models.UpdateCockroachData) *entities.Cockroach); ok {
+		r0 = returnFunc(ctx, id, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Cockroach)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32, *models.UpdateCockroachData) error); ok {
+		r1 = returnFunc(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCockroachUsecase_UpdateCockroach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCockroach'
+type MockCockroachUsecase_UpdateCockroach_Call struct {
+	*mock.Call
+}
+
+// UpdateCockroach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+//   - in *models.UpdateCockroachData
+func (_e *MockCockroachUsecase_Expecter) UpdateCockroach(ctx interface{}, id interface{}, in interface{}) *MockCockroachUsecase_UpdateCockroach_Call {
+	return &MockCockroachUsecase_UpdateCockroach_Call{Call: _e.mock.On("UpdateCockroach", ctx, id, in)}
+}
+
+func (_c *MockCockroachUsecase_UpdateCockroach_Call) Run(run func(ctx context.Context, id uint32, in *models.UpdateCockroachData)) *MockCockroachUsecase_UpdateCockroach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		var arg2 *models.UpdateCockroachData
+		if args[2] != nil {
+			arg2 = args[2].(*models.UpdateCockroachData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCockroachUsecase_UpdateCockroach_Call) Return(cockroach *entities.Cockroach, err error) *MockCockroachUsecase_UpdateCockroach_Call {
+	_c.Call.Return(cockroach, err)
+	return _c
+}
+
+func (_c *MockCockroachUsecase_UpdateCockroach_Call) RunAndReturn(run func(ctx context.Context, id uint32, in *models.UpdateCockroachData) (*entities.Cockroach, error)) *MockCockroachUsecase_UpdateCockroach_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+type PrivacyHandler interface {
+	RequestExport(c *gin.Context)
+	GetOwnRequests(c *gin.Context)
+	GetRequest(c *gin.Context)
+	DownloadExport(c *gin.Context)
+	GetRequests(c *gin.Context)
+	RequestUserExport(c *gin.Context)
+	RequestUserErasure(c *gin.Context)
+	Routes(routerGroup *gin.RouterGroup)
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	db "template-golang/db/sqlc"
+	authMiddlewares "template-golang/modules/auth/middlewares"
+	authModels "template-golang/modules/auth/models"
+	authUsecases "template-golang/modules/auth/usecases"
+	"template-golang/modules/privacy/models"
+	"template-golang/modules/privacy/usecases"
+	"template-golang/pkg/authz"
+	pkgContext "template-golang/pkg/context"
+	pkgErrors "template-golang/pkg/errors"
+	"template-golang/pkg/personaldata"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+type privacyHttpHandler struct {
+	dataRequestUsecase usecases.DataRequestUsecase
+	auditLogger        authUsecases.AuditLogger
+	authMiddleware     authMiddlewares.AuthMiddleware
+}
+
+func NewPrivacyHttpHandler(dataRequestUsecase usecases.DataRequestUsecase, auditLogger authUsecases.AuditLogger, authMiddleware authMiddlewares.AuthMiddleware) PrivacyHandler {
+	return &privacyHttpHandler{
+		dataRequestUsecase: dataRequestUsecase,
+		auditLogger:        auditLogger,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+// RequestExport queues an export of the data of the current user
+func (h *privacyHttpHandler) RequestExport(c *gin.Context) {
+	principal, ok := authz.FromContext(c.Request.Context())
+	if !ok {
+		response.Unauthorized(c, "Unauthorized")
+		return
+	}
+
+	h.requestExport(c, principal.UserID)
+}
+
+// GetOwnRequests lists the requests of the current user page by page, newest first
+func (h *privacyHttpHandler) GetOwnRequests(c *gin.Context) {
+	principal, ok := authz.FromContext(c.Request.Context())
+	if !ok {
+		response.Unauthorized(c, "Unauthorized")
+		return
+	}
+
+	h.listRequests(c, models.DataRequestFilter{AuthID: principal.UserID})
+}
+
+// GetRequest returns a request, e.g. to poll its status
+func (h *privacyHttpHandler) GetRequest(c *gin.Context) {
+	request, err := h.dataRequestUsecase.GetRequest(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve request")
+		return
+	}
+
+	response.Success(c, usecases.NewDataRequest(request))
+}
+
+// DownloadExport sends the data of a completed export as a ZIP archive, or as a single JSON
+// document with format=json
+func (h *privacyHttpHandler) DownloadExport(c *gin.Context) {
+	var query models.DownloadQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if errs := validator.ValidateStruct(query); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+
+	export, err := h.dataRequestUsecase.Download(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to download export")
+		return
+	}
+
+	var body bytes.Buffer
+	contentType, extension := "application/zip", models.ExportFormatZip
+	if query.Format == models.ExportFormatJSON {
+		contentType, extension = "application/json", models.ExportFormatJSON
+		encoder := json.NewEncoder(&body)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(export)
+	} else {
+		err = export.WriteZip(&body)
+	}
+	if err != nil {
+		_ = c.Error(err)
+		response.InternalServerError(c, "Failed to download export")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="personal-data-%s.%s"`, export.AuthID, extension))
+	c.Data(http.StatusOK, contentType, body.Bytes())
+}
+
+// GetRequests lists the requests of every user page by page, newest first, filtered by user,
+// kind and status
+func (h *privacyHttpHandler) GetRequests(c *gin.Context) {
+	var filter models.DataRequestFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if errs := validator.ValidateStruct(filter); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+
+	h.listRequests(c, filter)
+}
+
+// RequestUserExport queues an export of the data of a user on their behalf
+func (h *privacyHttpHandler) RequestUserExport(c *gin.Context) {
+	h.requestExport(c, c.Param("id"))
+}
+
+// RequestUserErasure queues the deletion or anonymization of the data of a user. Admins cannot
+// erase themselves.
+func (h *privacyHttpHandler) RequestUserErasure(c *gin.Context) {
+	var req models.ErasureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body")
+		return
+	}
+	if errs := validator.ValidateStruct(req); errs != nil {
+		response.ValidationError(c, errs)
+		return
+	}
+
+	authID := c.Param("id")
+	request, err := h.dataRequestUsecase.RequestErasure(c.Request.Context(), authID, personaldata.ErasureMode(req.Mode))
+	h.audit(c, models.AuditActionErasureRequest, authID, request, err, map[string]any{"mode": req.Mode})
+	if err != nil {
+		respondError(c, err, "Failed to request erasure")
+		return
+	}
+
+	response.Created(c, usecases.NewDataRequest(request))
+}
+
+func (h *privacyHttpHandler) requestExport(c *gin.Context, authID string) {
+	request, err := h.dataRequestUsecase.RequestExport(c.Request.Context(), authID)
+	h.audit(c, models.AuditActionExportRequest, authID, request, err, map[string]any{})
+	if err != nil {
+		respondError(c, err, "Failed to request export")
+		return
+	}
+
+	response.Created(c, usecases.NewDataRequest(request))
+}
+
+func (h *privacyHttpHandler) listRequests(c *gin.Context, filter models.DataRequestFilter) {
+	pagination := response.GetPaginationFromContext(c)
+	requests, total, err := h.dataRequestUsecase.ListRequests(c.Request.Context(), filter, pagination.Limit, pagination.Offset())
+	if err != nil {
+		respondError(c, err, "Failed to retrieve requests")
+		return
+	}
+
+	dataRequests := make([]models.DataRequest, 0, len(requests))
+	for _, request := range requests {
+		dataRequests = append(dataRequests, usecases.NewDataRequest(request))
+	}
+	response.Paginated(c, dataRequests, pagination, total)
+}
+
+// audit records the request of action on the data of authID in the audit log of the auth module
+func (h *privacyHttpHandler) audit(c *gin.Context, action string, authID string, request *db.DataRequest, err error, metadata map[string]any) {
+	event := authModels.AuditEvent{
+		Action:     action,
+		TargetType: authModels.AuditTargetUser,
+		TargetID:   authID,
+		Outcome:    authModels.AuditOutcomeSuccess,
+		Metadata:   metadata,
+	}
+	if request != nil {
+		event.Metadata["request_id"] = request.ID
+	}
+	if err != nil {
+		event.Outcome = authModels.AuditOutcomeFailure
+		event.Metadata["reason"] = err.Error()
+	}
+	h.auditLogger.Log(requestContext(c), event)
+}
+
+// requestContext returns the context of the request carrying the request ID, user and client of
+// its pkg/context.RequestContext, for the audit log
+func requestContext(c *gin.Context) context.Context {
+	return pkgContext.NewRequestContext(c).WithRequestValues(c.Request.Context())
+}
+
+// respondError responds 404 for unknown requests, 403 for missing permissions, 409 for
+// requests that conflict with pending ones, 410 for expired exports and 500 with message
+// otherwise
+func respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		response.NotFound(c, "Request not found")
+	case errors.Is(err, authz.ErrForbidden), errors.Is(err, authz.ErrUnauthenticated):
+		response.Forbidden(c, "Insufficient permissions")
+	case errors.Is(err, usecases.ErrRequestPending):
+		response.Conflict(c, "A request of this kind is already pending")
+	case errors.Is(err, usecases.ErrSelfErasure):
+		response.Conflict(c, "Admins cannot erase their own data")
+	case errors.Is(err, usecases.ErrExportNotReady):
+		response.Conflict(c, "Export is not ready")
+	case errors.Is(err, usecases.ErrExportExpired):
+		gone := pkgErrors.NotFound("Export has expired")
+		gone.StatusCode = http.StatusGone
+		response.Error(c, gone)
+	default:
+		_ = c.Error(err)
+		response.InternalServerError(c, message)
+	}
+}
+
+func (h *privacyHttpHandler) Routes(routerGroup *gin.RouterGroup) {
+	privacyGroup := routerGroup.Group("/privacy")
+	privacyGroup.Use(h.authMiddleware.Handle())
+	privacyGroup.POST("/export", h.RequestExport)
+	privacyGroup.GET("/requests", h.GetOwnRequests)
+	privacyGroup.GET("/requests/:id", h.GetRequest)
+	privacyGroup.GET("/requests/:id/download", h.DownloadExport)
+
+	// API keys are accepted here, each route requires a privacy permission
+	privacyAdminGroup := routerGroup.Group("/admin/privacy")
+	privacyAdminGroup.Use(h.authMiddleware.AcceptAPIKeys(), h.authMiddleware.Handle())
+	readPrivacy := h.authMiddleware.Requires(models.PermissionPrivacyRead)
+	writePrivacy := h.authMiddleware.Requires(models.PermissionPrivacyWrite)
+	privacyAdminGroup.GET("/requests", readPrivacy, h.GetRequests)
+	privacyAdminGroup.GET("/requests/:id", readPrivacy, h.GetRequest)
+	privacyAdminGroup.GET("/requests/:id/download", readPrivacy, h.DownloadExport)
+	privacyAdminGroup.POST("/users/:id/export", writePrivacy, h.RequestUserExport)
+	privacyAdminGroup.POST("/users/:id/erasure", writePrivacy, h.RequestUserErasure)
+}
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/middlewares"
+	authMocks "template-golang/modules/auth/middlewares/mocks"
+	authModels "template-golang/modules/auth/models"
+	authRepositories "template-golang/modules/auth/repositories"
+	authUsecases "template-golang/modules/auth/usecases"
+	authUsecaseMocks "template-golang/modules/auth/usecases/mocks"
+	"template-golang/modules/privacy/models"
+	"template-golang/modules/privacy/usecases"
+	"template-golang/modules/privacy/usecases/mocks"
+	"template-golang/pkg/authz"
+	"template-golang/pkg/personaldata"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuditLogger(t *testing.T) *authUsecaseMocks.MockAuditLogger {
+	auditLogger := authUsecaseMocks.NewMockAuditLogger(t)
+	auditLogger.EXPECT().Log(mock.Anything, mock.Anything).Maybe()
+	return auditLogger
+}
+
+// serve runs the request through a router authenticating it as userID
+func serve(handler gin.HandlerFunc, route string, method string, path string, userID string, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		if userID != "" {
+			c.Request = c.Request.WithContext(authz.WithPrincipal(c.Request.Context(), authz.Principal{UserID: userID}))
+		}
+	}, handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestPrivacyHttpHandler_RequestExport(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		setupMocks     func(*mocks.MockDataRequestUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:   "success",
+			userID: "user-1",
+			setupMocks: func(m *mocks.MockDataRequestUsecase) {
+				m.EXPECT().RequestExport(mock.Anything, "user-1").
+					Return(&db.DataRequest{ID: "request-1", AuthID: "user-1", Kind: models.DataRequestKindExport, Status: models.DataRequestStatusPending}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   []string{`"id":"request-1"`, `"status":"pending"`},
+		},
+		{
+			name:   "pending export",
+			userID: "user-1",
+			setupMocks: func(m *mocks.MockDataRequestUsecase) {
+				m.EXPECT().RequestExport(mock.Anything, "user-1").Return(nil, usecases.ErrRequestPending)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   []string{`"message":"A request of this kind is already pending"`},
+		},
+		{
+			name:           "unauthenticated",
+			setupMocks:     func(m *mocks.MockDataRequestUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockDataRequestUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase, auditLogger: newTestAuditLogger(t)}
+
+			w := serve(handler.RequestExport, "/privacy/export", "POST", "/privacy/export", tt.userID, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestPrivacyHttpHandler_GetOwnRequests(t *testing.T) {
+	mockUsecase := mocks.NewMockDataRequestUsecase(t)
+	mockUsecase.EXPECT().ListRequests(mock.Anything, models.DataRequestFilter{AuthID: "user-1"}, 10, 0).
+		Return([]*db.DataRequest{{ID: "request-1", AuthID: "user-1"}}, 1, nil)
+	handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase}
+
+	w := serve(handler.GetOwnRequests, "/privacy/requests", "GET", "/privacy/requests", "user-1", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"request-1"`)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+}
+
+func TestPrivacyHttpHandler_GetRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func(*mocks.MockDataRequestUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:  "filtered",
+			query: "?kind=erasure&status=failed&page=2&limit=5",
+			setupMocks: func(m *mocks.MockDataRequestUsecase) {
+				m.EXPECT().ListRequests(mock.Anything, models.DataRequestFilter{Kind: "erasure", Status: "failed"}, 5, 5).
+					Return([]*db.DataRequest{}, 6, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"page":2`, `"total":6`},
+		},
+		{
+			name:           "invalid kind",
+			query:          "?kind=delete",
+			setupMocks:     func(m *mocks.MockDataRequestUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"type":"validation"`},
+		},
+		{
+			name:  "missing permission",
+			query: "",
+			setupMocks: func(m *mocks.MockDataRequestUsecase) {
+				m.EXPECT().ListRequests(mock.Anything, mock.Anything, 10, 0).
+					Return(nil, 0, fmt.Errorf("%w: missing permission privacy:read", authz.ErrForbidden))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   []string{`"message":"Insufficient permissions"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockDataRequestUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase}
+
+			w := serve(handler.GetRequests, "/admin/privacy/requests", "GET", "/admin/privacy/requests"+tt.query, "admin-1", "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestPrivacyHttpHandler_GetRequest(t *testing.T) {
+	mockUsecase := mocks.NewMockDataRequestUsecase(t)
+	mockUsecase.EXPECT().GetRequest(mock.Anything, "request-2").Return(nil, pgx.ErrNoRows)
+	handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase}
+
+	w := serve(handler.GetRequest, "/privacy/requests/:id", "GET", "/privacy/requests/request-2", "user-1", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"Request not found"`)
+}
+
+func TestPrivacyHttpHandler_DownloadExport(t *testing.T) {
+	export := &personaldata.Export{
+		AuthID:     "user-1",
+		ExportedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Modules:    map[string]json.RawMessage{"auth": json.RawMessage(`{"account":{"id":"user-1"}}`)},
+	}
+
+	t.Run("zip", func(t *testing.T) {
+		mockUsecase := mocks.NewMockDataRequestUsecase(t)
+		mockUsecase.EXPECT().Download(mock.Anything, "request-1").Return(export, nil)
+		handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase}
+
+		w := serve(handler.DownloadExport, "/privacy/requests/:id/download", "GET", "/privacy/requests/request-1/download", "user-1", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="personal-data-user-1.zip"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		names := []string{}
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+		assert.Equal(t, []string{"export.json", "auth.json"}, names)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		mockUsecase := mocks.NewMockDataRequestUsecase(t)
+		mockUsecase.EXPECT().Download(mock.Anything, "request-1").Return(export, nil)
+		handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase}
+
+		w := serve(handler.DownloadExport, "/privacy/requests/:id/download", "GET", "/privacy/requests/request-1/download?format=json", "user-1", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var got personaldata.Export
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.JSONEq(t, `{"account":{"id":"user-1"}}`, string(got.Modules["auth"]))
+	})
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"not ready", usecases.ErrExportNotReady, http.StatusConflict},
+		{"expired", usecases.ErrExportExpired, http.StatusGone},
+		{"request of another user", pgx.ErrNoRows, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockDataRequestUsecase(t)
+			mockUsecase.EXPECT().Download(mock.Anything, "request-1").Return(nil, tt.err)
+			handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase}
+
+			w := serve(handler.DownloadExport, "/privacy/requests/:id/download", "GET", "/privacy/requests/request-1/download", "user-1", "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestPrivacyHttpHandler_RequestUserErasure(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*mocks.MockDataRequestUsecase)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name: "success",
+			body: `{"mode":"anonymize"}`,
+			setupMocks: func(m *mocks.MockDataRequestUsecase) {
+				mode := "anonymize"
+				m.EXPECT().RequestErasure(mock.Anything, "user-2", personaldata.ErasureAnonymize).
+					Return(&db.DataRequest{ID: "request-1", AuthID: "user-2", Kind: models.DataRequestKindErasure, ErasureMode: &mode, Status: models.DataRequestStatusPending}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   []string{`"kind":"erasure"`, `"erasure_mode":"anonymize"`},
+		},
+		{
+			name:           "invalid mode",
+			body:           `{"mode":"shred"}`,
+			setupMocks:     func(m *mocks.MockDataRequestUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"type":"validation"`},
+		},
+		{
+			name: "own data",
+			body: `{"mode":"delete"}`,
+			setupMocks: func(m *mocks.MockDataRequestUsecase) {
+				m.EXPECT().RequestErasure(mock.Anything, "user-2", personaldata.ErasureDelete).Return(nil, usecases.ErrSelfErasure)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   []string{`"message":"Admins cannot erase their own data"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockDataRequestUsecase(t)
+			tt.setupMocks(mockUsecase)
+			handler := &privacyHttpHandler{dataRequestUsecase: mockUsecase, auditLogger: newTestAuditLogger(t)}
+
+			w := serve(handler.RequestUserErasure, "/admin/privacy/users/:id/erasure", "POST", "/admin/privacy/users/user-2/erasure", "admin-1", tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestPrivacyHttpHandler_Routes(t *testing.T) {
+	mockAuthMiddleware := authMocks.NewMockAuthMiddleware(t)
+	mockAuthMiddleware.On("Handle").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+	mockAuthMiddleware.On("AcceptAPIKeys").Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+	mockAuthMiddleware.On("Requires", mock.Anything).Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+	}))
+
+	handler := NewPrivacyHttpHandler(mocks.NewMockDataRequestUsecase(t), newTestAuditLogger(t), mockAuthMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.Routes(router.Group("/api/v1"))
+
+	routeMap := make(map[string]bool)
+	for _, route := range router.Routes() {
+		routeMap[route.Method+" "+route.Path] = true
+	}
+	for _, expected := range []string{
+		"POST /api/v1/privacy/export",
+		"GET /api/v1/privacy/requests",
+		"GET /api/v1/privacy/requests/:id",
+		"GET /api/v1/privacy/requests/:id/download",
+		"GET /api/v1/admin/privacy/requests",
+		"GET /api/v1/admin/privacy/requests/:id",
+		"GET /api/v1/admin/privacy/requests/:id/download",
+		"POST /api/v1/admin/privacy/users/:id/export",
+		"POST /api/v1/admin/privacy/users/:id/erasure",
+	} {
+		assert.True(t, routeMap[expected], "Route %s should be registered", expected)
+	}
+}
+
+func TestPrivacyHttpHandler_Routes_RefuseAPIKeys(t *testing.T) {
+	apiKeyUsecase := authUsecaseMocks.NewMockAPIKeyUsecase(t)
+	accountStatus := authUsecaseMocks.NewMockAccountStatusStore(t)
+
+	conf := &config.Config{}
+	authMiddleware := middlewares.NewAuthMiddleware(authUsecaseMocks.NewMockJWTUsecase(t), apiKeyUsecase,
+		authUsecases.NewPermissionStore(conf, nil), accountStatus,
+		authUsecases.NewLoginThrottle(conf, authRepositories.NewMemoryLoginAttemptRepository()))
+	handler := NewPrivacyHttpHandler(mocks.NewMockDataRequestUsecase(t), newTestAuditLogger(t), authMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.Routes(router.Group("/api/v1"))
+
+	// A key scoped to cockroach:write must not export or download its owner's personal data
+	for _, route := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/privacy/export"},
+		{http.MethodGet, "/api/v1/privacy/requests"},
+		{http.MethodGet, "/api/v1/privacy/requests/request-1"},
+		{http.MethodGet, "/api/v1/privacy/requests/request-1/download"},
+	} {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(route.method, route.path, nil)
+			req.Header.Set(authModels.APIKeyHeader, "abc123.secret")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Contains(t, w.Body.String(), "API keys are not accepted on this route")
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Permissions checked by the privacy module
+const (
+	PermissionPrivacyRead  = "privacy:read"
+	PermissionPrivacyWrite = "privacy:write"
+)
+
+// Actions of the audit log
+const (
+	AuditActionExportRequest  = "privacy.export_request"
+	AuditActionErasureRequest = "privacy.erasure_request"
+)
+
+// Kinds of data requests
+const (
+	DataRequestKindExport  = "export"
+	DataRequestKindErasure = "erasure"
+)
+
+// Statuses of data requests. Pending requests wait for a replica to run them.
+const (
+	DataRequestStatusPending   = "pending"
+	DataRequestStatusRunning   = "running"
+	DataRequestStatusCompleted = "completed"
+	DataRequestStatusFailed    = "failed"
+)
+
+// Formats of downloaded exports
+const (
+	ExportFormatZip  = "zip"
+	ExportFormatJSON = "json"
+)
+
+// DataRequestFilter is the query of GET /admin/privacy/requests. Zero fields do not filter.
+type DataRequestFilter struct {
+	AuthID string `form:"auth_id" validate:"omitempty,max=36"`
+	Kind   string `form:"kind" validate:"omitempty,oneof=export erasure"`
+	Status string `form:"status" validate:"omitempty,oneof=pending running completed failed"`
+}
+
+// ErasureRequest is the body of POST /admin/privacy/users/:id/erasure
+type ErasureRequest struct {
+	Mode string `json:"mode" validate:"required,oneof=delete anonymize"`
+}
+
+// DownloadQuery is the query of the download routes
+type DownloadQuery struct {
+	Format string `form:"format" validate:"omitempty,oneof=zip json"`
+}
+
+// DataRequest is an export or erasure request. The exported data is only part of its download.
+type DataRequest struct {
+	ID          string     `json:"id"`
+	AuthID      string     `json:"auth_id"`
+	RequestedBy string     `json:"requested_by,omitempty"`
+	Kind        string     `json:"kind"`
+	ErasureMode string     `json:"erasure_mode,omitempty"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,63 @@
+package privacy
+
+import (
+	"context"
+	"sync"
+	"template-golang/modules/privacy/handlers"
+	"template-golang/modules/privacy/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Privacy struct {
+	Handler      handlers.PrivacyHandler
+	DataRequests usecases.DataRequestUsecase
+
+	stopBackground context.CancelFunc
+	backgroundDone sync.WaitGroup
+}
+
+func (p *Privacy) Name() string {
+	return "privacy"
+}
+
+func (p *Privacy) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	p.Handler.Routes(routerGroup)
+}
+
+// Start runs the export and erasure requests of every replica in the background and deletes
+// expired exports
+func (p *Privacy) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	p.stopBackground = cancel
+
+	p.backgroundDone.Add(1)
+	go func() {
+		defer p.backgroundDone.Done()
+		p.DataRequests.Run(runCtx)
+	}()
+
+	return nil
+}
+
+// Stop interrupts the running request, which is picked up again after PRIVACY_JOB_TIMEOUT
+func (p *Privacy) Stop(ctx context.Context) error {
+	if p.stopBackground == nil {
+		return nil
+	}
+
+	p.stopBackground()
+
+	done := make(chan struct{})
+	go func() {
+		p.backgroundDone.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
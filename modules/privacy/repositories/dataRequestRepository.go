@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DataRequestRepository interface {
+	CreateDataRequest(ctx context.Context, authID string, requestedBy *string, kind string, erasureMode *string) (*db.DataRequest, error)
+	GetDataRequest(ctx context.Context, id string) (*db.DataRequest, error)
+	// ListDataRequests returns a page of the requests matching the non-nil filters, newest
+	// first, without their result
+	ListDataRequests(ctx context.Context, authID *string, kind *string, status *string, limit int, offset int) ([]*db.DataRequest, error)
+	CountDataRequests(ctx context.Context, authID *string, kind *string, status *string) (int64, error)
+	// ClaimDataRequest marks the oldest pending request, or a request running since before
+	// staleBefore, running and returns it. It returns pgx.ErrNoRows when there is none.
+	ClaimDataRequest(ctx context.Context, staleBefore time.Time) (*db.DataRequest, error)
+	// CompleteDataRequest marks a request completed, keeping result until expiresAt. A zero
+	// expiresAt stores no expiry, for requests without result.
+	CompleteDataRequest(ctx context.Context, id string, result []byte, expiresAt time.Time) error
+	FailDataRequest(ctx context.Context, id string, reason string) error
+	// DeleteDataRequestResults drops the exported data of every request of authID
+	DeleteDataRequestResults(ctx context.Context, authID string) (int64, error)
+	DeleteExpiredDataRequestResults(ctx context.Context) (int64, error)
+}
+
+type dataRequestRepository struct {
+	queries *db.Queries
+}
+
+func NewDataRequestRepository(queries *db.Queries) DataRequestRepository {
+	return &dataRequestRepository{
+		queries: queries,
+	}
+}
+
+// q returns the queries bound to the transaction in ctx, if any
+func (r *dataRequestRepository) q(ctx context.Context) *db.Queries {
+	return database.Queries(ctx, r.queries)
+}
+
+func (r *dataRequestRepository) CreateDataRequest(ctx context.Context, authID string, requestedBy *string, kind string, erasureMode *string) (*db.DataRequest, error) {
+	request, err := r.q(ctx).CreateDataRequest(ctx, authID, requestedBy, kind, erasureMode)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *dataRequestRepository) GetDataRequest(ctx context.Context, id string) (*db.DataRequest, error) {
+	request, err := r.q(ctx).GetDataRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *dataRequestRepository) ListDataRequests(ctx context.Context, authID *string, kind *string, status *string, limit int, offset int) ([]*db.DataRequest, error) {
+	rows, err := r.q(ctx).ListDataRequests(ctx, authID, kind, status, int32(offset), int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*db.DataRequest, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &db.DataRequest{
+			ID:          row.ID,
+			CreatedAt:   row.CreatedAt,
+			AuthID:      row.AuthID,
+			RequestedBy: row.RequestedBy,
+			Kind:        row.Kind,
+			ErasureMode: row.ErasureMode,
+			Status:      row.Status,
+			Error:       row.Error,
+			StartedAt:   row.StartedAt,
+			CompletedAt: row.CompletedAt,
+			ExpiresAt:   row.ExpiresAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *dataRequestRepository) CountDataRequests(ctx context.Context, authID *string, kind *string, status *string) (int64, error) {
+	return r.q(ctx).CountDataRequests(ctx, authID, kind, status)
+}
+
+func (r *dataRequestRepository) ClaimDataRequest(ctx context.Context, staleBefore time.Time) (*db.DataRequest, error) {
+	request, err := r.q(ctx).ClaimDataRequest(ctx, pgtype.Timestamptz{Time: staleBefore, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *dataRequestRepository) CompleteDataRequest(ctx context.Context, id string, result []byte, expiresAt time.Time) error {
+	return r.q(ctx).CompleteDataRequest(ctx, result, pgtype.Timestamptz{Time: expiresAt, Valid: !expiresAt.IsZero()}, id)
+}
+
+func (r *dataRequestRepository) FailDataRequest(ctx context.Context, id string, reason string) error {
+	return r.q(ctx).FailDataRequest(ctx, &reason, id)
+}
+
+func (r *dataRequestRepository) DeleteDataRequestResults(ctx context.Context, authID string) (int64, error) {
+	return r.q(ctx).DeleteDataRequestResults(ctx, authID)
+}
+
+func (r *dataRequestRepository) DeleteExpiredDataRequestResults(ctx context.Context) (int64, error) {
+	return r.q(ctx).DeleteExpiredDataRequestResults(ctx)
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/privacy/models"
+	"template-golang/pkg/personaldata"
+)
+
+var (
+	// ErrRequestPending is returned when the user already has a request of the kind waiting or
+	// running
+	ErrRequestPending = errors.New("a request of this kind is already pending")
+	// ErrSelfErasure is returned when an admin requests the erasure of their own data
+	ErrSelfErasure = errors.New("cannot erase own data")
+	// ErrExportNotReady is returned when downloading an erasure, or an export that has not
+	// completed
+	ErrExportNotReady = errors.New("export is not ready")
+	// ErrExportExpired is returned when downloading an export whose data was deleted
+	ErrExportExpired = errors.New("export has expired")
+)
+
+// DataRequestUsecase handles data subject requests: exports of everything the registered
+// modules store about a user, and erasures deleting or anonymizing it. Requests are jobs run
+// in the background by Run. Users can export and see their own data; other users take the
+// privacy permissions. Methods return pgx.ErrNoRows for unknown requests and requests of
+// other users.
+type DataRequestUsecase interface {
+	// RequestExport queues an export of the data of authID
+	RequestExport(ctx context.Context, authID string) (*db.DataRequest, error)
+	// RequestErasure queues the erasure of the data of authID in mode. It takes the
+	// privacy:write permission.
+	RequestErasure(ctx context.Context, authID string, mode personaldata.ErasureMode) (*db.DataRequest, error)
+	// GetRequest returns a request without its result
+	GetRequest(ctx context.Context, id string) (*db.DataRequest, error)
+	// ListRequests returns a page of the requests matching filter, newest first, and the number
+	// of all matching requests
+	ListRequests(ctx context.Context, filter models.DataRequestFilter, limit int, offset int) ([]*db.DataRequest, int, error)
+	// Download returns the data of a completed export
+	Download(ctx context.Context, id string) (*personaldata.Export, error)
+
+	// Process runs the oldest pending request, reporting false when there was none
+	Process(ctx context.Context) (bool, error)
+	// Run processes pending requests every PRIVACY_POLL_INTERVAL and deletes expired exports
+	// until ctx is done
+	Run(ctx context.Context)
+
+	// ExportPersonalData returns the requests of authID, so the privacy module is part of the
+	// exports too
+	ExportPersonalData(ctx context.Context, authID string) (any, error)
+	// ErasePersonalData deletes the exported data of authID. The requests themselves are kept
+	// as the record of the erasure.
+	ErasePersonalData(ctx context.Context, authID string, mode personaldata.ErasureMode) error
+}
@@ -0,0 +1,333 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/privacy/models"
+	"template-golang/modules/privacy/repositories"
+	"template-golang/pkg/authz"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/personaldata"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	defaultPollInterval = 10 * time.Second
+	defaultExportTTL    = 7 * 24 * time.Hour
+	defaultJobTimeout   = 15 * time.Minute
+
+	// maxExportedRequests caps the requests of a user in their export
+	maxExportedRequests = 1000
+)
+
+const sqlStateUniqueViolation = "23505"
+
+// isUniqueViolation reports whether err was caused by a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation
+}
+
+type dataRequestUsecaseImpl struct {
+	registry        *personaldata.Registry
+	dataRequestRepo repositories.DataRequestRepository
+
+	pollInterval time.Duration
+	exportTTL    time.Duration
+	jobTimeout   time.Duration
+}
+
+func NewDataRequestUsecase(conf *config.Config, registry *personaldata.Registry, dataRequestRepo repositories.DataRequestRepository) DataRequestUsecase {
+	u := &dataRequestUsecaseImpl{
+		registry:        registry,
+		dataRequestRepo: dataRequestRepo,
+		pollInterval:    conf.Privacy.PollInterval,
+		exportTTL:       conf.Privacy.ExportTTL,
+		jobTimeout:      conf.Privacy.JobTimeout,
+	}
+	if u.pollInterval <= 0 {
+		u.pollInterval = defaultPollInterval
+	}
+	if u.exportTTL <= 0 {
+		u.exportTTL = defaultExportTTL
+	}
+	if u.jobTimeout <= 0 {
+		u.jobTimeout = defaultJobTimeout
+	}
+	return u
+}
+
+func (u *dataRequestUsecaseImpl) RequestExport(ctx context.Context, authID string) (*db.DataRequest, error) {
+	principal, err := requireAccess(ctx, authID, models.PermissionPrivacyWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.createRequest(ctx, authID, principal.UserID, models.DataRequestKindExport, nil)
+}
+
+func (u *dataRequestUsecaseImpl) RequestErasure(ctx context.Context, authID string, mode personaldata.ErasureMode) (*db.DataRequest, error) {
+	if err := authz.Require(ctx, models.PermissionPrivacyWrite); err != nil {
+		return nil, err
+	}
+	principal, _ := authz.FromContext(ctx)
+	if principal.UserID == authID {
+		return nil, ErrSelfErasure
+	}
+	if !mode.Valid() {
+		return nil, fmt.Errorf("unknown erasure mode %q", mode)
+	}
+
+	erasureMode := string(mode)
+	return u.createRequest(ctx, authID, principal.UserID, models.DataRequestKindErasure, &erasureMode)
+}
+
+func (u *dataRequestUsecaseImpl) createRequest(ctx context.Context, authID string, requestedBy string, kind string, erasureMode *string) (*db.DataRequest, error) {
+	request, err := u.dataRequestRepo.CreateDataRequest(ctx, authID, &requestedBy, kind, erasureMode)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrRequestPending
+		}
+		return nil, fmt.Errorf("failed to create data request: %w", err)
+	}
+
+	logger.Infof("Auth %s requested the %s of the data of auth %s", requestedBy, kind, authID)
+	return request, nil
+}
+
+func (u *dataRequestUsecaseImpl) GetRequest(ctx context.Context, id string) (*db.DataRequest, error) {
+	request, err := u.getRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Result = nil
+	return request, nil
+}
+
+// getRequest returns the request id when the principal of ctx may read it
+func (u *dataRequestUsecaseImpl) getRequest(ctx context.Context, id string) (*db.DataRequest, error) {
+	if _, ok := authz.FromContext(ctx); !ok {
+		return nil, authz.ErrUnauthenticated
+	}
+
+	request, err := u.dataRequestRepo.GetDataRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Requests of other users are not revealed to users without privacy:read
+	if _, err := requireAccess(ctx, request.AuthID, models.PermissionPrivacyRead); err != nil {
+		return nil, pgx.ErrNoRows
+	}
+	return request, nil
+}
+
+func (u *dataRequestUsecaseImpl) ListRequests(ctx context.Context, filter models.DataRequestFilter, limit int, offset int) ([]*db.DataRequest, int, error) {
+	if filter.AuthID == "" {
+		if err := authz.Require(ctx, models.PermissionPrivacyRead); err != nil {
+			return nil, 0, err
+		}
+	} else if _, err := requireAccess(ctx, filter.AuthID, models.PermissionPrivacyRead); err != nil {
+		return nil, 0, err
+	}
+
+	authID, kind, status := stringPtr(filter.AuthID), stringPtr(filter.Kind), stringPtr(filter.Status)
+	total, err := u.dataRequestRepo.CountDataRequests(ctx, authID, kind, status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count data requests: %w", err)
+	}
+	if total == 0 {
+		return []*db.DataRequest{}, 0, nil
+	}
+
+	requests, err := u.dataRequestRepo.ListDataRequests(ctx, authID, kind, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list data requests: %w", err)
+	}
+
+	return requests, int(total), nil
+}
+
+func (u *dataRequestUsecaseImpl) Download(ctx context.Context, id string) (*personaldata.Export, error) {
+	request, err := u.getRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Kind != models.DataRequestKindExport || request.Status != models.DataRequestStatusCompleted {
+		return nil, ErrExportNotReady
+	}
+	if request.Result == nil || (request.ExpiresAt.Valid && !request.ExpiresAt.Time.After(time.Now())) {
+		return nil, ErrExportExpired
+	}
+
+	var export personaldata.Export
+	if err := json.Unmarshal(request.Result, &export); err != nil {
+		return nil, fmt.Errorf("failed to decode export: %w", err)
+	}
+	return &export, nil
+}
+
+func (u *dataRequestUsecaseImpl) Process(ctx context.Context) (bool, error) {
+	// Requests running for longer than the job timeout were left behind by a stopped replica
+	request, err := u.dataRequestRepo.ClaimDataRequest(ctx, time.Now().Add(-u.jobTimeout))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim data request: %w", err)
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, u.jobTimeout)
+	defer cancel()
+
+	result, expiresAt, err := u.run(jobCtx, request)
+	if err != nil {
+		// A request interrupted by shutdown is left running and picked up again
+		if ctx.Err() != nil {
+			return true, ctx.Err()
+		}
+		logger.Errorf("Data request %s failed: %v", request.ID, err)
+		if err := u.dataRequestRepo.FailDataRequest(ctx, request.ID, err.Error()); err != nil {
+			return true, fmt.Errorf("failed to mark data request %s failed: %w", request.ID, err)
+		}
+		return true, nil
+	}
+
+	if err := u.dataRequestRepo.CompleteDataRequest(ctx, request.ID, result, expiresAt); err != nil {
+		return true, fmt.Errorf("failed to complete data request %s: %w", request.ID, err)
+	}
+	logger.Infof("Completed the %s of the data of auth %s", request.Kind, request.AuthID)
+	return true, nil
+}
+
+// run carries out request, returning the exported data and until when it is kept
+func (u *dataRequestUsecaseImpl) run(ctx context.Context, request *db.DataRequest) ([]byte, time.Time, error) {
+	switch request.Kind {
+	case models.DataRequestKindExport:
+		export, err := u.registry.Export(ctx, request.AuthID)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		result, err := json.Marshal(export)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to marshal export: %w", err)
+		}
+		return result, time.Now().Add(u.exportTTL), nil
+	case models.DataRequestKindErasure:
+		mode := personaldata.ErasureMode("")
+		if request.ErasureMode != nil {
+			mode = personaldata.ErasureMode(*request.ErasureMode)
+		}
+		return nil, time.Time{}, u.registry.Erase(ctx, request.AuthID, mode)
+	default:
+		return nil, time.Time{}, fmt.Errorf("unknown data request kind %q", request.Kind)
+	}
+}
+
+func (u *dataRequestUsecaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := u.Process(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Errorf("Failed to process data requests: %v", err)
+			}
+			if !processed || err != nil {
+				break
+			}
+		}
+
+		deleted, err := u.dataRequestRepo.DeleteExpiredDataRequestResults(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Errorf("Failed to delete expired exports: %v", err)
+		}
+		if deleted > 0 {
+			logger.Infof("Deleted %d expired exports", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *dataRequestUsecaseImpl) ExportPersonalData(ctx context.Context, authID string) (any, error) {
+	requests, err := u.dataRequestRepo.ListDataRequests(ctx, &authID, nil, nil, maxExportedRequests, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data requests: %w", err)
+	}
+
+	data := make([]models.DataRequest, 0, len(requests))
+	for _, request := range requests {
+		data = append(data, NewDataRequest(request))
+	}
+	return map[string]any{"requests": data}, nil
+}
+
+func (u *dataRequestUsecaseImpl) ErasePersonalData(ctx context.Context, authID string, mode personaldata.ErasureMode) error {
+	if _, err := u.dataRequestRepo.DeleteDataRequestResults(ctx, authID); err != nil {
+		return fmt.Errorf("failed to delete exports: %w", err)
+	}
+	return nil
+}
+
+// NewDataRequest returns the fields of request users see
+func NewDataRequest(request *db.DataRequest) models.DataRequest {
+	dataRequest := models.DataRequest{
+		ID:        request.ID,
+		AuthID:    request.AuthID,
+		Kind:      request.Kind,
+		Status:    request.Status,
+		CreatedAt: request.CreatedAt.Time,
+	}
+	if request.RequestedBy != nil {
+		dataRequest.RequestedBy = *request.RequestedBy
+	}
+	if request.ErasureMode != nil {
+		dataRequest.ErasureMode = *request.ErasureMode
+	}
+	if request.Error != nil {
+		dataRequest.Error = *request.Error
+	}
+	if request.StartedAt.Valid {
+		dataRequest.StartedAt = &request.StartedAt.Time
+	}
+	if request.CompletedAt.Valid {
+		dataRequest.CompletedAt = &request.CompletedAt.Time
+	}
+	if request.ExpiresAt.Valid {
+		dataRequest.ExpiresAt = &request.ExpiresAt.Time
+	}
+	return dataRequest
+}
+
+// requireAccess returns the principal of ctx when it is authID or has permission
+func requireAccess(ctx context.Context, authID string, permission string) (authz.Principal, error) {
+	principal, ok := authz.FromContext(ctx)
+	if !ok {
+		return principal, authz.ErrUnauthenticated
+	}
+	if principal.UserID == authID {
+		return principal, nil
+	}
+	return principal, authz.Require(ctx, permission)
+}
+
+// stringPtr returns nil for empty s, so the queries do not filter on it
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
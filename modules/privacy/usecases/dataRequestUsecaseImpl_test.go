@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/privacy/models"
+	repoMocks "template-golang/modules/privacy/repositories/mocks"
+	"template-golang/pkg/authz"
+	"template-golang/pkg/personaldata"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testModule is the exporter and eraser of a module in the registry
+type testModule struct {
+	data     any
+	err      error
+	erasedBy []personaldata.ErasureMode
+}
+
+func (m *testModule) ExportPersonalData(ctx context.Context, authID string) (any, error) {
+	return m.data, m.err
+}
+
+func (m *testModule) ErasePersonalData(ctx context.Context, authID string, mode personaldata.ErasureMode) error {
+	m.erasedBy = append(m.erasedBy, mode)
+	return m.err
+}
+
+func setupDataRequestUsecase(t *testing.T, module *testModule) (DataRequestUsecase, *repoMocks.MockDataRequestRepository) {
+	repo := repoMocks.NewMockDataRequestRepository(t)
+	registry := personaldata.NewRegistry()
+	if module != nil {
+		registry.Register("test", module, module)
+	}
+	return NewDataRequestUsecase(&config.Config{}, registry, repo), repo
+}
+
+func userContext(userID string, permissions ...string) context.Context {
+	return authz.WithPrincipal(context.Background(), authz.Principal{UserID: userID, Permissions: permissions})
+}
+
+func TestDataRequestUsecase_RequestExport(t *testing.T) {
+	t.Run("own data", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		requestedBy := "user-1"
+		repo.EXPECT().CreateDataRequest(mock.Anything, "user-1", &requestedBy, models.DataRequestKindExport, (*string)(nil)).
+			Return(&db.DataRequest{ID: "request-1", AuthID: "user-1", Status: models.DataRequestStatusPending}, nil).Once()
+
+		request, err := u.RequestExport(userContext("user-1"), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "request-1", request.ID)
+	})
+
+	t.Run("data of another user takes privacy:write", func(t *testing.T) {
+		u, _ := setupDataRequestUsecase(t, nil)
+
+		_, err := u.RequestExport(userContext("user-1", models.PermissionPrivacyRead), "user-2")
+		assert.ErrorIs(t, err, authz.ErrForbidden)
+	})
+
+	t.Run("pending export", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		repo.EXPECT().CreateDataRequest(mock.Anything, "user-2", mock.Anything, models.DataRequestKindExport, (*string)(nil)).
+			Return(nil, &pgconn.PgError{Code: sqlStateUniqueViolation}).Once()
+
+		_, err := u.RequestExport(userContext("admin-1", models.PermissionPrivacyWrite), "user-2")
+		assert.ErrorIs(t, err, ErrRequestPending)
+	})
+}
+
+func TestDataRequestUsecase_RequestErasure(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		mode := string(personaldata.ErasureAnonymize)
+		repo.EXPECT().CreateDataRequest(mock.Anything, "user-2", mock.Anything, models.DataRequestKindErasure, &mode).
+			Return(&db.DataRequest{ID: "request-1"}, nil).Once()
+
+		_, err := u.RequestErasure(userContext("admin-1", models.PermissionPrivacyWrite), "user-2", personaldata.ErasureAnonymize)
+		assert.NoError(t, err)
+	})
+
+	t.Run("own data", func(t *testing.T) {
+		u, _ := setupDataRequestUsecase(t, nil)
+
+		_, err := u.RequestErasure(userContext("admin-1", models.PermissionPrivacyWrite), "admin-1", personaldata.ErasureDelete)
+		assert.ErrorIs(t, err, ErrSelfErasure)
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		u, _ := setupDataRequestUsecase(t, nil)
+
+		_, err := u.RequestErasure(userContext("user-1"), "user-1", personaldata.ErasureDelete)
+		assert.ErrorIs(t, err, authz.ErrForbidden)
+	})
+}
+
+func TestDataRequestUsecase_GetRequest(t *testing.T) {
+	request := &db.DataRequest{ID: "request-1", AuthID: "user-2", Result: []byte(`{}`)}
+
+	t.Run("request of another user", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		repo.EXPECT().GetDataRequest(mock.Anything, "request-1").Return(request, nil).Once()
+
+		_, err := u.GetRequest(userContext("user-1"), "request-1")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("privacy:read", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		repo.EXPECT().GetDataRequest(mock.Anything, "request-1").Return(request, nil).Once()
+
+		got, err := u.GetRequest(userContext("admin-1", models.PermissionPrivacyRead), "request-1")
+		require.NoError(t, err)
+		assert.Nil(t, got.Result)
+	})
+}
+
+func TestDataRequestUsecase_ListRequests(t *testing.T) {
+	t.Run("every user takes privacy:read", func(t *testing.T) {
+		u, _ := setupDataRequestUsecase(t, nil)
+
+		_, _, err := u.ListRequests(userContext("user-1"), models.DataRequestFilter{}, 10, 0)
+		assert.ErrorIs(t, err, authz.ErrForbidden)
+	})
+
+	t.Run("own requests", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		authID := "user-1"
+		repo.EXPECT().CountDataRequests(mock.Anything, &authID, (*string)(nil), (*string)(nil)).Return(1, nil).Once()
+		repo.EXPECT().ListDataRequests(mock.Anything, &authID, (*string)(nil), (*string)(nil), 10, 0).
+			Return([]*db.DataRequest{{ID: "request-1"}}, nil).Once()
+
+		requests, total, err := u.ListRequests(userContext("user-1"), models.DataRequestFilter{AuthID: "user-1"}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, requests, 1)
+	})
+}
+
+func TestDataRequestUsecase_Download(t *testing.T) {
+	future := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+	past := pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	tests := []struct {
+		name    string
+		request *db.DataRequest
+		err     error
+	}{
+		{"pending", &db.DataRequest{Kind: models.DataRequestKindExport, Status: models.DataRequestStatusPending}, ErrExportNotReady},
+		{"erasure", &db.DataRequest{Kind: models.DataRequestKindErasure, Status: models.DataRequestStatusCompleted}, ErrExportNotReady},
+		{"deleted", &db.DataRequest{Kind: models.DataRequestKindExport, Status: models.DataRequestStatusCompleted, ExpiresAt: past}, ErrExportExpired},
+		{"expired", &db.DataRequest{Kind: models.DataRequestKindExport, Status: models.DataRequestStatusCompleted, ExpiresAt: past, Result: []byte(`{}`)}, ErrExportExpired},
+		{"completed", &db.DataRequest{Kind: models.DataRequestKindExport, Status: models.DataRequestStatusCompleted, ExpiresAt: future, Result: []byte(`{"auth_id":"user-1"}`)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, repo := setupDataRequestUsecase(t, nil)
+			tt.request.ID, tt.request.AuthID = "request-1", "user-1"
+			repo.EXPECT().GetDataRequest(mock.Anything, "request-1").Return(tt.request, nil).Once()
+
+			export, err := u.Download(userContext("user-1"), "request-1")
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", export.AuthID)
+		})
+	}
+}
+
+func TestDataRequestUsecase_Process(t *testing.T) {
+	t.Run("nothing pending", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, nil)
+		repo.EXPECT().ClaimDataRequest(mock.Anything, mock.Anything).Return(nil, pgx.ErrNoRows).Once()
+
+		processed, err := u.Process(context.Background())
+		require.NoError(t, err)
+		assert.False(t, processed)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, &testModule{data: map[string]string{"name": "john"}})
+		repo.EXPECT().ClaimDataRequest(mock.Anything, mock.Anything).
+			Return(&db.DataRequest{ID: "request-1", AuthID: "user-1", Kind: models.DataRequestKindExport}, nil).Once()
+		repo.EXPECT().CompleteDataRequest(mock.Anything, "request-1", mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, id string, result []byte, expiresAt time.Time) error {
+				var export personaldata.Export
+				require.NoError(t, json.Unmarshal(result, &export))
+				assert.Equal(t, "user-1", export.AuthID)
+				assert.JSONEq(t, `{"name":"john"}`, string(export.Modules["test"]))
+				assert.WithinDuration(t, time.Now().Add(defaultExportTTL), expiresAt, time.Minute)
+				return nil
+			}).Once()
+
+		processed, err := u.Process(context.Background())
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("erasure", func(t *testing.T) {
+		module := &testModule{}
+		u, repo := setupDataRequestUsecase(t, module)
+		mode := string(personaldata.ErasureDelete)
+		repo.EXPECT().ClaimDataRequest(mock.Anything, mock.Anything).
+			Return(&db.DataRequest{ID: "request-1", AuthID: "user-1", Kind: models.DataRequestKindErasure, ErasureMode: &mode}, nil).Once()
+		repo.EXPECT().CompleteDataRequest(mock.Anything, "request-1", []byte(nil), time.Time{}).Return(nil).Once()
+
+		processed, err := u.Process(context.Background())
+		require.NoError(t, err)
+		assert.True(t, processed)
+		assert.Equal(t, []personaldata.ErasureMode{personaldata.ErasureDelete}, module.erasedBy)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		u, repo := setupDataRequestUsecase(t, &testModule{err: errors.New("boom")})
+		repo.EXPECT().ClaimDataRequest(mock.Anything, mock.Anything).
+			Return(&db.DataRequest{ID: "request-1", AuthID: "user-1", Kind: models.DataRequestKindExport}, nil).Once()
+		repo.EXPECT().FailDataRequest(mock.Anything, "request-1", "failed to export module test: boom").Return(nil).Once()
+
+		processed, err := u.Process(context.Background())
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+}
@@ -0,0 +1,94 @@
+// Package authz carries the authenticated principal of a request in its context, so use cases
+// can check permissions themselves instead of relying on the route layer alone.
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnauthenticated is returned when ctx carries no principal
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned when the principal lacks a required permission
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Wildcard grants every permission. A permission ending in ":*", such as "cockroach:*",
+// grants every permission of its resource.
+const Wildcard = "*"
+
+// Principal is the authenticated user of a request and the permissions granted by their role
+type Principal struct {
+	UserID      string
+	Role        string
+	Permissions []string
+	// APIKey is set when the request authenticated with an API key, whose permissions are
+	// limited to its scopes
+	APIKey bool
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal carried by ctx, if any
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Has reports whether p is granted permission
+func (p Principal) Has(permission string) bool {
+	for _, granted := range p.Permissions {
+		if Grants(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Grants reports whether the granted permission covers permission, directly or by wildcard
+func Grants(granted string, permission string) bool {
+	if granted == Wildcard || granted == permission {
+		return true
+	}
+	if resource, ok := strings.CutSuffix(granted, ":*"); ok {
+		return strings.HasPrefix(permission, resource+":")
+	}
+	return false
+}
+
+// Restrict returns the scopes that granted covers, so a credential limited to scopes never gets
+// more than granted
+func Restrict(granted []string, scopes []string) []string {
+	p := Principal{Permissions: granted}
+	restricted := []string{}
+	for _, scope := range scopes {
+		if p.Has(scope) {
+			restricted = append(restricted, scope)
+		}
+	}
+	return restricted
+}
+
+// Require returns nil when the principal in ctx has every permission, ErrUnauthenticated when
+// ctx has no principal and ErrForbidden naming the first missing permission otherwise
+func Require(ctx context.Context, permissions ...string) error {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	for _, permission := range permissions {
+		if !p.Has(permission) {
+			return fmt.Errorf("%w: missing permission %s", ErrForbidden, permission)
+		}
+	}
+	return nil
+}
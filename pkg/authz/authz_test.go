@@ -0,0 +1,60 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrants(t *testing.T) {
+	tests := []struct {
+		granted    string
+		permission string
+		expected   bool
+	}{
+		{"*", "users:write", true},
+		{"users:read", "users:read", true},
+		{"users:read", "users:write", false},
+		{"cockroach:*", "cockroach:delete", true},
+		{"cockroach:*", "cockroaches:read", false},
+		{"users:*", "users", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Grants(tt.granted, tt.permission), "%s grants %s", tt.granted, tt.permission)
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	assert.Equal(t, []string{"cockroach:write", "users:read"},
+		Restrict([]string{"cockroach:*", "users:read"}, []string{"cockroach:write", "users:read", "users:write", "*"}))
+	assert.Equal(t, []string{"*"}, Restrict([]string{"*"}, []string{"*"}))
+	assert.Empty(t, Restrict(nil, []string{"users:read"}))
+}
+
+func TestRequire(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), Principal{
+		UserID:      "auth-1",
+		Role:        "staff",
+		Permissions: []string{"users:read", "cockroach:*"},
+	})
+
+	assert.NoError(t, Require(ctx, "users:read", "cockroach:write"))
+	assert.NoError(t, Require(ctx))
+
+	err := Require(ctx, "users:read", "users:write")
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.Contains(t, err.Error(), "users:write")
+
+	assert.ErrorIs(t, Require(context.Background(), "users:read"), ErrUnauthenticated)
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	p, ok := FromContext(WithPrincipal(context.Background(), Principal{UserID: "auth-1"}))
+	assert.True(t, ok)
+	assert.Equal(t, "auth-1", p.UserID)
+}
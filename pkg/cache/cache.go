@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache is a concurrency-safe in-memory map whose entries expire after a time-to-live.
+// Expired entries are never returned and are removed by DeleteExpired.
+type TTLCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]entry[V]
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// NewTTLCache creates a cache whose entries live for ttl unless set with SetWithTTL
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		entries: make(map[K]entry[V]),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Get returns the value for key if it is present and not expired
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value for the default time-to-live
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores value for ttl. A ttl of zero or less removes the key.
+func (c *TTLCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		delete(c.entries, key)
+		return
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: c.now().Add(ttl)}
+}
+
+// Delete removes key
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// DeleteExpired removes every expired entry and returns how many were removed
+func (c *TTLCache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	removed := 0
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Len returns the number of entries, including expired ones not yet removed
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
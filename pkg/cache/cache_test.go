@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCache returns a cache with a clock the test controls
+func newTestCache(ttl time.Duration) (*TTLCache[string, int], *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewTTLCache[string, int](ttl)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestTTLCache_GetSet(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+
+	_, ok := c.Get("missing")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func TestTTLCache_Expiry(t *testing.T) {
+	c, now := newTestCache(time.Minute)
+
+	c.Set("default", 1)
+	c.SetWithTTL("long", 2, time.Hour)
+
+	*now = now.Add(time.Minute)
+
+	_, ok := c.Get("default")
+	assert.False(t, ok, "entry expires once its ttl has passed")
+
+	value, ok := c.Get("long")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func TestTTLCache_SetWithNonPositiveTTLRemovesKey(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+
+	c.Set("a", 1)
+	c.SetWithTTL("a", 2, 0)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestTTLCache_Delete(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+
+	c.Set("a", 1)
+	c.Delete("a")
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestTTLCache_DeleteExpired(t *testing.T) {
+	c, now := newTestCache(time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.SetWithTTL("c", 3, time.Hour)
+
+	*now = now.Add(2 * time.Minute)
+
+	assert.Equal(t, 2, c.DeleteExpired())
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestTTLCache_ConcurrentAccess(t *testing.T) {
+	c := NewTTLCache[int, int](time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Set(i, i)
+			c.Get(i)
+			c.DeleteExpired()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, c.Len())
+}
@@ -53,6 +53,10 @@ func NewRequestContext(ginCtx *gin.Context) *RequestContext {
 		ctx = context.WithValue(ctx, UserClaimsKey, claims)
 	}
 
+	if role := ginCtx.GetString("user_role"); role != "" {
+		ctx = context.WithValue(ctx, UserRoleKey, role)
+	}
+
 	// Add IP address
 	ctx = context.WithValue(ctx, IPAddressKey, ginCtx.ClientIP())
 
@@ -76,6 +80,17 @@ func (rc *RequestContext) WithValue(key ContextKey, value interface{}) *RequestC
 	return rc
 }
 
+// WithRequestValues returns a copy of ctx carrying the request ID, user ID, IP address and user
+// agent of the request, for code that only receives the context of the request
+func (rc *RequestContext) WithRequestValues(ctx context.Context) context.Context {
+	for _, key := range []ContextKey{RequestIDKey, UserIDKey, IPAddressKey, UserAgentKey} {
+		if value := rc.GetStringValue(key); value != "" {
+			ctx = context.WithValue(ctx, key, value)
+		}
+	}
+	return ctx
+}
+
 // WithTimeout adds a timeout to the context
 func (rc *RequestContext) WithTimeout(timeout time.Duration) (*RequestContext, context.CancelFunc) {
 	ctx, cancel := context.WithTimeout(rc.ctx, timeout)
@@ -29,6 +29,7 @@ func TestNewRequestContext(t *testing.T) {
 	c.Set("request_id", "test-request-123")
 	c.Set("userID", "user-456")
 	c.Set("claims", map[string]interface{}{"role": "admin"})
+	c.Set("user_role", "admin")
 
 	rc := NewRequestContext(c)
 
@@ -40,6 +41,7 @@ func TestNewRequestContext(t *testing.T) {
 	assert.Equal(t, "test-request-123", rc.GetRequestID())
 	assert.Equal(t, "user-456", rc.GetUserID())
 	assert.NotNil(t, rc.GetUserClaims())
+	assert.Equal(t, "admin", rc.GetUserRole())
 	assert.NotEmpty(t, rc.GetIPAddress())
 	assert.NotZero(t, rc.GetStartTime())
 }
@@ -105,6 +107,26 @@ func TestRequestContext_WithCancel(t *testing.T) {
 	}
 }
 
+func TestRequestContext_WithRequestValues(t *testing.T) {
+	_, c, _ := setupTestGin()
+	c.Request.Header.Set("User-Agent", "test-agent")
+	c.Set("request_id", "test-request-123")
+	c.Set("userID", "user-456")
+	rc := NewRequestContext(c)
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := rc.WithRequestValues(parent)
+
+	assert.Equal(t, "test-request-123", ctx.Value(RequestIDKey))
+	assert.Equal(t, "user-456", ctx.Value(UserIDKey))
+	assert.Equal(t, rc.GetIPAddress(), ctx.Value(IPAddressKey))
+	assert.Equal(t, "test-agent", ctx.Value(UserAgentKey))
+
+	// The values are added to ctx, which keeps its cancellation
+	cancel()
+	assert.Equal(t, context.Canceled, ctx.Err())
+}
+
 func TestRequestContext_Getters(t *testing.T) {
 	_, c, _ := setupTestGin()
 	rc := NewRequestContext(c)
@@ -0,0 +1,220 @@
+// Package encryption encrypts secrets stored at rest with envelope encryption: each value is
+// sealed with AES-256-GCM under its own random data key, and the data key is sealed with a key
+// of a key ring, whose ID is stored next to it. Rotating keys means adding a key, making it
+// the active one and re-encrypting the stored values, after which the old key can be removed.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Prefix starts every encrypted value, values without it are plaintext stored before
+// encryption was enabled
+const Prefix = "enc:v1:"
+
+// KeySize is the size of the keys of a key ring and of the data keys, AES-256
+const KeySize = 32
+
+var (
+	ErrNoKeys         = errors.New("no encryption keys configured")
+	ErrUnknownKey     = errors.New("value is encrypted with an unknown key")
+	ErrInvalidValue   = errors.New("invalid encrypted value")
+	ErrInvalidKey     = errors.New("encryption keys must be 32 bytes encoded in base64")
+	ErrInvalidKeyID   = errors.New("encryption key ids must be 1 to 32 letters, digits or dashes")
+	ErrNoActiveKey    = errors.New("the active encryption key is not in the key ring")
+	ErrDuplicateKeyID = errors.New("duplicate encryption key id")
+)
+
+// keyIDPattern keeps key IDs free of the ":" separator and of LIKE wildcards, so stored values
+// can be matched by prefix in SQL
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,32}$`)
+
+var encoding = base64.RawURLEncoding
+
+// KeyRing encrypts values with its active key and decrypts values encrypted with any of its keys
+type KeyRing struct {
+	activeID string
+	keys     map[string]cipher.AEAD
+}
+
+// NewKeyRing returns a key ring of the 32 byte keys by ID, encrypting with the key activeID
+func NewKeyRing(keys map[string][]byte, activeID string) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	ring := &KeyRing{activeID: activeID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKeyID, id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		ring.keys[id] = aead
+	}
+	if _, ok := ring.keys[activeID]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoActiveKey, activeID)
+	}
+
+	return ring, nil
+}
+
+// ParseKeyRing parses keys given as comma separated "id:base64 key" pairs, e.g.
+// "2026-01:q83v...,2026-07:3q2+...". activeID may be empty when there is a single key.
+func ParseKeyRing(spec string, activeID string) (*KeyRing, error) {
+	keys := map[string][]byte{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: expected id:key", ErrInvalidKey)
+		}
+		id = strings.TrimSpace(id)
+		if _, exists := keys[id]; exists {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateKeyID, id)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, ErrInvalidKey)
+		}
+		keys[id] = key
+	}
+
+	activeID = strings.TrimSpace(activeID)
+	if activeID == "" && len(keys) == 1 {
+		for id := range keys {
+			activeID = id
+		}
+	}
+
+	return NewKeyRing(keys, activeID)
+}
+
+// ActiveKeyID returns the ID of the key new values are encrypted with
+func (k *KeyRing) ActiveKeyID() string {
+	return k.activeID
+}
+
+// ActivePrefix returns the start of the values encrypted with the active key
+func (k *KeyRing) ActivePrefix() string {
+	return Prefix + k.activeID + ":"
+}
+
+// Encrypt seals plaintext under a new data key wrapped by the active key
+func (k *KeyRing) Encrypt(plaintext string) (string, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	// The key ID is authenticated with the data key, so it cannot be swapped for another one
+	wrappedKey, err := seal(k.keys[k.activeID], dataKey, []byte(k.activeID))
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dataAEAD, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return k.ActivePrefix() + encoding.EncodeToString(wrappedKey) + ":" + encoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt opens a value returned by Encrypt. Plaintext values are returned as they are.
+func (k *KeyRing) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, Prefix), ":")
+	if len(parts) != 3 {
+		return "", ErrInvalidValue
+	}
+	keyID := parts[0]
+	keyAEAD, ok := k.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, keyID)
+	}
+	wrappedKey, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidValue
+	}
+	ciphertext, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrInvalidValue
+	}
+
+	dataKey, err := open(keyAEAD, wrappedKey, []byte(keyID))
+	if err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", ErrInvalidValue
+	}
+	plaintext, err := open(dataAEAD, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// IsCurrent reports whether value is encrypted with the active key
+func (k *KeyRing) IsCurrent(value string) bool {
+	return strings.HasPrefix(value, k.ActivePrefix())
+}
+
+// IsEncrypted reports whether value was returned by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal returns the random nonce followed by the sealed plaintext
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidValue
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrInvalidValue
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,116 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, KeySize))
+}
+
+func TestKeyRing_EncryptDecrypt(t *testing.T) {
+	ring, err := ParseKeyRing("k1:"+testKey(1), "")
+	require.NoError(t, err)
+	assert.Equal(t, "k1", ring.ActiveKeyID())
+
+	encrypted, err := ring.Encrypt("access-token")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:k1:"))
+	assert.NotContains(t, encrypted, "access-token")
+	assert.True(t, ring.IsCurrent(encrypted))
+
+	// Every value gets its own data key and nonce
+	again, err := ring.Encrypt("access-token")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again)
+
+	decrypted, err := ring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", decrypted)
+}
+
+func TestKeyRing_DecryptPlaintext(t *testing.T) {
+	ring, err := ParseKeyRing("k1:"+testKey(1), "")
+	require.NoError(t, err)
+
+	decrypted, err := ring.Decrypt("legacy-token")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", decrypted)
+	assert.False(t, ring.IsCurrent("legacy-token"))
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldRing, err := ParseKeyRing("k1:"+testKey(1), "")
+	require.NoError(t, err)
+	encrypted, err := oldRing.Encrypt("secret")
+	require.NoError(t, err)
+
+	ring, err := ParseKeyRing("k1:"+testKey(1)+", k2:"+testKey(2), "k2")
+	require.NoError(t, err)
+	assert.False(t, ring.IsCurrent(encrypted))
+
+	decrypted, err := ring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	reencrypted, err := ring.Encrypt(decrypted)
+	require.NoError(t, err)
+	assert.True(t, ring.IsCurrent(reencrypted))
+
+	// Once the old key is removed, its values cannot be read anymore
+	_, err = oldRing.Decrypt(reencrypted)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyRing_DecryptTampered(t *testing.T) {
+	ring, err := ParseKeyRing("k1:"+testKey(1)+",k2:"+testKey(2), "k1")
+	require.NoError(t, err)
+	encrypted, err := ring.Encrypt("secret")
+	require.NoError(t, err)
+
+	// Relabeling the value with another key of the ring is detected
+	_, err = ring.Decrypt(strings.Replace(encrypted, ":k1:", ":k2:", 1))
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	i := len(encrypted) - 5
+	flipped := byte('A')
+	if encrypted[i] == 'A' {
+		flipped = 'B'
+	}
+	_, err = ring.Decrypt(encrypted[:i] + string(flipped) + encrypted[i+1:])
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = ring.Decrypt("enc:v1:k1:garbage")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestParseKeyRing_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		activeID string
+		err      error
+	}{
+		{"empty", "", "", ErrNoKeys},
+		{"missing id", testKey(1), "", ErrInvalidKey},
+		{"short key", "k1:" + base64.StdEncoding.EncodeToString([]byte("short")), "", ErrInvalidKey},
+		{"not base64", "k1:not base64!", "", ErrInvalidKey},
+		{"invalid id", "k_1:" + testKey(1), "", ErrInvalidKeyID},
+		{"duplicate id", "k1:" + testKey(1) + ",k1:" + testKey(2), "k1", ErrDuplicateKeyID},
+		{"no active key with several keys", "k1:" + testKey(1) + ",k2:" + testKey(2), "", ErrNoActiveKey},
+		{"unknown active key", "k1:" + testKey(1), "k2", ErrNoActiveKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseKeyRing(tt.spec, tt.activeID)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams keep the tests fast, they are weaker than DefaultParams
+var testParams = Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHashAndVerify(t *testing.T) {
+	encoded, err := HashWithParams("S3cret!pass", testParams)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	ok, err := Verify("S3cret!pass", encoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("wrong", encoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestHash_UsesRandomSalt(t *testing.T) {
+	first, err := HashWithParams("S3cret!pass", testParams)
+	require.NoError(t, err)
+	second, err := HashWithParams("S3cret!pass", testParams)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestVerify_Bcrypt(t *testing.T) {
+	encoded, err := bcrypt.GenerateFromPassword([]byte("S3cret!pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ok, err := Verify("S3cret!pass", string(encoded))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("wrong", string(encoded))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.True(t, NeedsRehash(string(encoded)))
+}
+
+func TestVerify_InvalidHash(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"plaintext",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!$a2V5",
+	} {
+		_, err := Verify("S3cret!pass", encoded)
+		assert.ErrorIs(t, err, ErrInvalidHash, encoded)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	weak, err := HashWithParams("S3cret!pass", testParams)
+	require.NoError(t, err)
+	assert.True(t, NeedsRehash(weak))
+
+	current, err := Hash("S3cret!pass")
+	require.NoError(t, err)
+	assert.False(t, NeedsRehash(current))
+}
@@ -0,0 +1,181 @@
+// Package personaldata gathers what every module stores about a user, keyed by the auths.id of
+// the user, to answer data subject requests. Modules register an Exporter returning their data
+// and an Eraser deleting or anonymizing it.
+package personaldata
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErasureMode is how an erasure gets rid of the data of a user
+type ErasureMode string
+
+const (
+	// ErasureDelete deletes the data for good
+	ErasureDelete ErasureMode = "delete"
+	// ErasureAnonymize strips the data of what identifies the user and keeps the rest, e.g. for
+	// statistics or records that must be kept
+	ErasureAnonymize ErasureMode = "anonymize"
+)
+
+// Valid reports whether m is a known erasure mode
+func (m ErasureMode) Valid() bool {
+	return m == ErasureDelete || m == ErasureAnonymize
+}
+
+// Exporter returns the data a module stores about a user
+type Exporter interface {
+	// ExportPersonalData returns the data stored about authID, which is marshaled to JSON. It
+	// never contains secrets such as password hashes or tokens.
+	ExportPersonalData(ctx context.Context, authID string) (any, error)
+}
+
+// Eraser gets rid of the data a module stores about a user
+type Eraser interface {
+	// ErasePersonalData deletes or anonymizes the data stored about authID. Erasing data that
+	// is already gone is not an error, so a failed erasure can be run again.
+	ErasePersonalData(ctx context.Context, authID string, mode ErasureMode) error
+}
+
+// Export is the data of a user in every module, by module name
+type Export struct {
+	AuthID     string                     `json:"auth_id"`
+	ExportedAt time.Time                  `json:"exported_at"`
+	Modules    map[string]json.RawMessage `json:"modules"`
+}
+
+type registration struct {
+	name     string
+	exporter Exporter
+	eraser   Eraser
+}
+
+// Registry holds the exporters and erasers of the modules
+type Registry struct {
+	mu      sync.RWMutex
+	modules []registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the exporter and eraser of the module name, either may be nil. Erasers run in
+// the reverse order of registration, so modules whose data references the auth of a user are
+// erased before the module owning the auth. Registering a name twice panics.
+func (r *Registry) Register(name string, exporter Exporter, eraser Eraser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.modules {
+		if m.name == name {
+			panic(fmt.Sprintf("personaldata: module %q registered twice", name))
+		}
+	}
+	r.modules = append(r.modules, registration{name: name, exporter: exporter, eraser: eraser})
+}
+
+// Modules returns the names of the registered modules in order of registration
+func (r *Registry) Modules() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.modules))
+	for _, m := range r.modules {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// Export collects the data of authID from every exporter
+func (r *Registry) Export(ctx context.Context, authID string) (*Export, error) {
+	r.mu.RLock()
+	modules := append([]registration(nil), r.modules...)
+	r.mu.RUnlock()
+
+	export := &Export{AuthID: authID, ExportedAt: time.Now().UTC(), Modules: map[string]json.RawMessage{}}
+	for _, m := range modules {
+		if m.exporter == nil {
+			continue
+		}
+		data, err := m.exporter.ExportPersonalData(ctx, authID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export module %s: %w", m.name, err)
+		}
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal module %s: %w", m.name, err)
+		}
+		export.Modules[m.name] = raw
+	}
+	return export, nil
+}
+
+// Erase runs every eraser on authID, last registered first, stopping at the first failure
+func (r *Registry) Erase(ctx context.Context, authID string, mode ErasureMode) error {
+	if !mode.Valid() {
+		return fmt.Errorf("unknown erasure mode %q", mode)
+	}
+
+	r.mu.RLock()
+	modules := append([]registration(nil), r.modules...)
+	r.mu.RUnlock()
+
+	for i := len(modules) - 1; i >= 0; i-- {
+		if modules[i].eraser == nil {
+			continue
+		}
+		if err := modules[i].eraser.ErasePersonalData(ctx, authID, mode); err != nil {
+			return fmt.Errorf("failed to erase module %s: %w", modules[i].name, err)
+		}
+	}
+	return nil
+}
+
+// WriteZip writes e as a ZIP archive holding the data of each module in <module>.json and
+// the user and time of the export in export.json
+func (e *Export) WriteZip(w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	names := make([]string, 0, len(e.Modules))
+	for name := range e.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := struct {
+		AuthID     string    `json:"auth_id"`
+		ExportedAt time.Time `json:"exported_at"`
+		Modules    []string  `json:"modules"`
+	}{AuthID: e.AuthID, ExportedAt: e.ExportedAt, Modules: names}
+	if err := writeJSONFile(archive, "export.json", manifest, e.ExportedAt); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := writeJSONFile(archive, name+".json", e.Modules[name], e.ExportedAt); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func writeJSONFile(archive *zip.Writer, name string, value any, modified time.Time) error {
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
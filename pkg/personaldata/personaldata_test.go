@@ -0,0 +1,88 @@
+package personaldata
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeModule struct {
+	data   any
+	err    error
+	erased *[]string
+	name   string
+}
+
+func (m fakeModule) ExportPersonalData(ctx context.Context, authID string) (any, error) {
+	return m.data, m.err
+}
+
+func (m fakeModule) ErasePersonalData(ctx context.Context, authID string, mode ErasureMode) error {
+	*m.erased = append(*m.erased, m.name+":"+string(mode))
+	return m.err
+}
+
+func TestRegistry_Export(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("auth", fakeModule{data: map[string]string{"email": "john@example.com"}}, nil)
+	registry.Register("empty", nil, nil)
+	assert.Equal(t, []string{"auth", "empty"}, registry.Modules())
+
+	export, err := registry.Export(context.Background(), "auth-1")
+	require.NoError(t, err)
+	assert.Equal(t, "auth-1", export.AuthID)
+	assert.JSONEq(t, `{"email":"john@example.com"}`, string(export.Modules["auth"]))
+	assert.NotContains(t, export.Modules, "empty")
+
+	registry.Register("broken", fakeModule{err: errors.New("boom")}, nil)
+	_, err = registry.Export(context.Background(), "auth-1")
+	assert.ErrorContains(t, err, "broken")
+}
+
+func TestRegistry_Erase(t *testing.T) {
+	var erased []string
+	registry := NewRegistry()
+	registry.Register("auth", nil, fakeModule{name: "auth", erased: &erased})
+	registry.Register("privacy", nil, fakeModule{name: "privacy", erased: &erased})
+
+	// Modules referencing the auth are erased before the auth module
+	require.NoError(t, registry.Erase(context.Background(), "auth-1", ErasureAnonymize))
+	assert.Equal(t, []string{"privacy:anonymize", "auth:anonymize"}, erased)
+
+	assert.Error(t, registry.Erase(context.Background(), "auth-1", ErasureMode("shred")))
+
+	assert.Panics(t, func() { registry.Register("auth", nil, nil) })
+}
+
+func TestExport_WriteZip(t *testing.T) {
+	export := &Export{AuthID: "auth-1", Modules: map[string]json.RawMessage{
+		"auth":    json.RawMessage(`{"email":"john@example.com"}`),
+		"privacy": json.RawMessage(`[]`),
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteZip(&buf))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		files[file.Name] = string(content)
+	}
+
+	assert.Len(t, files, 3)
+	assert.Contains(t, files["export.json"], `"auth_id": "auth-1"`)
+	assert.JSONEq(t, `{"email":"john@example.com"}`, files["auth.json"])
+	assert.JSONEq(t, `[]`, files["privacy.json"])
+}
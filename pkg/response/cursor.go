@@ -0,0 +1,282 @@
+package response
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pkgErrors "template-golang/pkg/errors"
+)
+
+// Cursor pagination walks a list ordered newest first, by created_at then id, from a row to the
+// next ones (keyset pagination). Unlike offsets, pages stay fast deep into the list and do not
+// shift when rows are inserted in front of them.
+//
+// A cursor is the position of a row in a list and its filter, signed with HMAC-SHA256 so clients
+// can only hand back cursors this API gave them, to the list and filter they were given for. Lists query the first rows without a cursor, the rows after it,
+// or those before it for a prev cursor, fetching one more row than the limit to know whether
+// there is a further page. One query serves the three: the filter is written once, and each
+// direction is a branch of its own that only runs when backward says so. A nullable cursor
+// ORed into a single branch would keep generic plans from using the index, while the COALESCE
+// of the cursor to the top of the list still bounds the index scan:
+//
+//	-- name: ListThings :many
+//	WITH matching AS NOT MATERIALIZED (
+//	    SELECT * FROM things
+//	    WHERE <filter>
+//	)
+//	(
+//	    SELECT * FROM matching
+//	    WHERE NOT sqlc.arg('backward')::boolean
+//	      AND (created_at, id) < (COALESCE(sqlc.narg('cursor_created_at')::timestamptz, 'infinity'), COALESCE(sqlc.narg('cursor_id'), ''))
+//	    ORDER BY created_at DESC, id DESC
+//	    LIMIT sqlc.arg('limit')
+//	)
+//	UNION ALL
+//	(
+//	    SELECT * FROM (
+//	        SELECT * FROM matching
+//	        WHERE sqlc.arg('backward')::boolean
+//	          AND (created_at, id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id'))
+//	        ORDER BY created_at ASC, id ASC
+//	        LIMIT sqlc.arg('limit')
+//	    ) AS before_cursor
+//	)
+//	ORDER BY created_at DESC, id DESC;
+//
+// with an index on (created_at DESC, id DESC). CursorPage then turns the rows into the page and
+// its next and prev cursors.
+
+// MinCursorSecretLength is the minimum length of the secret signing the cursors
+const MinCursorSecretLength = 32
+
+var (
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrWeakCursorSecret = errors.New("cursor secret must be at least 32 bytes")
+)
+
+// CursorDirection tells which side of its position a cursor pages to
+type CursorDirection string
+
+const (
+	// CursorNext pages to the older rows after the position
+	CursorNext CursorDirection = "next"
+	// CursorPrev pages to the newer rows before the position
+	CursorPrev CursorDirection = "prev"
+)
+
+// Cursor is the position of a row in a list ordered by created_at DESC, id DESC
+type Cursor struct {
+	CreatedAt time.Time       `json:"t"`
+	ID        string          `json:"i"`
+	Direction CursorDirection `json:"d"`
+	// List names the list of the row
+	List string `json:"l"`
+	// Filter is the CursorFilterHash of the filter of the list
+	Filter string `json:"f"`
+}
+
+// CursorPagination is the page requested with the cursor and limit query parameters
+type CursorPagination struct {
+	// Cursor is nil for the first page
+	Cursor *Cursor
+	Limit  int
+	// List and Filter are those of the cursors of the page
+	List   string
+	Filter string
+}
+
+// cursorQuery is the query of a cursor paginated list
+type cursorQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+var (
+	cursorKeyMu sync.RWMutex
+	cursorKey   = randomCursorKey()
+)
+
+// randomCursorKey returns the key signing the cursors until SetCursorSecret is called. Its
+// cursors are not understood by other replicas or after a restart.
+func randomCursorKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// SetCursorSecret makes secret sign the cursors, which must be shared by every replica
+func SetCursorSecret(secret string) error {
+	if len(secret) < MinCursorSecretLength {
+		return ErrWeakCursorSecret
+	}
+
+	// Derive the key, so the secret can be shared with other uses
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("pagination-cursor"))
+
+	cursorKeyMu.Lock()
+	defer cursorKeyMu.Unlock()
+	cursorKey = mac.Sum(nil)
+	return nil
+}
+
+func signCursor(payload []byte) []byte {
+	cursorKeyMu.RLock()
+	defer cursorKeyMu.RUnlock()
+	mac := hmac.New(sha256.New, cursorKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// EncodeCursor returns the opaque token of cursor
+func EncodeCursor(cursor Cursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
+}
+
+// DecodeCursor returns the cursor of a token returned by EncodeCursor, or ErrInvalidCursor when
+// the token was not signed by this API
+func DecodeCursor(token string) (*Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signCursor(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Direction != CursorNext && cursor.Direction != CursorPrev {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// CursorFilterHash returns the hash of the filter of a list, which its cursors are bound to
+func CursorFilterHash(filter any) string {
+	payload, _ := json.Marshal(filter)
+	sum := sha256.Sum256(payload)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Backward reports whether the page is before the cursor
+func (p CursorPagination) Backward() bool {
+	return p.Cursor != nil && p.Cursor.Direction == CursorPrev
+}
+
+// FetchLimit is the number of rows to query, one more than the limit to know whether there is
+// a further page
+func (p CursorPagination) FetchLimit() int {
+	return p.Limit + 1
+}
+
+// GetCursorPaginationFromContext binds the cursor and limit query parameters of list, filtered
+// by filter. The limit defaults like GetPaginationFromContext; an invalid cursor, or one of
+// another list or filter, is a bad request.
+func GetCursorPaginationFromContext(c *gin.Context, list string, filter any) (CursorPagination, error) {
+	query := cursorQuery{Limit: DefaultPagination().Limit}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return CursorPagination{}, pkgErrors.BadRequest("Invalid query parameters: " + err.Error())
+	}
+
+	limits := PaginationRequest{Page: 1, Limit: query.Limit}
+	limits.ValidateAndDefault()
+	pagination := CursorPagination{Limit: limits.Limit, List: list, Filter: CursorFilterHash(filter)}
+
+	if query.Cursor != "" {
+		cursor, err := DecodeCursor(query.Cursor)
+		if err != nil || cursor.List != pagination.List || cursor.Filter != pagination.Filter {
+			return CursorPagination{}, pkgErrors.BadRequest("Invalid cursor")
+		}
+		pagination.Cursor = cursor
+	}
+
+	return pagination, nil
+}
+
+// CursorPage returns the page of rows queried for pagination, newest first, with the tokens of
+// its next and prev cursors, empty at either end of the list. position returns the created_at
+// and id of a row.
+func CursorPage[T any](rows []T, pagination CursorPagination, position func(T) (time.Time, string)) ([]T, string, string) {
+	hasMore := len(rows) > pagination.Limit
+
+	// The row past the limit is the newest one of a page before the cursor
+	backward := pagination.Backward()
+	if hasMore {
+		if backward {
+			rows = rows[len(rows)-pagination.Limit:]
+		} else {
+			rows = rows[:pagination.Limit]
+		}
+	}
+
+	encode := func(createdAt time.Time, id string, direction CursorDirection) string {
+		return EncodeCursor(Cursor{
+			CreatedAt: createdAt,
+			ID:        id,
+			Direction: direction,
+			List:      pagination.List,
+			Filter:    pagination.Filter,
+		})
+	}
+	cursor := func(row T, direction CursorDirection) string {
+		createdAt, id := position(row)
+		return encode(createdAt, id, direction)
+	}
+
+	if len(rows) == 0 {
+		// Nothing is left before the cursor, yet the list goes on from it
+		if backward {
+			return rows, encode(pagination.Cursor.CreatedAt, pagination.Cursor.ID, CursorNext), ""
+		}
+		return rows, "", ""
+	}
+
+	// Paging back from a cursor leaves at least its row after the page, paging on from one
+	// leaves at least its row before
+	var next, prev string
+	if hasMore || backward {
+		next = cursor(rows[len(rows)-1], CursorNext)
+	}
+	if backward && hasMore || !backward && pagination.Cursor != nil {
+		prev = cursor(rows[0], CursorPrev)
+	}
+
+	return rows, next, prev
+}
+
+// CursorPaginated sends a page of a cursor paginated list
+func CursorPaginated(c *gin.Context, data interface{}, pagination CursorPagination, next string, prev string) {
+	response := Response{
+		Success: true,
+		Data:    data,
+		Meta: &Meta{
+			Limit: pagination.Limit,
+			Next:  next,
+			Prev:  prev,
+		},
+		Timestamp: time.Now(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,269 @@
+package response
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cursorRow struct {
+	createdAt time.Time
+	id        int
+}
+
+func cursorRowPosition(row cursorRow) (time.Time, string) {
+	return row.createdAt, strconv.Itoa(row.id)
+}
+
+// cursorRows returns the rows with the ids, each created a minute before the previous one
+func cursorRows(ids ...int) []cursorRow {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rows := make([]cursorRow, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, cursorRow{createdAt: start.Add(-time.Duration(id) * time.Minute), id: id})
+	}
+	return rows
+}
+
+func TestEncodeCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.UTC), ID: "42", Direction: CursorNext}
+
+	decoded, err := DecodeCursor(EncodeCursor(cursor))
+
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, "42", decoded.ID)
+	assert.Equal(t, CursorNext, decoded.Direction)
+}
+
+func TestDecodeCursor_RejectsTamperedCursors(t *testing.T) {
+	token := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "42", Direction: CursorNext})
+	payload, signature, _ := strings.Cut(token, ".")
+	forged, _ := json.Marshal(Cursor{CreatedAt: time.Now(), ID: "1", Direction: CursorNext})
+
+	for name, token := range map[string]string{
+		"empty":             "",
+		"no signature":      payload,
+		"forged payload":    base64.RawURLEncoding.EncodeToString(forged) + "." + signature,
+		"invalid signature": payload + "." + base64.RawURLEncoding.EncodeToString([]byte("signature")),
+		"not base64":        "!!!." + signature,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeCursor(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestSetCursorSecret(t *testing.T) {
+	t.Cleanup(func() {
+		cursorKeyMu.Lock()
+		cursorKey = randomCursorKey()
+		cursorKeyMu.Unlock()
+	})
+
+	assert.ErrorIs(t, SetCursorSecret("short"), ErrWeakCursorSecret)
+
+	token := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "42", Direction: CursorNext})
+	require.NoError(t, SetCursorSecret(strings.Repeat("s", MinCursorSecretLength)))
+
+	_, err := DecodeCursor(token)
+	assert.ErrorIs(t, err, ErrInvalidCursor, "cursors signed with the previous key are refused")
+	_, err = DecodeCursor(EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "42", Direction: CursorNext}))
+	assert.NoError(t, err)
+}
+
+func TestGetCursorPaginationFromContext(t *testing.T) {
+	type thingFilter struct {
+		Kind string
+	}
+	filter := thingFilter{Kind: "a"}
+	cursor := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "42", Direction: CursorPrev, List: "things", Filter: CursorFilterHash(filter)})
+	otherList := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "42", Direction: CursorPrev, List: "others", Filter: CursorFilterHash(filter)})
+	otherFilter := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "42", Direction: CursorPrev, List: "things", Filter: CursorFilterHash(thingFilter{Kind: "b"})})
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedLimit int
+		expectedID    string
+		expectError   bool
+	}{
+		{"defaults", "", 10, "", false},
+		{"limit capped", "?limit=500", 100, "", false},
+		{"cursor", "?limit=5&cursor=" + cursor, 5, "42", false},
+		{"invalid cursor", "?cursor=abc.def", 0, "", true},
+		{"cursor of another list", "?cursor=" + otherList, 0, "", true},
+		{"cursor of another filter", "?cursor=" + otherFilter, 0, "", true},
+		{"invalid limit", "?limit=ten", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			pagination, err := GetCursorPaginationFromContext(c, "things", filter)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, pagination.Limit)
+			assert.Equal(t, "things", pagination.List)
+			assert.Equal(t, CursorFilterHash(filter), pagination.Filter)
+			if tt.expectedID == "" {
+				assert.Nil(t, pagination.Cursor)
+			} else {
+				require.NotNil(t, pagination.Cursor)
+				assert.Equal(t, tt.expectedID, pagination.Cursor.ID)
+				assert.True(t, pagination.Backward())
+			}
+		})
+	}
+}
+
+// pageIDs returns the ids of the page and decodes its cursors
+func pageIDs(t *testing.T, rows []cursorRow, next string, prev string) ([]int, *Cursor, *Cursor) {
+	ids := make([]int, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.id)
+	}
+
+	var nextCursor, prevCursor *Cursor
+	var err error
+	if next != "" {
+		nextCursor, err = DecodeCursor(next)
+		require.NoError(t, err)
+		assert.Equal(t, CursorNext, nextCursor.Direction)
+	}
+	if prev != "" {
+		prevCursor, err = DecodeCursor(prev)
+		require.NoError(t, err)
+		assert.Equal(t, CursorPrev, prevCursor.Direction)
+	}
+	return ids, nextCursor, prevCursor
+}
+
+func TestCursorPage(t *testing.T) {
+	t.Run("first page", func(t *testing.T) {
+		rows, next, prev := CursorPage(cursorRows(1, 2, 3), CursorPagination{Limit: 2}, cursorRowPosition)
+
+		ids, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		assert.Equal(t, []int{1, 2}, ids)
+		require.NotNil(t, nextCursor)
+		assert.Equal(t, "2", nextCursor.ID)
+		assert.Nil(t, prevCursor)
+	})
+
+	t.Run("cursors bound to the list", func(t *testing.T) {
+		pagination := CursorPagination{Limit: 1, Cursor: &Cursor{ID: "1", Direction: CursorNext}, List: "things", Filter: "hash"}
+
+		rows, next, prev := CursorPage(cursorRows(2, 3), pagination, cursorRowPosition)
+
+		_, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		require.NotNil(t, nextCursor)
+		require.NotNil(t, prevCursor)
+		for _, cursor := range []*Cursor{nextCursor, prevCursor} {
+			assert.Equal(t, "things", cursor.List)
+			assert.Equal(t, "hash", cursor.Filter)
+		}
+	})
+
+	t.Run("only page", func(t *testing.T) {
+		rows, next, prev := CursorPage(cursorRows(1, 2), CursorPagination{Limit: 2}, cursorRowPosition)
+
+		ids, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		assert.Equal(t, []int{1, 2}, ids)
+		assert.Nil(t, nextCursor)
+		assert.Nil(t, prevCursor)
+	})
+
+	t.Run("last page after a cursor", func(t *testing.T) {
+		pagination := CursorPagination{Limit: 2, Cursor: &Cursor{ID: "2", Direction: CursorNext}}
+
+		rows, next, prev := CursorPage(cursorRows(3), pagination, cursorRowPosition)
+
+		ids, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		assert.Equal(t, []int{3}, ids)
+		assert.Nil(t, nextCursor)
+		require.NotNil(t, prevCursor)
+		assert.Equal(t, "3", prevCursor.ID)
+	})
+
+	t.Run("page before a cursor", func(t *testing.T) {
+		pagination := CursorPagination{Limit: 2, Cursor: &Cursor{ID: "4", Direction: CursorPrev}}
+
+		// The newest row is past the limit
+		rows, next, prev := CursorPage(cursorRows(1, 2, 3), pagination, cursorRowPosition)
+
+		ids, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		assert.Equal(t, []int{2, 3}, ids)
+		require.NotNil(t, nextCursor)
+		assert.Equal(t, "3", nextCursor.ID)
+		require.NotNil(t, prevCursor)
+		assert.Equal(t, "2", prevCursor.ID)
+	})
+
+	t.Run("first page before a cursor", func(t *testing.T) {
+		pagination := CursorPagination{Limit: 2, Cursor: &Cursor{ID: "3", Direction: CursorPrev}}
+
+		rows, next, prev := CursorPage(cursorRows(1, 2), pagination, cursorRowPosition)
+
+		ids, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		assert.Equal(t, []int{1, 2}, ids)
+		require.NotNil(t, nextCursor)
+		assert.Nil(t, prevCursor)
+	})
+
+	t.Run("prev page empty", func(t *testing.T) {
+		createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		pagination := CursorPagination{Limit: 2, Cursor: &Cursor{CreatedAt: createdAt, ID: "1", Direction: CursorPrev}, List: "things"}
+
+		rows, next, prev := CursorPage([]cursorRow{}, pagination, cursorRowPosition)
+
+		ids, nextCursor, prevCursor := pageIDs(t, rows, next, prev)
+		assert.Empty(t, ids)
+		require.NotNil(t, nextCursor)
+		assert.Equal(t, "1", nextCursor.ID)
+		assert.True(t, createdAt.Equal(nextCursor.CreatedAt))
+		assert.Equal(t, "things", nextCursor.List)
+		assert.Nil(t, prevCursor)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		rows, next, prev := CursorPage([]cursorRow{}, CursorPagination{Limit: 2}, cursorRowPosition)
+
+		assert.Empty(t, rows)
+		assert.Empty(t, next)
+		assert.Empty(t, prev)
+	})
+}
+
+func TestCursorPaginated(t *testing.T) {
+	router, w := setupGin()
+	router.GET("/test", func(c *gin.Context) {
+		CursorPaginated(c, []string{"a", "b"}, CursorPagination{Limit: 2}, "next-cursor", "")
+	})
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, 2, response.Meta.Limit)
+	assert.Equal(t, "next-cursor", response.Meta.Next)
+	assert.Empty(t, response.Meta.Prev)
+	assert.Zero(t, response.Meta.Total)
+}
@@ -33,6 +33,9 @@ type Meta struct {
 	Limit      int `json:"limit,omitempty"`
 	Total      int `json:"total,omitempty"`
 	TotalPages int `json:"total_pages,omitempty"`
+	// Next and Prev are the cursors of the pages around a cursor paginated page
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
 }
 
 // PaginationRequest represents pagination parameters
@@ -1,11 +1,14 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"template-golang/config"
-	"template-golang/modules/auth"
-	"template-golang/modules/cockroach"
+	"template-golang/pkg/logger"
 	"time"
 
 	docs "template-golang/docs"
@@ -21,26 +24,28 @@ const (
 	apiV1Path = "/api/v1"
 )
 
-type Modules struct {
-	cockroach *cockroach.Cockroach
-	auth      *auth.Auth
+type namedCloser struct {
+	name   string
+	closer Closer
 }
 
 type ginServer struct {
-	router  *gin.Engine
-	conf    *config.Config
-	modules Modules
+	router     *gin.Engine
+	httpServer *http.Server
+	conf       *config.Config
+	modules    []Module
+
+	mu           sync.Mutex
+	closers      []namedCloser
+	shutdownOnce sync.Once
+	shutdownErr  error
 }
 
-func NewGin(
-	conf *config.Config,
-	cockroach *cockroach.Cockroach,
-	auth *auth.Auth,
-) Server {
+func NewGin(conf *config.Config, modules ...Module) Server {
 	// TODO: make it configurable
 	corsHandler := cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
@@ -48,20 +53,117 @@ func NewGin(
 	})
 
 	r := gin.Default()
+	// The client IP keys the login throttle, so X-Forwarded-For is only believed from the
+	// configured proxies
+	if err := r.SetTrustedProxies(trustedProxies(conf.Server.TrustedProxies)); err != nil {
+		panic(fmt.Errorf("invalid SERVER_TRUSTED_PROXIES: %w", err))
+	}
 
 	r.Use(corsHandler)
 
 	return &ginServer{
 		router: r,
-		conf:   conf,
-		modules: Modules{
-			cockroach: cockroach,
-			auth:      auth,
+		httpServer: &http.Server{
+			Addr:         fmt.Sprintf(":%d", conf.Server.Port),
+			Handler:      r,
+			ReadTimeout:  conf.Server.ReadTimeout,
+			WriteTimeout: conf.Server.WriteTimeout,
+			IdleTimeout:  conf.Server.IdleTimeout,
 		},
+		conf:    conf,
+		modules: modules,
+	}
+}
+
+// trustedProxies parses the comma separated proxies of SERVER_TRUSTED_PROXIES, nil for none
+func trustedProxies(value string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+func (s *ginServer) RegisterCloser(name string, closer Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closers = append(s.closers, namedCloser{name: name, closer: closer})
+}
+
+func (s *ginServer) Start(ctx context.Context) error {
+	s.initializeRoutes()
+
+	if err := s.startModules(ctx); err != nil {
+		return errors.Join(err, s.closeResources(context.Background()))
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Infof("HTTP server listening on %s", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	select {
+	case err, ok := <-serveErr:
+		if ok {
+			// The listener failed before any shutdown was requested; still release resources.
+			shutdownErr := s.closeResources(context.Background())
+			return errors.Join(fmt.Errorf("failed to start server: %w", err), shutdownErr)
+		}
+		return nil
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received, draining connections")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.conf.Server.ShutdownTimeout)
+	defer cancel()
+
+	return s.Shutdown(shutdownCtx)
+}
+
+func (s *ginServer) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		var errs []error
+
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to drain http server: %w", err))
+		}
+
+		errs = append(errs, s.closeResources(ctx))
+		s.shutdownErr = errors.Join(errs...)
+	})
+
+	return s.shutdownErr
+}
+
+// closeResources runs the registered closers in reverse registration order.
+func (s *ginServer) closeResources(ctx context.Context) error {
+	s.mu.Lock()
+	closers := s.closers
+	s.closers = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		c := closers[i]
+		if err := c.closer(ctx); err != nil {
+			logger.Errorf("Failed to close %s: %v", c.name, err)
+			errs = append(errs, fmt.Errorf("failed to close %s: %w", c.name, err))
+			continue
+		}
+		logger.Infof("Closed %s", c.name)
 	}
+
+	return errors.Join(errs...)
 }
 
-func (s *ginServer) Start() {
+func (s *ginServer) initializeRoutes() {
 	docs.SwaggerInfo.BasePath = apiV1Path
 
 	v1 := s.router.Group(apiV1Path)
@@ -70,18 +172,53 @@ func (s *ginServer) Start() {
 		c.String(http.StatusOK, "OK")
 	})
 
-	s.initializeCockroachHttpHandler()
+	for _, m := range s.modules {
+		moduleGroup := v1.Group("")
+		if mp, ok := m.(MiddlewareProvider); ok {
+			moduleGroup.Use(mp.Middlewares()...)
+		}
+		m.RegisterRoutes(moduleGroup)
 
-	s.modules.auth.Handler.Routes(v1)
+		if rp, ok := m.(RootRouteProvider); ok {
+			rp.RegisterRootRoutes(&s.router.RouterGroup)
+		}
+	}
 
 	if gin.Mode() == gin.DebugMode {
 		s.initSwagger()
 	}
 
-	serverUrl := fmt.Sprintf(":%d", s.conf.Server.Port)
+	s.logRoutes()
+}
+
+// startModules runs module start hooks in order and registers their stop hooks as closers,
+// so modules are stopped in reverse order before resources registered earlier are closed.
+func (s *ginServer) startModules(ctx context.Context) error {
+	for _, m := range s.modules {
+		if starter, ok := m.(Starter); ok {
+			if err := starter.Start(ctx); err != nil {
+				return fmt.Errorf("failed to start module %s: %w", m.Name(), err)
+			}
+			logger.Infof("Started module %s", m.Name())
+		}
+
+		if stopper, ok := m.(Stopper); ok {
+			s.RegisterCloser("module "+m.Name(), stopper.Stop)
+		}
+	}
+
+	return nil
+}
 
-	if err := s.router.Run(serverUrl); err != nil {
-		panic(fmt.Sprintf("Failed to start server: %v", err))
+func (s *ginServer) logRoutes() {
+	names := make([]string, 0, len(s.modules))
+	for _, m := range s.modules {
+		names = append(names, m.Name())
+	}
+	logger.Infof("Mounted modules %v under %s", names, apiV1Path)
+
+	for _, route := range s.router.Routes() {
+		logger.Infof("%-7s %s --> %s", route.Method, route.Path, route.Handler)
 	}
 }
 
@@ -101,9 +238,3 @@ func (s *ginServer) initSwagger() {
 	fmt.Printf("Swagger JSON URL: http://localhost:%d/swagger/doc.json\n", s.conf.Server.Port)
 	fmt.Println()
 }
-
-func (s *ginServer) initializeCockroachHttpHandler() {
-	v1 := s.router.Group(apiV1Path)
-	cockroachRouters := v1.Group("/cockroach")
-	cockroachRouters.POST("", s.modules.cockroach.Handler.DetectCockroach)
-}
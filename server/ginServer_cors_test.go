@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"template-golang/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGinServer_CORSPreflightAllowsPatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := NewGin(&config.Config{}).(*ginServer)
+
+	// PATCH /cockroach/:id and PATCH /auth/me are sent cross-origin by the web client
+	for _, path := range []string{"/api/v1/cockroach/1", "/api/v1/auth/me"} {
+		req := httptest.NewRequest(http.MethodOptions, path, nil)
+		req.Header.Set("Origin", "http://localhost:3000")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code, path)
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodPatch, path)
+		assert.Equal(t, "http://localhost:3000", w.Header().Get("Access-Control-Allow-Origin"), path)
+	}
+}
@@ -0,0 +1,217 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"template-golang/config"
+	"template-golang/server"
+	"template-golang/server/mocks"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// lifecycleModule combines the generated mocks into a module with start and stop hooks
+type lifecycleModule struct {
+	*mocks.MockModule
+	*mocks.MockStarter
+	*mocks.MockStopper
+}
+
+func setupTestServer(t *testing.T, modules ...server.Module) server.Server {
+	gin.SetMode(gin.TestMode)
+
+	conf := &config.Config{
+		Server: config.ServerConfig{
+			Port:            0,
+			ShutdownTimeout: 5 * time.Second,
+		},
+	}
+
+	return server.NewGin(conf, modules...)
+}
+
+func newMockModule(t *testing.T, name string, mounted *[]string) *mocks.MockModule {
+	m := mocks.NewMockModule(t)
+	m.EXPECT().Name().Return(name).Maybe()
+	m.EXPECT().RegisterRoutes(mock.Anything).Run(func(routerGroup *gin.RouterGroup) {
+		*mounted = append(*mounted, name+" "+routerGroup.BasePath())
+	}).Once()
+	return m
+}
+
+func TestGinServer_MountsModulesUnderAPIBasePathInOrder(t *testing.T) {
+	var mounted []string
+	s := setupTestServer(t,
+		newMockModule(t, "cockroach", &mounted),
+		newMockModule(t, "auth", &mounted),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, s.Start(ctx))
+	assert.Equal(t, []string{"cockroach /api/v1", "auth /api/v1"}, mounted)
+}
+
+func TestGinServer_StartAndStopHooks(t *testing.T) {
+	var mounted []string
+	first := lifecycleModule{
+		MockModule:  newMockModule(t, "first", &mounted),
+		MockStarter: mocks.NewMockStarter(t),
+		MockStopper: mocks.NewMockStopper(t),
+	}
+	second := lifecycleModule{
+		MockModule:  newMockModule(t, "second", &mounted),
+		MockStarter: mocks.NewMockStarter(t),
+		MockStopper: mocks.NewMockStopper(t),
+	}
+
+	var events []string
+	for _, m := range []lifecycleModule{first, second} {
+		name := m.MockModule.Name()
+		m.MockStarter.EXPECT().Start(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+			events = append(events, "start "+name)
+			return nil
+		}).Once()
+		m.MockStopper.EXPECT().Stop(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+			events = append(events, "stop "+name)
+			return nil
+		}).Once()
+	}
+
+	s := setupTestServer(t, first, second)
+	s.RegisterCloser("database", func(ctx context.Context) error {
+		events = append(events, "close database")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, s.Start(ctx))
+	assert.Equal(t, []string{
+		"start first",
+		"start second",
+		"stop second",
+		"stop first",
+		"close database",
+	}, events)
+}
+
+func TestGinServer_Start_FailsWhenModuleFailsToStart(t *testing.T) {
+	var mounted []string
+	m := lifecycleModule{
+		MockModule:  newMockModule(t, "broken", &mounted),
+		MockStarter: mocks.NewMockStarter(t),
+		MockStopper: mocks.NewMockStopper(t),
+	}
+	m.MockStarter.EXPECT().Start(mock.Anything).Return(errors.New("boom")).Once()
+
+	s := setupTestServer(t, m)
+
+	err := s.Start(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start module broken")
+}
+
+func TestGinServer_Shutdown_ClosesResourcesInReverseOrder(t *testing.T) {
+	s := setupTestServer(t)
+
+	var closed []string
+	for _, name := range []string{"logger", "database", "cache"} {
+		s.RegisterCloser(name, func(ctx context.Context) error {
+			closed = append(closed, name)
+			return nil
+		})
+	}
+
+	err := s.Shutdown(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cache", "database", "logger"}, closed)
+}
+
+func TestGinServer_Shutdown_ContinuesAfterCloserError(t *testing.T) {
+	s := setupTestServer(t)
+
+	loggerClosed := false
+	s.RegisterCloser("logger", func(ctx context.Context) error {
+		loggerClosed = true
+		return nil
+	})
+	s.RegisterCloser("database", func(ctx context.Context) error {
+		return errors.New("pool busy")
+	})
+
+	err := s.Shutdown(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to close database")
+	assert.True(t, loggerClosed)
+
+	// A second call returns the same result without closing twice
+	loggerClosed = false
+	assert.Equal(t, err, s.Shutdown(context.Background()))
+	assert.False(t, loggerClosed)
+}
+
+func TestGinServer_Start_ShutsDownWhenContextCancelled(t *testing.T) {
+	s := setupTestServer(t)
+
+	closed := make(chan struct{})
+	s.RegisterCloser("database", func(ctx context.Context) error {
+		close(closed)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Start(ctx)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop after context cancellation")
+	}
+
+	select {
+	case <-closed:
+	default:
+		t.Fatal("database closer was not called")
+	}
+}
+
+// rootRouteModule is a module that also serves routes from the server root
+type rootRouteModule struct {
+	*mocks.MockModule
+	*mocks.MockRootRouteProvider
+}
+
+func TestGinServer_MountsRootRoutesOutsideAPIBasePath(t *testing.T) {
+	var mounted []string
+	m := rootRouteModule{
+		MockModule:            newMockModule(t, "auth", &mounted),
+		MockRootRouteProvider: mocks.NewMockRootRouteProvider(t),
+	}
+	m.MockRootRouteProvider.EXPECT().RegisterRootRoutes(mock.Anything).Run(func(router *gin.RouterGroup) {
+		mounted = append(mounted, "root "+router.BasePath())
+	}).Once()
+
+	s := setupTestServer(t, m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, s.Start(ctx))
+	assert.Equal(t, []string{"auth /api/v1", "root /"}, mounted)
+}
@@ -5,6 +5,9 @@
 package mocks
 
 import (
+	"context"
+	"template-golang/server"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -35,35 +38,150 @@ func (_m *MockServer) EXPECT() *MockServer_Expecter {
 	return &MockServer_Expecter{mock: &_m.Mock}
 }
 
-// Start provides a mock function for the type MockServer
-func (_mock *MockServer) Start() {
-	_mock.Called()
+// RegisterCloser provides a mock function for the type MockServer
+func (_mock *MockServer) RegisterCloser(name string, closer server.Closer) {
+	_mock.Called(name, closer)
 	return
 }
 
+// MockServer_RegisterCloser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterCloser'
+type MockServer_RegisterCloser_Call struct {
+	*mock.Call
+}
+
+// RegisterCloser is a helper method to define mock.On call
+//   - name string
+//   - closer server.Closer
+func (_e *MockServer_Expecter) RegisterCloser(name interface{}, closer interface{}) *MockServer_RegisterCloser_Call {
+	return &MockServer_RegisterCloser_Call{Call: _e.mock.On("RegisterCloser", name, closer)}
+}
+
+func (_c *MockServer_RegisterCloser_Call) Run(run func(name string, closer server.Closer)) *MockServer_RegisterCloser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 server.Closer
+		if args[1] != nil {
+			arg1 = args[1].(server.Closer)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockServer_RegisterCloser_Call) Return() *MockServer_RegisterCloser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockServer_RegisterCloser_Call) RunAndReturn(run func(name string, closer server.Closer)) *MockServer_RegisterCloser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Shutdown provides a mock function for the type MockServer
+func (_mock *MockServer) Shutdown(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockServer_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockServer_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockServer_Expecter) Shutdown(ctx interface{}) *MockServer_Shutdown_Call {
+	return &MockServer_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MockServer_Shutdown_Call) Run(run func(ctx context.Context)) *MockServer_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockServer_Shutdown_Call) Return(err error) *MockServer_Shutdown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockServer_Shutdown_Call) RunAndReturn(run func(ctx context.Context) error) *MockServer_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function for the type MockServer
+func (_mock *MockServer) Start(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
 // MockServer_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
 type MockServer_Start_Call struct {
 	*mock.Call
 }
 
 // Start is a helper method to define mock.On call
-func (_e *MockServer_Expecter) Start() *MockServer_Start_Call {
-	return &MockServer_Start_Call{Call: _e.mock.On("Start")}
+//   - ctx context.Context
+func (_e *MockServer_Expecter) Start(ctx interface{}) *MockServer_Start_Call {
+	return &MockServer_Start_Call{Call: _e.mock.On("Start", ctx)}
 }
 
-func (_c *MockServer_Start_Call) Run(run func()) *MockServer_Start_Call {
+func (_c *MockServer_Start_Call) Run(run func(ctx context.Context)) *MockServer_Start_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockServer_Start_Call) Return() *MockServer_Start_Call {
-	_c.Call.Return()
+func (_c *MockServer_Start_Call) Return(err error) *MockServer_Start_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockServer_Start_Call) RunAndReturn(run func()) *MockServer_Start_Call {
-	_c.Run(run)
+func (_c *MockServer_Start_Call) RunAndReturn(run func(ctx context.Context) error) *MockServer_Start_Call {
+	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Module is a feature module mounted by the server under the API base path.
+type Module interface {
+	Name() string
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+}
+
+// MiddlewareProvider is implemented by modules whose routes need module-scoped middleware.
+type MiddlewareProvider interface {
+	Middlewares() []gin.HandlerFunc
+}
+
+// RootRouteProvider is implemented by modules that serve routes outside the API base path,
+// such as /.well-known documents. Module middlewares are not applied to them.
+type RootRouteProvider interface {
+	RegisterRootRoutes(router *gin.RouterGroup)
+}
+
+// Starter is implemented by modules that run background work while the server is up.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by modules that must release resources on shutdown.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
@@ -1,5 +1,15 @@
 package server
 
+import "context"
+
+// Closer releases a resource when the server shuts down.
+type Closer func(ctx context.Context) error
+
 type Server interface {
-	Start()
+	// Start serves HTTP until ctx is cancelled or the listener fails, then shuts down gracefully.
+	Start(ctx context.Context) error
+	// Shutdown drains in-flight requests and closes registered resources in reverse order.
+	Shutdown(ctx context.Context) error
+	// RegisterCloser registers a resource to be closed on shutdown.
+	RegisterCloser(name string, closer Closer)
 }
@@ -0,0 +1,46 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"template-golang/modules/auth/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware_AccountStatus_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+	ctx := context.Background()
+
+	email := "status@example.com"
+	user, err := authRepo.CreateAuth(ctx, &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+	tokens, err := jwtUsecase.IssueTokens(ctx, user.ID)
+	require.NoError(t, err)
+
+	w := serveJSON(t, router, "GET", "/api/v1/auth/example", tokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Deactivating the account without revoking its tokens still locks it out
+	_, err = authRepo.UpdateAuthActive(ctx, user.ID, false)
+	require.NoError(t, err)
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", tokens.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Account is disabled")
+
+	// Neither can it refresh its tokens
+	w = serveJSON(t, router, "POST", "/api/v1/auth/token/refresh", "", `{"refresh_token":"`+tokens.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+
+	_, err = authRepo.UpdateAuthActive(ctx, user.ID, true)
+	require.NoError(t, err)
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", tokens.AccessToken, "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	require.NoError(t, authRepo.SoftDeleteAuth(ctx, user.ID))
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", tokens.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
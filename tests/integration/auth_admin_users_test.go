@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"template-golang/modules/auth/models"
+	"template-golang/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_AdminUserManagement_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+	ctx := context.Background()
+
+	adminEmail := "admin-users@example.com"
+	admin, err := authRepo.CreateAuth(ctx, &adminEmail, nil, &adminEmail, string(models.RoleAdmin), true)
+	require.NoError(t, err)
+	adminToken, err := jwtUsecase.GenerateJWT(ctx, admin.ID)
+	require.NoError(t, err)
+
+	var users []string
+	for _, name := range []string{"first", "second", "third"} {
+		email := name + "@example.com"
+		user, err := authRepo.CreateAuth(ctx, &email, nil, &email, string(models.RoleUser), true)
+		require.NoError(t, err)
+		users = append(users, user.ID)
+	}
+
+	// Users are listed newest first page by page without their password hashes
+	type usersPage struct {
+		Data []models.AdminUser `json:"data"`
+		Meta response.Meta      `json:"meta"`
+	}
+	listUsers := func(query string) usersPage {
+		w := serveJSON(t, router, "GET", "/api/v1/admin/auth/users"+query, adminToken, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), `"password"`)
+		var page usersPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return page
+	}
+
+	page := listUsers("?role=user&limit=2")
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, []string{users[2], users[1]}, []string{page.Data[0].ID, page.Data[1].ID})
+	assert.Empty(t, page.Meta.Prev)
+	require.NotEmpty(t, page.Meta.Next)
+
+	// A user created meanwhile does not shift the next page
+	laterEmail := "later@example.com"
+	_, err = authRepo.CreateAuth(ctx, &laterEmail, nil, &laterEmail, string(models.RoleUser), true)
+	require.NoError(t, err)
+
+	page = listUsers("?role=user&limit=2&cursor=" + page.Meta.Next)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, users[0], page.Data[0].ID)
+	assert.Empty(t, page.Meta.Next)
+	require.NotEmpty(t, page.Meta.Prev)
+
+	page = listUsers("?role=user&limit=2&cursor=" + page.Meta.Prev)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, []string{users[2], users[1]}, []string{page.Data[0].ID, page.Data[1].ID})
+	assert.NotEmpty(t, page.Meta.Prev, "the user created meanwhile is before the page")
+
+	w := serveJSON(t, router, "GET", "/api/v1/admin/auth/users?cursor=forged", adminToken, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	// Change the role, deactivate, delete and restore a user
+	target := users[0]
+	userToken, err := jwtUsecase.IssueTokens(ctx, target)
+	require.NoError(t, err)
+
+	waitForNextSecond()
+	w = serveJSON(t, router, "PUT", "/api/v1/admin/auth/users/"+target+"/role", adminToken, `{"role":"staff"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	auth, err := authRepo.GetAuthByID(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.RoleStaff), auth.Role)
+
+	// Tokens issued with the old role stop working
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", userToken.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serveJSON(t, router, "POST", "/api/v1/admin/auth/users/"+target+"/deactivate", adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/users?active=false", adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), target)
+
+	w = serveJSON(t, router, "DELETE", "/api/v1/admin/auth/users/"+target, adminToken, "")
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/users/"+target, adminToken, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/users?deleted=true", adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), target)
+
+	w = serveJSON(t, router, "POST", "/api/v1/admin/auth/users/"+target+"/restore", adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/users/"+target, adminToken, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Admins cannot lock themselves out
+	w = serveJSON(t, router, "DELETE", "/api/v1/admin/auth/users/"+admin.ID, adminToken, "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Staff may list users but not change them
+	w = serveJSON(t, router, "POST", "/api/v1/admin/auth/users/"+target+"/activate", adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	staffToken, err := jwtUsecase.GenerateJWT(ctx, target)
+	require.NoError(t, err)
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/users", staffToken, "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveJSON(t, router, "POST", "/api/v1/admin/auth/users/"+users[1]+"/deactivate", staffToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"template-golang/modules/auth/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_APIKeys_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+	ctx := context.Background()
+
+	adminEmail := "admin-keys@example.com"
+	admin, err := authRepo.CreateAuth(ctx, &adminEmail, nil, &adminEmail, string(models.RoleAdmin), true)
+	require.NoError(t, err)
+	adminToken, err := jwtUsecase.GenerateJWT(ctx, admin.ID)
+	require.NoError(t, err)
+
+	serveAPIKey := func(method string, path string, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(models.APIKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// An admin creates a key for themselves that can only read users
+	w := serveJSON(t, router, "POST", "/api/v1/admin/auth/api-keys", adminToken,
+		`{"auth_id":"`+admin.ID+`","name":"reporting","scopes":["users:read"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created struct {
+		Data models.CreatedAPIKey `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Data.Key)
+
+	// The key authenticates as the admin within its scopes, and only on routes checking permissions
+	w = serveAPIKey("GET", "/api/v1/auth/example", created.Data.Key)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	w = serveAPIKey("GET", "/api/v1/auth/me", created.Data.Key)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	w = serveAPIKey("GET", "/api/v1/admin/auth/users", created.Data.Key)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveAPIKey("POST", "/api/v1/admin/auth/users/"+admin.ID+"/deactivate", created.Data.Key)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	req := httptest.NewRequest("GET", "/api/v1/admin/auth/users", nil)
+	req.Header.Set("Authorization", "ApiKey "+created.Data.Key)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The key is listed without its secret, with its last use
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/api-keys?auth_id="+admin.ID, adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), created.Data.Prefix)
+	assert.Contains(t, w.Body.String(), `"last_used_at"`)
+	assert.NotContains(t, w.Body.String(), created.Data.Key)
+
+	// Revoked keys stop working
+	w = serveJSON(t, router, "DELETE", "/api/v1/admin/auth/api-keys/"+created.Data.ID, adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveAPIKey("GET", "/api/v1/admin/auth/users", created.Data.Key)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Unknown keys are rejected
+	w = serveAPIKey("GET", "/api/v1/admin/auth/users", created.Data.Prefix+".wrong")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"template-golang/modules/auth/models"
+	"template-golang/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_AuditLog_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+	ctx := context.Background()
+
+	adminEmail := "audit-admin@example.com"
+	admin, err := authRepo.CreateAuth(ctx, &adminEmail, nil, &adminEmail, string(models.RoleAdmin), true)
+	require.NoError(t, err)
+	adminToken, err := jwtUsecase.GenerateJWT(ctx, admin.ID)
+	require.NoError(t, err)
+
+	w := serveJSON(t, router, "POST", "/api/v1/auth/register", "", `{"username":"audited_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"audited_user","password":"wrong"}`)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"audited_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	user, err := authRepo.GetAuthByUsername(ctx, "audited_user")
+	require.NoError(t, err)
+	w = serveJSON(t, router, "PUT", "/api/v1/admin/auth/users/"+user.ID+"/role", adminToken, `{"role":"staff"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	listEvents := func(query url.Values) []models.AuditEventResponse {
+		w := serveJSON(t, router, "GET", "/api/v1/admin/auth/audit-events?"+query.Encode(), adminToken, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var page struct {
+			Data []models.AuditEventResponse `json:"data"`
+			Meta response.Meta               `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, len(page.Data), page.Meta.Total)
+		return page.Data
+	}
+
+	// The failed login names no actor, the successful one names the user
+	logins := listEvents(url.Values{"action": {models.AuditActionPasswordLogin}})
+	require.Len(t, logins, 2)
+	assert.Equal(t, models.AuditOutcomeSuccess, logins[0].Outcome)
+	assert.Equal(t, user.ID, logins[0].ActorID)
+	assert.NotEmpty(t, logins[0].IPAddress)
+	assert.Equal(t, models.AuditOutcomeFailure, logins[1].Outcome)
+	assert.Empty(t, logins[1].ActorID)
+	assert.JSONEq(t, `{"username":"audited_user","reason":"invalid credentials"}`, string(logins[1].Metadata))
+
+	// The role change names the admin and the user
+	changes := listEvents(url.Values{"actor_id": {admin.ID}})
+	require.Len(t, changes, 1)
+	assert.Equal(t, models.AuditActionUserRoleChange, changes[0].Action)
+	assert.Equal(t, user.ID, changes[0].TargetID)
+	assert.JSONEq(t, `{"role":"staff"}`, string(changes[0].Metadata))
+
+	failures := listEvents(url.Values{"outcome": {models.AuditOutcomeFailure}})
+	assert.Len(t, failures, 1)
+
+	// Only admins read the audit log
+	userTokens, err := jwtUsecase.IssueTokens(ctx, user.ID)
+	require.NoError(t, err)
+	w = serveJSON(t, router, "GET", "/api/v1/admin/auth/audit-events", userTokens.AccessToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"template-golang/database"
 	"template-golang/modules/auth/handlers"
 	"template-golang/modules/auth/middlewares"
 	"template-golang/modules/auth/repositories"
@@ -32,12 +33,16 @@ func TestAuthHandler_AuthCallback_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
@@ -66,7 +71,7 @@ func TestAuthHandler_AuthCallback_Integration(t *testing.T) {
 			name:           "AuthCallback with invalid provider",
 			provider:       "invalid",
 			queryParams:    map[string]string{},
-			expectedStatus: http.StatusUnauthorized, // Gothic will fail for unknown provider
+			expectedStatus: http.StatusNotFound, // Provider is not configured
 			expectError:    true,
 		},
 		{
@@ -105,6 +110,8 @@ func TestAuthHandler_AuthCallback_Integration(t *testing.T) {
 			if tt.name == "AuthCallback without provider" {
 				// The route pattern matches but provider is empty, so handler returns 400
 				assert.Equal(t, http.StatusBadRequest, w.Code)
+			} else if tt.name == "AuthCallback with invalid provider" {
+				assert.Equal(t, tt.expectedStatus, w.Code)
 			} else {
 				// For other cases, we expect either unauthorized or some error from Gothic
 				// since we don't have proper OAuth setup
@@ -130,12 +137,16 @@ func TestAuthHandler_AuthCallback_MissingProvider_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router with test route that matches the handler's expected behavior
 	gin.SetMode(gin.TestMode)
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthCodeUsecase_Integration(t *testing.T) {
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration
+	conf := SetupTestConfig(t)
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	// Setup dependencies
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	authCodeRepo := repositories.NewAuthCodeRepository(queries)
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	authCodeUsecase := usecases.NewAuthCodeUsecase(conf, jwtUsecase, authCodeRepo)
+
+	ctx := context.Background()
+	email := "code@example.com"
+	auth, err := authRepo.CreateAuth(ctx, &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+
+	t.Run("exchanges once", func(t *testing.T) {
+		code, err := authCodeUsecase.Issue(ctx, auth.ID, TestCodeChallenge)
+		require.NoError(t, err)
+
+		tokens, err := authCodeUsecase.Exchange(ctx, code, TestCodeVerifier)
+		require.NoError(t, err)
+		result, err := jwtUsecase.ValidateJWT(ctx, tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.ID, result.UserID)
+
+		_, err = authCodeUsecase.Exchange(ctx, code, TestCodeVerifier)
+		assert.ErrorIs(t, err, usecases.ErrInvalidAuthCode)
+	})
+
+	t.Run("wrong verifier burns the code", func(t *testing.T) {
+		code, err := authCodeUsecase.Issue(ctx, auth.ID, TestCodeChallenge)
+		require.NoError(t, err)
+
+		_, err = authCodeUsecase.Exchange(ctx, code, "x"+TestCodeVerifier[1:])
+		assert.ErrorIs(t, err, usecases.ErrInvalidAuthCode)
+
+		_, err = authCodeUsecase.Exchange(ctx, code, TestCodeVerifier)
+		assert.ErrorIs(t, err, usecases.ErrInvalidAuthCode)
+	})
+
+	t.Run("expired codes cannot be consumed and are cleaned up", func(t *testing.T) {
+		_, err := authCodeRepo.CreateAuthCode(ctx, auth.ID, "expired-code-hash", TestCodeChallenge, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		_, err = authCodeRepo.ConsumeAuthCode(ctx, "expired-code-hash")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		// The expired code and the used codes of the subtests above
+		deleted, err := authCodeRepo.DeleteExpiredAuthCodes(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, deleted)
+	})
+}
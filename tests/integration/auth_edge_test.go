@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"template-golang/database"
 	"template-golang/modules/auth/handlers"
 	"template-golang/modules/auth/middlewares"
 	"template-golang/modules/auth/repositories"
@@ -32,12 +33,16 @@ func TestAuthHandler_EdgeCases_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
@@ -173,12 +178,16 @@ func TestAuthHandler_ConcurrentRequests_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
@@ -194,7 +203,7 @@ func TestAuthHandler_ConcurrentRequests_Integration(t *testing.T) {
 			go func(id int) {
 				defer func() { done <- true }()
 
-				req, err := http.NewRequest("GET", "/api/v1/auth/line/login", nil)
+				req, err := http.NewRequest("GET", "/api/v1/auth/line/login"+TestPKCEQuery, nil)
 				require.NoError(t, err)
 
 				w := httptest.NewRecorder()
@@ -1,13 +1,16 @@
 package integration
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"template-golang/database"
 	"template-golang/modules/auth/handlers"
 	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
 	"template-golang/modules/auth/repositories"
 	"template-golang/modules/auth/usecases"
 
@@ -31,12 +34,16 @@ func TestAuthHandler_Example_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
@@ -104,20 +111,26 @@ func TestAuthHandler_Example_WithValidJWT_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Generate a valid JWT token for testing
-	// First create a test user in the database if needed
+	// First create a test user in the database
 	// Then generate JWT for that user
-	testUserID := "test-user-123"
+	email := "example@example.com"
+	testUser, err := authRepo.CreateAuth(context.Background(), &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
 
 	// Generate JWT token
-	validToken, err := jwtUsecase.GenerateJWT(testUserID)
+	validToken, err := jwtUsecase.GenerateJWT(context.Background(), testUser.ID)
 	if err != nil {
 		t.Skipf("Could not generate JWT token for testing: %v", err)
 		return
@@ -148,3 +161,73 @@ func TestAuthHandler_Example_WithValidJWT_Integration(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "example")
 	}
 }
+
+func TestAuthHandler_AdminUsers_RoleFromJWT_Integration(t *testing.T) {
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration
+	conf := SetupTestConfig(t)
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	// Setup dependencies
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	// Create auth handler
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+	authHandler.Routes(api)
+
+	tests := []struct {
+		name           string
+		role           models.Role
+		expectedStatus int
+	}{
+		{
+			name:           "admin can list users",
+			role:           models.RoleAdmin,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "user is forbidden",
+			role:           models.RoleUser,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := string(tt.role) + "@example.com"
+			user, err := authRepo.CreateAuth(context.Background(), &email, nil, &email, string(tt.role), true)
+			require.NoError(t, err)
+
+			token, err := jwtUsecase.GenerateJWT(context.Background(), user.ID)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("GET", "/api/v1/admin/auth/users", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
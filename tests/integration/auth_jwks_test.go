@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	"template-golang/modules/auth/handlers"
+	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+	"template-golang/pkg/encryption"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_JWKSAndKeyRotation_Integration(t *testing.T) {
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration
+	conf := SetupTestConfig(t)
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	// Setup dependencies with keys stored in the database
+	txManager := database.NewTxManager(pool, conf)
+	keyRing := SetupTestKeyRing(t)
+	authRepo := repositories.NewAuthRepository(queries, keyRing)
+	keySet := usecases.NewKeySet(conf, repositories.NewSigningKeyRepository(queries, keyRing), txManager)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), txManager)
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	// Create auth handler
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, keyRing), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	authHandler.WellKnownRoutes(&router.RouterGroup)
+
+	ctx := context.Background()
+	require.NoError(t, keySet.Refresh(ctx))
+
+	email := "jwks@example.com"
+	user, err := authRepo.CreateAuth(ctx, &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+
+	// Sign a token with the first database key
+	first, err := keySet.Rotate(ctx)
+	require.NoError(t, err)
+
+	oldToken, err := jwtUsecase.GenerateJWT(ctx, user.ID)
+	require.NoError(t, err)
+
+	// Rotate again: new tokens use the new key, the old token still verifies
+	second, err := keySet.Rotate(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Kid, second.Kid)
+
+	// The private keys are stored encrypted
+	stored, err := queries.ListSigningKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	for _, key := range stored {
+		assert.True(t, encryption.IsEncrypted(key.PrivateKey))
+		assert.NotContains(t, key.PrivateKey, "PRIVATE KEY")
+	}
+
+	active, err := keySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, second.Kid, active.Kid)
+
+	result, err := jwtUsecase.ValidateJWT(ctx, oldToken)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	// Both database keys are published
+	req, err := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var jwks models.JWKS
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+
+	kids := make(map[string]bool)
+	for _, key := range jwks.Keys {
+		kids[key.Kid] = true
+		assert.Equal(t, "EC", key.Kty)
+		assert.Equal(t, "ES256", key.Alg)
+	}
+	assert.True(t, kids[first.Kid])
+	assert.True(t, kids[second.Kid])
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"template-golang/modules/auth/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_LinkProvider_Integration(t *testing.T) {
+	router, authRepo, _ := setupOIDCRouter(t, "fake-subject-link", "linked@example.com", models.TokenDeliveryCode)
+
+	// A local account links the fake provider
+	w := serveJSON(t, router, "POST", "/api/v1/auth/register", "",
+		`{"username":"link_user","email":"link@example.com","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var tokens models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+
+	w = serveJSON(t, router, "POST", "/api/v1/auth/fake/link", tokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	sessionCookies := w.Result().Cookies()
+
+	var link models.LinkProviderResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &link))
+
+	// The issuer approves and the callback links instead of logging in
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirect.Get(link.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", callback.RequestURI(), nil)
+	for _, cookie := range sessionCookies {
+		req.AddCookie(cookie)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	assert.Equal(t, "http://localhost:3000/auth/callback?linked=fake", w.Header().Get("Location"))
+
+	auth, err := authRepo.GetAuthByEmail(context.Background(), "link@example.com")
+	require.NoError(t, err)
+	authMethod, err := authRepo.GetAuthMethodByProviderAndID(context.Background(), "fake", "fake-subject-link")
+	require.NoError(t, err)
+	require.NotNil(t, authMethod.AuthID)
+	assert.Equal(t, auth.ID, *authMethod.AuthID)
+
+	// The linked provider is listed without its tokens
+	w = serveJSON(t, router, "GET", "/api/v1/auth/providers", tokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"provider":"fake"`)
+	assert.NotContains(t, w.Body.String(), "fake-access-token")
+
+	// Unlinking works once, the password still logs in
+	w = serveJSON(t, router, "DELETE", "/api/v1/auth/fake/link", tokens.AccessToken, "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveJSON(t, router, "DELETE", "/api/v1/auth/fake/link", tokens.AccessToken, "")
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+	// Linking needs an authenticated user
+	w = serveJSON(t, router, "POST", "/api/v1/auth/fake/link", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"template-golang/database"
 	"template-golang/modules/auth/handlers"
 	"template-golang/modules/auth/middlewares"
 	"template-golang/modules/auth/repositories"
@@ -31,12 +32,16 @@ func TestAuthHandler_Login_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
@@ -59,8 +64,8 @@ func TestAuthHandler_Login_Integration(t *testing.T) {
 		{
 			name:           "Login with invalid provider",
 			provider:       "invalid",
-			expectedStatus: http.StatusTemporaryRedirect, // Gothic might still redirect for unknown providers
-			expectRedirect: true,
+			expectedStatus: http.StatusNotFound, // Provider is not configured
+			expectRedirect: false,
 		},
 		{
 			name:           "Login without provider",
@@ -76,7 +81,7 @@ func TestAuthHandler_Login_Integration(t *testing.T) {
 			if tt.provider == "" {
 				url = "/api/v1/auth//login" // Invalid URL pattern
 			} else {
-				url = "/api/v1/auth/" + tt.provider + "/login"
+				url = "/api/v1/auth/" + tt.provider + "/login" + TestPKCEQuery
 			}
 
 			req, err := http.NewRequest("GET", url, nil)
@@ -88,6 +93,8 @@ func TestAuthHandler_Login_Integration(t *testing.T) {
 			if tt.name == "Login without provider" {
 				// The route pattern matches but provider is empty, so handler returns 400
 				assert.Equal(t, http.StatusBadRequest, w.Code)
+			} else if tt.name == "Login with invalid provider" {
+				assert.Equal(t, tt.expectedStatus, w.Code)
 			} else {
 				// For valid providers, Gothic will try to redirect to OAuth provider
 				// We expect either a redirect (302/307) or an error from Gothic due to missing session store
@@ -115,12 +122,16 @@ func TestAuthHandler_Login_MissingProvider_Integration(t *testing.T) {
 	queries := CreateTestDatabase(t, pool)
 
 	// Setup dependencies
-	authRepo := repositories.NewAuthRepository(queries)
-	jwtUsecase := usecases.NewJWTUsecase(conf, authRepo)
-	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
 
 	// Create auth handler
-	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, conf, authMiddleware, authRepo)
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
 
 	// Setup Gin router with test route that matches the handler's expected behavior
 	gin.SetMode(gin.TestMode)
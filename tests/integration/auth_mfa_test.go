@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"template-golang/modules/auth/models"
+	"template-golang/pkg/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_MFA_Integration(t *testing.T) {
+	router, _, _ := setupRevocationRouter(t)
+
+	w := serveJSON(t, router, "POST", "/api/v1/auth/register", "",
+		`{"username":"mfa_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var registered models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registered))
+
+	// Enrol and confirm with a code of the secret
+	w = serveJSON(t, router, "POST", "/api/v1/auth/mfa/enroll", registered.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var enrollment models.MFAEnrollment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &enrollment))
+	assert.Contains(t, enrollment.URI, "otpauth://totp/")
+
+	code, err := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	w = serveJSON(t, router, "POST", "/api/v1/auth/mfa/confirm", registered.AccessToken, `{"code":"`+code+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var confirmation models.MFAConfirmation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &confirmation))
+	require.NotEmpty(t, confirmation.RecoveryCodes)
+
+	// Logins now return an mfa_pending token without a refresh token
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"mfa_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var pending models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pending))
+	assert.True(t, pending.MFARequired)
+	assert.Empty(t, pending.RefreshToken)
+
+	// The pending token only opens the MFA routes
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", pending.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// The code used to confirm cannot be replayed
+	w = serveJSON(t, router, "POST", "/api/v1/auth/mfa/verify", pending.AccessToken, `{"code":"`+code+`"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	// A recovery code completes the login once
+	w = serveJSON(t, router, "POST", "/api/v1/auth/mfa/verify", pending.AccessToken, `{"code":"`+confirmation.RecoveryCodes[0]+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var verified models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verified))
+	assert.NotEmpty(t, verified.RefreshToken)
+	assert.False(t, verified.MFARequired)
+
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", verified.AccessToken, "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The pending token was revoked by the verification
+	w = serveJSON(t, router, "POST", "/api/v1/auth/mfa/verify", pending.AccessToken, `{"code":"`+confirmation.RecoveryCodes[1]+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// A used recovery code does not work for the next login
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"mfa_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pending))
+
+	w = serveJSON(t, router, "POST", "/api/v1/auth/mfa/verify", pending.AccessToken, `{"code":"`+confirmation.RecoveryCodes[0]+`"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Disabling with another recovery code brings back plain logins
+	w = serveJSON(t, router, "DELETE", "/api/v1/auth/mfa", verified.AccessToken, `{"code":"`+confirmation.RecoveryCodes[2]+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"mfa_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var plain models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &plain))
+	assert.False(t, plain.MFARequired)
+	assert.NotEmpty(t, plain.RefreshToken)
+}
@@ -0,0 +1,239 @@
+package integration
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	"template-golang/modules/auth/handlers"
+	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeOIDCClientID = "fake-client"
+
+// newFakeOIDCServer starts an OpenID Connect issuer that approves every authorization request
+// and issues an id_token for subject
+func newFakeOIDCServer(t *testing.T, subject string, email string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+		})
+	})
+
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		callback, err := url.Parse(r.URL.Query().Get("redirect_uri"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query := callback.Query()
+		query.Set("code", "fake-code")
+		query.Set("state", r.URL.Query().Get("state"))
+		callback.RawQuery = query.Encode()
+		http.Redirect(w, r, callback.String(), http.StatusFound)
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := json.Marshal(map[string]any{
+			"iss":   server.URL,
+			"aud":   fakeOIDCClientID,
+			"sub":   subject,
+			"email": email,
+			"name":  "Fake User",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		})
+		encode := base64.RawURLEncoding.EncodeToString
+		idToken := encode([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + encode(claims) + "." + encode([]byte("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "fake-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	return server
+}
+
+// setupOIDCRouter serves the auth routes with a provider named fake that logs subject in
+// at a fake issuer, handing tokens to the frontend with tokenDelivery
+func setupOIDCRouter(t *testing.T, subject string, email string, tokenDelivery string) (*gin.Engine, repositories.AuthRepository, usecases.JWTUsecase) {
+	t.Helper()
+
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration with a provider file pointing at the fake issuer
+	conf := SetupTestConfig(t)
+	conf.Auth.TokenDelivery = tokenDelivery
+	issuer := newFakeOIDCServer(t, subject, email)
+
+	t.Setenv("FAKE_OIDC_CLIENT_SECRET", "fake-secret")
+	providersFile := filepath.Join(t.TempDir(), "providers.yaml")
+	require.NoError(t, os.WriteFile(providersFile, []byte(`providers:
+  - name: fake
+    type: oidc
+    client_id: `+fakeOIDCClientID+`
+    client_secret: ${FAKE_OIDC_CLIENT_SECRET}
+    callback_url: http://localhost:8080/api/v1/auth/fake/callback
+    discovery_url: `+issuer.URL+`/.well-known/openid-configuration
+`), 0o600))
+	conf.Auth.ProvidersFile = providersFile
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	// Setup dependencies
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	// Create auth handler
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+	authHandler.Routes(api)
+
+	return router, authRepo, jwtUsecase
+}
+
+// completeOIDCLogin starts a login with query, lets the fake issuer approve it and returns the
+// response of the callback
+func completeOIDCLogin(t *testing.T, router *gin.Engine, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	// Login redirects to the issuer and stores the state in the session cookie
+	req := httptest.NewRequest("GET", "/api/v1/auth/fake/login"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusTemporaryRedirect, w.Code, w.Body.String())
+	sessionCookies := w.Result().Cookies()
+
+	// The issuer approves and sends the browser back to the callback
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirect.Get(w.Header().Get("Location"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	req = httptest.NewRequest("GET", callback.RequestURI(), nil)
+	for _, cookie := range sessionCookies {
+		req.AddCookie(cookie)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	return w
+}
+
+func TestAuthHandler_OIDCProvider_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupOIDCRouter(t, "fake-subject-1", "oidc@example.com", models.TokenDeliveryCode)
+
+	w := completeOIDCLogin(t, router, TestPKCEQuery)
+
+	// The frontend receives a one-time code and exchanges it with its PKCE verifier
+	redirect, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.NotContains(t, redirect.RawQuery, "token")
+
+	exchange := `{"code":"` + redirect.Query().Get("code") + `","code_verifier":"` + TestCodeVerifier + `"}`
+	w = serveJSON(t, router, "POST", "/api/v1/auth/token", "", exchange)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var tokens models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	result, err := jwtUsecase.ValidateJWT(context.Background(), tokens.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "oidc@example.com", result.Claims.Email)
+
+	authMethod, err := authRepo.GetAuthMethodByProviderAndID(context.Background(), "fake", "fake-subject-1")
+	require.NoError(t, err)
+	require.NotNil(t, authMethod.AuthID)
+	assert.Equal(t, result.UserID, *authMethod.AuthID)
+
+	// The code works only once
+	w = serveJSON(t, router, "POST", "/api/v1/auth/token", "", exchange)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Providers that are not configured are not found
+	for _, path := range []string{"/api/v1/auth/google/login", "/api/v1/auth/google/callback", "/api/v1/auth/google/logout"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestAuthHandler_OIDCProvider_CookieDelivery_Integration(t *testing.T) {
+	router, _, _ := setupOIDCRouter(t, "fake-subject-2", "cookie@example.com", models.TokenDeliveryCookie)
+
+	w := completeOIDCLogin(t, router, "")
+
+	// The tokens arrive as HttpOnly cookies and the redirect carries none of them
+	assert.Equal(t, "http://localhost:3000/auth/callback", w.Header().Get("Location"))
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	require.Contains(t, cookies, models.AccessTokenCookie)
+	require.Contains(t, cookies, models.RefreshTokenCookie)
+	assert.True(t, cookies[models.AccessTokenCookie].HttpOnly)
+	assert.Equal(t, "/api/v1/auth", cookies[models.RefreshTokenCookie].Path)
+
+	// The access token cookie authenticates requests
+	req := httptest.NewRequest("GET", "/api/v1/auth/example", nil)
+	req.AddCookie(cookies[models.AccessTokenCookie])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The refresh token cookie refreshes without a body
+	req = httptest.NewRequest("POST", "/api/v1/auth/token/refresh", nil)
+	req.AddCookie(cookies[models.RefreshTokenCookie])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "access_token")
+}
@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"template-golang/modules/auth/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_PasswordFlow_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+
+	// Register a local account
+	w := serveJSON(t, router, "POST", "/api/v1/auth/register", "",
+		`{"username":"local_user","email":"Local@Example.com","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var registered models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registered))
+	result, err := jwtUsecase.ValidateJWT(context.Background(), registered.AccessToken)
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+
+	auth, err := authRepo.GetAuthByEmail(context.Background(), "local@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, result.UserID, auth.ID)
+	require.NotNil(t, auth.Password)
+	assert.NotContains(t, *auth.Password, "S3cret!pass")
+
+	// The same username or email cannot register again
+	w = serveJSON(t, router, "POST", "/api/v1/auth/register", "",
+		`{"username":"LOCAL_USER","password":"S3cret!pass"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Log in with the username and with the email
+	for _, identifier := range []string{"local_user", "local@example.com"} {
+		w = serveJSON(t, router, "POST", "/api/v1/auth/login", "",
+			`{"username":"`+identifier+`","password":"S3cret!pass"}`)
+		assert.Equal(t, http.StatusOK, w.Code, identifier)
+	}
+
+	// Unknown accounts and wrong passwords fail the same way
+	for _, body := range []string{
+		`{"username":"local_user","password":"wrong"}`,
+		`{"username":"nobody","password":"S3cret!pass"}`,
+	} {
+		w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", body)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, body)
+		assert.JSONEq(t, `{"error":"Invalid username or password"}`, w.Body.String())
+	}
+
+	// Change the password
+	w = serveJSON(t, router, "PUT", "/api/v1/auth/password", registered.AccessToken,
+		`{"current_password":"wrong","new_password":"N3w!password"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serveJSON(t, router, "PUT", "/api/v1/auth/password", registered.AccessToken,
+		`{"current_password":"S3cret!pass","new_password":"N3w!password"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Only the new password works afterwards
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"local_user","password":"S3cret!pass"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"local_user","password":"N3w!password"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/models"
+	"template-golang/pkg/password"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_Profile_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+	ctx := context.Background()
+
+	username := "profile"
+	email := "profile@example.com"
+	hash, err := password.Hash("S3cret!pass")
+	require.NoError(t, err)
+	user, err := authRepo.CreateAuth(ctx, &username, &hash, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+	accessToken := "provider-access-token"
+	_, err = authRepo.CreateAuthMethod(ctx, db.CreateAuthMethodParams{
+		AuthID:      &user.ID,
+		Provider:    "github",
+		ProviderID:  "github-profile",
+		Email:       &email,
+		AccessToken: &accessToken,
+	})
+	require.NoError(t, err)
+	tokens, err := jwtUsecase.IssueTokens(ctx, user.ID)
+	require.NoError(t, err)
+
+	w := serveJSON(t, router, "GET", "/api/v1/auth/me", tokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var profile models.Profile
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+	assert.Equal(t, user.ID, profile.ID)
+	assert.Equal(t, "profile", profile.Username)
+	assert.True(t, profile.HasPassword)
+	require.Len(t, profile.Providers, 1)
+	assert.Equal(t, "github", profile.Providers[0].Provider)
+	assert.NotContains(t, w.Body.String(), accessToken)
+	assert.NotContains(t, w.Body.String(), hash)
+
+	// Changing the email takes the current password
+	w = serveJSON(t, router, "PATCH", "/api/v1/auth/me", tokens.AccessToken, `{"email":"new@example.com"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "PATCH", "/api/v1/auth/me", tokens.AccessToken,
+		`{"username":"Renamed","email":"New@example.com","current_password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"username":"renamed"`)
+	assert.Contains(t, w.Body.String(), `"email":"new@example.com"`)
+
+	// Usernames of other accounts are taken
+	other := "taken"
+	_, err = authRepo.CreateAuth(ctx, &other, nil, nil, string(models.RoleUser), true)
+	require.NoError(t, err)
+	w = serveJSON(t, router, "PATCH", "/api/v1/auth/me", tokens.AccessToken, `{"username":"taken"}`)
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "DELETE", "/api/v1/auth/me", tokens.AccessToken, `{"password":"wrong"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "DELETE", "/api/v1/auth/me", tokens.AccessToken, `{"password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The deleted account is signed out everywhere
+	w = serveJSON(t, router, "GET", "/api/v1/auth/me", tokens.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveJSON(t, router, "POST", "/api/v1/auth/token/refresh", "", `{"refresh_token":"`+tokens.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Its username, email and provider are free for a new account
+	username = "renamed"
+	email = "new@example.com"
+	_, err = authRepo.CreateAuth(ctx, &username, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+	methods, err := authRepo.GetAuthMethodsByAuthID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, methods)
+	_, err = authRepo.GetAuthMethodByProviderAndID(ctx, "github", "github-profile")
+	assert.Error(t, err)
+}
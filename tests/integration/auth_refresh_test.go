@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	"template-golang/modules/auth/handlers"
+	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_RefreshToken_Integration(t *testing.T) {
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration
+	conf := SetupTestConfig(t)
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	// Setup dependencies
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries)), auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewMemoryLoginAttemptRepository())
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	// Create auth handler
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+	authHandler.Routes(api)
+
+	// Log a user in
+	email := "refresh@example.com"
+	auth, err := authRepo.CreateAuth(context.Background(), &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+
+	initial, err := jwtUsecase.IssueTokens(context.Background(), auth.ID)
+	require.NoError(t, err)
+
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/api/v1/auth/token/refresh",
+			strings.NewReader(`{"refresh_token":"`+refreshToken+`"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The first refresh rotates the token
+	w := refresh(initial.RefreshToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var rotated models.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+	assert.NotEmpty(t, rotated.AccessToken)
+	assert.NotEqual(t, initial.RefreshToken, rotated.RefreshToken)
+
+	// Presenting the used token again is treated as theft
+	w = refresh(initial.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// ... which revokes the token issued by the rotation as well
+	w = refresh(rotated.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Unknown tokens are rejected
+	w = refresh("not-a-refresh-token")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
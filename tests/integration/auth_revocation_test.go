@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	"template-golang/modules/auth/handlers"
+	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRevocationRouter(t *testing.T) (*gin.Engine, repositories.AuthRepository, usecases.JWTUsecase) {
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration
+	conf := SetupTestConfig(t)
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	// Setup dependencies
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	revocationStore := usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries))
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, revocationStore, auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), database.NewTxManager(pool, conf))
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewLoginAttemptRepository(queries))
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	// Create auth handler
+	authHandler := handlers.NewAuthHttpHandler(jwtUsecase, usecases.NewPasswordUsecase(jwtUsecase, authRepo), usecases.NewAuthCodeUsecase(conf, jwtUsecase, repositories.NewAuthCodeRepository(queries)), usecases.NewAccountLinkUsecase(authRepo, database.NewTxManager(pool, conf)), usecases.NewUserAdminUsecase(jwtUsecase, authRepo, database.NewTxManager(pool, conf)), usecases.NewProfileUsecase(jwtUsecase, authRepo, usecases.NewPermissionStore(conf, nil), database.NewTxManager(pool, conf)), apiKeyUsecase, usecases.NewMFAUsecase(conf, jwtUsecase, authRepo, repositories.NewMFARepository(queries, SetupTestKeyRing(t)), database.NewTxManager(pool, conf)), loginThrottle, auditLogger, keySet, conf, authMiddleware, authRepo, SetupTestProviders(t, conf))
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+	authHandler.Routes(api)
+
+	return router, authRepo, jwtUsecase
+}
+
+func serveJSON(t *testing.T, router *gin.Engine, method string, path string, accessToken string, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// waitForNextSecond sleeps until the next second, since revoking every token of an account
+// spares the tokens issued in the second of the revocation
+func waitForNextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
+
+func TestAuthHandler_LogoutSession_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+
+	email := "logout@example.com"
+	auth, err := authRepo.CreateAuth(context.Background(), &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+
+	session, err := jwtUsecase.IssueTokens(context.Background(), auth.ID)
+	require.NoError(t, err)
+	other, err := jwtUsecase.IssueTokens(context.Background(), auth.ID)
+	require.NoError(t, err)
+
+	w := serveJSON(t, router, "POST", "/api/v1/auth/logout", session.AccessToken,
+		`{"refresh_token":"`+session.RefreshToken+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The access and refresh token of the session stop working
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", session.AccessToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Token has been revoked")
+
+	w = serveJSON(t, router, "POST", "/api/v1/auth/token/refresh", "", `{"refresh_token":"`+session.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Other sessions of the same user are not affected
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", other.AccessToken, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthHandler_LogoutAll_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase := setupRevocationRouter(t)
+
+	email := "logout-all@example.com"
+	auth, err := authRepo.CreateAuth(context.Background(), &email, nil, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+
+	first, err := jwtUsecase.IssueTokens(context.Background(), auth.ID)
+	require.NoError(t, err)
+	second, err := jwtUsecase.IssueTokens(context.Background(), auth.ID)
+	require.NoError(t, err)
+
+	waitForNextSecond()
+	w := serveJSON(t, router, "POST", "/api/v1/auth/logout/all", first.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Every session of the user is signed out
+	for _, pair := range []*models.TokenPair{first, second} {
+		w = serveJSON(t, router, "GET", "/api/v1/auth/example", pair.AccessToken, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = serveJSON(t, router, "POST", "/api/v1/auth/token/refresh", "", `{"refresh_token":"`+pair.RefreshToken+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+
+	// Logging in again right away works
+	fresh, err := jwtUsecase.IssueTokens(context.Background(), auth.ID)
+	require.NoError(t, err)
+
+	w = serveJSON(t, router, "GET", "/api/v1/auth/example", fresh.AccessToken, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
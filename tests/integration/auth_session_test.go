@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/sessions"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStore_Integration(t *testing.T) {
+	// Setup test database
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	// Wait for database to be ready
+	WaitForDB(t, pool, 10*time.Second)
+
+	// Setup test configuration
+	conf := SetupTestConfig(t)
+	conf.Auth.SessionSecret = "integration-session-secret-0123456789"
+
+	// Create database instance
+	queries := CreateTestDatabase(t, pool)
+
+	sessionRepo := repositories.NewSessionRepository(queries)
+	store, err := sessions.NewStore(conf, sessionRepo)
+	require.NoError(t, err)
+
+	// Save a session the way gothic does at login
+	session, err := store.New(httptest.NewRequest("GET", "/", nil), "_gothic_session")
+	require.NoError(t, err)
+	session.Values["line"] = "oauth-state"
+
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(httptest.NewRequest("GET", "/", nil), w, session))
+
+	stored, err := sessionRepo.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored.Data), session.ID)
+
+	// Another store with the same secret, as on another replica, loads it from the cookie
+	otherReplica, err := sessions.NewStore(conf, repositories.NewSessionRepository(queries))
+	require.NoError(t, err)
+
+	callback := httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		callback.AddCookie(cookie)
+	}
+	loaded, err := otherReplica.Get(callback, "_gothic_session")
+	require.NoError(t, err)
+	assert.False(t, loaded.IsNew)
+	assert.Equal(t, "oauth-state", loaded.Values["line"])
+
+	// Logging out deletes the row
+	loaded.Options.MaxAge = -1
+	require.NoError(t, otherReplica.Save(callback, httptest.NewRecorder(), loaded))
+	_, err = sessionRepo.GetSession(context.Background(), session.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// Expired sessions are not loaded and are cleaned up
+	require.NoError(t, sessionRepo.SaveSession(context.Background(), "expired-session", []byte{}, time.Now().Add(-time.Minute)))
+	_, err = sessionRepo.GetSession(context.Background(), "expired-session")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	deleted, err := sessionRepo.DeleteExpiredSessions(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+
+	// A forged cookie starts a new session
+	forged := httptest.NewRequest("GET", "/", nil)
+	forged.AddCookie(&http.Cookie{Name: "_gothic_session", Value: "forged"})
+	fresh, err := store.New(forged, "_gothic_session")
+	require.NoError(t, err)
+	assert.True(t, fresh.IsNew)
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"template-golang/modules/auth/repositories"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_LoginThrottle_Integration(t *testing.T) {
+	router, _, _ := setupRevocationRouter(t)
+
+	w := serveJSON(t, router, "POST", "/api/v1/auth/register", "",
+		`{"username":"throttled_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// The free attempts and the failure starting the backoff are answered normally
+	for i := 0; i < 6; i++ {
+		w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"throttled_user","password":"wrong"}`)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "attempt %d", i+1)
+	}
+
+	// Then even the right password is turned away until the delay is over
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"throttled_user","password":"S3cret!pass"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	time.Sleep(1100 * time.Millisecond)
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"throttled_user","password":"S3cret!pass"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The success forgot the failures of the account, not those of the IP
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"throttled_user","password":"wrong"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveJSON(t, router, "POST", "/api/v1/auth/login", "", `{"username":"throttled_user","password":"S3cret!pass"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
+
+func TestLoginAttemptRepository_Integration(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+	WaitForDB(t, pool, 10*time.Second)
+
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(CreateTestDatabase(t, pool))
+	ctx := context.Background()
+
+	_, err := loginAttemptRepo.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	for want := int32(1); want <= 3; want++ {
+		attempt, err := loginAttemptRepo.RecordLoginFailure(ctx, "ip:1.2.3.4", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, want, attempt.Failures)
+	}
+
+	// A window starting after the last failure starts the count over
+	attempt, err := loginAttemptRepo.RecordLoginFailure(ctx, "ip:1.2.3.4", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), attempt.Failures)
+
+	blockedUntil := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	require.NoError(t, loginAttemptRepo.BlockLoginAttempts(ctx, "ip:1.2.3.4", blockedUntil))
+	attempt, err = loginAttemptRepo.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.True(t, attempt.BlockedUntil.Time.Equal(blockedUntil))
+
+	_, err = loginAttemptRepo.RecordLoginFailure(ctx, "ip:5.6.7.8", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	// Blocked keys outlive their window, the others are cleaned up
+	deleted, err := loginAttemptRepo.DeleteExpiredLoginAttempts(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	require.NoError(t, loginAttemptRepo.DeleteLoginAttempts(ctx, "ip:1.2.3.4"))
+	_, err = loginAttemptRepo.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestLoginAttemptRepository_Reserve_Integration(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+	WaitForDB(t, pool, 10*time.Second)
+
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(CreateTestDatabase(t, pool))
+	ctx := context.Background()
+	delays := []time.Duration{0, 0, time.Minute, time.Hour}
+
+	// Concurrent attempts are counted one by one, so only the free ones and the one starting
+	// the backoff are reserved
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := loginAttemptRepo.ReserveLoginAttempt(ctx, "ip:1.2.3.4", time.Now().Add(-time.Minute), delays)
+			if err == nil {
+				reserved.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), reserved.Load())
+
+	attempt, err := loginAttemptRepo.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), attempt.Failures)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), attempt.BlockedUntil.Time, 5*time.Second)
+
+	// Releasing the last attempt takes back its failure and its block
+	require.NoError(t, loginAttemptRepo.ReleaseLoginAttempt(ctx, "ip:1.2.3.4"))
+	attempt, err = loginAttemptRepo.GetLoginAttempt(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempt.Failures)
+	assert.False(t, attempt.BlockedUntil.Valid)
+
+	// Past the end of delays, the last delay applies
+	for range 2 {
+		_, err = loginAttemptRepo.ReserveLoginAttempt(ctx, "ip:5.6.7.8", time.Now().Add(-time.Minute), []time.Duration{0})
+		require.NoError(t, err)
+	}
+	attempt, err = loginAttemptRepo.GetLoginAttempt(ctx, "ip:5.6.7.8")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempt.Failures)
+}
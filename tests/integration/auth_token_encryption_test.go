@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"template-golang/config"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+	"template-golang/pkg/encryption"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthRepository_TokenEncryption_Integration(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+	WaitForDB(t, pool, 10*time.Second)
+
+	queries := CreateTestDatabase(t, pool)
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	mfaRepo := repositories.NewMFARepository(queries, SetupTestKeyRing(t))
+	ctx := context.Background()
+
+	username := "encrypted_user"
+	auth, err := authRepo.CreateAuth(ctx, &username, nil, nil, "user", true)
+	require.NoError(t, err)
+
+	accessToken, refreshToken, idToken := "access-token", "refresh-token", "id-token"
+	method, err := authRepo.CreateAuthMethod(ctx, db.CreateAuthMethodParams{
+		AuthID:       &auth.ID,
+		Provider:     "line",
+		ProviderID:   "line-user",
+		AccessToken:  &accessToken,
+		RefreshToken: &refreshToken,
+		IDToken:      &idToken,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, accessToken, *method.AccessToken)
+	assert.Nil(t, method.AccessTokenSecret)
+
+	// The row only holds ciphertext
+	stored, err := queries.GetAuthMethodByProviderAndID(ctx, "line", "line-user")
+	require.NoError(t, err)
+	for _, token := range []*string{stored.AccessToken, stored.RefreshToken, stored.IDToken} {
+		require.NotNil(t, token)
+		assert.True(t, strings.HasPrefix(*token, "enc:v1:test:"), *token)
+	}
+
+	// A method stored before encryption was enabled is read as it is
+	legacyToken := "legacy-access-token"
+	_, err = queries.CreateAuthMethod(ctx, db.CreateAuthMethodParams{
+		AuthID:      &auth.ID,
+		Provider:    "google",
+		ProviderID:  "google-user",
+		AccessToken: &legacyToken,
+	})
+	require.NoError(t, err)
+
+	legacy, err := authRepo.GetAuthMethodByProviderAndID(ctx, "google", "google-user")
+	require.NoError(t, err)
+	assert.Equal(t, legacyToken, *legacy.AccessToken)
+
+	// The MFA secret is encrypted the same way
+	_, err = mfaRepo.SetMFASecret(ctx, auth.ID, "JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	storedAuth, err := queries.GetAuthByID(ctx, auth.ID)
+	require.NoError(t, err)
+	require.NotNil(t, storedAuth.MFASecret)
+	assert.True(t, strings.HasPrefix(*storedAuth.MFASecret, "enc:v1:test:"), *storedAuth.MFASecret)
+
+	withSecret, err := authRepo.GetAuthByID(ctx, auth.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", *withSecret.MFASecret)
+
+	// Rotating to a new key re-encrypts both methods and the MFA secret
+	rotatedKeyRing, err := encryption.ParseKeyRing(testTokenEncryptionKeys+",next:AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=", "next")
+	require.NoError(t, err)
+	rotatedRepo := repositories.NewAuthRepository(queries, rotatedKeyRing)
+	reencryptor := usecases.NewTokenReencryptor(&config.Config{}, rotatedRepo, repositories.NewMFARepository(queries, rotatedKeyRing))
+
+	reencrypted, err := reencryptor.Reencrypt(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, reencrypted)
+
+	storedAuth, err = queries.GetAuthByID(ctx, auth.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(*storedAuth.MFASecret, "enc:v1:next:"), *storedAuth.MFASecret)
+
+	methods, err := queries.GetAuthMethodsByAuthID(ctx, &auth.ID)
+	require.NoError(t, err)
+	require.Len(t, methods, 2)
+	for _, m := range methods {
+		assert.True(t, strings.HasPrefix(*m.AccessToken, "enc:v1:next:"), *m.AccessToken)
+	}
+
+	decrypted, err := rotatedRepo.GetAuthMethodsByAuthID(ctx, auth.ID)
+	require.NoError(t, err)
+	tokens := map[string]string{}
+	for _, m := range decrypted {
+		tokens[m.Provider] = *m.AccessToken
+	}
+	assert.Equal(t, map[string]string{"line": accessToken, "google": legacyToken}, tokens)
+
+	// Nothing is left to re-encrypt
+	reencrypted, err = reencryptor.Reencrypt(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, reencrypted)
+}
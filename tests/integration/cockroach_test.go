@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+	cockroachEntities "template-golang/modules/cockroach/entities"
+	cockroachHandlers "template-golang/modules/cockroach/handlers"
+	cockroachRepositories "template-golang/modules/cockroach/repositories"
+	cockroachUsecases "template-golang/modules/cockroach/usecases"
+	"template-golang/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCockroachRouter(t *testing.T) (*gin.Engine, repositories.AuthRepository, usecases.JWTUsecase, cockroachRepositories.CockroachRepository) {
+	pool, cleanup := SetupTestDB(t)
+	t.Cleanup(cleanup)
+	WaitForDB(t, pool, 10*time.Second)
+
+	conf := SetupTestConfig(t)
+	queries := CreateTestDatabase(t, pool)
+	txManager := database.NewTxManager(pool, conf)
+
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	revocationStore := usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries))
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, revocationStore, auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), txManager)
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewLoginAttemptRepository(queries))
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	cockroachRepo := cockroachRepositories.NewPostgresRepository(queries)
+	cockroachUsecase := cockroachUsecases.NewCockroachUsecaseImpl(cockroachRepo, cockroachRepositories.NewFCMMessaging())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	cockroachHandlers.NewCockroachHttpHandler(cockroachUsecase, authMiddleware).Routes(router.Group("/api/v1"))
+
+	return router, authRepo, jwtUsecase, cockroachRepo
+}
+
+func TestCockroachHandler_CRUD_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase, cockroachRepo := setupCockroachRouter(t)
+	ctx := context.Background()
+
+	issueTokens := func(name string, role models.Role) string {
+		user, err := authRepo.CreateAuth(ctx, &name, nil, nil, string(role), true)
+		require.NoError(t, err)
+		tokens, err := jwtUsecase.IssueTokens(ctx, user.ID)
+		require.NoError(t, err)
+		return tokens.AccessToken
+	}
+	userToken := issueTokens("cockroach-user", models.RoleUser)
+	staffToken := issueTokens("cockroach-staff", models.RoleStaff)
+
+	var ids []uint32
+	for amount := uint32(1); amount <= 3; amount++ {
+		cockroach, err := cockroachRepo.InsertCockroachData(ctx, &cockroachEntities.InsertCockroachDto{Amount: amount})
+		require.NoError(t, err)
+		ids = append(ids, cockroach.Id)
+	}
+
+	// Sightings require authentication
+	w := serveJSON(t, router, "GET", "/api/v1/cockroach", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+
+	// Newest first, page by page
+	type cockroachesPage struct {
+		Data []cockroachEntities.Cockroach `json:"data"`
+		Meta response.Meta                 `json:"meta"`
+	}
+	listCockroaches := func(query string) cockroachesPage {
+		w := serveJSON(t, router, "GET", "/api/v1/cockroach"+query, userToken, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var page cockroachesPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return page
+	}
+
+	page := listCockroaches("?limit=2")
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, ids[2], page.Data[0].Id)
+	assert.Equal(t, ids[1], page.Data[1].Id)
+	require.NotEmpty(t, page.Meta.Next)
+
+	page = listCockroaches("?limit=2&cursor=" + page.Meta.Next)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, ids[0], page.Data[0].Id)
+	assert.Empty(t, page.Meta.Next)
+	require.NotEmpty(t, page.Meta.Prev)
+
+	page = listCockroaches("?limit=2&cursor=" + page.Meta.Prev)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, ids[2], page.Data[0].Id)
+	assert.Empty(t, page.Meta.Prev)
+
+	page = listCockroaches("?created_to=" + time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	assert.Empty(t, page.Data)
+
+	// Users report and correct sightings, only staff delete them
+	path := fmt.Sprintf("/api/v1/cockroach/%d", ids[0])
+	w = serveJSON(t, router, "PATCH", path, userToken, `{"amount":7}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"amount":7`)
+
+	w = serveJSON(t, router, "DELETE", path, userToken, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "DELETE", path, staffToken, "")
+	assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "GET", path, userToken, "")
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	w = serveJSON(t, router, "PATCH", path, userToken, `{"amount":7}`)
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	w = serveJSON(t, router, "DELETE", path, staffToken, "")
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+}
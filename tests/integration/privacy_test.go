@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"template-golang/database"
+	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/middlewares"
+	"template-golang/modules/auth/models"
+	"template-golang/modules/auth/repositories"
+	"template-golang/modules/auth/usecases"
+	privacyHandlers "template-golang/modules/privacy/handlers"
+	privacyRepositories "template-golang/modules/privacy/repositories"
+	privacyUsecases "template-golang/modules/privacy/usecases"
+	"template-golang/pkg/password"
+	"template-golang/pkg/personaldata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPrivacyRouter(t *testing.T) (*gin.Engine, repositories.AuthRepository, usecases.JWTUsecase, privacyUsecases.DataRequestUsecase) {
+	pool, cleanup := SetupTestDB(t)
+	t.Cleanup(cleanup)
+	WaitForDB(t, pool, 10*time.Second)
+
+	conf := SetupTestConfig(t)
+	queries := CreateTestDatabase(t, pool)
+	txManager := database.NewTxManager(pool, conf)
+
+	authRepo := repositories.NewAuthRepository(queries, SetupTestKeyRing(t))
+	keySet := usecases.NewKeySet(conf, nil, nil)
+	revocationStore := usecases.NewRevocationStore(conf, repositories.NewRevokedTokenRepository(queries))
+	auditLogger := usecases.NewAuditLogger(repositories.NewAuditEventRepository(queries))
+	jwtUsecase := usecases.NewJWTUsecase(conf, keySet, revocationStore, auditLogger, authRepo, repositories.NewRefreshTokenRepository(queries), txManager)
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(repositories.NewAPIKeyRepository(queries), authRepo, usecases.NewPermissionStore(conf, nil))
+	loginThrottle := usecases.NewLoginThrottle(conf, repositories.NewLoginAttemptRepository(queries))
+	authMiddleware := middlewares.NewAuthMiddleware(jwtUsecase, apiKeyUsecase, usecases.NewPermissionStore(conf, nil), usecases.NewAccountStatusStore(conf, authRepo), loginThrottle)
+
+	registry := personaldata.NewRegistry()
+	authPersonalData := usecases.NewPersonalData(repositories.NewPersonalDataRepository(queries), loginThrottle, txManager)
+	registry.Register("auth", authPersonalData, authPersonalData)
+	dataRequestUsecase := privacyUsecases.NewDataRequestUsecase(conf, registry, privacyRepositories.NewDataRequestRepository(queries))
+	registry.Register("privacy", dataRequestUsecase, dataRequestUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	privacyHandlers.NewPrivacyHttpHandler(dataRequestUsecase, auditLogger, authMiddleware).Routes(router.Group("/api/v1"))
+
+	return router, authRepo, jwtUsecase, dataRequestUsecase
+}
+
+// processDataRequests runs the pending data requests like the background job does
+func processDataRequests(t *testing.T, dataRequestUsecase privacyUsecases.DataRequestUsecase) {
+	for {
+		processed, err := dataRequestUsecase.Process(context.Background())
+		require.NoError(t, err)
+		if !processed {
+			return
+		}
+	}
+}
+
+func TestPrivacyHandler_ExportAndErasure_Integration(t *testing.T) {
+	router, authRepo, jwtUsecase, dataRequestUsecase := setupPrivacyRouter(t)
+	ctx := context.Background()
+
+	username := "privacy"
+	email := "privacy@example.com"
+	hash, err := password.Hash("S3cret!pass")
+	require.NoError(t, err)
+	user, err := authRepo.CreateAuth(ctx, &username, &hash, &email, string(models.RoleUser), true)
+	require.NoError(t, err)
+	accessToken := "provider-access-token"
+	_, err = authRepo.CreateAuthMethod(ctx, db.CreateAuthMethodParams{
+		AuthID:      &user.ID,
+		Provider:    "github",
+		ProviderID:  "github-privacy",
+		Email:       &email,
+		AccessToken: &accessToken,
+	})
+	require.NoError(t, err)
+	userTokens, err := jwtUsecase.IssueTokens(ctx, user.ID)
+	require.NoError(t, err)
+
+	adminName := "privacy-admin"
+	admin, err := authRepo.CreateAuth(ctx, &adminName, nil, nil, string(models.RoleAdmin), true)
+	require.NoError(t, err)
+	adminTokens, err := jwtUsecase.IssueTokens(ctx, admin.ID)
+	require.NoError(t, err)
+
+	// The user requests an export of their data, one at a time
+	w := serveJSON(t, router, "POST", "/api/v1/privacy/export", userTokens.AccessToken, "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "pending", created.Data.Status)
+	exportID := created.Data.ID
+
+	w = serveJSON(t, router, "POST", "/api/v1/privacy/export", userTokens.AccessToken, "")
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	w = serveJSON(t, router, "GET", "/api/v1/privacy/requests/"+exportID+"/download", userTokens.AccessToken, "")
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	processDataRequests(t, dataRequestUsecase)
+
+	w = serveJSON(t, router, "GET", "/api/v1/privacy/requests/"+exportID, userTokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"status":"completed"`)
+
+	w = serveJSON(t, router, "GET", "/api/v1/privacy/requests/"+exportID+"/download?format=json", userTokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var export personaldata.Export
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+	assert.Equal(t, user.ID, export.AuthID)
+	var personalData models.PersonalData
+	require.NoError(t, json.Unmarshal(export.Modules["auth"], &personalData))
+	assert.Equal(t, "privacy", personalData.Account.Username)
+	require.Len(t, personalData.Providers, 1)
+	assert.Equal(t, "github", personalData.Providers[0].Provider)
+	assert.Contains(t, string(export.Modules["privacy"]), exportID)
+	assert.NotContains(t, w.Body.String(), hash)
+	assert.NotContains(t, w.Body.String(), accessToken)
+
+	w = serveJSON(t, router, "GET", "/api/v1/privacy/requests/"+exportID+"/download", userTokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+	// Admins see the requests of every user. Users cannot request erasures, admins cannot erase
+	// themselves.
+	w = serveJSON(t, router, "GET", "/api/v1/privacy/requests/"+exportID, adminTokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serveJSON(t, router, "POST", "/api/v1/admin/privacy/users/"+user.ID+"/erasure", userTokens.AccessToken, `{"mode":"delete"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	w = serveJSON(t, router, "POST", "/api/v1/admin/privacy/users/"+admin.ID+"/erasure", adminTokens.AccessToken, `{"mode":"delete"}`)
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	// An admin erases the user
+	w = serveJSON(t, router, "POST", "/api/v1/admin/privacy/users/"+user.ID+"/erasure", adminTokens.AccessToken, `{"mode":"delete"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	processDataRequests(t, dataRequestUsecase)
+
+	w = serveJSON(t, router, "GET", "/api/v1/admin/privacy/requests?auth_id="+user.ID+"&kind=erasure", adminTokens.AccessToken, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"status":"completed"`)
+	assert.Contains(t, w.Body.String(), `"erasure_mode":"delete"`)
+
+	_, err = authRepo.GetAuthByID(ctx, user.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	w = serveJSON(t, router, "GET", "/api/v1/admin/privacy/requests/"+exportID+"/download", adminTokens.AccessToken, "")
+	assert.Equal(t, http.StatusGone, w.Code, w.Body.String())
+}
@@ -11,15 +11,27 @@ import (
 
 	"template-golang/config"
 	db "template-golang/db/sqlc"
+	"template-golang/modules/auth/providers"
+	"template-golang/pkg/encryption"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
+	"github.com/markbates/goth"
 	"github.com/stretchr/testify/require"
 )
 
+// PKCE verifier and S256 challenge the tests start OAuth logins with (RFC 7636 appendix B)
+const (
+	TestCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	TestCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	// TestPKCEQuery is the query string of a login started with TestCodeChallenge
+	TestPKCEQuery = "?code_challenge=" + TestCodeChallenge + "&code_challenge_method=S256"
+)
+
 // TestDBConfig holds test database configuration
 type TestDBConfig struct {
 	Host     string
@@ -202,6 +214,28 @@ func SetupTestConfig(t *testing.T) *config.Config {
 	}
 }
 
+// testTokenEncryptionKeys is the provider token key ring of the tests, a single all-zero key
+const testTokenEncryptionKeys = "test:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+
+// SetupTestKeyRing returns the key ring encrypting the provider tokens stored by the tests
+func SetupTestKeyRing(t *testing.T) *encryption.KeyRing {
+	t.Helper()
+
+	keyRing, err := encryption.ParseKeyRing(testTokenEncryptionKeys, "")
+	require.NoError(t, err, "Failed to parse token encryption keys")
+	return keyRing
+}
+
+// SetupTestProviders builds the login providers configured in conf
+func SetupTestProviders(t *testing.T, conf *config.Config) []goth.Provider {
+	t.Helper()
+
+	authProviders, err := providers.NewProviders(conf)
+	require.NoError(t, err, "Failed to create login providers")
+
+	return authProviders
+}
+
 // CreateTestDatabase creates a database instance for testing
 func CreateTestDatabase(t *testing.T, pool *pgxpool.Pool) *db.Queries {
 	t.Helper()